@@ -45,20 +45,42 @@ func fetchClient(ctx context.Context, monitor metrics.Service, address string) (
 
 	if !exists {
 		var err error
-		client, err = httpclient.New(ctx,
+		// The consensus client cannot be supplied with a custom transport, so
+		// Unix domain socket addresses are reached through a local proxy.
+		clientAddress := address
+		if path, isUnixSocket := util.UnixSocketPath(address); isUnixSocket {
+			clientAddress, err = util.StartUnixSocketProxy(ctx, path)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to start unix socket proxy")
+			}
+			log.Trace().Str("address", address).Str("proxy_address", clientAddress).Msg("Started unix socket proxy")
+		}
+
+		params := []httpclient.Parameter{
 			httpclient.WithLogLevel(util.LogLevel(fmt.Sprintf("eth2client.%s", address))),
 			httpclient.WithMonitor(monitor),
 			httpclient.WithTimeout(util.Timeout(fmt.Sprintf("eth2client.%s", address))),
-			httpclient.WithAddress(address),
+			httpclient.WithAddress(clientAddress),
 			httpclient.WithAllowDelayedStart(viper.GetBool("eth2client.allow-delayed-start")),
 			httpclient.WithExtraHeaders(map[string]string{
-				"User-Agent": fmt.Sprintf("Vouch/%s", ReleaseVersion),
+				"User-Agent": util.UserAgent(fmt.Sprintf("eth2client.%s", address), ReleaseVersion),
 			}),
 			httpclient.WithReducedMemoryUsage(util.HierarchicalBool("reduced-memory-usage", fmt.Sprintf("eth2client.%s", address))),
-		)
+		}
+		// Validator queries are chunked by the client, with sizes chosen per beacon node unless overridden.
+		if viper.GetInt("eth2client.index-chunk-size") > 0 {
+			params = append(params, httpclient.WithIndexChunkSize(viper.GetInt("eth2client.index-chunk-size")))
+		}
+		if viper.GetInt("eth2client.pubkey-chunk-size") > 0 {
+			params = append(params, httpclient.WithPubKeyChunkSize(viper.GetInt("eth2client.pubkey-chunk-size")))
+		}
+		client, err = httpclient.New(ctx, params...)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to initiate consensus client")
 		}
+		if httpClient, isHTTPClient := client.(*httpclient.Service); isHTTPClient {
+			client = util.SyncGraceClient(httpClient, viper.GetDuration("eth2client.sync-grace-period"))
+		}
 
 		knownClientsMu.Lock()
 		knownClients[address] = client
@@ -110,3 +132,29 @@ func fetchMultiClient(ctx context.Context, monitor metrics.Service, name string,
 
 	return client, nil
 }
+
+// fetchBulkQueryClient fetches the client for heavy queries that are not
+// time-critical, such as obtaining the state of all validators, falling back
+// to the supplied client if no beacon nodes are configured for bulk queries.
+// Time-critical queries such as those for duties must not use this client.
+func fetchBulkQueryClient(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service) (eth2client.Service, error) {
+	return util.BulkQueryClient(eth2Client, func(addresses []string) (eth2client.Service, error) {
+		return fetchMultiClient(ctx, monitor, "bulk-query", addresses)
+	})
+}
+
+// fetchKnownClients returns the individual clients that have been created.
+func fetchKnownClients() []eth2client.Service {
+	knownClientsMu.Lock()
+	defer knownClientsMu.Unlock()
+
+	clients := make([]eth2client.Service, 0, len(knownClients))
+	for id, client := range knownClients {
+		if strings.HasPrefix(id, "multi:") {
+			continue
+		}
+		clients = append(clients, client)
+	}
+
+	return clients
+}
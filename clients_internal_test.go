@@ -0,0 +1,102 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+// routingClient is a client that records the validator queries made of it.
+type routingClient struct {
+	address         string
+	validatorsCalls int
+}
+
+func (*routingClient) Name() string { return "routing" }
+
+func (c *routingClient) Address() string { return c.address }
+
+func (*routingClient) IsActive() bool { return true }
+
+func (*routingClient) IsSynced() bool { return true }
+
+func (*routingClient) FarFutureEpoch(_ context.Context) (phase0.Epoch, error) {
+	return 0xffffffffffffffff, nil
+}
+
+func (c *routingClient) Validators(_ context.Context,
+	_ *api.ValidatorsOpts,
+) (
+	*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator],
+	error,
+) {
+	c.validatorsCalls++
+
+	return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+		Data: map[phase0.ValidatorIndex]*apiv1.Validator{},
+	}, nil
+}
+
+func TestValidatorsManagerRouting(t *testing.T) {
+	ctx := context.Background()
+	monitor := nullmetrics.New(ctx)
+
+	tests := []struct {
+		name          string
+		bulkAddresses []string
+		mainQueries   int
+		bulkQueries   int
+	}{
+		{
+			name:        "Default",
+			mainQueries: 1,
+		},
+		{
+			name:          "BulkQuery",
+			bulkAddresses: []string{"bulk"},
+			bulkQueries:   1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mainClient := &routingClient{address: "main"}
+			bulkClient := &routingClient{address: "bulk"}
+			knownClientsMu.Lock()
+			knownClients["multi:bulk"] = bulkClient
+			knownClientsMu.Unlock()
+			defer func() {
+				knownClientsMu.Lock()
+				delete(knownClients, "multi:bulk")
+				knownClientsMu.Unlock()
+			}()
+			viper.Set("bulk-query.beacon-node-addresses", test.bulkAddresses)
+			defer viper.Set("bulk-query.beacon-node-addresses", nil)
+
+			validatorsManager, err := startValidatorsManager(ctx, monitor, mainClient)
+			require.NoError(t, err)
+			require.NoError(t, validatorsManager.RefreshValidatorsFromBeaconNode(ctx, nil))
+			require.Equal(t, test.mainQueries, mainClient.validatorsCalls)
+			require.Equal(t, test.bulkQueries, bulkClient.validatorsCalls)
+		})
+	}
+}
@@ -31,18 +31,21 @@ import (
 	"time"
 
 	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	"github.com/attestantio/go-block-relay/services/builderbidprovider"
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/accountmanager"
 	dirkaccountmanager "github.com/attestantio/vouch/services/accountmanager/dirk"
+	multiaccountmanager "github.com/attestantio/vouch/services/accountmanager/multi"
 	walletaccountmanager "github.com/attestantio/vouch/services/accountmanager/wallet"
 	"github.com/attestantio/vouch/services/attestationaggregator"
 	standardattestationaggregator "github.com/attestantio/vouch/services/attestationaggregator/standard"
 	"github.com/attestantio/vouch/services/attester"
 	standardattester "github.com/attestantio/vouch/services/attester/standard"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/beaconblockproposer/filesink"
 	standardbeaconblockproposer "github.com/attestantio/vouch/services/beaconblockproposer/standard"
 	"github.com/attestantio/vouch/services/beaconcommitteesubscriber"
 	standardbeaconcommitteesubscriber "github.com/attestantio/vouch/services/beaconcommitteesubscriber/standard"
@@ -57,6 +60,8 @@ import (
 	dynamicgraffitiprovider "github.com/attestantio/vouch/services/graffitiprovider/dynamic"
 	staticgraffitiprovider "github.com/attestantio/vouch/services/graffitiprovider/static"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/metrics/exportsink"
+	"github.com/attestantio/vouch/services/metrics/logsink"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	prometheusmetrics "github.com/attestantio/vouch/services/metrics/prometheus"
 	"github.com/attestantio/vouch/services/proposalpreparer"
@@ -65,6 +70,8 @@ import (
 	advancedscheduler "github.com/attestantio/vouch/services/scheduler/advanced"
 	"github.com/attestantio/vouch/services/signer"
 	standardsigner "github.com/attestantio/vouch/services/signer/standard"
+	"github.com/attestantio/vouch/services/snapshot"
+	standardsnapshot "github.com/attestantio/vouch/services/snapshot/standard"
 	"github.com/attestantio/vouch/services/submitter"
 	immediatesubmitter "github.com/attestantio/vouch/services/submitter/immediate"
 	multinodesubmitter "github.com/attestantio/vouch/services/submitter/multinode"
@@ -77,17 +84,22 @@ import (
 	"github.com/attestantio/vouch/services/validatorsmanager"
 	standardvalidatorsmanager "github.com/attestantio/vouch/services/validatorsmanager/standard"
 	bestaggregateattestationstrategy "github.com/attestantio/vouch/strategies/aggregateattestation/best"
+	fallbackaggregateattestationstrategy "github.com/attestantio/vouch/strategies/aggregateattestation/fallback"
 	firstaggregateattestationstrategy "github.com/attestantio/vouch/strategies/aggregateattestation/first"
 	bestattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/best"
+	fallbackattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/fallback"
 	firstattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/first"
 	majorityattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/majority"
 	bestbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/beaconblockproposal/best"
+	fallbackbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/beaconblockproposal/fallback"
 	firstbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/beaconblockproposal/first"
+	fallbackbeaconblockrootstrategy "github.com/attestantio/vouch/strategies/beaconblockroot/fallback"
 	firstbeaconblockrootstrategy "github.com/attestantio/vouch/strategies/beaconblockroot/first"
 	majoritybeaconblockrootstrategy "github.com/attestantio/vouch/strategies/beaconblockroot/majority"
 	"github.com/attestantio/vouch/strategies/builderbid"
 	bestbuilderbidstrategy "github.com/attestantio/vouch/strategies/builderbid/best"
 	bestsynccommitteecontributionstrategy "github.com/attestantio/vouch/strategies/synccommitteecontribution/best"
+	fallbacksynccommitteecontributionstrategy "github.com/attestantio/vouch/strategies/synccommitteecontribution/fallback"
 	firstsynccommitteecontributionstrategy "github.com/attestantio/vouch/strategies/synccommitteecontribution/first"
 	"github.com/attestantio/vouch/util"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -136,6 +148,11 @@ func main2() int {
 		return 0
 	}
 
+	if err := util.CheckConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %v\n", err)
+		return 1
+	}
+
 	if err := initLogging(); err != nil {
 		fmt.Fprintf(os.Stderr, "failed to initialise logging: %v\n", err)
 		return 1
@@ -228,20 +245,40 @@ func fetchConfig() error {
 	viper.SetDefault("timeout", 2*time.Second)
 	viper.SetDefault("eth2client.timeout", 2*time.Minute)
 	viper.SetDefault("eth2client.allow-delayed-start", true)
+	viper.SetDefault("eth2client.sync-grace-period", 12*time.Second)
+	viper.SetDefault("metrics.prometheus.failure-streak-threshold", 5)
+	viper.SetDefault("dutyoutcomes.export.format", "json")
+	viper.SetDefault("dutyoutcomes.export.interval", time.Hour)
 	viper.SetDefault("controller.max-proposal-delay", 0)
-	viper.SetDefault("controller.max-attestation-delay", 4*time.Second)
-	viper.SetDefault("controller.max-sync-committee-message-delay", 4*time.Second)
-	viper.SetDefault("controller.attestation-aggregation-delay", 8*time.Second)
-	viper.SetDefault("controller.sync-committee-aggregation-delay", 8*time.Second)
+	// Message and aggregation delays are derived from the slot duration if not set.
 	viper.SetDefault("controller.fast-track.attestations", true)
 	viper.SetDefault("controller.fast-track.sync-committees", true)
 	viper.SetDefault("controller.fast-track.grace", 200*time.Millisecond)
+	viper.SetDefault("controller.activation-horizon", 2)
+	viper.SetDefault("controller.standby.promotion-slots", 2)
+	viper.SetDefault("controller.proposal-preparation-lead", 1)
+	viper.SetDefault("controller.unexpected-duties-policy", "drop")
+	viper.SetDefault("controller.capability-refresh", true)
 	viper.SetDefault("blockrelay.timeout", 1*time.Second)
 	viper.SetDefault("blockrelay.listen-address", "0.0.0.0:18550")
 	viper.SetDefault("blockrelay.fallback-gas-limit", uint64(30000000))
+	viper.SetDefault("blockrelay.max-bid-age", 12*time.Second)
+	viper.SetDefault("blockrelay.stale-registration-action", "reregister")
 	viper.SetDefault("accountmanager.dirk.timeout", 30*time.Second)
-	viper.SetDefault("strategies.beaconblockproposal.best.execution-payload-factor", float64(0.0005))
+	viper.SetDefault("accountmanager.conflict-resolution", "error")
+	viper.SetDefault("strategies.beaconblockproposal.best.execution-value-weight", float64(1))
+	viper.SetDefault("strategies.beaconblockproposal.best.execution-payload-factor", float64(0))
+	viper.SetDefault("strategies.beaconblockproposal.best.execution-payload-gas-baseline", uint64(15000000))
+	viper.SetDefault("strategies.beaconblockproposal.best.execution-payload-transactions-baseline", uint64(150))
+	viper.SetDefault("strategies.beaconblockproposal.best.value-source", "beacon-node")
+	viper.SetDefault("strategies.aggregateattestation.best.completion-threshold", float64(1))
+	viper.SetDefault("strategies.attestationdata.best.head-slot-policy", "none")
+	viper.SetDefault("strategies.attestationdata.best.proposal-consistency", "none")
 	viper.SetDefault("beaconblockproposer.builder-boost-factor", 91)
+	viper.SetDefault("beaconblockproposer.unblind-retry-remaining-fraction", float64(0.75))
+	viper.SetDefault("beaconblockproposer.fallback-proposal-deadline", time.Duration(0))
+	viper.SetDefault("attester.retry-remaining-fraction", float64(0.5))
+	viper.SetDefault("synccommitteemessenger.retry-remaining-fraction", float64(0.5))
 
 	if err := viper.ReadInConfig(); err != nil {
 		switch {
@@ -319,19 +356,20 @@ func startServices(ctx context.Context,
 		// Wait for genesis (or signal, or context cancel).
 		sigCh := make(chan os.Signal, 1)
 		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
-		log.Info().Str("genesis", fmt.Sprintf("%v", genesisTime)).Msg("Waiting for genesis")
-		ctx, cancel := context.WithDeadline(ctx, genesisTime)
-		defer cancel()
-		select {
-		case <-sigCh:
-			return nil, nil, errors.New("signal received")
-		case <-ctx.Done():
-			switch ctx.Err() {
-			case context.DeadlineExceeded:
-				log.Info().Msg("Genesis time")
-			case context.Canceled:
-				return nil, nil, errors.New("context cancelled")
+		waitCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			select {
+			case <-sigCh:
+				log.Info().Msg("Signal received")
+				cancel()
+			case <-waitCtx.Done():
 			}
+		}()
+		err := chainTime.(chaintime.GenesisWaiter).WaitForGenesis(waitCtx)
+		cancel()
+		signal.Stop(sigCh)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to wait for genesis")
 		}
 	}
 
@@ -345,7 +383,7 @@ func startServices(ctx context.Context,
 		return nil, nil, err
 	}
 
-	submitter, err := selectSubmitterStrategy(ctx, monitor, eth2Client)
+	submitter, err := selectSubmitterStrategy(ctx, monitor, eth2Client, chainTime)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to select submitter")
 	}
@@ -355,7 +393,7 @@ func startServices(ctx context.Context,
 		return nil, nil, err
 	}
 
-	beaconBlockProposer, attester, attestationAggregator, beaconCommitteeSubscriber, err := startSigningServices(ctx, majordomo, monitor, eth2Client, chainTime, cacheSvc, signerSvc, blockRelay, accountManager, submitter)
+	beaconBlockProposer, attester, attestationAggregator, beaconCommitteeSubscriber, proposalExplainer, err := startSigningServices(ctx, majordomo, monitor, eth2Client, chainTime, cacheSvc, signerSvc, blockRelay, accountManager, submitter)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -385,7 +423,7 @@ func startServices(ctx context.Context,
 	if bellatrixCapable {
 		log.Trace().Msg("Starting proposals preparer")
 		proposalPreparer, err = standardproposalpreparer.New(ctx,
-			standardproposalpreparer.WithLogLevel(util.LogLevel("proposalspreparor")),
+			standardproposalpreparer.WithLogLevel(util.LogLevel("proposalpreparer")),
 			standardproposalpreparer.WithMonitor(monitor),
 			standardproposalpreparer.WithChainTimeService(chainTime),
 			standardproposalpreparer.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
@@ -403,6 +441,30 @@ func startServices(ctx context.Context,
 		return nil, nil, errors.Wrap(err, "failed to fetch multiclient for controller")
 	}
 
+	// Duty outcomes are published to the log, and to the metrics service if it accepts them.
+	dutyOutcomeLogSink, err := logsink.New(ctx,
+		logsink.WithLogLevel(util.LogLevel("dutyoutcomes")),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start duty outcome log sink")
+	}
+	dutyOutcomeSinks := []metrics.DutyOutcomeSink{dutyOutcomeLogSink}
+	if dutyOutcomeSink, isSink := monitor.(metrics.DutyOutcomeSink); isSink {
+		dutyOutcomeSinks = append(dutyOutcomeSinks, dutyOutcomeSink)
+	}
+	if viper.GetString("dutyoutcomes.export.path") != "" {
+		dutyOutcomeExportSink, err := exportsink.New(ctx,
+			exportsink.WithLogLevel(util.LogLevel("dutyoutcomes")),
+			exportsink.WithPath(viper.GetString("dutyoutcomes.export.path")),
+			exportsink.WithFormat(viper.GetString("dutyoutcomes.export.format")),
+			exportsink.WithInterval(viper.GetDuration("dutyoutcomes.export.interval")),
+		)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to start duty outcome export sink")
+		}
+		dutyOutcomeSinks = append(dutyOutcomeSinks, dutyOutcomeExportSink)
+	}
+
 	log.Trace().Msg("Starting controller")
 	controller, err := standardcontroller.New(ctx,
 		standardcontroller.WithLogLevel(util.LogLevel("controller")),
@@ -411,8 +473,8 @@ func startServices(ctx context.Context,
 		standardcontroller.WithChainTimeService(chainTime),
 		standardcontroller.WithWaitedForGenesis(waitedForGenesis),
 		standardcontroller.WithProposerDutiesProvider(eth2Client.(eth2client.ProposerDutiesProvider)),
-		standardcontroller.WithAttesterDutiesProvider(eth2Client.(eth2client.AttesterDutiesProvider)),
-		standardcontroller.WithSyncCommitteeDutiesProvider(eth2Client.(eth2client.SyncCommitteeDutiesProvider)),
+		standardcontroller.WithAttesterDutiesProvider(util.ChunkedAttesterDutiesProvider(eth2Client.(eth2client.AttesterDutiesProvider), util.IndexChunkSize())),
+		standardcontroller.WithSyncCommitteeDutiesProvider(util.ChunkedSyncCommitteeDutiesProvider(eth2Client.(eth2client.SyncCommitteeDutiesProvider), util.IndexChunkSize())),
 		standardcontroller.WithEventsProvider(eventsConsensusClient.(eth2client.EventsProvider)),
 		standardcontroller.WithScheduler(scheduler),
 		standardcontroller.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
@@ -429,6 +491,7 @@ func startServices(ctx context.Context,
 		standardcontroller.WithAccountsRefresher(accountManager.(accountmanager.Refresher)),
 		standardcontroller.WithBlockToSlotSetter(cacheSvc.(cache.BlockRootToSlotSetter)),
 		standardcontroller.WithMaxProposalDelay(viper.GetDuration("controller.max-proposal-delay")),
+		standardcontroller.WithProposalOffset(viper.GetDuration("controller.proposal-offset")),
 		standardcontroller.WithMaxAttestationDelay(viper.GetDuration("controller.max-attestation-delay")),
 		standardcontroller.WithAttestationAggregationDelay(viper.GetDuration("controller.attestation-aggregation-delay")),
 		standardcontroller.WithMaxSyncCommitteeMessageDelay(viper.GetDuration("controller.max-sync-committee-message-delay")),
@@ -436,11 +499,41 @@ func startServices(ctx context.Context,
 		standardcontroller.WithFastTrackAttestations(viper.GetBool("controller.fast-track.attestations")),
 		standardcontroller.WithFastTrackSyncCommittees(viper.GetBool("controller.fast-track.sync-committees")),
 		standardcontroller.WithFastTrackGrace(viper.GetDuration("controller.fast-track.grace")),
+		standardcontroller.WithActivationHorizon(phase0.Epoch(viper.GetUint64("controller.activation-horizon"))),
+		standardcontroller.WithQuarantineUntilSlot(phase0.Slot(viper.GetUint64("controller.quarantine-until-slot"))),
+		standardcontroller.WithQuarantineSlots(viper.GetUint64("controller.quarantine-slots")),
+		standardcontroller.WithProposalNotificationLead(viper.GetUint64("controller.proposal-notification-lead")),
+		standardcontroller.WithProposalNotificationURL(viper.GetString("controller.proposal-notification-url")),
+		standardcontroller.WithProposalPreparationLead(viper.GetUint64("controller.proposal-preparation-lead")),
+		standardcontroller.WithUnexpectedDutiesPolicy(viper.GetString("controller.unexpected-duties-policy")),
+		standardcontroller.WithCapabilityRefresh(viper.GetBool("controller.capability-refresh")),
+		standardcontroller.WithStandby(viper.GetBool("controller.standby.enable")),
+		standardcontroller.WithStandbyPromotionSlots(viper.GetUint64("controller.standby.promotion-slots")),
+		standardcontroller.WithStandbyListenAddress(viper.GetString("controller.standby.listen-address")),
+		standardcontroller.WithStandbyPeerURL(viper.GetString("controller.standby.peer-url")),
+		standardcontroller.WithStandbyToken(viper.GetString("controller.standby.token")),
+		standardcontroller.WithDutyOutcomeSinks(dutyOutcomeSinks),
 	)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to start controller service")
 	}
 
+	if viper.GetString("snapshot.listen-address") != "" {
+		log.Trace().Msg("Starting snapshot service")
+		if _, err := standardsnapshot.New(ctx,
+			standardsnapshot.WithLogLevel(util.LogLevel("snapshot")),
+			standardsnapshot.WithListenAddress(viper.GetString("snapshot.listen-address")),
+			standardsnapshot.WithReleaseVersion(ReleaseVersion),
+			standardsnapshot.WithChainTime(chainTime),
+			standardsnapshot.WithScheduler(scheduler),
+			standardsnapshot.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
+			standardsnapshot.WithBeaconNodes(fetchKnownClients()),
+			standardsnapshot.WithProposalExplainer(proposalExplainer),
+		); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to start snapshot service")
+		}
+	}
+
 	return chainTime, controller, nil
 }
 
@@ -467,6 +560,7 @@ func startBasicServices(ctx context.Context,
 		standardchaintime.WithLogLevel(util.LogLevel("chaintime")),
 		standardchaintime.WithGenesisProvider(eth2Client.(eth2client.GenesisProvider)),
 		standardchaintime.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithMaxGenesisWait(viper.GetDuration("chaintime.max-genesis-wait")),
 	)
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "failed to start chain time service")
@@ -538,6 +632,8 @@ func startProviders(ctx context.Context,
 	eth2Client eth2client.Service,
 	chainTime chaintime.Service,
 	cache cache.Service,
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider,
+	builderBidProvider builderbidprovider.Service,
 ) (
 	graffitiprovider.Service,
 	eth2client.ProposalProvider,
@@ -552,19 +648,19 @@ func startProviders(ctx context.Context,
 	}
 
 	log.Trace().Msg("Selecting beacon block proposal provider")
-	beaconBlockProposalProvider, err := selectProposalProvider(ctx, monitor, eth2Client, chainTime, cache)
+	beaconBlockProposalProvider, err := selectProposalProvider(ctx, monitor, eth2Client, chainTime, cache, validatingAccountsProvider, builderBidProvider, viper.GetString("strategies.beaconblockproposal.style"))
 	if err != nil {
 		return nil, nil, nil, nil, errors.Wrap(err, "failed to select beacon block proposal provider")
 	}
 
 	log.Trace().Msg("Selecting attestation data provider")
-	attestationDataProvider, err := selectAttestationDataProvider(ctx, monitor, eth2Client, chainTime, cache)
+	attestationDataProvider, err := selectAttestationDataProvider(ctx, monitor, eth2Client, chainTime, cache, viper.GetString("strategies.attestationdata.style"))
 	if err != nil {
 		return nil, nil, nil, nil, errors.Wrap(err, "failed to select attestation data provider")
 	}
 
 	log.Trace().Msg("Selecting aggregate attestation provider")
-	aggregateAttestationProvider, err := selectAggregateAttestationProvider(ctx, monitor, eth2Client)
+	aggregateAttestationProvider, err := selectAggregateAttestationProvider(ctx, monitor, eth2Client, viper.GetString("strategies.aggregateattestation.style"))
 	if err != nil {
 		return nil, nil, nil, nil, errors.Wrap(err, "failed to select aggregate attestation provider")
 	}
@@ -588,7 +684,7 @@ func startAltairServices(ctx context.Context,
 ) {
 	log.Trace().Msg("Starting sync committee subscriber service")
 	syncCommitteeSubscriber, err := standardsynccommitteesubscriber.New(ctx,
-		standardsynccommitteesubscriber.WithLogLevel(util.LogLevel("synccommiteesubscriber")),
+		standardsynccommitteesubscriber.WithLogLevel(util.LogLevel("synccommitteesubscriber")),
 		standardsynccommitteesubscriber.WithMonitor(monitor.(metrics.SyncCommitteeSubscriptionMonitor)),
 		standardsynccommitteesubscriber.WithSyncCommitteeSubmitter(submitterStrategy.(submitter.SyncCommitteeSubscriptionsSubmitter)),
 	)
@@ -597,13 +693,13 @@ func startAltairServices(ctx context.Context,
 	}
 
 	log.Trace().Msg("Selecting sync committee contribution provider")
-	syncCommitteeContributionProvider, err := selectSyncCommitteeContributionProvider(ctx, monitor, eth2Client)
+	syncCommitteeContributionProvider, err := selectSyncCommitteeContributionProvider(ctx, monitor, eth2Client, viper.GetString("strategies.synccommitteecontribution.style"))
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "failed to select sync committee contribution provider")
 	}
 
 	log.Trace().Msg("Selecting beacon block root provider")
-	beaconBlockRootProvider, err := selectBeaconBlockRootProvider(ctx, monitor, eth2Client, cacheSvc)
+	beaconBlockRootProvider, err := selectBeaconBlockRootProvider(ctx, monitor, eth2Client, cacheSvc, viper.GetString("strategies.beaconblockroot.style"))
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "failed to select beacon block root provider")
 	}
@@ -632,6 +728,9 @@ func startAltairServices(ctx context.Context,
 		standardsynccommitteemessenger.WithChainTimeService(chainTime),
 		standardsynccommitteemessenger.WithSyncCommitteeAggregator(syncCommitteeAggregator),
 		standardsynccommitteemessenger.WithBeaconBlockRootProvider(beaconBlockRootProvider),
+		standardsynccommitteemessenger.WithBeaconBlockHeadersProvider(eth2Client.(eth2client.BeaconBlockHeadersProvider)),
+		standardsynccommitteemessenger.WithHeadFreshnessWait(viper.GetDuration("synccommitteemessenger.head-freshness-wait")),
+		standardsynccommitteemessenger.WithRetryRemainingFraction(viper.GetFloat64("synccommitteemessenger.retry-remaining-fraction")),
 		standardsynccommitteemessenger.WithSyncCommitteeMessagesSubmitter(submitterStrategy.(submitter.SyncCommitteeMessagesSubmitter)),
 		standardsynccommitteemessenger.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
 		standardsynccommitteemessenger.WithSyncCommitteeRootSigner(signerSvc.(signer.SyncCommitteeRootSigner)),
@@ -660,20 +759,52 @@ func startSigningServices(ctx context.Context,
 	attester.Service,
 	attestationaggregator.Service,
 	beaconcommitteesubscriber.Service,
+	snapshot.ProposalExplainer,
 	error,
 ) {
-	graffitiProvider, proposalProvider, attestationDataProvider, aggregateAttestationProvider, err := startProviders(ctx, majordomo, monitor, eth2Client, chainTime, cacheSvc)
+	graffitiProvider, proposalProvider, attestationDataProvider, aggregateAttestationProvider, err := startProviders(ctx, majordomo, monitor, eth2Client, chainTime, cacheSvc, accountManager.(accountmanager.ValidatingAccountsProvider), blockRelay.(builderbidprovider.Service))
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
+	}
+
+	var fallbackProposalProvider eth2client.ProposalProvider
+	if viper.GetBool("beaconblockproposer.fallback-proposal") {
+		fallbackProposalProvider = eth2Client.(eth2client.ProposalProvider)
+	}
+
+	proposerConfirmationProviders := make(map[string]eth2client.ProposerDutiesProvider)
+	if viper.GetBool("beaconblockproposer.confirm-proposer") {
+		for _, address := range util.BeaconNodeAddressesForProposing() {
+			client, err := fetchClient(ctx, monitor, address)
+			if err != nil {
+				return nil, nil, nil, nil, nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for proposer confirmation", address))
+			}
+			proposerConfirmationProviders[address] = client.(eth2client.ProposerDutiesProvider)
+		}
+	}
+
+	proposalRecordSinks := make([]beaconblockproposer.ProposalRecordSink, 0)
+	if viper.GetString("beaconblockproposer.record-file") != "" {
+		proposalRecordFileSink, err := filesink.New(ctx,
+			filesink.WithLogLevel(util.LogLevel("beaconblockproposer")),
+			filesink.WithPath(viper.GetString("beaconblockproposer.record-file")),
+		)
+		if err != nil {
+			return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start proposal record file sink")
+		}
+		proposalRecordSinks = append(proposalRecordSinks, proposalRecordFileSink)
 	}
 
 	beaconBlockProposer, err := standardbeaconblockproposer.New(ctx,
 		standardbeaconblockproposer.WithLogLevel(util.LogLevel("beaconblockproposer")),
 		standardbeaconblockproposer.WithChainTime(chainTime),
 		standardbeaconblockproposer.WithProposalDataProvider(proposalProvider),
+		standardbeaconblockproposer.WithFallbackProposalDataProvider(fallbackProposalProvider),
+		standardbeaconblockproposer.WithFallbackProposalDeadline(viper.GetDuration("beaconblockproposer.fallback-proposal-deadline")),
 		standardbeaconblockproposer.WithBlockAuctioneer(blockRelay.(blockauctioneer.BlockAuctioneer)),
 		standardbeaconblockproposer.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
 		standardbeaconblockproposer.WithExecutionChainHeadProvider(cacheSvc.(cache.ExecutionChainHeadProvider)),
+		standardbeaconblockproposer.WithProposedBlockRootSetter(cacheSvc.(cache.ProposedBlockRootSetter)),
 		standardbeaconblockproposer.WithGraffitiProvider(graffitiProvider),
 		standardbeaconblockproposer.WithMonitor(monitor),
 		standardbeaconblockproposer.WithProposalSubmitter(submitterStrategy.(submitter.ProposalSubmitter)),
@@ -682,9 +813,15 @@ func startSigningServices(ctx context.Context,
 		standardbeaconblockproposer.WithBlobSidecarSigner(signerSvc.(signer.BlobSidecarSigner)),
 		standardbeaconblockproposer.WithUnblindFromAllRelays(viper.GetBool("beaconblockproposer.unblind-from-all-relays")),
 		standardbeaconblockproposer.WithBuilderBoostFactor(viper.GetUint64("beaconblockproposer.builder-boost-factor")),
+		standardbeaconblockproposer.WithRelayOnly(viper.GetBool("beaconblockproposer.relay-only")),
+		standardbeaconblockproposer.WithUnblindRetryRemainingFraction(viper.GetFloat64("beaconblockproposer.unblind-retry-remaining-fraction")),
+		standardbeaconblockproposer.WithVerifyDeliveredValue(viper.GetBool("beaconblockproposer.verify-delivered-value")),
+		standardbeaconblockproposer.WithExecutionConfigProvider(blockRelay.(blockrelay.ExecutionConfigProvider)),
+		standardbeaconblockproposer.WithProposerConfirmationProviders(proposerConfirmationProviders),
+		standardbeaconblockproposer.WithProposalRecordSinks(proposalRecordSinks),
 	)
 	if err != nil {
-		return nil, nil, nil, nil, errors.Wrap(err, "failed to start beacon block proposer service")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start beacon block proposer service")
 	}
 
 	log.Trace().Msg("Starting attester")
@@ -698,9 +835,11 @@ func startSigningServices(ctx context.Context,
 		standardattester.WithMonitor(monitor.(metrics.AttestationMonitor)),
 		standardattester.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
 		standardattester.WithBeaconAttestationsSigner(signerSvc.(signer.BeaconAttestationsSigner)),
+		standardattester.WithLockAttestationData(viper.GetBool("attester.lock-attestation-data")),
+		standardattester.WithRetryRemainingFraction(viper.GetFloat64("attester.retry-remaining-fraction")),
 	)
 	if err != nil {
-		return nil, nil, nil, nil, errors.Wrap(err, "failed to start attester service")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start attester service")
 	}
 
 	log.Trace().Msg("Starting beacon attestation aggregator")
@@ -713,26 +852,30 @@ func startSigningServices(ctx context.Context,
 		standardattestationaggregator.WithSlotSelectionSigner(signerSvc.(signer.SlotSelectionSigner)),
 		standardattestationaggregator.WithAggregateAndProofSigner(signerSvc.(signer.AggregateAndProofSigner)),
 		standardattestationaggregator.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardattestationaggregator.WithMaxAggregationsPerSlot(viper.GetUint64("attestationaggregator.max-aggregations-per-slot")),
 	)
 	if err != nil {
-		return nil, nil, nil, nil, errors.Wrap(err, "failed to start beacon attestation aggregator service")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start beacon attestation aggregator service")
 	}
 
 	log.Trace().Msg("Starting beacon committee subscriber service")
 	beaconCommitteeSubscriber, err := standardbeaconcommitteesubscriber.New(ctx,
-		standardbeaconcommitteesubscriber.WithLogLevel(util.LogLevel("beaconcommiteesubscriber")),
+		standardbeaconcommitteesubscriber.WithLogLevel(util.LogLevel("beaconcommitteesubscriber")),
 		standardbeaconcommitteesubscriber.WithProcessConcurrency(util.ProcessConcurrency("beaconcommitteesubscriber")),
 		standardbeaconcommitteesubscriber.WithMonitor(monitor.(metrics.BeaconCommitteeSubscriptionMonitor)),
 		standardbeaconcommitteesubscriber.WithChainTimeService(chainTime),
-		standardbeaconcommitteesubscriber.WithAttesterDutiesProvider(eth2Client.(eth2client.AttesterDutiesProvider)),
+		standardbeaconcommitteesubscriber.WithAttesterDutiesProvider(util.ChunkedAttesterDutiesProvider(eth2Client.(eth2client.AttesterDutiesProvider), util.IndexChunkSize())),
 		standardbeaconcommitteesubscriber.WithAttestationAggregator(attestationAggregator),
 		standardbeaconcommitteesubscriber.WithBeaconCommitteeSubmitter(submitterStrategy.(submitter.BeaconCommitteeSubscriptionsSubmitter)),
 	)
 	if err != nil {
-		return nil, nil, nil, nil, errors.Wrap(err, "failed to start beacon committee subscriber service")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "failed to start beacon committee subscriber service")
 	}
 
-	return beaconBlockProposer, attester, attestationAggregator, beaconCommitteeSubscriber, nil
+	// The proposal explainer is only available if the proposal provider can explain its selections.
+	proposalExplainer, _ := proposalProvider.(snapshot.ProposalExplainer)
+
+	return beaconBlockProposer, attester, attestationAggregator, beaconCommitteeSubscriber, proposalExplainer, nil
 }
 
 // logModules logs a list of modules with their versions.
@@ -857,6 +1000,7 @@ func startMonitor(ctx context.Context,
 			prometheusmetrics.WithAddress(viper.GetString("metrics.prometheus.listen-address")),
 			prometheusmetrics.WithChainTime(chainTime),
 			prometheusmetrics.WithCreateServer(createServer),
+			prometheusmetrics.WithFailureStreakThreshold(viper.GetUint64("metrics.prometheus.failure-streak-threshold")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start prometheus metrics service")
@@ -879,12 +1023,16 @@ func selectScheduler(ctx context.Context, monitor metrics.Service) (scheduler.Se
 		scheduler, err = advancedscheduler.New(ctx,
 			advancedscheduler.WithLogLevel(util.LogLevel("scheduler.advanced")),
 			advancedscheduler.WithMonitor(monitor.(metrics.SchedulerMonitor)),
+			advancedscheduler.WithMaxJobDelay(viper.GetDuration("scheduler.max-job-delay")),
+			advancedscheduler.WithOverdueSkipClasses(standardcontroller.OverdueSkippableJobClasses()),
 		)
 	default:
 		log.Info().Msg("Starting advanced scheduler")
 		scheduler, err = advancedscheduler.New(ctx,
 			advancedscheduler.WithLogLevel(util.LogLevel("scheduler.advanced")),
 			advancedscheduler.WithMonitor(monitor.(metrics.SchedulerMonitor)),
+			advancedscheduler.WithMaxJobDelay(viper.GetDuration("scheduler.max-job-delay")),
+			advancedscheduler.WithOverdueSkipClasses(standardcontroller.OverdueSkippableJobClasses()),
 		)
 	}
 	if err != nil {
@@ -941,11 +1089,18 @@ func startValidatorsManager(ctx context.Context, monitor metrics.Service, eth2Cl
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain far future epoch")
 	}
+
+	// Obtaining validator information is heavy, so use the bulk query beacon nodes if they are configured.
+	bulkQueryClient, err := fetchBulkQueryClient(ctx, monitor, eth2Client)
+	if err != nil {
+		return nil, err
+	}
+
 	validatorsManager, err := standardvalidatorsmanager.New(ctx,
 		standardvalidatorsmanager.WithLogLevel(util.LogLevel("validatorsmanager")),
 		standardvalidatorsmanager.WithMonitor(monitor.(metrics.ValidatorsManagerMonitor)),
 		standardvalidatorsmanager.WithClientMonitor(monitor.(metrics.ClientMonitor)),
-		standardvalidatorsmanager.WithValidatorsProvider(eth2Client.(eth2client.ValidatorsProvider)),
+		standardvalidatorsmanager.WithValidatorsProvider(bulkQueryClient.(eth2client.ValidatorsProvider)),
 		standardvalidatorsmanager.WithFarFutureEpoch(farFutureEpoch),
 	)
 	if err != nil {
@@ -961,6 +1116,7 @@ func startSigner(ctx context.Context, monitor metrics.Service, eth2Client eth2cl
 		standardsigner.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 		standardsigner.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
 		standardsigner.WithDomainProvider(eth2Client.(eth2client.DomainProvider)),
+		standardsigner.WithAttestationPriorityWait(viper.GetDuration("signer.attestation-priority-wait")),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to start signer provider service")
@@ -970,12 +1126,7 @@ func startSigner(ctx context.Context, monitor metrics.Service, eth2Client eth2cl
 
 // startAccountManager starts the appropriate account manager given user input.
 func startAccountManager(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, validatorsManager validatorsmanager.Service, majordomo majordomo.Service, chainTime chaintime.Service) (accountmanager.Service, error) {
-	if len(viper.GetStringSlice("accountmanager.dirk.accounts")) > 0 &&
-		len(viper.GetStringSlice("accountmanager.wallet.accounts")) > 0 {
-		return nil, errors.New("multiple account managers configured; Vouch only supports a single account manager")
-	}
-
-	var accountManager accountmanager.Service
+	accountManagers := make([]*multiaccountmanager.NamedAccountManager, 0)
 	if len(viper.GetStringSlice("accountmanager.dirk.accounts")) > 0 {
 		log.Info().Msg("Starting dirk account manager")
 		certPEMBlock, err := majordomo.Fetch(ctx, viper.GetString("accountmanager.dirk.client-cert"))
@@ -993,7 +1144,7 @@ func startAccountManager(ctx context.Context, monitor metrics.Service, eth2Clien
 				return nil, errors.Wrap(err, "failed to obtain client CA certificate")
 			}
 		}
-		accountManager, err = dirkaccountmanager.New(ctx,
+		accountManager, err := dirkaccountmanager.New(ctx,
 			dirkaccountmanager.WithLogLevel(util.LogLevel("accountmanager.dirk")),
 			dirkaccountmanager.WithMonitor(monitor.(metrics.AccountManagerMonitor)),
 			dirkaccountmanager.WithTimeout(util.Timeout("accountmanager.dirk")),
@@ -1012,12 +1163,14 @@ func startAccountManager(ctx context.Context, monitor metrics.Service, eth2Clien
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start dirk account manager service")
 		}
-		return accountManager, nil
+		accountManagers = append(accountManagers, &multiaccountmanager.NamedAccountManager{
+			Name:           "dirk",
+			AccountManager: accountManager,
+		})
 	}
 
 	if len(viper.GetStringSlice("accountmanager.wallet.accounts")) > 0 {
 		log.Info().Msg("Starting wallet account manager")
-		var err error
 		passphrases := make([][]byte, 0)
 		for _, passphraseURL := range viper.GetStringSlice("accountmanager.wallet.passphrases") {
 			passphrase, err := majordomo.Fetch(ctx, passphraseURL)
@@ -1030,7 +1183,7 @@ func startAccountManager(ctx context.Context, monitor metrics.Service, eth2Clien
 		if len(passphrases) == 0 {
 			return nil, errors.New("no passphrases for wallet supplied")
 		}
-		accountManager, err = walletaccountmanager.New(ctx,
+		accountManager, err := walletaccountmanager.New(ctx,
 			walletaccountmanager.WithLogLevel(util.LogLevel("accountmanager.wallet")),
 			walletaccountmanager.WithMonitor(monitor.(metrics.AccountManagerMonitor)),
 			walletaccountmanager.WithProcessConcurrency(util.ProcessConcurrency("accountmanager.wallet")),
@@ -1046,10 +1199,31 @@ func startAccountManager(ctx context.Context, monitor metrics.Service, eth2Clien
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start wallet account manager service")
 		}
-		return accountManager, nil
+		accountManagers = append(accountManagers, &multiaccountmanager.NamedAccountManager{
+			Name:           "wallet",
+			AccountManager: accountManager,
+		})
 	}
 
-	return nil, errors.New("no account manager defined")
+	switch len(accountManagers) {
+	case 0:
+		return nil, errors.New("no account manager defined")
+	case 1:
+		return accountManagers[0].AccountManager, nil
+	default:
+		log.Info().Msg("Starting multi account manager")
+		accountManager, err := multiaccountmanager.New(ctx,
+			multiaccountmanager.WithLogLevel(util.LogLevel("accountmanager.multi")),
+			multiaccountmanager.WithAccountManagers(accountManagers),
+			multiaccountmanager.WithConflictResolution(viper.GetString("accountmanager.conflict-resolution")),
+			multiaccountmanager.WithPreferredAccountManager(viper.GetString("accountmanager.preferred-account-manager")),
+			multiaccountmanager.WithCurrentEpochProvider(chainTime),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start multi account manager service")
+		}
+		return accountManager, nil
+	}
 }
 
 // selectAttestationDataProvider selects the appropriate attestation data provider given user input.
@@ -1058,28 +1232,39 @@ func selectAttestationDataProvider(ctx context.Context,
 	eth2Client eth2client.Service,
 	chainTime chaintime.Service,
 	cacheSvc cache.Service,
+	style string,
 ) (eth2client.AttestationDataProvider, error) {
 	var attestationDataProvider eth2client.AttestationDataProvider
 	var err error
-	switch viper.GetString("strategies.attestationdata.style") {
+	switch style {
 	case "best":
 		log.Info().Msg("Starting best attestation data strategy")
 		attestationDataProviders := make(map[string]eth2client.AttestationDataProvider)
+		finalityProviders := make(map[string]eth2client.FinalityProvider)
 		for _, address := range util.BeaconNodeAddresses("strategies.attestationdata.best") {
 			client, err := fetchClient(ctx, monitor, address)
 			if err != nil {
 				return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for attestation data strategy", address))
 			}
 			attestationDataProviders[address] = client.(eth2client.AttestationDataProvider)
+			if viper.GetBool("strategies.attestationdata.best.check-finality") {
+				finalityProviders[address] = client.(eth2client.FinalityProvider)
+			}
 		}
 		attestationDataProvider, err = bestattestationdatastrategy.New(ctx,
+			bestattestationdatastrategy.WithMonitor(monitor),
 			bestattestationdatastrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			bestattestationdatastrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.attestationdata.best")),
 			bestattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.best")),
 			bestattestationdatastrategy.WithAttestationDataProviders(attestationDataProviders),
 			bestattestationdatastrategy.WithTimeout(util.Timeout("strategies.attestationdata.best")),
+			bestattestationdatastrategy.WithLogResults(util.HierarchicalBool("log-results", "strategies.attestationdata.best")),
 			bestattestationdatastrategy.WithChainTime(chainTime),
 			bestattestationdatastrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+			bestattestationdatastrategy.WithFinalityProviders(finalityProviders),
+			bestattestationdatastrategy.WithHeadSlotPolicy(viper.GetString("strategies.attestationdata.best.head-slot-policy")),
+			bestattestationdatastrategy.WithProposedBlockRootCache(cacheSvc.(cache.ProposedBlockRootProvider)),
+			bestattestationdatastrategy.WithProposalConsistency(viper.GetString("strategies.attestationdata.best.proposal-consistency")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start best attestation data strategy")
@@ -1122,10 +1307,32 @@ func selectAttestationDataProvider(ctx context.Context,
 			firstattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.first")),
 			firstattestationdatastrategy.WithAttestationDataProviders(attestationDataProviders),
 			firstattestationdatastrategy.WithTimeout(util.Timeout("strategies.attestationdata.first")),
+			firstattestationdatastrategy.WithChainTime(chainTime),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first attestation data strategy")
 		}
+	case "fallback":
+		log.Info().Msg("Starting fallback attestation data strategy")
+		attestationDataProviders := make([]eth2client.AttestationDataProvider, 0)
+		for _, fallbackStyle := range viper.GetStringSlice("strategies.attestationdata.fallback.styles") {
+			if fallbackStyle == "fallback" {
+				return nil, errors.New("fallback attestation data strategy cannot include itself")
+			}
+			provider, err := selectAttestationDataProvider(ctx, monitor, eth2Client, chainTime, cacheSvc, fallbackStyle)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to start %s attestation data strategy for fallback", fallbackStyle))
+			}
+			attestationDataProviders = append(attestationDataProviders, provider)
+		}
+		attestationDataProvider, err = fallbackattestationdatastrategy.New(ctx,
+			fallbackattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.fallback")),
+			fallbackattestationdatastrategy.WithAttestationDataProviders(attestationDataProviders),
+			fallbackattestationdatastrategy.WithTimeout(util.Timeout("strategies.attestationdata.fallback")),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start fallback attestation data strategy")
+		}
 	default:
 		log.Info().Msg("Starting simple attestation data strategy")
 		attestationDataProvider = eth2Client.(eth2client.AttestationDataProvider)
@@ -1138,13 +1345,14 @@ func selectAttestationDataProvider(ctx context.Context,
 func selectAggregateAttestationProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	style string,
 ) (
 	eth2client.AggregateAttestationProvider,
 	error,
 ) {
 	var aggregateAttestationProvider eth2client.AggregateAttestationProvider
 	var err error
-	switch viper.GetString("strategies.aggregateattestation.style") {
+	switch style {
 	case "best":
 		log.Info().Msg("Starting best aggregate attestation strategy")
 		aggregateAttestationProviders := make(map[string]eth2client.AggregateAttestationProvider)
@@ -1161,6 +1369,9 @@ func selectAggregateAttestationProvider(ctx context.Context,
 			bestaggregateattestationstrategy.WithLogLevel(util.LogLevel("strategies.aggregateattestation.best")),
 			bestaggregateattestationstrategy.WithAggregateAttestationProviders(aggregateAttestationProviders),
 			bestaggregateattestationstrategy.WithTimeout(util.Timeout("strategies.aggregateattestation.best")),
+			bestaggregateattestationstrategy.WithLogResults(util.HierarchicalBool("log-results", "strategies.aggregateattestation.best")),
+			bestaggregateattestationstrategy.WithCompletionThreshold(viper.GetFloat64("strategies.aggregateattestation.best.completion-threshold")),
+			bestaggregateattestationstrategy.WithCompletionBonus(viper.GetFloat64("strategies.aggregateattestation.best.completion-bonus")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start best aggregate attestation strategy")
@@ -1184,6 +1395,27 @@ func selectAggregateAttestationProvider(ctx context.Context,
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first aggregate attestation strategy")
 		}
+	case "fallback":
+		log.Info().Msg("Starting fallback aggregate attestation strategy")
+		aggregateAttestationProviders := make([]eth2client.AggregateAttestationProvider, 0)
+		for _, fallbackStyle := range viper.GetStringSlice("strategies.aggregateattestation.fallback.styles") {
+			if fallbackStyle == "fallback" {
+				return nil, errors.New("fallback aggregate attestation strategy cannot include itself")
+			}
+			provider, err := selectAggregateAttestationProvider(ctx, monitor, eth2Client, fallbackStyle)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to start %s aggregate attestation strategy for fallback", fallbackStyle))
+			}
+			aggregateAttestationProviders = append(aggregateAttestationProviders, provider)
+		}
+		aggregateAttestationProvider, err = fallbackaggregateattestationstrategy.New(ctx,
+			fallbackaggregateattestationstrategy.WithLogLevel(util.LogLevel("strategies.aggregateattestation.fallback")),
+			fallbackaggregateattestationstrategy.WithAggregateAttestationProviders(aggregateAttestationProviders),
+			fallbackaggregateattestationstrategy.WithTimeout(util.Timeout("strategies.aggregateattestation.fallback")),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start fallback aggregate attestation strategy")
+		}
 	default:
 		log.Info().Msg("Starting simple aggregate attestation strategy")
 		aggregateAttestationProvider = eth2Client.(eth2client.AggregateAttestationProvider)
@@ -1198,10 +1430,13 @@ func selectProposalProvider(ctx context.Context,
 	eth2Client eth2client.Service,
 	chainTime chaintime.Service,
 	cacheSvc cache.Service,
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider,
+	builderBidProvider builderbidprovider.Service,
+	style string,
 ) (eth2client.ProposalProvider, error) {
 	var proposalProvider eth2client.ProposalProvider
 	var err error
-	switch viper.GetString("strategies.beaconblockproposal.style") {
+	switch style {
 	case "best":
 		log.Info().Msg("Starting best beacon block proposal strategy")
 		proposalProviders := make(map[string]eth2client.ProposalProvider)
@@ -1213,6 +1448,7 @@ func selectProposalProvider(ctx context.Context,
 			proposalProviders[address] = client.(eth2client.ProposalProvider)
 		}
 		proposalProvider, err = bestbeaconblockproposalstrategy.New(ctx,
+			bestbeaconblockproposalstrategy.WithMonitor(monitor),
 			bestbeaconblockproposalstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			bestbeaconblockproposalstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.beaconblockproposal.best")),
 			bestbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockproposal.best")),
@@ -1222,8 +1458,22 @@ func selectProposalProvider(ctx context.Context,
 			bestbeaconblockproposalstrategy.WithProposalProviders(proposalProviders),
 			bestbeaconblockproposalstrategy.WithSignedBeaconBlockProvider(eth2Client.(eth2client.SignedBeaconBlockProvider)),
 			bestbeaconblockproposalstrategy.WithTimeout(util.Timeout("strategies.beaconblockproposal.best")),
+			bestbeaconblockproposalstrategy.WithLogResults(util.HierarchicalBool("log-results", "strategies.beaconblockproposal.best")),
 			bestbeaconblockproposalstrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+			bestbeaconblockproposalstrategy.WithExecutionValueWeight(viper.GetFloat64("strategies.beaconblockproposal.best.execution-value-weight")),
 			bestbeaconblockproposalstrategy.WithExecutionPayloadFactor(viper.GetFloat64("strategies.beaconblockproposal.best.execution-payload-factor")),
+			bestbeaconblockproposalstrategy.WithExecutionPayloadGasBaseline(viper.GetUint64("strategies.beaconblockproposal.best.execution-payload-gas-baseline")),
+			bestbeaconblockproposalstrategy.WithExecutionPayloadTransactionsBaseline(viper.GetUint64("strategies.beaconblockproposal.best.execution-payload-transactions-baseline")),
+			bestbeaconblockproposalstrategy.WithPriorBlocksWalkLimit(viper.GetUint64("strategies.beaconblockproposal.best.prior-blocks-walk-limit")),
+			bestbeaconblockproposalstrategy.WithDiversityBias(viper.GetFloat64("strategies.beaconblockproposal.best.diversity-bias")),
+			bestbeaconblockproposalstrategy.WithValueSource(viper.GetString("strategies.beaconblockproposal.best.value-source")),
+			bestbeaconblockproposalstrategy.WithBuilderBidProvider(builderBidProvider),
+			bestbeaconblockproposalstrategy.WithSyncParticipationMinimum(viper.GetFloat64("strategies.beaconblockproposal.best.sync-participation-minimum")),
+			bestbeaconblockproposalstrategy.WithSyncParticipationPenalty(viper.GetFloat64("strategies.beaconblockproposal.best.sync-participation-penalty")),
+			bestbeaconblockproposalstrategy.WithShadowScoring(viper.GetBool("strategies.beaconblockproposal.best.shadow.enable")),
+			bestbeaconblockproposalstrategy.WithShadowSyncParticipationMinimum(viper.GetFloat64("strategies.beaconblockproposal.best.shadow.sync-participation-minimum")),
+			bestbeaconblockproposalstrategy.WithShadowSyncParticipationPenalty(viper.GetFloat64("strategies.beaconblockproposal.best.shadow.sync-participation-penalty")),
+			bestbeaconblockproposalstrategy.WithValidatingAccountsProvider(validatingAccountsProvider),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start best beacon block proposal strategy")
@@ -1247,6 +1497,27 @@ func selectProposalProvider(ctx context.Context,
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first beacon block proposal strategy")
 		}
+	case "fallback":
+		log.Info().Msg("Starting fallback beacon block proposal strategy")
+		proposalProviders := make([]eth2client.ProposalProvider, 0)
+		for _, fallbackStyle := range viper.GetStringSlice("strategies.beaconblockproposal.fallback.styles") {
+			if fallbackStyle == "fallback" {
+				return nil, errors.New("fallback beacon block proposal strategy cannot include itself")
+			}
+			provider, err := selectProposalProvider(ctx, monitor, eth2Client, chainTime, cacheSvc, validatingAccountsProvider, builderBidProvider, fallbackStyle)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to start %s beacon block proposal strategy for fallback", fallbackStyle))
+			}
+			proposalProviders = append(proposalProviders, provider)
+		}
+		proposalProvider, err = fallbackbeaconblockproposalstrategy.New(ctx,
+			fallbackbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockproposal.fallback")),
+			fallbackbeaconblockproposalstrategy.WithProposalProviders(proposalProviders),
+			fallbackbeaconblockproposalstrategy.WithTimeout(util.Timeout("strategies.beaconblockproposal.fallback")),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start fallback beacon block proposal strategy")
+		}
 	default:
 		log.Info().Msg("Starting simple beacon block proposal strategy")
 		proposalProvider = eth2Client.(eth2client.ProposalProvider)
@@ -1259,10 +1530,11 @@ func selectProposalProvider(ctx context.Context,
 func selectSyncCommitteeContributionProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	style string,
 ) (eth2client.SyncCommitteeContributionProvider, error) {
 	var syncCommitteeContributionProvider eth2client.SyncCommitteeContributionProvider
 	var err error
-	switch viper.GetString("strategies.synccommitteecontribution.style") {
+	switch style {
 	case "best":
 		log.Info().Msg("Starting best sync committee contribution strategy")
 		syncCommitteeContributionProviders := make(map[string]eth2client.SyncCommitteeContributionProvider)
@@ -1279,6 +1551,8 @@ func selectSyncCommitteeContributionProvider(ctx context.Context,
 			bestsynccommitteecontributionstrategy.WithLogLevel(util.LogLevel("strategies.synccommitteecontribution.best")),
 			bestsynccommitteecontributionstrategy.WithSyncCommitteeContributionProviders(syncCommitteeContributionProviders),
 			bestsynccommitteecontributionstrategy.WithTimeout(util.Timeout("strategies.synccommitteecontribution.best")),
+			bestsynccommitteecontributionstrategy.WithLogResults(util.HierarchicalBool("log-results", "strategies.synccommitteecontribution.best")),
+			bestsynccommitteecontributionstrategy.WithMergeContributions(viper.GetBool("strategies.synccommitteecontribution.best.merge-contributions")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start best sync committee contribution strategy")
@@ -1302,6 +1576,27 @@ func selectSyncCommitteeContributionProvider(ctx context.Context,
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first sync committee contribution strategy")
 		}
+	case "fallback":
+		log.Info().Msg("Starting fallback sync committee contribution strategy")
+		syncCommitteeContributionProviders := make([]eth2client.SyncCommitteeContributionProvider, 0)
+		for _, fallbackStyle := range viper.GetStringSlice("strategies.synccommitteecontribution.fallback.styles") {
+			if fallbackStyle == "fallback" {
+				return nil, errors.New("fallback sync committee contribution strategy cannot include itself")
+			}
+			provider, err := selectSyncCommitteeContributionProvider(ctx, monitor, eth2Client, fallbackStyle)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to start %s sync committee contribution strategy for fallback", fallbackStyle))
+			}
+			syncCommitteeContributionProviders = append(syncCommitteeContributionProviders, provider)
+		}
+		syncCommitteeContributionProvider, err = fallbacksynccommitteecontributionstrategy.New(ctx,
+			fallbacksynccommitteecontributionstrategy.WithLogLevel(util.LogLevel("strategies.synccommitteecontribution.fallback")),
+			fallbacksynccommitteecontributionstrategy.WithSyncCommitteeContributionProviders(syncCommitteeContributionProviders),
+			fallbacksynccommitteecontributionstrategy.WithTimeout(util.Timeout("strategies.synccommitteecontribution.fallback")),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start fallback sync committee contribution strategy")
+		}
 	default:
 		log.Info().Msg("Starting simple sync committee contribution strategy")
 		syncCommitteeContributionProvider = eth2Client.(eth2client.SyncCommitteeContributionProvider)
@@ -1315,10 +1610,11 @@ func selectBeaconBlockRootProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
 	cacheSvc cache.Service,
+	style string,
 ) (eth2client.BeaconBlockRootProvider, error) {
 	var beaconBlockRootProvider eth2client.BeaconBlockRootProvider
 	var err error
-	switch viper.GetString("strategies.beaconblockroot.style") {
+	switch style {
 	case "majority":
 		log.Info().Msg("Starting majority beacon block root strategy")
 		beaconBlockRootProviders := make(map[string]eth2client.BeaconBlockRootProvider)
@@ -1360,6 +1656,27 @@ func selectBeaconBlockRootProvider(ctx context.Context,
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first beacon block root strategy")
 		}
+	case "fallback":
+		log.Info().Msg("Starting fallback beacon block root strategy")
+		beaconBlockRootProviders := make([]eth2client.BeaconBlockRootProvider, 0)
+		for _, fallbackStyle := range viper.GetStringSlice("strategies.beaconblockroot.fallback.styles") {
+			if fallbackStyle == "fallback" {
+				return nil, errors.New("fallback beacon block root strategy cannot include itself")
+			}
+			provider, err := selectBeaconBlockRootProvider(ctx, monitor, eth2Client, cacheSvc, fallbackStyle)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to start %s beacon block root strategy for fallback", fallbackStyle))
+			}
+			beaconBlockRootProviders = append(beaconBlockRootProviders, provider)
+		}
+		beaconBlockRootProvider, err = fallbackbeaconblockrootstrategy.New(ctx,
+			fallbackbeaconblockrootstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockroot.fallback")),
+			fallbackbeaconblockrootstrategy.WithBeaconBlockRootProviders(beaconBlockRootProviders),
+			fallbackbeaconblockrootstrategy.WithTimeout(util.Timeout("strategies.beaconblockroot.fallback")),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start fallback beacon block root strategy")
+		}
 	default:
 		log.Info().Msg("Starting simple beacon block root strategy")
 		beaconBlockRootProvider = eth2Client.(eth2client.BeaconBlockRootProvider)
@@ -1369,7 +1686,7 @@ func selectBeaconBlockRootProvider(ctx context.Context,
 }
 
 // selectSubmitterStrategy selects the appropriate submitter strategy given user input.
-func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service) (submitter.Service, error) {
+func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, chainTime chaintime.Service) (submitter.Service, error) {
 	log.Trace().Msg("Selecting submitter strategy")
 
 	var submitter submitter.Service
@@ -1380,16 +1697,56 @@ func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2C
 		submitter, err = startMultinodeSubmitter(ctx, monitor)
 	default:
 		log.Info().Msg("Starting standard submitter strategy")
+		// Allow proposals to be submitted to alternative beacon nodes if the main client fails.
+		fallbackProposalSubmitters := make(map[string]eth2client.ProposalSubmitter)
+		if addresses := util.BeaconNodeAddressesForProposing(); len(addresses) > 1 {
+			for _, address := range addresses {
+				client, err := fetchClient(ctx, monitor, address)
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for fallback proposal submitter", address))
+				}
+				fallbackProposalSubmitters[address] = client.(eth2client.ProposalSubmitter)
+			}
+		}
+		// Allow attestations to be submitted to alternative beacon nodes if the main client does not accept them.
+		verifyAttestations := viper.GetBool("submitter.verify-attestations")
+		fallbackAttestationsSubmitters := make(map[string]eth2client.AttestationsSubmitter)
+		if addresses := util.BeaconNodeAddressesForAttesting(); verifyAttestations && len(addresses) > 1 {
+			for _, address := range addresses {
+				client, err := fetchClient(ctx, monitor, address)
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for fallback attestations submitter", address))
+				}
+				fallbackAttestationsSubmitters[address] = client.(eth2client.AttestationsSubmitter)
+			}
+		}
+		// Allow aggregate attestations to be submitted to alternative beacon nodes if the main client does not accept them.
+		fallbackAggregateSubmitters := make(map[string]eth2client.AggregateAttestationsSubmitter)
+		if addresses := util.BeaconNodeAddressesForAttesting(); len(addresses) > 1 {
+			for _, address := range addresses {
+				client, err := fetchClient(ctx, monitor, address)
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for fallback aggregate attestations submitter", address))
+				}
+				fallbackAggregateSubmitters[address] = client.(eth2client.AggregateAttestationsSubmitter)
+			}
+		}
 		submitter, err = immediatesubmitter.New(ctx,
 			immediatesubmitter.WithLogLevel(util.LogLevel("submitter.immediate")),
 			immediatesubmitter.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			immediatesubmitter.WithProposalSubmitter(eth2Client.(eth2client.ProposalSubmitter)),
+			immediatesubmitter.WithFallbackProposalSubmitters(fallbackProposalSubmitters),
 			immediatesubmitter.WithAttestationsSubmitter(eth2Client.(eth2client.AttestationsSubmitter)),
+			immediatesubmitter.WithVerifyAttestations(verifyAttestations),
+			immediatesubmitter.WithFallbackAttestationsSubmitters(fallbackAttestationsSubmitters),
+			immediatesubmitter.WithRecoverLateAttestations(verifyAttestations && viper.GetBool("submitter.recover-late-attestations")),
+			immediatesubmitter.WithChainTime(chainTime),
 			immediatesubmitter.WithSyncCommitteeMessagesSubmitter(eth2Client.(eth2client.SyncCommitteeMessagesSubmitter)),
 			immediatesubmitter.WithSyncCommitteeContributionsSubmitter(eth2Client.(eth2client.SyncCommitteeContributionsSubmitter)),
 			immediatesubmitter.WithSyncCommitteeSubscriptionsSubmitter(eth2Client.(eth2client.SyncCommitteeSubscriptionsSubmitter)),
 			immediatesubmitter.WithBeaconCommitteeSubscriptionsSubmitter(eth2Client.(eth2client.BeaconCommitteeSubscriptionsSubmitter)),
 			immediatesubmitter.WithAggregateAttestationsSubmitter(eth2Client.(eth2client.AggregateAttestationsSubmitter)),
+			immediatesubmitter.WithFallbackAggregateAttestationsSubmitters(fallbackAggregateSubmitters),
 			immediatesubmitter.WithProposalPreparationsSubmitter(eth2Client.(eth2client.ProposalPreparationsSubmitter)),
 		)
 	}
@@ -1406,7 +1763,7 @@ func startMultinodeSubmitter(ctx context.Context,
 	error,
 ) {
 	aggregateAttestationSubmitters := make(map[string]eth2client.AggregateAttestationsSubmitter)
-	for _, address := range util.BeaconNodeAddresses("submitter.aggregateattestation.multinode") {
+	for _, address := range util.BeaconNodeAddressesForSubmitting("aggregateattestation") {
 		client, err := fetchClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for aggregate attestation submitter strategy", address))
@@ -1415,7 +1772,7 @@ func startMultinodeSubmitter(ctx context.Context,
 	}
 
 	attestationsSubmitters := make(map[string]eth2client.AttestationsSubmitter)
-	for _, address := range util.BeaconNodeAddresses("submitter.attestation.multinode") {
+	for _, address := range util.BeaconNodeAddressesForSubmitting("attestation") {
 		client, err := fetchClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for attestation submitter strategy", address))
@@ -1424,7 +1781,7 @@ func startMultinodeSubmitter(ctx context.Context,
 	}
 
 	proposalSubmitters := make(map[string]eth2client.ProposalSubmitter)
-	for _, address := range util.BeaconNodeAddresses("submitter.proposal.multinode") {
+	for _, address := range util.BeaconNodeAddressesForSubmitting("proposal") {
 		client, err := fetchClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for proposal submitter strategy", address))
@@ -1433,7 +1790,7 @@ func startMultinodeSubmitter(ctx context.Context,
 	}
 
 	beaconCommitteeSubscriptionsSubmitters := make(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter)
-	for _, address := range util.BeaconNodeAddresses("submitter.beaconcommitteesubscription.multinode") {
+	for _, address := range util.BeaconNodeAddressesForSubmitting("beaconcommitteesubscription") {
 		client, err := fetchClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for beacon committee subscription submitter strategy", address))
@@ -1442,7 +1799,7 @@ func startMultinodeSubmitter(ctx context.Context,
 	}
 
 	proposalPreparationSubmitters := make(map[string]eth2client.ProposalPreparationsSubmitter)
-	for _, address := range util.BeaconNodeAddresses("submitter.proposalpreparation.multinode") {
+	for _, address := range util.BeaconNodeAddressesForSubmitting("proposalpreparation") {
 		client, err := fetchClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for proposal preparation submitter strategy", address))
@@ -1451,7 +1808,7 @@ func startMultinodeSubmitter(ctx context.Context,
 	}
 
 	syncCommitteeContributionsSubmitters := make(map[string]eth2client.SyncCommitteeContributionsSubmitter)
-	for _, address := range util.BeaconNodeAddresses("submitter.synccommitteecontribution.multinode") {
+	for _, address := range util.BeaconNodeAddressesForSubmitting("synccommitteecontribution") {
 		client, err := fetchClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for sync committee contribution submitter strategy", address))
@@ -1460,7 +1817,7 @@ func startMultinodeSubmitter(ctx context.Context,
 	}
 
 	syncCommitteeMessagesSubmitters := make(map[string]eth2client.SyncCommitteeMessagesSubmitter)
-	for _, address := range util.BeaconNodeAddresses("submitter.synccommitteemessage.multinode") {
+	for _, address := range util.BeaconNodeAddressesForSubmitting("synccommitteemessage") {
 		client, err := fetchClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for sync committee message submitter strategy", address))
@@ -1469,7 +1826,7 @@ func startMultinodeSubmitter(ctx context.Context,
 	}
 
 	syncCommitteeSubscriptionsSubmitters := make(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter)
-	for _, address := range util.BeaconNodeAddresses("submitter.synccommitteesubscription.multinode") {
+	for _, address := range util.BeaconNodeAddressesForSubmitting("synccommitteesubscription") {
 		client, err := fetchClient(ctx, monitor, address)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for sync committee subscription submitter strategy", address))
@@ -1490,6 +1847,11 @@ func startMultinodeSubmitter(ctx context.Context,
 		multinodesubmitter.WithAggregateAttestationsSubmitters(aggregateAttestationSubmitters),
 		multinodesubmitter.WithBeaconCommitteeSubscriptionsSubmitters(beaconCommitteeSubscriptionsSubmitters),
 		multinodesubmitter.WithProposalPreparationsSubmitters(proposalPreparationSubmitters),
+		multinodesubmitter.WithProposalQuorum(submitterQuorum("submitter.proposal.multinode", len(proposalSubmitters))),
+		multinodesubmitter.WithAttestationsQuorum(submitterQuorum("submitter.attestation.multinode", len(attestationsSubmitters))),
+		multinodesubmitter.WithAggregateAttestationsQuorum(submitterQuorum("submitter.aggregateattestation.multinode", len(aggregateAttestationSubmitters))),
+		multinodesubmitter.WithSyncCommitteeMessagesQuorum(submitterQuorum("submitter.synccommitteemessage.multinode", len(syncCommitteeMessagesSubmitters))),
+		multinodesubmitter.WithSyncCommitteeContributionsQuorum(submitterQuorum("submitter.synccommitteecontribution.multinode", len(syncCommitteeContributionsSubmitters))),
 	)
 	if err != nil {
 		return nil, err
@@ -1498,6 +1860,21 @@ func startMultinodeSubmitter(ctx context.Context,
 	return submitter, nil
 }
 
+// submitterQuorum returns the number of successful submissions required for
+// the given path.  This defaults to 1, and can be set to 'all' to require
+// successful submission to all of the submitters.
+func submitterQuorum(path string, submitters int) int {
+	key := fmt.Sprintf("%s.quorum", path)
+	switch {
+	case !viper.IsSet(key):
+		return 1
+	case viper.GetString(key) == "all":
+		return submitters
+	default:
+		return viper.GetInt(key)
+	}
+}
+
 // runCommands potentially runs commands.
 // Returns true if Vouch should exit.
 func runCommands(ctx context.Context,
@@ -1630,6 +2007,9 @@ func startBlockRelay(ctx context.Context,
 		standardblockrelay.WithConfigURL(viper.GetString("blockrelay.config.url")),
 		standardblockrelay.WithFallbackFeeRecipient(fallbackFeeRecipient),
 		standardblockrelay.WithFallbackGasLimit(viper.GetUint64("blockrelay.fallback-gas-limit")),
+		standardblockrelay.WithMaxBidAge(viper.GetDuration("blockrelay.max-bid-age")),
+		standardblockrelay.WithMaxRegistrationAge(viper.GetDuration("blockrelay.max-registration-age")),
+		standardblockrelay.WithStaleRegistrationAction(viper.GetString("blockrelay.stale-registration-action")),
 		standardblockrelay.WithClientCertURL(viper.GetString("blockrelay.config.client-cert")),
 		standardblockrelay.WithClientKeyURL(viper.GetString("blockrelay.config.client-key")),
 		standardblockrelay.WithCACertURL(viper.GetString("blockrelay.config.ca-cert")),
@@ -1678,6 +2058,9 @@ func selectBuilderBidProvider(ctx context.Context,
 			bestbuilderbidstrategy.WithChainTime(chainTime),
 			bestbuilderbidstrategy.WithTimeout(util.Timeout("strategies.builderbid.best")),
 			bestbuilderbidstrategy.WithReleaseVersion(ReleaseVersion),
+			bestbuilderbidstrategy.WithStrictVerification(viper.GetBool("blockrelay.strict-bid-verification")),
+			bestbuilderbidstrategy.WithRelayConcurrency(viper.GetInt64("blockrelay.relay-concurrency")),
+			bestbuilderbidstrategy.WithRelayTimeout(viper.GetDuration("blockrelay.relay-timeout")),
 		)
 	default:
 		err = fmt.Errorf("unknown builder bid strategy %s", viper.GetString("strategies.builderbid.style"))
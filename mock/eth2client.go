@@ -575,15 +575,26 @@ func (m *SleepyBeaconCommitteeSubscriptionsSubmitter) SubmitBeaconCommitteeSubsc
 }
 
 // ProposalProvider is a mock for eth2client.ProposalProvider.
-type ProposalProvider struct{}
+type ProposalProvider struct {
+	genesisTime time.Time
+}
 
 // NewProposalProvider returns a mock beacon block proposal provider.
 func NewProposalProvider() eth2client.ProposalProvider {
 	return &ProposalProvider{}
 }
 
+// NewTimedProposalProvider returns a mock beacon block proposal provider
+// whose execution payload timestamps match the start of the slot for the
+// given genesis time and the slot duration of the mock spec provider.
+func NewTimedProposalProvider(genesisTime time.Time) eth2client.ProposalProvider {
+	return &ProposalProvider{
+		genesisTime: genesisTime,
+	}
+}
+
 // Proposal is a mock.
-func (*ProposalProvider) Proposal(_ context.Context,
+func (m *ProposalProvider) Proposal(_ context.Context,
 	opts *api.ProposalOpts,
 ) (
 	*api.Response[*api.VersionedProposal],
@@ -668,10 +679,15 @@ func (*ProposalProvider) Proposal(_ context.Context,
 				ExecutionPayload: &capella.ExecutionPayload{
 					FeeRecipient: bellatrix.ExecutionAddress{0x01},
 				},
-				SyncAggregate: &altair.SyncAggregate{},
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: bitfield.NewBitvector512(),
+				},
 			},
 		},
 	}
+	if !m.genesisTime.IsZero() {
+		block.Capella.Body.ExecutionPayload.Timestamp = uint64(m.genesisTime.Add(time.Duration(opts.Slot) * 12 * time.Second).Unix())
+	}
 
 	return &api.Response[*api.VersionedProposal]{
 		Data:     block,
@@ -722,6 +738,64 @@ func (m *SleepyProposalProvider) Proposal(ctx context.Context,
 	return m.next.Proposal(ctx, opts)
 }
 
+// WrongSlotProposalProvider is a mock for eth2client.ProposalProvider.
+type WrongSlotProposalProvider struct {
+	next eth2client.ProposalProvider
+}
+
+// NewWrongSlotProposalProvider returns a mock beacon block proposal provider
+// that returns proposals for the slot after the one requested.
+func NewWrongSlotProposalProvider(next eth2client.ProposalProvider) eth2client.ProposalProvider {
+	return &WrongSlotProposalProvider{
+		next: next,
+	}
+}
+
+// Proposal is a mock.
+func (m *WrongSlotProposalProvider) Proposal(ctx context.Context,
+	opts *api.ProposalOpts,
+) (
+	*api.Response[*api.VersionedProposal],
+	error,
+) {
+	return m.next.Proposal(ctx, &api.ProposalOpts{
+		Slot:                   opts.Slot + 1,
+		RandaoReveal:           opts.RandaoReveal,
+		Graffiti:               opts.Graffiti,
+		SkipRandaoVerification: opts.SkipRandaoVerification,
+	})
+}
+
+// WrongTimestampProposalProvider is a mock for eth2client.ProposalProvider.
+type WrongTimestampProposalProvider struct {
+	next eth2client.ProposalProvider
+}
+
+// NewWrongTimestampProposalProvider returns a mock beacon block proposal provider
+// that returns proposals with an execution payload timestamp one second after
+// that of the next provider.
+func NewWrongTimestampProposalProvider(next eth2client.ProposalProvider) eth2client.ProposalProvider {
+	return &WrongTimestampProposalProvider{
+		next: next,
+	}
+}
+
+// Proposal is a mock.
+func (m *WrongTimestampProposalProvider) Proposal(ctx context.Context,
+	opts *api.ProposalOpts,
+) (
+	*api.Response[*api.VersionedProposal],
+	error,
+) {
+	resp, err := m.next.Proposal(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp.Data.Capella.Body.ExecutionPayload.Timestamp++
+
+	return resp, nil
+}
+
 // BeaconBlockRootProvider is a mock for eth2client.BeaconBlockRootProvider.
 type BeaconBlockRootProvider struct{}
 
@@ -1111,6 +1185,46 @@ func (m *SleepyAggregateAttestationProvider) AggregateAttestation(ctx context.Co
 	return m.next.AggregateAttestation(ctx, opts)
 }
 
+// DivergentAggregateAttestationProvider is a mock for eth2client.AggregateAttestationProvider
+// that returns a full aggregate attestation for different attestation data.
+type DivergentAggregateAttestationProvider struct {
+	next eth2client.AggregateAttestationProvider
+}
+
+// NewDivergentAggregateAttestationProvider returns a mock attestation data provider.
+func NewDivergentAggregateAttestationProvider(next eth2client.AggregateAttestationProvider) eth2client.AggregateAttestationProvider {
+	return &DivergentAggregateAttestationProvider{
+		next: next,
+	}
+}
+
+// AggregateAttestation is a mock.
+func (m *DivergentAggregateAttestationProvider) AggregateAttestation(ctx context.Context,
+	opts *api.AggregateAttestationOpts,
+) (
+	*api.Response[*phase0.Attestation],
+	error,
+) {
+	response, err := m.next.AggregateAttestation(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregationBits := bitfield.NewBitlist(response.Data.AggregationBits.Len())
+	for i := range aggregationBits.Len() {
+		aggregationBits.SetBitAt(i, true)
+	}
+	data := *response.Data.Data
+	data.Index++
+	response.Data = &phase0.Attestation{
+		AggregationBits: aggregationBits,
+		Data:            &data,
+		Signature:       response.Data.Signature,
+	}
+
+	return response, nil
+}
+
 // ErroringSpecProvider is a mock for eth2client.SpecProvider.
 type ErroringSpecProvider struct{}
 
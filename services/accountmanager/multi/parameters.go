@@ -0,0 +1,139 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"fmt"
+
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Conflict resolution policies for validators present in more than one account manager.
+const (
+	// ConflictResolutionError refuses to use a validator present in more than one account manager.
+	ConflictResolutionError = "error"
+	// ConflictResolutionPreferFirst uses the validator from the first account manager in which it is present.
+	ConflictResolutionPreferFirst = "prefer-first"
+	// ConflictResolutionPreferNamedManager uses the validator from the preferred account manager if it is
+	// present there, and otherwise from the first account manager in which it is present.
+	ConflictResolutionPreferNamedManager = "prefer-named-manager"
+)
+
+// NamedAccountManager is an account manager with a name by which it can be referenced.
+type NamedAccountManager struct {
+	Name           string
+	AccountManager accountmanager.Service
+}
+
+type parameters struct {
+	logLevel                zerolog.Level
+	accountManagers         []*NamedAccountManager
+	conflictResolution      string
+	preferredAccountManager string
+	currentEpochProvider    chaintime.Service
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithAccountManagers sets the account managers, in order of preference.
+func WithAccountManagers(managers []*NamedAccountManager) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.accountManagers = managers
+	})
+}
+
+// WithConflictResolution sets the policy for validators present in more than one account manager.
+func WithConflictResolution(policy string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.conflictResolution = policy
+	})
+}
+
+// WithPreferredAccountManager sets the name of the account manager preferred
+// by the prefer-named-manager conflict resolution policy.
+func WithPreferredAccountManager(name string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.preferredAccountManager = name
+	})
+}
+
+// WithCurrentEpochProvider sets the current epoch provider.
+func WithCurrentEpochProvider(provider chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.currentEpochProvider = provider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:           zerolog.GlobalLevel(),
+		conflictResolution: ConflictResolutionError,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.accountManagers) == 0 {
+		return nil, errors.New("no account managers specified")
+	}
+	names := make(map[string]struct{}, len(parameters.accountManagers))
+	for _, manager := range parameters.accountManagers {
+		if manager == nil || manager.AccountManager == nil {
+			return nil, errors.New("nil account manager specified")
+		}
+		if _, exists := names[manager.Name]; exists {
+			return nil, fmt.Errorf("duplicate account manager %q", manager.Name)
+		}
+		names[manager.Name] = struct{}{}
+	}
+	switch parameters.conflictResolution {
+	case ConflictResolutionError, ConflictResolutionPreferFirst:
+	case ConflictResolutionPreferNamedManager:
+		if parameters.preferredAccountManager == "" {
+			return nil, errors.New("no preferred account manager specified")
+		}
+		if _, exists := names[parameters.preferredAccountManager]; !exists {
+			return nil, fmt.Errorf("unknown preferred account manager %q", parameters.preferredAccountManager)
+		}
+	default:
+		return nil, fmt.Errorf("unknown conflict resolution %q", parameters.conflictResolution)
+	}
+	if parameters.currentEpochProvider == nil {
+		return nil, errors.New("no current epoch provider specified")
+	}
+
+	return &parameters, nil
+}
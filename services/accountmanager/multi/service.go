@@ -0,0 +1,212 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package multi is an account manager that combines the accounts of
+// multiple account managers.
+package multi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// Service is the account manager that combines multiple account managers.
+type Service struct {
+	accountManagers         []*NamedAccountManager
+	conflictResolution      string
+	preferredAccountManager string
+
+	// reported tracks the conflicts that have been logged, to avoid
+	// logging the same conflict every time accounts are obtained.
+	reported   map[string]struct{}
+	reportedMu sync.Mutex
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new multiple account manager.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "accountmanager").Str("impl", "multi").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		accountManagers:         parameters.accountManagers,
+		conflictResolution:      parameters.conflictResolution,
+		preferredAccountManager: parameters.preferredAccountManager,
+		reported:                make(map[string]struct{}),
+	}
+
+	// Check the current validating accounts, so that conflicts are found at
+	// startup rather than when validating.
+	if _, err := s.ValidatingAccountsForEpoch(ctx, parameters.currentEpochProvider.CurrentEpoch()); err != nil {
+		return nil, errors.Wrap(err, "failed to check validating accounts")
+	}
+
+	return s, nil
+}
+
+// Refresh refreshes the accounts of each account manager.
+func (s *Service) Refresh(ctx context.Context) {
+	for _, manager := range s.accountManagers {
+		if refresher, isRefresher := manager.AccountManager.(accountmanager.Refresher); isRefresher {
+			refresher.Refresh(ctx)
+		}
+	}
+}
+
+// ValidatingAccountsForEpoch obtains the validating accounts for a given epoch.
+func (s *Service) ValidatingAccountsForEpoch(ctx context.Context, epoch phase0.Epoch) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+	return s.validatingAccounts(ctx, func(provider accountmanager.ValidatingAccountsProvider) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+		return provider.ValidatingAccountsForEpoch(ctx, epoch)
+	})
+}
+
+// ValidatingAccountsForEpochByIndex obtains the specified validating accounts for a given epoch.
+func (s *Service) ValidatingAccountsForEpochByIndex(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) (
+	map[phase0.ValidatorIndex]e2wtypes.Account,
+	error,
+) {
+	return s.validatingAccounts(ctx, func(provider accountmanager.ValidatingAccountsProvider) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+		return provider.ValidatingAccountsForEpochByIndex(ctx, epoch, indices)
+	})
+}
+
+// AccountByPublicKey returns the account for the given public key.
+func (s *Service) AccountByPublicKey(ctx context.Context, pubkey phase0.BLSPubKey) (e2wtypes.Account, error) {
+	var account e2wtypes.Account
+	source := -1
+	for i, manager := range s.accountManagers {
+		provider, isProvider := manager.AccountManager.(accountmanager.AccountsProvider)
+		if !isProvider {
+			continue
+		}
+		candidate, err := provider.AccountByPublicKey(ctx, pubkey)
+		if err != nil || candidate == nil {
+			continue
+		}
+		if source == -1 {
+			account = candidate
+			source = i
+			continue
+		}
+		chosen, err := s.resolveConflict(fmt.Sprintf("public key %#x", pubkey), source, i)
+		if err != nil {
+			return nil, err
+		}
+		if chosen == i {
+			account = candidate
+			source = i
+		}
+	}
+
+	if account == nil {
+		return nil, errors.New("account not found")
+	}
+
+	return account, nil
+}
+
+// validatingAccounts obtains validating accounts from each account manager
+// using the supplied function, and combines them.
+func (s *Service) validatingAccounts(_ context.Context,
+	fetch func(provider accountmanager.ValidatingAccountsProvider) (map[phase0.ValidatorIndex]e2wtypes.Account, error),
+) (
+	map[phase0.ValidatorIndex]e2wtypes.Account,
+	error,
+) {
+	accounts := make(map[phase0.ValidatorIndex]e2wtypes.Account)
+	sources := make(map[phase0.ValidatorIndex]int)
+	for i, manager := range s.accountManagers {
+		provider, isProvider := manager.AccountManager.(accountmanager.ValidatingAccountsProvider)
+		if !isProvider {
+			continue
+		}
+		managerAccounts, err := fetch(provider)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to obtain validating accounts from account manager %q", manager.Name)
+		}
+		for index, account := range managerAccounts {
+			source, exists := sources[index]
+			if !exists {
+				accounts[index] = account
+				sources[index] = i
+				continue
+			}
+			chosen, err := s.resolveConflict(fmt.Sprintf("validator %d", index), source, i)
+			if err != nil {
+				return nil, err
+			}
+			if chosen == i {
+				accounts[index] = account
+				sources[index] = i
+			}
+		}
+	}
+
+	return accounts, nil
+}
+
+// resolveConflict resolves a validator that is present in two account managers,
+// given by their position, returning the position of the account manager to use.
+func (s *Service) resolveConflict(validator string, first int, second int) (int, error) {
+	firstName := s.accountManagers[first].Name
+	secondName := s.accountManagers[second].Name
+
+	chosen := first
+	switch s.conflictResolution {
+	case ConflictResolutionPreferFirst:
+		// Chosen is already the first.
+	case ConflictResolutionPreferNamedManager:
+		if secondName == s.preferredAccountManager {
+			chosen = second
+		}
+	default:
+		return 0, fmt.Errorf("%s present in account managers %q and %q", validator, firstName, secondName)
+	}
+
+	key := fmt.Sprintf("%s:%s:%s", validator, firstName, secondName)
+	s.reportedMu.Lock()
+	_, reported := s.reported[key]
+	s.reported[key] = struct{}{}
+	s.reportedMu.Unlock()
+	if !reported {
+		log.Warn().
+			Str("validator", validator).
+			Strs("account_managers", []string{firstName, secondName}).
+			Str("selected", s.accountManagers[chosen].Name).
+			Str("conflict_resolution", s.conflictResolution).
+			Msg("Validator present in multiple account managers")
+	}
+
+	return chosen, nil
+}
@@ -0,0 +1,314 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/accountmanager/multi"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// testAccount is an account identified by its name.
+type testAccount struct {
+	e2wtypes.Account
+	name string
+}
+
+func (a *testAccount) Name() string {
+	return a.name
+}
+
+// testAccountManager is an account manager with fixed accounts.
+type testAccountManager struct {
+	accounts map[phase0.ValidatorIndex]e2wtypes.Account
+	pubkeys  map[phase0.BLSPubKey]e2wtypes.Account
+}
+
+func newTestAccountManager(name string, indices ...phase0.ValidatorIndex) *testAccountManager {
+	m := &testAccountManager{
+		accounts: make(map[phase0.ValidatorIndex]e2wtypes.Account),
+		pubkeys:  make(map[phase0.BLSPubKey]e2wtypes.Account),
+	}
+	for _, index := range indices {
+		account := &testAccount{name: name}
+		m.accounts[index] = account
+		m.pubkeys[phase0.BLSPubKey{byte(index)}] = account
+	}
+
+	return m
+}
+
+func (m *testAccountManager) ValidatingAccountsForEpoch(_ context.Context, _ phase0.Epoch) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+	return m.accounts, nil
+}
+
+func (m *testAccountManager) ValidatingAccountsForEpochByIndex(_ context.Context,
+	_ phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) (
+	map[phase0.ValidatorIndex]e2wtypes.Account,
+	error,
+) {
+	accounts := make(map[phase0.ValidatorIndex]e2wtypes.Account)
+	for _, index := range indices {
+		if account, exists := m.accounts[index]; exists {
+			accounts[index] = account
+		}
+	}
+
+	return accounts, nil
+}
+
+func (m *testAccountManager) AccountByPublicKey(_ context.Context, pubkey phase0.BLSPubKey) (e2wtypes.Account, error) {
+	account, exists := m.pubkeys[pubkey]
+	if !exists {
+		return nil, errors.New("not found")
+	}
+
+	return account, nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	managers := []*multi.NamedAccountManager{
+		{
+			Name:           "dirk",
+			AccountManager: newTestAccountManager("dirk", 1, 2),
+		},
+		{
+			Name:           "wallet",
+			AccountManager: newTestAccountManager("wallet", 3, 4),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		params []multi.Parameter
+		err    string
+	}{
+		{
+			name: "AccountManagersMissing",
+			params: []multi.Parameter{
+				multi.WithLogLevel(zerolog.Disabled),
+				multi.WithCurrentEpochProvider(chainTime),
+			},
+			err: "problem with parameters: no account managers specified",
+		},
+		{
+			name: "AccountManagersDuplicate",
+			params: []multi.Parameter{
+				multi.WithLogLevel(zerolog.Disabled),
+				multi.WithAccountManagers([]*multi.NamedAccountManager{managers[0], managers[0]}),
+				multi.WithCurrentEpochProvider(chainTime),
+			},
+			err: `problem with parameters: duplicate account manager "dirk"`,
+		},
+		{
+			name: "ConflictResolutionUnknown",
+			params: []multi.Parameter{
+				multi.WithLogLevel(zerolog.Disabled),
+				multi.WithAccountManagers(managers),
+				multi.WithConflictResolution("unknown"),
+				multi.WithCurrentEpochProvider(chainTime),
+			},
+			err: `problem with parameters: unknown conflict resolution "unknown"`,
+		},
+		{
+			name: "PreferredAccountManagerMissing",
+			params: []multi.Parameter{
+				multi.WithLogLevel(zerolog.Disabled),
+				multi.WithAccountManagers(managers),
+				multi.WithConflictResolution(multi.ConflictResolutionPreferNamedManager),
+				multi.WithCurrentEpochProvider(chainTime),
+			},
+			err: "problem with parameters: no preferred account manager specified",
+		},
+		{
+			name: "PreferredAccountManagerUnknown",
+			params: []multi.Parameter{
+				multi.WithLogLevel(zerolog.Disabled),
+				multi.WithAccountManagers(managers),
+				multi.WithConflictResolution(multi.ConflictResolutionPreferNamedManager),
+				multi.WithPreferredAccountManager("unknown"),
+				multi.WithCurrentEpochProvider(chainTime),
+			},
+			err: `problem with parameters: unknown preferred account manager "unknown"`,
+		},
+		{
+			name: "CurrentEpochProviderMissing",
+			params: []multi.Parameter{
+				multi.WithLogLevel(zerolog.Disabled),
+				multi.WithAccountManagers(managers),
+			},
+			err: "problem with parameters: no current epoch provider specified",
+		},
+		{
+			name: "ConflictAtStartup",
+			params: []multi.Parameter{
+				multi.WithLogLevel(zerolog.Disabled),
+				multi.WithAccountManagers([]*multi.NamedAccountManager{
+					managers[0],
+					{
+						Name:           "wallet",
+						AccountManager: newTestAccountManager("wallet", 2, 3),
+					},
+				}),
+				multi.WithCurrentEpochProvider(chainTime),
+			},
+			err: `failed to check validating accounts: validator 2 present in account managers "dirk" and "wallet"`,
+		},
+		{
+			name: "Good",
+			params: []multi.Parameter{
+				multi.WithLogLevel(zerolog.Disabled),
+				multi.WithAccountManagers(managers),
+				multi.WithCurrentEpochProvider(chainTime),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := multi.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConflictResolution(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	// Validator 2 is in all account managers.
+	managers := []*multi.NamedAccountManager{
+		{
+			Name:           "dirk",
+			AccountManager: newTestAccountManager("dirk", 1, 2),
+		},
+		{
+			Name:           "wallet",
+			AccountManager: newTestAccountManager("wallet", 2, 3),
+		},
+		{
+			Name:           "other",
+			AccountManager: newTestAccountManager("other", 2, 4),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		params   []multi.Parameter
+		selected string
+		err      string
+	}{
+		{
+			name: "Error",
+			params: []multi.Parameter{
+				multi.WithConflictResolution(multi.ConflictResolutionError),
+			},
+			err: `validator 2 present in account managers "dirk" and "wallet"`,
+		},
+		{
+			name: "PreferFirst",
+			params: []multi.Parameter{
+				multi.WithConflictResolution(multi.ConflictResolutionPreferFirst),
+			},
+			selected: "dirk",
+		},
+		{
+			name: "PreferNamedManager",
+			params: []multi.Parameter{
+				multi.WithConflictResolution(multi.ConflictResolutionPreferNamedManager),
+				multi.WithPreferredAccountManager("wallet"),
+			},
+			selected: "wallet",
+		},
+		{
+			name: "PreferNamedManagerLast",
+			params: []multi.Parameter{
+				multi.WithConflictResolution(multi.ConflictResolutionPreferNamedManager),
+				multi.WithPreferredAccountManager("other"),
+			},
+			selected: "other",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := append([]multi.Parameter{
+				multi.WithLogLevel(zerolog.Disabled),
+				multi.WithAccountManagers(managers),
+				multi.WithCurrentEpochProvider(chainTime),
+			}, test.params...)
+			s, err := multi.New(ctx, params...)
+			if test.err != "" {
+				require.EqualError(t, err, "failed to check validating accounts: "+test.err)
+
+				return
+			}
+			require.NoError(t, err)
+
+			accounts, err := s.ValidatingAccountsForEpoch(ctx, 0)
+			require.NoError(t, err)
+			require.Len(t, accounts, 4)
+			require.Equal(t, "dirk", accounts[1].Name())
+			require.Equal(t, test.selected, accounts[2].Name())
+			require.Equal(t, "wallet", accounts[3].Name())
+			require.Equal(t, "other", accounts[4].Name())
+
+			accounts, err = s.ValidatingAccountsForEpochByIndex(ctx, 0, []phase0.ValidatorIndex{2, 3})
+			require.NoError(t, err)
+			require.Len(t, accounts, 2)
+			require.Equal(t, test.selected, accounts[2].Name())
+
+			account, err := s.AccountByPublicKey(ctx, phase0.BLSPubKey{0x02})
+			require.NoError(t, err)
+			require.Equal(t, test.selected, account.Name())
+
+			account, err = s.AccountByPublicKey(ctx, phase0.BLSPubKey{0x03})
+			require.NoError(t, err)
+			require.Equal(t, "wallet", account.Name())
+
+			_, err = s.AccountByPublicKey(ctx, phase0.BLSPubKey{0x05})
+			require.EqualError(t, err, "account not found")
+		})
+	}
+}
@@ -39,6 +39,7 @@ type IsAggregatorProvider interface {
 
 // Service is the attestation aggregation service.
 type Service interface {
-	// Aggregate carries out aggregation for a slot and committee.
+	// Aggregate carries out aggregation for a slot and committee, given a *Duty,
+	// or for multiple committees in a slot, given a []*Duty.
 	Aggregate(ctx context.Context, details interface{})
 }
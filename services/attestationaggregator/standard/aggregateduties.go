@@ -0,0 +1,108 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attestationaggregator"
+)
+
+// aggregateCandidate is a duty along with its aggregate attestation.
+type aggregateCandidate struct {
+	duty      *attestationaggregator.Duty
+	aggregate *phase0.Attestation
+}
+
+// aggregateDuties aggregates the attestations for a set of duties in a slot.
+// If a maximum number of aggregations per slot is configured then the
+// aggregates with the highest participation are submitted, as these add the
+// most value to the network, and the remainder are skipped.
+func (s *Service) aggregateDuties(ctx context.Context,
+	started time.Time,
+	duties []*attestationaggregator.Duty,
+) {
+	// Obtain the aggregates in parallel.
+	candidates := make([]*aggregateCandidate, len(duties))
+	var wg sync.WaitGroup
+	for i, duty := range duties {
+		wg.Add(1)
+		go func(i int, duty *attestationaggregator.Duty) {
+			defer wg.Done()
+			aggregateAttestation, err := s.obtainAggregate(ctx, started, duty)
+			if err != nil {
+				log.Error().Uint64("slot", uint64(duty.Slot)).Str("attestation_data_root", fmt.Sprintf("%#x", duty.AttestationDataRoot)).Err(err).Msg("Failed to obtain aggregate attestation")
+				s.monitor.AttestationAggregationCompleted(started, duty.Slot, "failed")
+				return
+			}
+			candidates[i] = &aggregateCandidate{
+				duty:      duty,
+				aggregate: aggregateAttestation,
+			}
+		}(i, duty)
+	}
+	wg.Wait()
+
+	selected, skipped := prioritiseAggregates(candidates, s.maxAggregationsPerSlot)
+	for _, candidate := range skipped {
+		log.Debug().
+			Uint64("slot", uint64(candidate.duty.Slot)).
+			Str("attestation_data_root", fmt.Sprintf("%#x", candidate.duty.AttestationDataRoot)).
+			Uint64("participation", candidate.aggregate.AggregationBits.Count()).
+			Uint64("max_aggregations", s.maxAggregationsPerSlot).
+			Msg("Maximum aggregations for slot reached; skipping lower-value aggregation")
+		s.monitor.AttestationAggregationCompleted(started, candidate.duty.Slot, "skipped")
+	}
+
+	for _, candidate := range selected {
+		wg.Add(1)
+		go func(candidate *aggregateCandidate) {
+			defer wg.Done()
+			s.submitAggregate(ctx, started, candidate.duty, candidate.aggregate)
+		}(candidate)
+	}
+	wg.Wait()
+}
+
+// prioritiseAggregates orders the candidates by the participation of their
+// aggregates, highest first, and returns those within the maximum along with
+// those beyond it.  Nil candidates are ignored, and a maximum of 0 selects
+// all candidates.
+func prioritiseAggregates(candidates []*aggregateCandidate,
+	maxAggregations uint64,
+) (
+	[]*aggregateCandidate,
+	[]*aggregateCandidate,
+) {
+	ordered := make([]*aggregateCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate != nil && candidate.aggregate != nil {
+			ordered = append(ordered, candidate)
+		}
+	}
+	if maxAggregations == 0 || uint64(len(ordered)) <= maxAggregations {
+		return ordered, nil
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].aggregate.AggregationBits.Count() > ordered[j].aggregate.AggregationBits.Count()
+	})
+
+	return ordered[:maxAggregations], ordered[maxAggregations:]
+}
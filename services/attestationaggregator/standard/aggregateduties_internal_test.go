@@ -0,0 +1,205 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/attestationaggregator"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mocksigner "github.com/attestantio/vouch/services/signer/mock"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+// participationAggregateProvider provides aggregates whose participation is
+// defined by the first byte of the attestation data root.
+type participationAggregateProvider struct{}
+
+func (*participationAggregateProvider) AggregateAttestation(_ context.Context,
+	opts *api.AggregateAttestationOpts,
+) (
+	*api.Response[*phase0.Attestation],
+	error,
+) {
+	aggregationBits := bitfield.NewBitlist(128)
+	for i := uint64(0); i < uint64(opts.AttestationDataRoot[0]); i++ {
+		aggregationBits.SetBitAt(i, true)
+	}
+
+	return &api.Response[*phase0.Attestation]{
+		Data: &phase0.Attestation{
+			AggregationBits: aggregationBits,
+			Data: &phase0.AttestationData{
+				Slot:   opts.Slot,
+				Index:  phase0.CommitteeIndex(opts.AttestationDataRoot[0]),
+				Source: &phase0.Checkpoint{},
+				Target: &phase0.Checkpoint{},
+			},
+		},
+	}, nil
+}
+
+// recordingAggregateSubmitter records the participation of submitted aggregates.
+type recordingAggregateSubmitter struct {
+	mu            sync.Mutex
+	participation []uint64
+}
+
+func (s *recordingAggregateSubmitter) SubmitAggregateAttestations(_ context.Context, aggregates []*phase0.SignedAggregateAndProof) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, aggregate := range aggregates {
+		s.participation = append(s.participation, aggregate.Message.Aggregate.AggregationBits.Count())
+	}
+
+	return nil
+}
+
+func TestAggregateDuties(t *testing.T) {
+	ctx := context.Background()
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	for i := phase0.ValidatorIndex(1); i <= 5; i++ {
+		validatingAccountsProvider.AddAccount(i, nil)
+	}
+
+	// Duties whose aggregates have participation of 10, 40, 20, 50 and 30.
+	duties := make([]*attestationaggregator.Duty, 0)
+	for i, participation := range []byte{10, 40, 20, 50, 30} {
+		duties = append(duties, &attestationaggregator.Duty{
+			Slot:                100,
+			AttestationDataRoot: phase0.Root{participation},
+			ValidatorIndex:      phase0.ValidatorIndex(i + 1),
+		})
+	}
+
+	tests := []struct {
+		name            string
+		maxAggregations uint64
+		participation   []uint64
+	}{
+		{
+			name:          "Unlimited",
+			participation: []uint64{50, 40, 30, 20, 10},
+		},
+		{
+			name:            "Capped",
+			maxAggregations: 2,
+			participation:   []uint64{50, 40},
+		},
+		{
+			name:            "CapAboveDuties",
+			maxAggregations: 10,
+			participation:   []uint64{50, 40, 30, 20, 10},
+		},
+		{
+			name:            "Single",
+			maxAggregations: 1,
+			participation:   []uint64{50},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			submitter := &recordingAggregateSubmitter{}
+			s := &Service{
+				monitor:                        nullmetrics.New(ctx),
+				slotsPerEpoch:                  32,
+				validatingAccountsProvider:     validatingAccountsProvider,
+				aggregateAttestationProvider:   &participationAggregateProvider{},
+				aggregateAttestationsSubmitter: submitter,
+				aggregateAndProofSigner:        mocksigner.New(),
+				maxAggregationsPerSlot:         test.maxAggregations,
+			}
+			s.Aggregate(ctx, duties)
+
+			// Submission is concurrent, so order the results.
+			sort.Slice(submitter.participation, func(i, j int) bool {
+				return submitter.participation[i] > submitter.participation[j]
+			})
+			require.Equal(t, test.participation, submitter.participation)
+		})
+	}
+}
+
+func TestPrioritiseAggregates(t *testing.T) {
+	candidate := func(validatorIndex phase0.ValidatorIndex, participation uint64) *aggregateCandidate {
+		aggregationBits := bitfield.NewBitlist(128)
+		for i := uint64(0); i < participation; i++ {
+			aggregationBits.SetBitAt(i, true)
+		}
+
+		return &aggregateCandidate{
+			duty:      &attestationaggregator.Duty{ValidatorIndex: validatorIndex},
+			aggregate: &phase0.Attestation{AggregationBits: aggregationBits},
+		}
+	}
+	low := candidate(1, 5)
+	mid := candidate(2, 10)
+	high := candidate(3, 20)
+	tiedHigh := candidate(4, 20)
+
+	tests := []struct {
+		name            string
+		candidates      []*aggregateCandidate
+		maxAggregations uint64
+		selected        []*aggregateCandidate
+		skipped         []*aggregateCandidate
+	}{
+		{
+			name:     "Empty",
+			selected: []*aggregateCandidate{},
+		},
+		{
+			name:       "NilCandidate",
+			candidates: []*aggregateCandidate{low, nil, mid},
+			selected:   []*aggregateCandidate{low, mid},
+		},
+		{
+			name:            "Capped",
+			candidates:      []*aggregateCandidate{low, high, mid},
+			maxAggregations: 2,
+			selected:        []*aggregateCandidate{high, mid},
+			skipped:         []*aggregateCandidate{low},
+		},
+		{
+			name:            "CappedWithFailure",
+			candidates:      []*aggregateCandidate{nil, low, mid},
+			maxAggregations: 2,
+			selected:        []*aggregateCandidate{low, mid},
+		},
+		{
+			name:            "TiedKeepsOrder",
+			candidates:      []*aggregateCandidate{low, high, tiedHigh},
+			maxAggregations: 1,
+			selected:        []*aggregateCandidate{high},
+			skipped:         []*aggregateCandidate{tiedHigh, low},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selected, skipped := prioritiseAggregates(test.candidates, test.maxAggregations)
+			require.Equal(t, test.selected, selected)
+			require.Equal(t, test.skipped, skipped)
+		})
+	}
+}
@@ -32,6 +32,7 @@ type parameters struct {
 	aggregateAttestationsSubmitter submitter.AggregateAttestationsSubmitter
 	slotSelectionSigner            signer.SlotSelectionSigner
 	aggregateAndProofSigner        signer.AggregateAndProofSigner
+	maxAggregationsPerSlot         uint64
 }
 
 // Parameter is the interface for service parameters.
@@ -101,6 +102,14 @@ func WithAggregateAndProofSigner(signer signer.AggregateAndProofSigner) Paramete
 	})
 }
 
+// WithMaxAggregationsPerSlot sets the maximum number of aggregations carried
+// out for a slot.  0 means no limit.
+func WithMaxAggregationsPerSlot(maxAggregations uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxAggregationsPerSlot = maxAggregations
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
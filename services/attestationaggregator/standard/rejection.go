@@ -0,0 +1,94 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+)
+
+// Reasons for which a beacon node can reject an aggregate.
+const (
+	// rejectionDuplicate is an aggregate that the beacon node has already
+	// seen, or for which it has already seen a superset.
+	rejectionDuplicate = "duplicate"
+	// rejectionStale is an aggregate that refers to a slot or block that the
+	// beacon node no longer considers current, or does not know about.
+	rejectionStale = "stale"
+	// rejectionInvalid is an aggregate that the beacon node considers invalid.
+	rejectionInvalid = "invalid"
+	// rejectionUnavailable is a failure to submit the aggregate for reasons
+	// other than its contents, for example the beacon node being unavailable.
+	rejectionUnavailable = "unavailable"
+)
+
+// rejectionError is returned when the beacon node does not accept an aggregate.
+type rejectionError struct {
+	reason string
+	err    error
+}
+
+func newRejectionError(err error) *rejectionError {
+	return &rejectionError{
+		reason: aggregateRejectionReason(err),
+		err:    err,
+	}
+}
+
+func (e *rejectionError) Error() string {
+	return fmt.Sprintf("aggregate rejected (%s): %v", e.reason, e.err)
+}
+
+func (e *rejectionError) Unwrap() error {
+	return e.err
+}
+
+// aggregateRejectionReason returns the reason for which the beacon node did not
+// accept an aggregate, based on the error returned when submitting it.
+// Beacon nodes do not return structured reasons, so this matches the
+// messages used by the common implementations.
+func aggregateRejectionReason(err error) string {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case containsAny(msg, "already known", "alreadyknown", "already seen", "supersetknown", "duplicate"):
+		return rejectionDuplicate
+	case containsAny(msg, "unknown head", "unknownheadblock", "unknown block", "unknowntargetroot", "past slot", "pastslot", "future slot", "futureslot"):
+		return rejectionStale
+	}
+
+	var apiErr *api.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+		return rejectionInvalid
+	}
+	if strings.Contains(msg, "invalid") {
+		return rejectionInvalid
+	}
+
+	return rejectionUnavailable
+}
+
+// containsAny returns true if the string contains any of the substrings.
+func containsAny(s string, substrs ...string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,251 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/attestationaggregator"
+	mocksigner "github.com/attestantio/vouch/services/signer/mock"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+// rejectingAggregateSubmitter returns the supplied errors in turn, and then
+// accepts the aggregates.
+type rejectingAggregateSubmitter struct {
+	mu          sync.Mutex
+	errs        []error
+	submissions int
+}
+
+func (s *rejectingAggregateSubmitter) SubmitAggregateAttestations(_ context.Context, _ []*phase0.SignedAggregateAndProof) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.submissions++
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+
+	return err
+}
+
+// countingAggregateProvider counts the aggregates provided.
+type countingAggregateProvider struct {
+	mu       sync.Mutex
+	provided int
+}
+
+func (p *countingAggregateProvider) AggregateAttestation(_ context.Context,
+	opts *api.AggregateAttestationOpts,
+) (
+	*api.Response[*phase0.Attestation],
+	error,
+) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.provided++
+
+	aggregationBits := bitfield.NewBitlist(128)
+	aggregationBits.SetBitAt(1, true)
+
+	return &api.Response[*phase0.Attestation]{
+		Data: &phase0.Attestation{
+			AggregationBits: aggregationBits,
+			Data: &phase0.AttestationData{
+				Slot:   opts.Slot,
+				Source: &phase0.Checkpoint{},
+				Target: &phase0.Checkpoint{},
+			},
+		},
+	}, nil
+}
+
+// recordingAggregationMonitor records aggregation results and rejections.
+type recordingAggregationMonitor struct {
+	mu         sync.Mutex
+	results    []string
+	rejections []string
+}
+
+func (m *recordingAggregationMonitor) AttestationAggregationCompleted(_ time.Time, _ phase0.Slot, result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results = append(m.results, result)
+}
+
+func (*recordingAggregationMonitor) AttestationAggregationCoverage(_ float64) {}
+
+func (m *recordingAggregationMonitor) AttestationAggregationRejected(reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejections = append(m.rejections, reason)
+}
+
+func TestAggregateRejectionReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+	}{
+		{
+			name:   "AlreadyKnown",
+			err:    errors.New("POST failed with status 400: {\"message\":\"AggregatorAlreadyKnown\"}"),
+			reason: rejectionDuplicate,
+		},
+		{
+			name:   "SupersetKnown",
+			err:    errors.New("AttestationSupersetKnown"),
+			reason: rejectionDuplicate,
+		},
+		{
+			name:   "UnknownHeadBlock",
+			err:    errors.New("UnknownHeadBlock { beacon_block_root: 0x01 }"),
+			reason: rejectionStale,
+		},
+		{
+			name:   "PastSlot",
+			err:    errors.New("attestation is from a past slot"),
+			reason: rejectionStale,
+		},
+		{
+			name: "BadRequest",
+			err: &api.Error{
+				Method:     http.MethodPost,
+				StatusCode: http.StatusBadRequest,
+				Data:       []byte("{\"message\":\"bad signature\"}"),
+			},
+			reason: rejectionInvalid,
+		},
+		{
+			name:   "Invalid",
+			err:    errors.New("invalid aggregate"),
+			reason: rejectionInvalid,
+		},
+		{
+			name: "ServerError",
+			err: &api.Error{
+				Method:     http.MethodPost,
+				StatusCode: http.StatusServiceUnavailable,
+			},
+			reason: rejectionUnavailable,
+		},
+		{
+			name:   "ConnectionRefused",
+			err:    errors.New("dial tcp 127.0.0.1:5052: connect: connection refused"),
+			reason: rejectionUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.reason, aggregateRejectionReason(test.err))
+		})
+	}
+}
+
+func TestAggregateRejected(t *testing.T) {
+	ctx := context.Background()
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	validatingAccountsProvider.AddAccount(1, nil)
+
+	duplicateErr := errors.New("AggregatorAlreadyKnown")
+	staleErr := errors.New("UnknownHeadBlock")
+	unavailableErr := errors.New("connection refused")
+
+	tests := []struct {
+		name        string
+		errs        []error
+		submissions int
+		provided    int
+		rejections  []string
+		result      string
+	}{
+		{
+			name:        "Accepted",
+			submissions: 1,
+			provided:    1,
+			rejections:  nil,
+			result:      "succeeded",
+		},
+		{
+			name:        "Duplicate",
+			errs:        []error{duplicateErr},
+			submissions: 1,
+			provided:    1,
+			rejections:  []string{rejectionDuplicate},
+			result:      "duplicate",
+		},
+		{
+			name:        "StaleThenAccepted",
+			errs:        []error{staleErr},
+			submissions: 2,
+			provided:    2,
+			rejections:  []string{rejectionStale},
+			result:      "succeeded",
+		},
+		{
+			name:        "StaleTwice",
+			errs:        []error{staleErr, staleErr},
+			submissions: 2,
+			provided:    2,
+			rejections:  []string{rejectionStale, rejectionStale},
+			result:      "failed",
+		},
+		{
+			name:        "Unavailable",
+			errs:        []error{unavailableErr},
+			submissions: 1,
+			provided:    1,
+			rejections:  []string{rejectionUnavailable},
+			result:      "failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			monitor := &recordingAggregationMonitor{}
+			submitter := &rejectingAggregateSubmitter{errs: test.errs}
+			provider := &countingAggregateProvider{}
+			s := &Service{
+				monitor:                        monitor,
+				slotsPerEpoch:                  32,
+				validatingAccountsProvider:     validatingAccountsProvider,
+				aggregateAttestationProvider:   provider,
+				aggregateAttestationsSubmitter: submitter,
+				aggregateAndProofSigner:        mocksigner.New(),
+			}
+			s.Aggregate(ctx, &attestationaggregator.Duty{
+				Slot:           100,
+				ValidatorIndex: 1,
+			})
+
+			require.Equal(t, test.submissions, submitter.submissions)
+			require.Equal(t, test.provided, provider.provided)
+			require.Equal(t, test.rejections, monitor.rejections)
+			require.Equal(t, []string{test.result}, monitor.results)
+		})
+	}
+}
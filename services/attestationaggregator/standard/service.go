@@ -31,6 +31,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 	"go.opentelemetry.io/otel"
 )
 
@@ -44,6 +45,7 @@ type Service struct {
 	aggregateAttestationsSubmitter submitter.AggregateAttestationsSubmitter
 	slotSelectionSigner            signer.SlotSelectionSigner
 	aggregateAndProofSigner        signer.AggregateAndProofSigner
+	maxAggregationsPerSlot         uint64
 }
 
 // module-wide log.
@@ -95,40 +97,79 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		aggregateAttestationsSubmitter: parameters.aggregateAttestationsSubmitter,
 		slotSelectionSigner:            parameters.slotSelectionSigner,
 		aggregateAndProofSigner:        parameters.aggregateAndProofSigner,
+		maxAggregationsPerSlot:         parameters.maxAggregationsPerSlot,
 	}
 
 	return s, nil
 }
 
 // Aggregate aggregates the attestations for a given slot/committee combination.
+// The data can also be a set of duties for a slot, in which case aggregation
+// is limited to the configured maximum per slot.
 func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 	ctx, span := otel.Tracer("attestantio.vouch.services.attestationaggregator.standard").Start(ctx, "Aggregate")
 	defer span.End()
 	started := time.Now()
 
-	duty, ok := data.(*attestationaggregator.Duty)
-	if !ok {
+	switch duties := data.(type) {
+	case *attestationaggregator.Duty:
+		s.aggregate(ctx, started, duties)
+	case []*attestationaggregator.Duty:
+		s.aggregateDuties(ctx, started, duties)
+	default:
 		log.Error().Msg("Passed invalid data structure")
 		s.monitor.AttestationAggregationCompleted(started, 0, "failed")
+	}
+}
+
+// aggregate aggregates the attestations for a single duty.
+func (s *Service) aggregate(ctx context.Context,
+	started time.Time,
+	duty *attestationaggregator.Duty,
+) {
+	aggregateAttestation, err := s.obtainAggregate(ctx, started, duty)
+	if err != nil {
+		log.Error().Uint64("slot", uint64(duty.Slot)).Str("attestation_data_root", fmt.Sprintf("%#x", duty.AttestationDataRoot)).Err(err).Msg("Failed to obtain aggregate attestation")
+		s.monitor.AttestationAggregationCompleted(started, duty.Slot, "failed")
 		return
 	}
+
+	s.submitAggregate(ctx, started, duty, aggregateAttestation)
+}
+
+// obtainAggregate obtains the aggregate attestation for a duty.
+func (s *Service) obtainAggregate(ctx context.Context,
+	started time.Time,
+	duty *attestationaggregator.Duty,
+) (
+	*phase0.Attestation,
+	error,
+) {
 	log := log.With().Uint64("slot", uint64(duty.Slot)).Str("attestation_data_root", fmt.Sprintf("%#x", duty.AttestationDataRoot)).Logger()
 	log.Trace().Msg("Aggregating")
 
-	// Obtain the aggregate attestation.
 	aggregateAttestationResponse, err := s.aggregateAttestationProvider.AggregateAttestation(ctx, &api.AggregateAttestationOpts{
 		Slot:                duty.Slot,
 		AttestationDataRoot: duty.AttestationDataRoot,
 	})
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to obtain aggregate attestation")
-		s.monitor.AttestationAggregationCompleted(started, duty.Slot, "failed")
-		return
+		return nil, err
 	}
-	aggregateAttestation := aggregateAttestationResponse.Data
-
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained aggregate attestation")
 
+	return aggregateAttestationResponse.Data, nil
+}
+
+// submitAggregate signs and submits an aggregate attestation for a duty.
+// If the beacon node rejects the aggregate because it is stale or invalid a
+// fresh aggregate is obtained and submitted in its place.
+func (s *Service) submitAggregate(ctx context.Context,
+	started time.Time,
+	duty *attestationaggregator.Duty,
+	aggregateAttestation *phase0.Attestation,
+) {
+	log := log.With().Uint64("slot", uint64(duty.Slot)).Str("attestation_data_root", fmt.Sprintf("%#x", duty.AttestationDataRoot)).Logger()
+
 	// Fetch the validating account.
 	epoch := phase0.Epoch(uint64(aggregateAttestation.Data.Slot) / s.slotsPerEpoch)
 	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpochByIndex(ctx, epoch, []phase0.ValidatorIndex{duty.ValidatorIndex})
@@ -145,6 +186,46 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 	account := accounts[duty.ValidatorIndex]
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained aggregating account")
 
+	err = s.signAndSubmitAggregate(ctx, started, duty, account, aggregateAttestation)
+	var rejection *rejectionError
+	if errors.As(err, &rejection) {
+		s.monitor.AttestationAggregationRejected(rejection.reason)
+		switch rejection.reason {
+		case rejectionDuplicate:
+			// The network already has this aggregate, or a better one, so there is nothing to gain by resubmitting.
+			log.Debug().Err(err).Msg("Aggregate already known to beacon node; not resubmitting")
+			s.monitor.AttestationAggregationCompleted(started, duty.Slot, "duplicate")
+			return
+		case rejectionStale, rejectionInvalid:
+			log.Debug().Err(err).Str("reason", rejection.reason).Msg("Aggregate rejected by beacon node; resubmitting with fresh aggregate")
+			aggregateAttestation, err = s.obtainAggregate(ctx, started, duty)
+			if err == nil {
+				err = s.signAndSubmitAggregate(ctx, started, duty, account, aggregateAttestation)
+				if errors.As(err, &rejection) {
+					s.monitor.AttestationAggregationRejected(rejection.reason)
+				}
+			}
+		}
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to aggregate attestation")
+		s.monitor.AttestationAggregationCompleted(started, duty.Slot, "failed")
+		return
+	}
+
+	frac := float64(aggregateAttestation.AggregationBits.Count()) /
+		float64(aggregateAttestation.AggregationBits.Len())
+	s.monitor.AttestationAggregationCoverage(frac)
+	s.monitor.AttestationAggregationCompleted(started, duty.Slot, "succeeded")
+}
+
+// signAndSubmitAggregate signs and submits an aggregate attestation.
+func (s *Service) signAndSubmitAggregate(ctx context.Context,
+	started time.Time,
+	duty *attestationaggregator.Duty,
+	account e2wtypes.Account,
+	aggregateAttestation *phase0.Attestation,
+) error {
 	// Sign the aggregate attestation.
 	aggregateAndProof := &phase0.AggregateAndProof{
 		AggregatorIndex: duty.ValidatorIndex,
@@ -153,13 +234,11 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 	}
 	aggregateAndProofRoot, err := aggregateAndProof.HashTreeRoot()
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to generate hash tree root of aggregate and proof")
+		return errors.Wrap(err, "failed to generate hash tree root of aggregate and proof")
 	}
 	sig, err := s.aggregateAndProofSigner.SignAggregateAndProof(ctx, account, duty.Slot, phase0.Root(aggregateAndProofRoot))
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to sign aggregate and proof")
-		s.monitor.AttestationAggregationCompleted(started, duty.Slot, "failed")
-		return
+		return errors.Wrap(err, "failed to sign aggregate and proof")
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Signed aggregate attestation")
 
@@ -171,16 +250,11 @@ func (s *Service) Aggregate(ctx context.Context, data interface{}) {
 		},
 	}
 	if err := s.aggregateAttestationsSubmitter.SubmitAggregateAttestations(ctx, signedAggregateAndProofs); err != nil {
-		log.Error().Err(err).Msg("Failed to submit aggregate and proof")
-		s.monitor.AttestationAggregationCompleted(started, duty.Slot, "failed")
-		return
+		return newRejectionError(err)
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Submitted aggregate attestation")
 
-	frac := float64(aggregateAndProof.Aggregate.AggregationBits.Count()) /
-		float64(aggregateAndProof.Aggregate.AggregationBits.Len())
-	s.monitor.AttestationAggregationCoverage(frac)
-	s.monitor.AttestationAggregationCompleted(started, duty.Slot, "succeeded")
+	return nil
 }
 
 // IsAggregator reports if we are an attestation aggregator for a given validator/committee/slot combination.
@@ -100,3 +100,21 @@ func MergeDuties(ctx context.Context, attesterDuties []*api.AttesterDuty) ([]*Du
 
 	return duties, nil
 }
+
+// signingHeldKey is the context key used to hold signing of attestations.
+type signingHeldKey struct{}
+
+// WithSigningHeld returns a context that instructs the attester to carry out
+// all of the work of attesting up to, but not including, signing.  This allows
+// an instance that is not permitted to sign, for example a standby, to keep its
+// attestation data and scoring warm.
+func WithSigningHeld(ctx context.Context) context.Context {
+	return context.WithValue(ctx, signingHeldKey{}, true)
+}
+
+// SigningHeld returns true if the context instructs the attester not to sign.
+func SigningHeld(ctx context.Context) bool {
+	held, ok := ctx.Value(signingHeldKey{}).(bool)
+
+	return ok && held
+}
@@ -23,11 +23,13 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Attest carries out attestations for a slot.
@@ -47,16 +49,14 @@ func (s *Service) Attest(ctx context.Context, data interface{}) ([]*phase0.Attes
 	validatorIndices := s.fetchValidatorIndices(ctx, duty)
 
 	// Fetch the attestation data.
-	attestationData, err := s.obtainAttestationData(ctx, duty)
+	attestationData, err := s.attestationData(ctx, duty)
 	if err != nil {
 		s.monitor.AttestationsCompleted(started, duty.Slot(), len(validatorIndices), "failed")
 		return nil, err
 	}
-
-	if err := s.validateAttestationData(ctx, duty, attestationData); err != nil {
-		s.monitor.AttestationsCompleted(started, duty.Slot(), len(validatorIndices), "failed")
-		return nil, err
-	}
+	span.AddEvent("Obtained attestation data", trace.WithAttributes(
+		attribute.Int64("elapsed_ms", time.Since(started).Milliseconds()),
+	))
 
 	// Fetch the validating accounts.
 	validatingAccounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpochByIndex(ctx, phase0.Epoch(uint64(duty.Slot())/s.slotsPerEpoch), validatorIndices)
@@ -75,17 +75,13 @@ func (s *Service) Attest(ctx context.Context, data interface{}) ([]*phase0.Attes
 	}
 
 	// Set the per-validator information.
-	validatorIndexToArrayIndexMap := make(map[phase0.ValidatorIndex]int)
-	for i, index := range validatorIndices {
-		validatorIndexToArrayIndexMap[index] = i
-	}
-	committeeIndices := make([]phase0.CommitteeIndex, len(validatingAccounts))
-	validatorCommitteeIndices := make([]phase0.ValidatorIndex, len(validatingAccounts))
-	committeeSizes := make([]uint64, len(validatingAccounts))
-	for i := range accountsArray {
-		committeeIndices[i] = duty.CommitteeIndices()[validatorIndexToArrayIndexMap[accountValidatorIndices[i]]]
-		validatorCommitteeIndices[i] = phase0.ValidatorIndex(duty.ValidatorCommitteeIndices()[validatorIndexToArrayIndexMap[accountValidatorIndices[i]]])
-		committeeSizes[i] = duty.CommitteeSize(committeeIndices[i])
+	committeeIndices, validatorCommitteeIndices, committeeSizes := s.committeeDetails(duty, accountValidatorIndices)
+
+	// Everything up to this point is carried out even if signing is held, so
+	// that the attestation data is obtained and scored as normal.
+	if attester.SigningHeld(ctx) {
+		s.log.Debug().Stringer("duty", duty).Msg("Signing held; not signing attestations")
+		return nil, nil
 	}
 
 	attestations, err := s.attest(ctx,
@@ -140,6 +136,11 @@ func (s *Service) attest(
 		return nil, errors.Wrap(err, "failed to sign beacon attestations")
 	}
 	s.log.Trace().Dur("elapsed", time.Since(started)).Msg("Signed")
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("Signed attestations", trace.WithAttributes(
+		attribute.Int64("elapsed_ms", time.Since(started).Milliseconds()),
+		attribute.Int("signatures", len(sigs)),
+	))
 
 	attestations := s.createAttestations(ctx, duty, committeeIndices, validatorCommitteeIndices, committeeSizes, data, sigs)
 	if len(attestations) == 0 {
@@ -149,10 +150,23 @@ func (s *Service) attest(
 
 	// Submit the attestations.
 	submissionStarted := time.Now()
-	if err := s.attestationsSubmitter.SubmitAttestations(ctx, attestations); err != nil {
+	attempts, err := util.RetryUntil(ctx, s.retryDeadline(duty.Slot()), submissionRetryInterval, func(ctx context.Context) error {
+		err := s.attestationsSubmitter.SubmitAttestations(ctx, attestations)
+		if err != nil {
+			s.log.Debug().Err(err).Msg("Failed to submit attestations")
+		}
+		return err
+	})
+	if err != nil {
 		return nil, errors.Wrap(err, "failed to submit attestations")
 	}
-	s.log.Trace().Dur("elapsed", time.Since(started)).Dur("submission_elapsed", time.Since(submissionStarted)).Msg("Submitted attestations")
+	s.log.Trace().Dur("elapsed", time.Since(started)).Dur("submission_elapsed", time.Since(submissionStarted)).Int("attempts", attempts).Msg("Submitted attestations")
+	span.AddEvent("Submitted attestations", trace.WithAttributes(
+		attribute.Int64("elapsed_ms", time.Since(started).Milliseconds()),
+		attribute.Int64("submission_elapsed_ms", time.Since(submissionStarted).Milliseconds()),
+		attribute.Int("attestations", len(attestations)),
+		attribute.Int("attempts", attempts),
+	))
 
 	return attestations, nil
 }
@@ -198,6 +212,52 @@ func (s *Service) createAttestations(_ context.Context,
 	return attestations
 }
 
+// committeeDetails returns the committee index, position within the committee
+// and committee size for each of the supplied validators, in the same order.
+// All committees covered by the duty are handled in a single pass.
+//
+// The details are obtained from the position of each validator in the duty,
+// rather than from its position in any filtered list of indices.  If a
+// validator appears in the duty more than once only its first committee is
+// used, as signing attestations for multiple committees with the same target
+// would be a slashable double vote.
+func (s *Service) committeeDetails(duty *attester.Duty,
+	validatorIndices []phase0.ValidatorIndex,
+) (
+	[]phase0.CommitteeIndex,
+	[]phase0.ValidatorIndex,
+	[]uint64,
+) {
+	dutyCommitteeIndices := duty.CommitteeIndices()
+	dutyValidatorCommitteeIndices := duty.ValidatorCommitteeIndices()
+
+	positions := make(map[phase0.ValidatorIndex]int, len(duty.ValidatorIndices()))
+	for i, index := range duty.ValidatorIndices() {
+		if first, exists := positions[index]; exists {
+			s.log.Warn().
+				Uint64("slot", uint64(duty.Slot())).
+				Uint64("validator_index", uint64(index)).
+				Uint64("committee_index", uint64(dutyCommitteeIndices[first])).
+				Uint64("ignored_committee_index", uint64(dutyCommitteeIndices[i])).
+				Msg("Validator has multiple committee assignments; attesting for first committee only")
+			continue
+		}
+		positions[index] = i
+	}
+
+	committeeIndices := make([]phase0.CommitteeIndex, len(validatorIndices))
+	validatorCommitteeIndices := make([]phase0.ValidatorIndex, len(validatorIndices))
+	committeeSizes := make([]uint64, len(validatorIndices))
+	for i, index := range validatorIndices {
+		position := positions[index]
+		committeeIndices[i] = dutyCommitteeIndices[position]
+		validatorCommitteeIndices[i] = phase0.ValidatorIndex(dutyValidatorCommitteeIndices[position])
+		committeeSizes[i] = duty.CommitteeSize(committeeIndices[i])
+	}
+
+	return committeeIndices, validatorCommitteeIndices, committeeSizes
+}
+
 func (s *Service) fetchValidatorIndices(_ context.Context,
 	duty *attester.Duty,
 ) []phase0.ValidatorIndex {
@@ -237,6 +297,56 @@ func (s *Service) fetchValidatorIndices(_ context.Context,
 	return validatorIndices
 }
 
+// attestationData obtains and validates the attestation data for a duty.
+// If attestation data is locked then the data is fetched once for each slot,
+// and the same data is returned for all duties in that slot.
+func (s *Service) attestationData(ctx context.Context,
+	duty *attester.Duty,
+) (
+	*phase0.AttestationData,
+	error,
+) {
+	if !s.lockAttestationData {
+		attestationData, err := s.obtainAttestationData(ctx, duty)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.validateAttestationData(ctx, duty, attestationData); err != nil {
+			return nil, err
+		}
+
+		return attestationData, nil
+	}
+
+	// Hold the lock while fetching, so that concurrent duties for the
+	// same slot wait for the data rather than fetching it themselves.
+	s.lockedAttestationDataMu.Lock()
+	defer s.lockedAttestationDataMu.Unlock()
+
+	if attestationData, exists := s.lockedAttestationData[duty.Slot()]; exists {
+		s.log.Trace().Uint64("slot", uint64(duty.Slot())).Msg("Using locked attestation data")
+		return attestationData, nil
+	}
+
+	attestationData, err := s.obtainAttestationData(ctx, duty)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateAttestationData(ctx, duty, attestationData); err != nil {
+		return nil, err
+	}
+
+	// Only data for the latest slot is required.
+	for slot := range s.lockedAttestationData {
+		if slot < duty.Slot() {
+			delete(s.lockedAttestationData, slot)
+		}
+	}
+	s.lockedAttestationData[duty.Slot()] = attestationData
+
+	return attestationData, nil
+}
+
 func (s *Service) obtainAttestationData(ctx context.Context,
 	duty *attester.Duty,
 ) (
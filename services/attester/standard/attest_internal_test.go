@@ -15,19 +15,23 @@ package standard
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
 	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
 	"github.com/attestantio/vouch/services/attester"
 	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	prometheusmetrics "github.com/attestantio/vouch/services/metrics/prometheus"
 	mocksigner "github.com/attestantio/vouch/services/signer/mock"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/require"
 )
 
@@ -558,3 +562,256 @@ func TestFetchValidatorIndices(t *testing.T) {
 		})
 	}
 }
+
+func TestCommitteeDetails(t *testing.T) {
+	ctx := context.Background()
+
+	// Validator 2 spans committees 0 and 1.
+	duty, err := attester.NewDuty(ctx,
+		100,                                    // slot.
+		2,                                      // committees at slot,
+		[]phase0.ValidatorIndex{1, 2, 3, 2, 4}, // validator indices.
+		[]phase0.CommitteeIndex{0, 0, 1, 1, 1}, // committee indices.
+		[]uint64{0, 1, 0, 1, 2},                // validator committee indices.
+		map[phase0.CommitteeIndex]uint64{0: 2, 1: 3}, // committee lengths.
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                      string
+		validatorIndices          []phase0.ValidatorIndex
+		committeeIndices          []phase0.CommitteeIndex
+		validatorCommitteeIndices []phase0.ValidatorIndex
+		committeeSizes            []uint64
+	}{
+		{
+			name:                      "Empty",
+			validatorIndices:          []phase0.ValidatorIndex{},
+			committeeIndices:          []phase0.CommitteeIndex{},
+			validatorCommitteeIndices: []phase0.ValidatorIndex{},
+			committeeSizes:            []uint64{},
+		},
+		{
+			name:                      "All",
+			validatorIndices:          []phase0.ValidatorIndex{1, 2, 3, 4},
+			committeeIndices:          []phase0.CommitteeIndex{0, 0, 1, 1},
+			validatorCommitteeIndices: []phase0.ValidatorIndex{0, 1, 0, 2},
+			committeeSizes:            []uint64{2, 2, 3, 3},
+		},
+		{
+			name:                      "Filtered",
+			validatorIndices:          []phase0.ValidatorIndex{4, 2},
+			committeeIndices:          []phase0.CommitteeIndex{1, 0},
+			validatorCommitteeIndices: []phase0.ValidatorIndex{2, 1},
+			committeeSizes:            []uint64{3, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+			s := &Service{
+				log: zerologger.With().Logger().Level(zerolog.WarnLevel),
+			}
+			committeeIndices, validatorCommitteeIndices, committeeSizes := s.committeeDetails(duty, test.validatorIndices)
+			require.Equal(t, test.committeeIndices, committeeIndices)
+			require.Equal(t, test.validatorCommitteeIndices, validatorCommitteeIndices)
+			require.Equal(t, test.committeeSizes, committeeSizes)
+			require.True(t, capture.HasLog(map[string]any{
+				"message":                 "Validator has multiple committee assignments; attesting for first committee only",
+				"validator_index":         uint64(2),
+				"committee_index":         uint64(0),
+				"ignored_committee_index": uint64(1),
+			}))
+		})
+	}
+}
+
+// flappingAttestationDataProvider returns attestation data with a different
+// head on each call, and counts the number of calls.
+type flappingAttestationDataProvider struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (p *flappingAttestationDataProvider) AttestationData(_ context.Context,
+	opts *api.AttestationDataOpts,
+) (
+	*api.Response[*phase0.AttestationData],
+	error,
+) {
+	p.mu.Lock()
+	p.calls++
+	calls := p.calls
+	p.mu.Unlock()
+
+	return &api.Response[*phase0.AttestationData]{
+		Data: &phase0.AttestationData{
+			Slot:            opts.Slot,
+			Index:           opts.CommitteeIndex,
+			BeaconBlockRoot: phase0.Root{byte(calls)},
+			Source: &phase0.Checkpoint{
+				Epoch: phase0.Epoch(opts.Slot/32 - 1),
+			},
+			Target: &phase0.Checkpoint{
+				Epoch: phase0.Epoch(opts.Slot / 32),
+			},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestLockedAttestationData(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	// Duties for different validators in the same slot.
+	duties := make([]*attester.Duty, 0, 4)
+	for i := 0; i < 4; i++ {
+		duty, err := attester.NewDuty(ctx,
+			100,
+			4,
+			[]phase0.ValidatorIndex{phase0.ValidatorIndex(i)},
+			[]phase0.CommitteeIndex{phase0.CommitteeIndex(i)},
+			[]uint64{0},
+			map[phase0.CommitteeIndex]uint64{phase0.CommitteeIndex(i): 1},
+		)
+		require.NoError(t, err)
+		duties = append(duties, duty)
+	}
+
+	tests := []struct {
+		name  string
+		lock  bool
+		calls int
+	}{
+		{
+			name:  "Unlocked",
+			lock:  false,
+			calls: len(duties),
+		},
+		{
+			name:  "Locked",
+			lock:  true,
+			calls: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider := &flappingAttestationDataProvider{}
+			s, err := New(ctx,
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithProcessConcurrency(1),
+				WithChainTimeService(chainTime),
+				WithSpecProvider(specProvider),
+				WithAttestationDataProvider(provider),
+				WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				WithBeaconAttestationsSigner(mocksigner.New()),
+				WithLockAttestationData(test.lock),
+			)
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			results := make([]*phase0.AttestationData, len(duties))
+			for i := range duties {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					data, err := s.attestationData(ctx, duties[i])
+					require.NoError(t, err)
+					results[i] = data
+				}(i)
+			}
+			wg.Wait()
+
+			require.Equal(t, test.calls, provider.calls)
+			if test.lock {
+				for i := range results {
+					require.Equal(t, results[0].BeaconBlockRoot, results[i].BeaconBlockRoot)
+				}
+			}
+		})
+	}
+}
+
+func TestAttestSigningHeld(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now().Add(-200 * 12 * time.Second)
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	duty, err := attester.NewDuty(ctx,
+		100,
+		1,
+		[]phase0.ValidatorIndex{1},
+		[]phase0.CommitteeIndex{0},
+		[]uint64{0},
+		map[phase0.CommitteeIndex]uint64{0: 1},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		held     bool
+		requests int
+	}{
+		{
+			name:     "NotHeld",
+			requests: 1,
+		},
+		{
+			name:     "Held",
+			held:     true,
+			requests: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider := &flappingAttestationDataProvider{}
+			signer := mocksigner.New()
+			s, err := New(ctx,
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(nullmetrics.New(ctx)),
+				WithProcessConcurrency(1),
+				WithChainTimeService(chainTime),
+				WithSpecProvider(specProvider),
+				WithAttestationDataProvider(provider),
+				WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+				WithBeaconAttestationsSigner(signer),
+			)
+			require.NoError(t, err)
+
+			attestCtx := ctx
+			if test.held {
+				attestCtx = attester.WithSigningHeld(ctx)
+			}
+			_, err = s.Attest(attestCtx, duty)
+			require.NoError(t, err)
+
+			// Attestation data is always obtained; signing only happens if not held.
+			require.Equal(t, 1, provider.calls)
+			require.Len(t, signer.Requests(), test.requests)
+		})
+	}
+}
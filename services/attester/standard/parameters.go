@@ -34,6 +34,8 @@ type parameters struct {
 	attestationsSubmitter      submitter.AttestationsSubmitter
 	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
 	beaconAttestationsSigner   signer.BeaconAttestationsSigner
+	lockAttestationData        bool
+	retryRemainingFraction     float64
 }
 
 // Parameter is the interface for service parameters.
@@ -110,10 +112,27 @@ func WithBeaconAttestationsSigner(signer signer.BeaconAttestationsSigner) Parame
 	})
 }
 
+// WithLockAttestationData sets whether to fetch attestation data once per
+// slot and reuse it for all attestations in that slot.
+func WithLockAttestationData(lock bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.lockAttestationData = lock
+	})
+}
+
+// WithRetryRemainingFraction sets the fraction of the slot that must remain
+// for a failed attestation submission to be retried.
+func WithRetryRemainingFraction(fraction float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.retryRemainingFraction = fraction
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:               zerolog.GlobalLevel(),
+		retryRemainingFraction: 1,
 	}
 	for _, p := range params {
 		if params != nil {
@@ -145,6 +164,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.beaconAttestationsSigner == nil {
 		return nil, errors.New("no beacon attestations signer specified")
 	}
+	if parameters.retryRemainingFraction < 0 || parameters.retryRemainingFraction > 1 {
+		return nil, errors.New("retry remaining fraction must be between 0 and 1")
+	}
 
 	return &parameters, nil
 }
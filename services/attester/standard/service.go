@@ -16,6 +16,7 @@ package standard
 import (
 	"context"
 	"sync"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
@@ -25,11 +26,15 @@ import (
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/signer"
 	"github.com/attestantio/vouch/services/submitter"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
 )
 
+// submissionRetryInterval is the interval between attempts to submit attestations.
+const submissionRetryInterval = 500 * time.Millisecond
+
 // Service is a beacon block attester.
 type Service struct {
 	log                        zerolog.Logger
@@ -43,6 +48,10 @@ type Service struct {
 	beaconAttestationsSigner   signer.BeaconAttestationsSigner
 	attested                   map[phase0.Epoch]map[phase0.ValidatorIndex]struct{}
 	attestedMu                 sync.Mutex
+	lockAttestationData        bool
+	lockedAttestationData      map[phase0.Slot]*phase0.AttestationData
+	lockedAttestationDataMu    sync.Mutex
+	retryRemainingFraction     float64
 }
 
 // New creates a new beacon block attester.
@@ -84,8 +93,20 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		attestationsSubmitter:      parameters.attestationsSubmitter,
 		beaconAttestationsSigner:   parameters.beaconAttestationsSigner,
 		attested:                   make(map[phase0.Epoch]map[phase0.ValidatorIndex]struct{}),
+		lockAttestationData:        parameters.lockAttestationData,
+		lockedAttestationData:      make(map[phase0.Slot]*phase0.AttestationData),
+		retryRemainingFraction:     parameters.retryRemainingFraction,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
 	return s, nil
 }
+
+// retryDeadline returns the time after which failed submissions for the
+// given slot are no longer retried.
+func (s *Service) retryDeadline(slot phase0.Slot) time.Time {
+	slotStart := s.chainTimeService.StartOfSlot(slot)
+	slotDuration := s.chainTimeService.StartOfSlot(slot + 1).Sub(slotStart)
+
+	return util.RetryDeadline(slotStart, slotDuration, s.retryRemainingFraction)
+}
@@ -189,6 +189,22 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no beacon attestations signer specified",
 		},
+		{
+			name: "RetryRemainingFractionInvalid",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(prometheusMetrics),
+				standard.WithProcessConcurrency(1),
+				standard.WithChainTimeService(chainTime),
+				standard.WithSpecProvider(specProvider),
+				standard.WithAttestationDataProvider(attestationDataProvider),
+				standard.WithAttestationsSubmitter(attestationsSubmitter),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithBeaconAttestationsSigner(beaconAttestationsSigner),
+				standard.WithRetryRemainingFraction(1.5),
+			},
+			err: "problem with parameters: retry remaining fraction must be between 0 and 1",
+		},
 		{
 			name: "Good",
 			params: []standard.Parameter{
@@ -0,0 +1,89 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filesink is a proposal record sink that appends records to a file,
+// one JSON object per line.
+package filesink
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a proposal record sink that writes records to a file.
+type Service struct {
+	file   *os.File
+	fileMu sync.Mutex
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new file proposal record sink.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "proposalrecords").Str("impl", "file").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	file, err := os.OpenFile(parameters.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open proposal record file")
+	}
+
+	s := &Service{
+		file: file,
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.fileMu.Lock()
+		defer s.fileMu.Unlock()
+		if err := s.file.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close proposal record file")
+		}
+	}()
+
+	return s, nil
+}
+
+// ProposalRecord is called with the record of a proposal.
+func (s *Service) ProposalRecord(_ context.Context, record *beaconblockproposer.ProposalRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal proposal record")
+		return
+	}
+	data = append(data, '\n')
+
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+	if _, err := s.file.Write(data); err != nil {
+		log.Error().Err(err).Uint64("slot", uint64(record.Slot)).Msg("Failed to write proposal record")
+		return
+	}
+	log.Trace().Uint64("slot", uint64(record.Slot)).Msg("Wrote proposal record")
+}
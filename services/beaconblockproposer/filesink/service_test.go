@@ -0,0 +1,141 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesink_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/beaconblockproposer/filesink"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []filesink.Parameter
+		err    string
+	}{
+		{
+			name: "PathMissing",
+			params: []filesink.Parameter{
+				filesink.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no path specified",
+		},
+		{
+			name: "PathBad",
+			params: []filesink.Parameter{
+				filesink.WithLogLevel(zerolog.Disabled),
+				filesink.WithPath(filepath.Join(t.TempDir(), "missing", "records.jsonl")),
+			},
+			err: "failed to open proposal record file",
+		},
+		{
+			name: "Good",
+			params: []filesink.Parameter{
+				filesink.WithLogLevel(zerolog.Disabled),
+				filesink.WithPath(filepath.Join(t.TempDir(), "records.jsonl")),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := filesink.New(ctx, test.params...)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProposalRecord(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+	s, err := filesink.New(ctx,
+		filesink.WithLogLevel(zerolog.Disabled),
+		filesink.WithPath(path),
+	)
+	require.NoError(t, err)
+
+	s.ProposalRecord(ctx, &beaconblockproposer.ProposalRecord{
+		Slot:               10,
+		ValidatorIndex:     5,
+		Method:             "auction",
+		BuilderBoostFactor: 91,
+		Candidates: []*beaconblockproposer.ProposalCandidate{
+			{Source: "local"},
+			{Source: "relay", Address: "https://relay1.example.com/", Value: "3000", Selected: true},
+		},
+		ExecutionValue: "3000",
+		ConsensusValue: "100",
+		BlockRoot:      phase0.Root{0x01},
+	})
+	s.ProposalRecord(ctx, &beaconblockproposer.ProposalRecord{
+		Slot:           11,
+		ValidatorIndex: 6,
+		Method:         "fallback",
+		Candidates: []*beaconblockproposer.ProposalCandidate{
+			{Source: "local", Value: "1000", Selected: true},
+		},
+		ExecutionValue: "1000",
+	})
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	records := make([]map[string]any, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := make(map[string]any)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, records, 2)
+
+	// Each record is a self-contained JSON object.
+	require.Equal(t, "10", records[0]["slot"])
+	require.Equal(t, "5", records[0]["validator_index"])
+	require.Equal(t, "auction", records[0]["method"])
+	require.Equal(t, float64(91), records[0]["builder_boost_factor"])
+	require.Equal(t, "3000", records[0]["execution_value"])
+	require.Equal(t, "100", records[0]["consensus_value"])
+	require.Equal(t, "0x0100000000000000000000000000000000000000000000000000000000000000", records[0]["block_root"])
+	require.Equal(t, []any{
+		map[string]any{"source": "local", "selected": false},
+		map[string]any{"source": "relay", "address": "https://relay1.example.com/", "value": "3000", "selected": true},
+	}, records[0]["candidates"])
+
+	require.Equal(t, "fallback", records[1]["method"])
+	require.NotContains(t, records[1], "consensus_value")
+	require.Equal(t, []any{
+		map[string]any{"source": "local", "value": "1000", "selected": true},
+	}, records[1]["candidates"])
+}
@@ -32,4 +32,6 @@ func (*service) Prepare(_ context.Context, _ interface{}) error {
 }
 
 // Propose is a mock.
-func (*service) Propose(_ context.Context, _ interface{}) {}
+func (*service) Propose(_ context.Context, _ interface{}) error {
+	return nil
+}
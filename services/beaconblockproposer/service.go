@@ -77,11 +77,52 @@ func (d *Duty) Account() e2wtypes.Account {
 	return d.account
 }
 
+// ProposalCandidate is a candidate source for a proposal.
+type ProposalCandidate struct {
+	// Source is the source of the candidate, either "local" or "relay".
+	Source string `json:"source"`
+	// Address is the address of the relay, for relay candidates.
+	Address string `json:"address,omitempty"`
+	// Value is the execution value of the candidate in wei, if known.
+	Value string `json:"value,omitempty"`
+	// Selected is true if the candidate was used for the proposal.
+	Selected bool `json:"selected"`
+}
+
+// ProposalRecord is a record of the candidates for a proposal and the
+// candidate selected, for offline analysis.
+type ProposalRecord struct {
+	// Slot is the slot of the proposal.
+	Slot phase0.Slot `json:"slot"`
+	// ValidatorIndex is the index of the proposing validator.
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	// Method is how the proposal was obtained: "direct", "auction" or "fallback".
+	Method string `json:"method"`
+	// BuilderBoostFactor is the builder boost factor passed to the beacon node.
+	BuilderBoostFactor uint64 `json:"builder_boost_factor"`
+	// Candidates are the candidate sources for the proposal.
+	Candidates []*ProposalCandidate `json:"candidates"`
+	// ExecutionValue is the execution value of the proposal in wei, if known.
+	ExecutionValue string `json:"execution_value,omitempty"`
+	// ConsensusValue is the consensus value of the proposal in wei, if known.
+	ConsensusValue string `json:"consensus_value,omitempty"`
+	// BlockRoot is the root of the proposed block.
+	BlockRoot phase0.Root `json:"block_root"`
+}
+
+// ProposalRecordSink receives records of proposals.
+type ProposalRecordSink interface {
+	// ProposalRecord is called with the record of a proposal.
+	// It is called synchronously, so must not block.
+	ProposalRecord(ctx context.Context, record *ProposalRecord)
+}
+
 // Service is the beacon block proposer service.
 type Service interface {
 	// Prepare prepares the proposal for a slot.
 	Prepare(ctx context.Context, details interface{}) error
 
 	// Propose carries out the proposal for a slot.
-	Propose(ctx context.Context, details interface{})
+	// It returns an error if the proposal was not made.
+	Propose(ctx context.Context, details interface{}) error
 }
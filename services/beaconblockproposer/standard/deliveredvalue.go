@@ -0,0 +1,187 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+)
+
+// checkDeliveredValue checks that the execution payload delivered by the
+// relays that supplied the winning bid pays the proposer at least the value
+// that the bid advertised, warning if it does not.
+//
+// The value delivered is the total value of the transactions in the payload
+// to the proposer's fee recipients, which is how builders pay proposers.  If
+// a proposer's fee recipient is the coinbase of the payload then the value is
+// made up of transaction fees, which cannot be calculated from the payload
+// alone, so the value is not verified.
+func (s *Service) checkDeliveredValue(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	auctionResults *blockauctioneer.Results,
+	proposal *api.VersionedSignedProposal,
+) {
+	if auctionResults == nil || auctionResults.Bid == nil {
+		return
+	}
+
+	result, advertised, delivered, err := s.deliveredValueResult(ctx, duty, auctionResults, proposal)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to verify delivered value")
+		result = "failed"
+	}
+
+	providers := make([]string, 0, len(auctionResults.Providers))
+	for _, provider := range auctionResults.Providers {
+		providers = append(providers, provider.Address())
+		monitorDeliveredValue(provider.Address(), result)
+	}
+
+	if result == "underdelivered" {
+		log.Warn().
+			Uint64("slot", uint64(duty.Slot())).
+			Strs("relays", providers).
+			Stringer("advertised", advertised).
+			Stringer("delivered", delivered).
+			Stringer("shortfall", new(big.Int).Sub(advertised, delivered)).
+			Msg("Relay delivered less value than its bid advertised")
+	}
+}
+
+// deliveredValueResult compares the value delivered by the proposal with the
+// value advertised by the winning bid.
+func (s *Service) deliveredValueResult(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	auctionResults *blockauctioneer.Results,
+	proposal *api.VersionedSignedProposal,
+) (
+	string,
+	*big.Int,
+	*big.Int,
+	error,
+) {
+	value, err := auctionResults.Bid.Value()
+	if err != nil {
+		return "", nil, nil, errors.Wrap(err, "failed to obtain bid value")
+	}
+	advertised := value.ToBig()
+
+	proposerConfig, err := s.executionConfigProvider.ProposerConfig(ctx, duty.Account(), util.ValidatorPubkey(duty.Account()))
+	if err != nil {
+		return "", nil, nil, errors.Wrap(err, "failed to obtain proposer configuration")
+	}
+	if proposerConfig == nil {
+		return "", nil, nil, errors.New("no proposer configuration")
+	}
+	feeRecipients := map[bellatrix.ExecutionAddress]struct{}{
+		proposerConfig.FeeRecipient: {},
+	}
+	for _, relay := range proposerConfig.Relays {
+		feeRecipients[relay.FeeRecipient] = struct{}{}
+	}
+
+	coinbase, transactions, err := executionPayloadPayments(proposal)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if _, isFeeRecipient := feeRecipients[coinbase]; isFeeRecipient {
+		return "unverifiable", advertised, nil, nil
+	}
+
+	delivered := deliveredValue(transactions, feeRecipients)
+	if delivered.Cmp(advertised) < 0 {
+		return "underdelivered", advertised, delivered, nil
+	}
+
+	return "delivered", advertised, delivered, nil
+}
+
+// deliveredValue returns the total value of the transactions to any of the fee recipients.
+func deliveredValue(transactions []bellatrix.Transaction,
+	feeRecipients map[bellatrix.ExecutionAddress]struct{},
+) *big.Int {
+	delivered := new(big.Int)
+	for i, tx := range transactions {
+		recipient, value, hasRecipient, err := transactionPayment(tx)
+		if err != nil {
+			log.Trace().Int("index", i).Err(err).Msg("Failed to decode transaction")
+			continue
+		}
+		if !hasRecipient {
+			continue
+		}
+		if _, isFeeRecipient := feeRecipients[recipient]; isFeeRecipient {
+			delivered.Add(delivered, value)
+		}
+	}
+
+	return delivered
+}
+
+// executionPayloadPayments returns the coinbase and transactions of the
+// execution payload in an unblinded proposal.
+func executionPayloadPayments(proposal *api.VersionedSignedProposal) (
+	bellatrix.ExecutionAddress,
+	[]bellatrix.Transaction,
+	error,
+) {
+	if proposal == nil || proposal.Blinded {
+		return bellatrix.ExecutionAddress{}, nil, errors.New("no unblinded proposal")
+	}
+
+	switch proposal.Version {
+	case spec.DataVersionBellatrix:
+		if proposal.Bellatrix == nil ||
+			proposal.Bellatrix.Message == nil ||
+			proposal.Bellatrix.Message.Body == nil ||
+			proposal.Bellatrix.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, nil, errors.New("no bellatrix execution payload")
+		}
+		payload := proposal.Bellatrix.Message.Body.ExecutionPayload
+
+		return payload.FeeRecipient, payload.Transactions, nil
+	case spec.DataVersionCapella:
+		if proposal.Capella == nil ||
+			proposal.Capella.Message == nil ||
+			proposal.Capella.Message.Body == nil ||
+			proposal.Capella.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, nil, errors.New("no capella execution payload")
+		}
+		payload := proposal.Capella.Message.Body.ExecutionPayload
+
+		return payload.FeeRecipient, payload.Transactions, nil
+	case spec.DataVersionDeneb:
+		if proposal.Deneb == nil ||
+			proposal.Deneb.SignedBlock == nil ||
+			proposal.Deneb.SignedBlock.Message == nil ||
+			proposal.Deneb.SignedBlock.Message.Body == nil ||
+			proposal.Deneb.SignedBlock.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, nil, errors.New("no deneb execution payload")
+		}
+		payload := proposal.Deneb.SignedBlock.Message.Body.ExecutionPayload
+
+		return payload.FeeRecipient, payload.Transactions, nil
+	default:
+		return bellatrix.ExecutionAddress{}, nil, fmt.Errorf("unsupported version %v", proposal.Version)
+	}
+}
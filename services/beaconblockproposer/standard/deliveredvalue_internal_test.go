@@ -0,0 +1,324 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	builderdeneb "github.com/attestantio/go-builder-client/api/deneb"
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// rlpString encodes a byte string in RLP.
+func rlpString(data []byte) []byte {
+	if len(data) == 1 && data[0] < 0x80 {
+		return data
+	}
+	return append(rlpPrefix(0x80, len(data)), data...)
+}
+
+// rlpList encodes a list of RLP-encoded items in RLP.
+func rlpList(items ...[]byte) []byte {
+	res := make([]byte, 0)
+	for _, item := range items {
+		res = append(res, item...)
+	}
+	return append(rlpPrefix(0xc0, len(res)), res...)
+}
+
+func rlpPrefix(offset byte, length int) []byte {
+	if length < 56 {
+		return []byte{offset + byte(length)}
+	}
+	lengthBytes := big.NewInt(int64(length)).Bytes()
+	return append([]byte{offset + 55 + byte(len(lengthBytes))}, lengthBytes...)
+}
+
+// legacyTx creates an RLP-encoded legacy transaction.
+func legacyTx(to []byte, value *big.Int) bellatrix.Transaction {
+	return rlpList(
+		rlpString([]byte{0x01}),       // nonce
+		rlpString([]byte{0x3b, 0x9a}), // gas price
+		rlpString([]byte{0x52, 0x08}), // gas
+		rlpString(to),
+		rlpString(value.Bytes()),
+		rlpString(make([]byte, 100)), // data
+		rlpString([]byte{0x25}),      // v
+		rlpString(make([]byte, 32)),  // r
+		rlpString(make([]byte, 32)),  // s
+	)
+}
+
+// dynamicFeeTx creates an RLP-encoded dynamic fee transaction.
+func dynamicFeeTx(to []byte, value *big.Int) bellatrix.Transaction {
+	return append([]byte{0x02}, rlpList(
+		rlpString([]byte{0x01}),       // chain ID
+		rlpString([]byte{0x02}),       // nonce
+		rlpString([]byte{0x3b, 0x9a}), // max priority fee per gas
+		rlpString([]byte{0x3b, 0x9a}), // max fee per gas
+		rlpString([]byte{0x52, 0x08}), // gas
+		rlpString(to),
+		rlpString(value.Bytes()),
+		rlpString(nil),              // data
+		rlpList(),                   // access list
+		rlpString(nil),              // y parity
+		rlpString(make([]byte, 32)), // r
+		rlpString(make([]byte, 32)), // s
+	)...)
+}
+
+func TestTransactionPayment(t *testing.T) {
+	recipient := bellatrix.ExecutionAddress{0x01, 0x02, 0x03}
+
+	tests := []struct {
+		name         string
+		tx           bellatrix.Transaction
+		recipient    bellatrix.ExecutionAddress
+		value        *big.Int
+		hasRecipient bool
+		err          string
+	}{
+		{
+			name: "Empty",
+			tx:   bellatrix.Transaction{},
+			err:  "empty transaction",
+		},
+		{
+			name: "UnknownType",
+			tx:   bellatrix.Transaction{0x7f, 0xc0},
+			err:  "unknown transaction type",
+		},
+		{
+			name: "Truncated",
+			tx:   legacyTx(recipient[:], big.NewInt(1))[:50],
+			err:  "item exceeds data",
+		},
+		{
+			name:         "Legacy",
+			tx:           legacyTx(recipient[:], big.NewInt(1000000000000000000)),
+			recipient:    recipient,
+			value:        big.NewInt(1000000000000000000),
+			hasRecipient: true,
+		},
+		{
+			name:         "DynamicFee",
+			tx:           dynamicFeeTx(recipient[:], big.NewInt(12345)),
+			recipient:    recipient,
+			value:        big.NewInt(12345),
+			hasRecipient: true,
+		},
+		{
+			name:         "ZeroValue",
+			tx:           dynamicFeeTx(recipient[:], big.NewInt(0)),
+			recipient:    recipient,
+			value:        big.NewInt(0),
+			hasRecipient: true,
+		},
+		{
+			name:  "ContractCreation",
+			tx:    dynamicFeeTx(nil, big.NewInt(5)),
+			value: big.NewInt(5),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recipient, value, hasRecipient, err := transactionPayment(test.tx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.hasRecipient, hasRecipient)
+			require.Equal(t, test.recipient, recipient)
+			require.Equal(t, 0, test.value.Cmp(value))
+		})
+	}
+}
+
+type fixedExecutionConfigProvider struct {
+	config *beaconblockproposer.ProposerConfig
+}
+
+func (p *fixedExecutionConfigProvider) ProposerConfig(_ context.Context,
+	_ e2wtypes.Account,
+	_ phase0.BLSPubKey,
+) (
+	*beaconblockproposer.ProposerConfig,
+	error,
+) {
+	return p.config, nil
+}
+
+func TestDeliveredValueResult(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	feeRecipient := bellatrix.ExecutionAddress{0x01}
+	relayFeeRecipient := bellatrix.ExecutionAddress{0x02}
+	builder := bellatrix.ExecutionAddress{0x03}
+	other := bellatrix.ExecutionAddress{0x04}
+
+	s := &Service{
+		executionConfigProvider: &fixedExecutionConfigProvider{
+			config: &beaconblockproposer.ProposerConfig{
+				FeeRecipient: feeRecipient,
+				Relays: []*beaconblockproposer.RelayConfig{
+					{
+						Address:      "relay",
+						FeeRecipient: relayFeeRecipient,
+					},
+				},
+			},
+		},
+	}
+	proposalDuty := duty(1, 2, phase0.BLSSignature{}, account)
+
+	auctionResults := func(value uint64) *blockauctioneer.Results {
+		return &blockauctioneer.Results{
+			Bid: &builderspec.VersionedSignedBuilderBid{
+				Version: spec.DataVersionDeneb,
+				Deneb: &builderdeneb.SignedBuilderBid{
+					Message: &builderdeneb.BuilderBid{
+						Value: uint256.NewInt(value),
+					},
+				},
+			},
+		}
+	}
+
+	proposal := func(coinbase bellatrix.ExecutionAddress, transactions ...bellatrix.Transaction) *api.VersionedSignedProposal {
+		return &api.VersionedSignedProposal{
+			Version: spec.DataVersionDeneb,
+			Deneb: &apiv1deneb.SignedBlockContents{
+				SignedBlock: &deneb.SignedBeaconBlock{
+					Message: &deneb.BeaconBlock{
+						Body: &deneb.BeaconBlockBody{
+							ExecutionPayload: &deneb.ExecutionPayload{
+								FeeRecipient: coinbase,
+								Transactions: transactions,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		auctionResults *blockauctioneer.Results
+		proposal       *api.VersionedSignedProposal
+		result         string
+		delivered      *big.Int
+		err            string
+	}{
+		{
+			name:           "Blinded",
+			auctionResults: auctionResults(1000),
+			proposal: &api.VersionedSignedProposal{
+				Version: spec.DataVersionDeneb,
+				Blinded: true,
+			},
+			err: "no unblinded proposal",
+		},
+		{
+			name:           "Delivered",
+			auctionResults: auctionResults(1000),
+			proposal: proposal(builder,
+				dynamicFeeTx(other[:], big.NewInt(5000)),
+				dynamicFeeTx(feeRecipient[:], big.NewInt(1000)),
+			),
+			result:    "delivered",
+			delivered: big.NewInt(1000),
+		},
+		{
+			name:           "DeliveredToRelayFeeRecipient",
+			auctionResults: auctionResults(1000),
+			proposal: proposal(builder,
+				legacyTx(relayFeeRecipient[:], big.NewInt(1200)),
+			),
+			result:    "delivered",
+			delivered: big.NewInt(1200),
+		},
+		{
+			name:           "UnderDelivered",
+			auctionResults: auctionResults(1000),
+			proposal: proposal(builder,
+				dynamicFeeTx(other[:], big.NewInt(5000)),
+				dynamicFeeTx(feeRecipient[:], big.NewInt(600)),
+			),
+			result:    "underdelivered",
+			delivered: big.NewInt(600),
+		},
+		{
+			name:           "NoPayment",
+			auctionResults: auctionResults(1000),
+			proposal: proposal(builder,
+				dynamicFeeTx(other[:], big.NewInt(5000)),
+			),
+			result:    "underdelivered",
+			delivered: big.NewInt(0),
+		},
+		{
+			name:           "ProposerIsCoinbase",
+			auctionResults: auctionResults(1000),
+			proposal: proposal(feeRecipient,
+				dynamicFeeTx(other[:], big.NewInt(5000)),
+			),
+			result: "unverifiable",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, advertised, delivered, err := s.deliveredValueResult(ctx, proposalDuty, test.auctionResults, test.proposal)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.result, result)
+			require.Equal(t, 0, big.NewInt(1000).Cmp(advertised))
+			if test.delivered != nil {
+				require.Equal(t, 0, test.delivered.Cmp(delivered))
+			}
+		})
+	}
+}
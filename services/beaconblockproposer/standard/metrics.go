@@ -29,6 +29,8 @@ var (
 	beaconBlockProposalMarkTimer         prometheus.Histogram
 	beaconBlockProposalProcessLatestSlot prometheus.Gauge
 	beaconBlockProposalSource            *prometheus.CounterVec
+	deliveredValueVerifications          *prometheus.CounterVec
+	proposerConfirmations                *prometheus.CounterVec
 )
 
 func registerMetrics(ctx context.Context, monitor metrics.Service) error {
@@ -115,6 +117,26 @@ func registerPrometheusMetrics(_ context.Context) error {
 		return err
 	}
 
+	deliveredValueVerifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposer",
+		Name:      "delivered_value_verifications_total",
+		Help:      "The number of checks of the value delivered by relays against the value of their bids.",
+	}, []string{"relay", "result"})
+	if err := prometheus.Register(deliveredValueVerifications); err != nil {
+		return err
+	}
+
+	proposerConfirmations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposer",
+		Name:      "proposer_confirmations_total",
+		Help:      "The number of checks with beacon nodes that a validator is the proposer for a slot before proposing.",
+	}, []string{"result"})
+	if err := prometheus.Register(proposerConfirmations); err != nil {
+		return err
+	}
+
 	bestBidRelayCount = prometheus.NewHistogram(prometheus.HistogramOpts{
 		Namespace: "vouch",
 		Subsystem: "beaconblockproposer",
@@ -163,3 +185,21 @@ func monitorBeaconBlockProposalSource(source string) {
 
 	beaconBlockProposalSource.WithLabelValues(source).Inc()
 }
+
+// monitorDeliveredValue is called when the value delivered by a relay has been checked against its bid.
+func monitorDeliveredValue(relay string, result string) {
+	if deliveredValueVerifications == nil {
+		return
+	}
+
+	deliveredValueVerifications.WithLabelValues(relay, result).Inc()
+}
+
+// monitorProposerConfirmation is called when a validator has been checked as the proposer for a slot.
+func monitorProposerConfirmation(result string) {
+	if proposerConfirmations == nil {
+		return
+	}
+
+	proposerConfirmations.WithLabelValues(result).Inc()
+}
@@ -15,10 +15,13 @@ package standard
 
 import (
 	"errors"
+	"time"
 
 	"github.com/attestantio/go-block-relay/services/blockauctioneer"
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/blockrelay"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/graffitiprovider"
@@ -29,20 +32,29 @@ import (
 )
 
 type parameters struct {
-	logLevel                   zerolog.Level
-	monitor                    metrics.Service
-	chainTime                  chaintime.Service
-	blockAuctioneer            blockauctioneer.BlockAuctioneer
-	proposalProvider           eth2client.ProposalProvider
-	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
-	executionChainHeadProvider cache.ExecutionChainHeadProvider
-	graffitiProvider           graffitiprovider.Service
-	proposalSubmitter          submitter.ProposalSubmitter
-	randaoRevealSigner         signer.RANDAORevealSigner
-	beaconBlockSigner          signer.BeaconBlockSigner
-	blobSidecarSigner          signer.BlobSidecarSigner
-	unblindFromAllRelays       bool
-	builderBoostFactor         uint64
+	logLevel                      zerolog.Level
+	monitor                       metrics.Service
+	chainTime                     chaintime.Service
+	blockAuctioneer               blockauctioneer.BlockAuctioneer
+	proposalProvider              eth2client.ProposalProvider
+	fallbackProposalProvider      eth2client.ProposalProvider
+	fallbackProposalDeadline      time.Duration
+	validatingAccountsProvider    accountmanager.ValidatingAccountsProvider
+	executionChainHeadProvider    cache.ExecutionChainHeadProvider
+	proposedBlockRootSetter       cache.ProposedBlockRootSetter
+	graffitiProvider              graffitiprovider.Service
+	proposalSubmitter             submitter.ProposalSubmitter
+	randaoRevealSigner            signer.RANDAORevealSigner
+	beaconBlockSigner             signer.BeaconBlockSigner
+	blobSidecarSigner             signer.BlobSidecarSigner
+	unblindFromAllRelays          bool
+	builderBoostFactor            uint64
+	relayOnly                     bool
+	unblindRetryRemainingFraction float64
+	verifyDeliveredValue          bool
+	executionConfigProvider       blockrelay.ExecutionConfigProvider
+	proposerConfirmationProviders map[string]eth2client.ProposerDutiesProvider
+	proposalRecordSinks           []beaconblockproposer.ProposalRecordSink
 }
 
 // Parameter is the interface for service parameters.
@@ -84,6 +96,24 @@ func WithProposalDataProvider(provider eth2client.ProposalProvider) Parameter {
 	})
 }
 
+// WithFallbackProposalDataProvider sets the provider used to obtain a
+// minimal proposal if the main proposal data provider fails.
+func WithFallbackProposalDataProvider(provider eth2client.ProposalProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackProposalProvider = provider
+	})
+}
+
+// WithFallbackProposalDeadline sets the time into the slot after which a
+// fallback proposal is requested if the main proposal data provider has not
+// yet returned a proposal.  If 0, a fallback proposal is requested only if the
+// main proposal data provider fails.
+func WithFallbackProposalDeadline(deadline time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackProposalDeadline = deadline
+	})
+}
+
 // WithMonitor sets the monitor for this module.
 func WithMonitor(monitor metrics.Service) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -105,6 +135,13 @@ func WithExecutionChainHeadProvider(provider cache.ExecutionChainHeadProvider) P
 	})
 }
 
+// WithProposedBlockRootSetter sets the setter for the roots of blocks we propose.
+func WithProposedBlockRootSetter(setter cache.ProposedBlockRootSetter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposedBlockRootSetter = setter
+	})
+}
+
 // WithGraffitiProvider sets the graffiti provider.
 func WithGraffitiProvider(provider graffitiprovider.Service) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -154,10 +191,56 @@ func WithBuilderBoostFactor(factor uint64) Parameter {
 	})
 }
 
+// WithRelayOnly will only propose blocks with execution payloads obtained from relays if set.
+func WithRelayOnly(relayOnly bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.relayOnly = relayOnly
+	})
+}
+
+// WithUnblindRetryRemainingFraction sets the fraction of the slot that must
+// remain for a failed attempt to unblind a proposal to be retried.
+func WithUnblindRetryRemainingFraction(fraction float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.unblindRetryRemainingFraction = fraction
+	})
+}
+
+// WithVerifyDeliveredValue will check that relays deliver the value advertised by their bids if set.
+func WithVerifyDeliveredValue(verify bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.verifyDeliveredValue = verify
+	})
+}
+
+// WithExecutionConfigProvider sets the execution configuration provider.
+func WithExecutionConfigProvider(provider blockrelay.ExecutionConfigProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.executionConfigProvider = provider
+	})
+}
+
+// WithProposerConfirmationProviders sets the proposer duties providers used to
+// confirm that the validator is still the proposer for the slot before proposing.
+// If not supplied the confirmation is not carried out.
+func WithProposerConfirmationProviders(providers map[string]eth2client.ProposerDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposerConfirmationProviders = providers
+	})
+}
+
+// WithProposalRecordSinks sets the sinks to which records of proposals are published.
+func WithProposalRecordSinks(sinks []beaconblockproposer.ProposalRecordSink) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalRecordSinks = sinks
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:                      zerolog.GlobalLevel(),
+		unblindRetryRemainingFraction: defaultUnblindRetryRemainingFraction,
 	}
 	for _, p := range params {
 		if params != nil {
@@ -172,15 +255,31 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.proposalProvider == nil {
 		return nil, errors.New("no proposal data provider specified")
 	}
+	// Fallback proposal provider can be nil.
+	if parameters.fallbackProposalDeadline < 0 {
+		return nil, errors.New("fallback proposal deadline cannot be negative")
+	}
+	// Proposed block root setter can be nil.
 	// Some items are required if the auctioneer is present.
 	if parameters.blockAuctioneer != nil {
 		if parameters.executionChainHeadProvider == nil {
 			return nil, errors.New("no execution chain head provider specified")
 		}
 	}
+	if parameters.relayOnly {
+		if parameters.blockAuctioneer == nil {
+			return nil, errors.New("no block auctioneer specified for relay-only mode")
+		}
+		if parameters.fallbackProposalProvider != nil {
+			return nil, errors.New("fallback proposal provider cannot be used in relay-only mode")
+		}
+	}
 	if parameters.chainTime == nil {
 		return nil, errors.New("no chain time service specified")
 	}
+	if parameters.fallbackProposalDeadline >= parameters.chainTime.StartOfSlot(1).Sub(parameters.chainTime.StartOfSlot(0)) {
+		return nil, errors.New("fallback proposal deadline must be within the slot")
+	}
 	if parameters.validatingAccountsProvider == nil {
 		return nil, errors.New("no validating accounts provider specified")
 	}
@@ -196,6 +295,12 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.blobSidecarSigner == nil {
 		return nil, errors.New("no blob sidecar signer specified")
 	}
+	if parameters.verifyDeliveredValue && parameters.executionConfigProvider == nil {
+		return nil, errors.New("no execution config provider specified")
+	}
+	if parameters.unblindRetryRemainingFraction < 0 || parameters.unblindRetryRemainingFraction > 1 {
+		return nil, errors.New("unblind retry remaining fraction must be between 0 and 1")
+	}
 
 	return &parameters, nil
 }
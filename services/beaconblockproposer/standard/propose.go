@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -57,7 +58,7 @@ type BlindedProposerWithExpectedPayload interface {
 }
 
 // Propose proposes a block.
-func (s *Service) Propose(ctx context.Context, data interface{}) {
+func (s *Service) Propose(ctx context.Context, data interface{}) error {
 	ctx, span := otel.Tracer("attestantio.vouch.services.beaconblockproposer.standard").Start(ctx, "Propose")
 	defer span.End()
 	started := time.Now()
@@ -66,18 +67,27 @@ func (s *Service) Propose(ctx context.Context, data interface{}) {
 	if !ok {
 		log.Error().Msg("Passed invalid data structure")
 		monitorBeaconBlockProposalCompleted(started, 0, s.chainTime.StartOfSlot(0), "failed")
-		return
+		return errors.New("passed invalid data structure")
 	}
 	slot, err := validateDuty(duty)
 	if err != nil {
 		log.Error().Err(err).Msg("Invalid duty")
 		monitorBeaconBlockProposalCompleted(started, slot, s.chainTime.StartOfSlot(slot), "failed")
-		return
+		return errors.Wrap(err, "invalid duty")
 	}
 	span.SetAttributes(attribute.Int64("slot", int64(slot)))
 	log := log.With().Uint64("proposing_slot", uint64(slot)).Uint64("validator_index", uint64(duty.ValidatorIndex())).Logger()
 	log.Trace().Msg("Proposing")
 
+	if len(s.proposerConfirmationProviders) > 0 {
+		if err := s.confirmProposer(ctx, duty); err != nil {
+			log.Warn().Err(err).Msg("Validator not confirmed as proposer for slot; not proposing")
+			monitorBeaconBlockProposalCompleted(started, slot, s.chainTime.StartOfSlot(slot), "failed")
+			return errors.Wrap(err, "failed to confirm proposer")
+		}
+		log.Trace().Dur("elapsed", time.Since(started)).Msg("Confirmed proposer")
+	}
+
 	graffiti, err := s.obtainGraffiti(ctx, slot, duty.ValidatorIndex())
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to obtain graffiti")
@@ -90,11 +100,13 @@ func (s *Service) Propose(ctx context.Context, data interface{}) {
 	if err := s.proposeBlock(ctx, duty, graffiti); err != nil {
 		log.Error().Err(err).Msg("Failed to propose block")
 		monitorBeaconBlockProposalCompleted(started, slot, s.chainTime.StartOfSlot(slot), "failed")
-		return
+		return errors.Wrap(err, "failed to propose block")
 	}
 
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Submitted proposal")
 	monitorBeaconBlockProposalCompleted(started, slot, s.chainTime.StartOfSlot(slot), "succeeded")
+
+	return nil
 }
 
 // validateDuty validates that the information supplied to us in a duty is suitable for proposing.
@@ -167,31 +179,39 @@ func (s *Service) proposeBlock(ctx context.Context,
 		}
 	}
 
-	proposalResponse, err := s.proposalProvider.Proposal(ctx, &api.ProposalOpts{
-		Slot:               duty.Slot(),
-		RandaoReveal:       duty.RANDAOReveal(),
-		Graffiti:           graffiti,
-		BuilderBoostFactor: &s.builderBoostFactor,
-	})
-	if err != nil {
-		return errors.Wrap(err, "failed to obtain proposal")
-	}
-	proposal := proposalResponse.Data
-	if proposal.Blinded {
-		monitorBeaconBlockProposalSource("auction")
-	} else {
-		monitorBeaconBlockProposalSource("direct")
+	builderBoostFactor := s.builderBoostFactor
+	if s.relayOnly {
+		if err := checkRelayOnlyAuctionResults(auctionResults); err != nil {
+			log.Warn().Err(err).Msg("Relay-only mode and no relay payload available; block will not be proposed")
+			return err
+		}
+		// Ensure that the beacon node selects the relay payload.
+		builderBoostFactor = math.MaxUint64
 	}
 
-	if err := s.confirmProposalData(ctx, proposal, duty); err != nil {
+	var method string
+	proposal, fallback, err := s.obtainProposalOrFallback(ctx, duty, graffiti, builderBoostFactor)
+	switch {
+	case err != nil:
 		return err
+	case fallback:
+		method = "fallback"
+		builderBoostFactor = 0
+	case s.relayOnly && !proposal.Blinded:
+		return errors.New("relay-only mode but proposal is not blinded")
+	case proposal.Blinded:
+		method = "auction"
+	default:
+		method = "direct"
 	}
+	monitorBeaconBlockProposalSource(method)
 
 	signedProposal, err := s.signProposalData(ctx, proposal, duty)
 	if err != nil {
 		return err
 	}
 
+	unblinded := false
 	if signedProposal.Blinded {
 		// Select the relays to unblind the proposal.
 		providers := make([]builderclient.UnblindedProposalProvider, 0, len(auctionResults.AllProviders))
@@ -214,19 +234,190 @@ func (s *Service) proposeBlock(ctx context.Context,
 		}
 
 		log.Trace().Int("providers", len(providers)).Msg("Obtained relays that can unblind the proposal")
-		if err := s.unblindProposal(ctx, signedProposal, providers); err != nil {
+		if err := s.unblindProposal(ctx, duty.Slot(), signedProposal, providers); err != nil {
 			return errors.Wrap(err, "failed to unblind block")
 		}
+		unblinded = true
 	}
 
 	if err := s.proposalSubmitter.SubmitProposal(ctx, signedProposal); err != nil {
 		return errors.Wrap(err, "failed to submit proposal")
 	}
 
+	if unblinded && s.verifyDeliveredValue {
+		s.checkDeliveredValue(ctx, duty, auctionResults, signedProposal)
+	}
+
+	s.recordProposal(ctx, duty, method, builderBoostFactor, auctionResults, proposal)
+
+	if s.proposedBlockRootSetter != nil {
+		// Note the root of our proposal, allowing our attestations to be consistent with it.
+		root, err := proposal.Root()
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to obtain root of proposal")
+		} else {
+			s.proposedBlockRootSetter.SetProposedBlockRoot(duty.Slot(), root)
+		}
+	}
+
 	return nil
 }
 
-func (*Service) confirmProposalData(_ context.Context,
+// checkRelayOnlyAuctionResults checks that the results of an auction
+// can be used to propose a block in relay-only mode.
+func checkRelayOnlyAuctionResults(auctionResults *blockauctioneer.Results) error {
+	switch {
+	case auctionResults == nil:
+		return errors.New("block auction failed")
+	case len(auctionResults.AllProviders) == 0:
+		return errors.New("no relays configured")
+	case auctionResults.Bid == nil:
+		return errors.New("no bid obtained from relays")
+	default:
+		return nil
+	}
+}
+
+// obtainProposal obtains and confirms a proposal from the given provider.
+func (s *Service) obtainProposal(ctx context.Context,
+	provider consensusclient.ProposalProvider,
+	duty *beaconblockproposer.Duty,
+	graffiti [32]byte,
+	builderBoostFactor uint64,
+) (
+	*api.VersionedProposal,
+	error,
+) {
+	proposalResponse, err := provider.Proposal(ctx, &api.ProposalOpts{
+		Slot:               duty.Slot(),
+		RandaoReveal:       duty.RANDAOReveal(),
+		Graffiti:           graffiti,
+		BuilderBoostFactor: &builderBoostFactor,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proposal")
+	}
+	proposal := proposalResponse.Data
+
+	if err := s.confirmProposalData(ctx, proposal, duty); err != nil {
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+// proposalResult is the result of an attempt to obtain a proposal.
+type proposalResult struct {
+	proposal *api.VersionedProposal
+	fallback bool
+	err      error
+}
+
+// obtainProposalOrFallback obtains a proposal from the proposal provider.  If
+// a fallback provider is configured, a fallback proposal is also requested if
+// the proposal provider fails or has not returned a proposal by the fallback
+// proposal deadline, and the first proposal obtained is used.  It returns true
+// if the proposal is a fallback proposal.
+func (s *Service) obtainProposalOrFallback(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	graffiti [32]byte,
+	builderBoostFactor uint64,
+) (
+	*api.VersionedProposal,
+	bool,
+	error,
+) {
+	if s.fallbackProposalProvider == nil {
+		proposal, err := s.obtainProposal(ctx, s.proposalProvider, duty, graffiti, builderBoostFactor)
+
+		return proposal, false, err
+	}
+
+	primaryCtx, primaryCancel := context.WithCancel(ctx)
+	defer primaryCancel()
+	// The fallback has its own context, so that it is not affected if the
+	// proposal provider has consumed the caller's deadline, but it cannot
+	// run beyond the end of the slot.
+	fallbackCtx, fallbackCancel := context.WithDeadline(context.WithoutCancel(ctx), s.chainTime.StartOfSlot(duty.Slot()+1))
+	defer fallbackCancel()
+
+	resultCh := make(chan *proposalResult, 2)
+	go func() {
+		proposal, err := s.obtainProposal(primaryCtx, s.proposalProvider, duty, graffiti, builderBoostFactor)
+		resultCh <- &proposalResult{proposal: proposal, err: err}
+	}()
+	outstanding := 1
+	fallbackStarted := false
+	startFallback := func() {
+		fallbackStarted = true
+		outstanding++
+		go func() {
+			proposal, err := s.obtainFallbackProposal(fallbackCtx, duty, graffiti)
+			resultCh <- &proposalResult{proposal: proposal, fallback: true, err: err}
+		}()
+	}
+
+	var deadlineCh <-chan time.Time
+	if s.fallbackProposalDeadline > 0 {
+		timer := time.NewTimer(time.Until(s.chainTime.StartOfSlot(duty.Slot()).Add(s.fallbackProposalDeadline)))
+		defer timer.Stop()
+		deadlineCh = timer.C
+	}
+
+	var err error
+	for outstanding > 0 {
+		select {
+		case result := <-resultCh:
+			outstanding--
+			if result.err == nil {
+				return result.proposal, result.fallback, nil
+			}
+			if result.fallback {
+				// The error from the fallback takes precedence, as it was the last resort.
+				err = result.err
+				continue
+			}
+			if !fallbackStarted {
+				err = result.err
+				log.Warn().Err(result.err).Msg("Failed to obtain proposal; attempting to obtain fallback proposal")
+				startFallback()
+			}
+		case <-deadlineCh:
+			deadlineCh = nil
+			if !fallbackStarted {
+				log.Warn().Dur("deadline", s.fallbackProposalDeadline).Msg("No proposal obtained by deadline; attempting to obtain fallback proposal")
+				startFallback()
+			}
+		}
+	}
+
+	return nil, false, err
+}
+
+// obtainFallbackProposal obtains a minimal proposal from the fallback provider.
+// The proposal is requested without any builder boost, so that the beacon node
+// returns a locally-built block that does not need to be unblinded by a relay.
+func (s *Service) obtainFallbackProposal(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	graffiti [32]byte,
+) (
+	*api.VersionedProposal,
+	error,
+) {
+	proposal, err := s.obtainProposal(ctx, s.fallbackProposalProvider, duty, graffiti, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain fallback proposal")
+	}
+	if proposal.Blinded {
+		// We have no auction results with which to unblind the proposal.
+		return nil, errors.New("fallback proposal is blinded")
+	}
+	log.Info().Msg("Using fallback proposal")
+
+	return proposal, nil
+}
+
+func (s *Service) confirmProposalData(_ context.Context,
 	proposal *api.VersionedProposal,
 	duty *beaconblockproposer.Duty,
 ) error {
@@ -238,6 +429,18 @@ func (*Service) confirmProposalData(_ context.Context,
 		return errors.New("proposal data for incorrect slot")
 	}
 
+	// The execution payload timestamp must be the start time of the slot, otherwise the block is invalid.
+	if proposal.Version >= spec.DataVersionBellatrix {
+		timestamp, err := proposal.Timestamp()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain proposal execution payload timestamp")
+		}
+		expected := s.chainTime.StartOfSlot(duty.Slot()).Unix()
+		if int64(timestamp) != expected {
+			return fmt.Errorf("proposal execution payload timestamp %d does not match slot timestamp %d", timestamp, expected)
+		}
+	}
+
 	// RANDAO reveal can be different in DVT situations, so do not check it.  It wil have already been checked by the underlying
 	// library that obtained the proposal, which is DVT-aware.
 
@@ -370,7 +573,8 @@ func (s *Service) auctionBlock(ctx context.Context,
 	return auctionResults, nil
 }
 
-func (*Service) unblindProposal(ctx context.Context,
+func (s *Service) unblindProposal(ctx context.Context,
+	slot phase0.Slot,
 	proposal *api.VersionedSignedProposal,
 	providers []builderclient.UnblindedProposalProvider,
 ) error {
@@ -379,19 +583,21 @@ func (*Service) unblindProposal(ctx context.Context,
 	// semaphore to track if a signed block has been returned by any provider.
 	sem := semaphore.NewWeighted(1)
 
+	// As we cannot fall back we move to a retry system, retrying until the
+	// configured fraction of the slot remains.
+	slotStart := s.chainTime.StartOfSlot(slot)
+	retryDeadline := util.RetryDeadline(slotStart, s.chainTime.StartOfSlot(slot+1).Sub(slotStart), s.unblindRetryRemainingFraction)
+
 	respCh := make(chan *api.VersionedSignedProposal, 1)
 	for _, provider := range providers {
 		go func(ctx context.Context, provider builderclient.UnblindedProposalProvider, ch chan *api.VersionedSignedProposal) {
 			log := log.With().Str("provider", provider.Address()).Logger()
 			log.Trace().Msg("Unblinding block with provider")
 
-			// As we cannot fall back we move to a retry system.
-			retryInterval := 250 * time.Millisecond
-
 			var signedProposal *api.VersionedSignedProposal
-			var err error
-			for retries := 3; retries > 0; retries-- {
+			_, _ = util.RetryUntil(ctx, retryDeadline, unblindRetryInterval, func(ctx context.Context) error {
 				// Unblind the blinded block.
+				var err error
 				signedProposal, err = provider.UnblindProposal(ctx, &api.VersionedSignedBlindedProposal{
 					Version:   proposal.Version,
 					Bellatrix: proposal.BellatrixBlinded,
@@ -403,21 +609,22 @@ func (*Service) unblindProposal(ctx context.Context,
 					// We failed to acquire the semaphore, which means another relay has responded already.
 					// As such, we can leave without going any further.
 					log.Trace().Msg("Another relay has already responded")
-					return
+					signedProposal = nil
+					return nil
 				}
 				sem.Release(1)
 
 				if err != nil {
-					log.Debug().Err(err).Int("retries", retries).Msg("Failed to unblind block")
+					log.Debug().Err(err).Msg("Failed to unblind block")
 					if strings.Contains(err.Error(), "POST failed with status 400") {
 						log.Debug().Msg("Responded with 400; not trying again as relay does not know of the payload")
-						return
+						return nil
 					}
-					time.Sleep(retryInterval)
-					continue
+					return err
 				}
-				break
-			}
+
+				return nil
+			})
 			if signedProposal == nil {
 				log.Debug().Msg("No signed block received")
 				return
@@ -15,10 +15,16 @@ package standard_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
 	mockblockauctioneer "github.com/attestantio/go-block-relay/services/blockauctioneer/mock"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	mockconsensusclient "github.com/attestantio/go-eth2-client/mock"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
@@ -31,6 +37,7 @@ import (
 	staticgraffitiprovider "github.com/attestantio/vouch/services/graffitiprovider/static"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	mocksigner "github.com/attestantio/vouch/services/signer/mock"
+	immediatesubmitter "github.com/attestantio/vouch/services/submitter/immediate"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
@@ -152,3 +159,594 @@ func TestPropose(t *testing.T) {
 		})
 	}
 }
+
+func TestProposeFallback(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	signer := mocksigner.New()
+
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+	graffitiProvider, err := staticgraffitiprovider.New(ctx)
+	require.NoError(t, err)
+
+	// Create an account.
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		logs   []map[string]any
+	}{
+		{
+			name: "NoFallback",
+			logs: []map[string]any{
+				{
+					"message": "Failed to propose block",
+					"error":   "failed to obtain proposal: error",
+				},
+			},
+		},
+		{
+			name: "FallbackFails",
+			params: []standard.Parameter{
+				standard.WithFallbackProposalDataProvider(mock.NewErroringProposalProvider()),
+			},
+			logs: []map[string]any{
+				{
+					"message": "Failed to obtain proposal; attempting to obtain fallback proposal",
+				},
+				{
+					"message": "Failed to propose block",
+					"error":   "failed to obtain fallback proposal: failed to obtain proposal: error",
+				},
+			},
+		},
+		{
+			name: "Fallback",
+			params: []standard.Parameter{
+				standard.WithFallbackProposalDataProvider(mock.NewTimedProposalProvider(genesisTime)),
+			},
+			logs: []map[string]any{
+				{
+					"message": "Failed to obtain proposal; attempting to obtain fallback proposal",
+				},
+				{
+					"message": "Using fallback proposal",
+				},
+				{
+					"message": "Submitted proposal",
+				},
+			},
+		},
+		{
+			name: "FallbackPrimarySlow",
+			params: []standard.Parameter{
+				standard.WithProposalDataProvider(mock.NewSleepyProposalProvider(5*time.Second, mock.NewTimedProposalProvider(genesisTime))),
+				standard.WithFallbackProposalDataProvider(mock.NewTimedProposalProvider(genesisTime)),
+				standard.WithFallbackProposalDeadline(100 * time.Millisecond),
+			},
+			logs: []map[string]any{
+				{
+					"message": "No proposal obtained by deadline; attempting to obtain fallback proposal",
+				},
+				{
+					"message": "Using fallback proposal",
+				},
+				{
+					"message": "Submitted proposal",
+				},
+			},
+		},
+		{
+			name: "FallbackWrongTimestamp",
+			params: []standard.Parameter{
+				standard.WithFallbackProposalDataProvider(mock.NewWrongTimestampProposalProvider(mock.NewTimedProposalProvider(genesisTime))),
+			},
+			logs: []map[string]any{
+				{
+					"message": "Failed to obtain proposal; attempting to obtain fallback proposal",
+				},
+				{
+					"message": "Failed to propose block",
+					"error":   fmt.Sprintf("failed to obtain fallback proposal: proposal execution payload timestamp %d does not match slot timestamp %d", genesisTime.Unix()+1, genesisTime.Unix()),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+			params := []standard.Parameter{
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithProposalDataProvider(mock.NewErroringProposalProvider()),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithProposalSubmitter(consensusClient),
+				standard.WithRANDAORevealSigner(signer),
+				standard.WithGraffitiProvider(graffitiProvider),
+				standard.WithBeaconBlockSigner(signer),
+				standard.WithBlobSidecarSigner(signer),
+			}
+			s, err := standard.New(ctx, append(params, test.params...)...)
+			require.NoError(t, err)
+
+			s.Propose(ctx, duty(phase0.BLSSignature{0x01}, account))
+
+			for _, log := range test.logs {
+				require.True(t, capture.HasLog(log), log["message"])
+			}
+		})
+	}
+}
+
+func TestProposeSubmissionFailover(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	signer := mocksigner.New()
+
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+	graffitiProvider, err := staticgraffitiprovider.New(ctx)
+	require.NoError(t, err)
+
+	// Create an account.
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		submitters map[string]eth2client.ProposalSubmitter
+		logs       []map[string]any
+	}{
+		{
+			name: "NoAlternatives",
+			logs: []map[string]any{
+				{
+					"message": "Failed to propose block",
+					"error":   "failed to submit proposal: failed to submit proposal: error",
+				},
+			},
+		},
+		{
+			name: "AlternativesFail",
+			submitters: map[string]eth2client.ProposalSubmitter{
+				"1": mock.NewErroringProposalSubmitter(),
+			},
+			logs: []map[string]any{
+				{
+					"message": "Failed to submit proposal; trying alternative beacon nodes",
+				},
+				{
+					"message": "Failed to propose block",
+					"error":   "failed to submit proposal: failed to submit proposal: error",
+				},
+			},
+		},
+		{
+			name: "Alternative",
+			submitters: map[string]eth2client.ProposalSubmitter{
+				"1": mock.NewErroringProposalSubmitter(),
+				"2": mock.NewProposalSubmitter(),
+			},
+			logs: []map[string]any{
+				{
+					"message": "Failed to submit proposal; trying alternative beacon nodes",
+				},
+				{
+					"message": "Submitted proposal",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+			// The node that provides the proposal fails before the proposal is submitted.
+			submitter, err := immediatesubmitter.New(ctx,
+				immediatesubmitter.WithProposalSubmitter(mock.NewErroringProposalSubmitter()),
+				immediatesubmitter.WithFallbackProposalSubmitters(test.submitters),
+				immediatesubmitter.WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				immediatesubmitter.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediatesubmitter.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+				immediatesubmitter.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediatesubmitter.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediatesubmitter.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediatesubmitter.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+			)
+			require.NoError(t, err)
+			s, err := standard.New(ctx,
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithProposalDataProvider(consensusClient),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithProposalSubmitter(submitter),
+				standard.WithRANDAORevealSigner(signer),
+				standard.WithGraffitiProvider(graffitiProvider),
+				standard.WithBeaconBlockSigner(signer),
+				standard.WithBlobSidecarSigner(signer),
+			)
+			require.NoError(t, err)
+
+			s.Propose(ctx, duty(phase0.BLSSignature{0x01}, account))
+
+			for _, log := range test.logs {
+				require.True(t, capture.HasLog(log), log["message"])
+			}
+		})
+	}
+}
+
+// countingProposalProvider counts the number of proposals requested.
+type countingProposalProvider struct {
+	eth2client.ProposalProvider
+	calls int
+}
+
+func (p *countingProposalProvider) Proposal(ctx context.Context,
+	opts *api.ProposalOpts,
+) (
+	*api.Response[*api.VersionedProposal],
+	error,
+) {
+	p.calls++
+
+	return p.ProposalProvider.Proposal(ctx, opts)
+}
+
+func TestProposeRelayOnly(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	signer := mocksigner.New()
+
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+	graffitiProvider, err := staticgraffitiprovider.New(ctx)
+	require.NoError(t, err)
+	cacheService := mockcache.New(map[phase0.Root]phase0.Slot{})
+
+	// Create an account.
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		blockAuctioneer blockauctioneer.BlockAuctioneer
+		logs            []map[string]any
+	}{
+		{
+			name:            "AuctionFails",
+			blockAuctioneer: mockblockauctioneer.NewErroring(),
+			logs: []map[string]any{
+				{
+					"message": "Relay-only mode and no relay payload available; block will not be proposed",
+					"error":   "block auction failed",
+				},
+				{
+					"message": "Failed to propose block",
+					"error":   "block auction failed",
+				},
+			},
+		},
+		{
+			name:            "NoRelays",
+			blockAuctioneer: mockblockauctioneer.New(),
+			logs: []map[string]any{
+				{
+					"message": "Relay-only mode and no relay payload available; block will not be proposed",
+					"error":   "no relays configured",
+				},
+				{
+					"message": "Failed to propose block",
+					"error":   "no relays configured",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+			proposalProvider := &countingProposalProvider{
+				ProposalProvider: consensusClient,
+			}
+			s, err := standard.New(ctx,
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithProposalDataProvider(proposalProvider),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithProposalSubmitter(consensusClient),
+				standard.WithRANDAORevealSigner(signer),
+				standard.WithGraffitiProvider(graffitiProvider),
+				standard.WithBeaconBlockSigner(signer),
+				standard.WithBlobSidecarSigner(signer),
+				standard.WithBlockAuctioneer(test.blockAuctioneer),
+				standard.WithExecutionChainHeadProvider(cacheService.(cache.ExecutionChainHeadProvider)),
+				standard.WithRelayOnly(true),
+			)
+			require.NoError(t, err)
+
+			s.Propose(ctx, duty(phase0.BLSSignature{0x01}, account))
+
+			for _, log := range test.logs {
+				require.True(t, capture.HasLog(log), log["message"])
+			}
+			// Local block production must not have been attempted.
+			require.Zero(t, proposalProvider.calls)
+		})
+	}
+}
+
+// TestProposeRelayTimeout confirms that a block is only requested once from the
+// beacon node when the relays fail to provide a bid, with no further request to
+// the fallback proposal provider.
+func TestProposeRelayTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	signer := mocksigner.New()
+
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+	graffitiProvider, err := staticgraffitiprovider.New(ctx)
+	require.NoError(t, err)
+	cacheService := mockcache.New(map[phase0.Root]phase0.Slot{})
+
+	// Create an account.
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	capture := logger.NewLogCapture()
+	proposalProvider := &countingProposalProvider{
+		ProposalProvider: mock.NewTimedProposalProvider(genesisTime),
+	}
+	fallbackProposalProvider := &countingProposalProvider{
+		ProposalProvider: mock.NewTimedProposalProvider(genesisTime),
+	}
+	s, err := standard.New(ctx,
+		standard.WithMonitor(nullmetrics.New(context.Background())),
+		standard.WithProposalDataProvider(proposalProvider),
+		standard.WithFallbackProposalDataProvider(fallbackProposalProvider),
+		standard.WithChainTime(chainTime),
+		standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+		standard.WithProposalSubmitter(consensusClient),
+		standard.WithRANDAORevealSigner(signer),
+		standard.WithGraffitiProvider(graffitiProvider),
+		standard.WithBeaconBlockSigner(signer),
+		standard.WithBlobSidecarSigner(signer),
+		standard.WithBlockAuctioneer(mockblockauctioneer.NewErroring()),
+		standard.WithExecutionChainHeadProvider(cacheService.(cache.ExecutionChainHeadProvider)),
+	)
+	require.NoError(t, err)
+
+	s.Propose(ctx, duty(phase0.BLSSignature{0x01}, account))
+
+	require.True(t, capture.HasLog(map[string]any{"message": "Failed to auction block"}))
+	require.True(t, capture.HasLog(map[string]any{"message": "Submitted proposal"}))
+	require.Equal(t, 1, proposalProvider.calls)
+	require.Zero(t, fallbackProposalProvider.calls)
+}
+
+// proposerDutiesProvider provides the proposer for slot 0.
+type proposerDutiesProvider struct {
+	proposer phase0.ValidatorIndex
+	err      error
+}
+
+func (p *proposerDutiesProvider) ProposerDuties(_ context.Context,
+	_ *api.ProposerDutiesOpts,
+) (
+	*api.Response[[]*apiv1.ProposerDuty],
+	error,
+) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &api.Response[[]*apiv1.ProposerDuty]{
+		Data: []*apiv1.ProposerDuty{
+			{
+				Slot:           0,
+				ValidatorIndex: p.proposer,
+			},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestProposeConfirmProposer(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	signer := mocksigner.New()
+
+	consensusClient, err := mockconsensusclient.New(ctx)
+	require.NoError(t, err)
+
+	// Create an account.
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	// The duty is for validator 0 at slot 0; a reorg makes validator 7 the proposer.
+	confirming := &proposerDutiesProvider{proposer: 0}
+	reorged := &proposerDutiesProvider{proposer: 7}
+	erroring := &proposerDutiesProvider{err: errors.New("error")}
+
+	tests := []struct {
+		name      string
+		providers map[string]eth2client.ProposerDutiesProvider
+		proposed  bool
+		err       string
+	}{
+		{
+			name:     "Disabled",
+			proposed: true,
+		},
+		{
+			name: "Confirmed",
+			providers: map[string]eth2client.ProposerDutiesProvider{
+				"1": confirming,
+				"2": confirming,
+				"3": confirming,
+			},
+			proposed: true,
+		},
+		{
+			name: "MinorityReorged",
+			providers: map[string]eth2client.ProposerDutiesProvider{
+				"1": confirming,
+				"2": confirming,
+				"3": reorged,
+			},
+			proposed: true,
+		},
+		{
+			name: "MajorityReorged",
+			providers: map[string]eth2client.ProposerDutiesProvider{
+				"1": confirming,
+				"2": reorged,
+				"3": reorged,
+			},
+			err: "proposer not confirmed by quorum of 2 of 3 providers",
+		},
+		{
+			name: "Reorged",
+			providers: map[string]eth2client.ProposerDutiesProvider{
+				"1": reorged,
+				"2": reorged,
+				"3": reorged,
+			},
+			err: "proposer not confirmed by quorum of 2 of 3 providers",
+		},
+		{
+			name: "Errors",
+			providers: map[string]eth2client.ProposerDutiesProvider{
+				"1": confirming,
+				"2": erroring,
+				"3": erroring,
+			},
+			err: "proposer not confirmed by quorum of 2 of 3 providers",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+			s, err := standard.New(ctx,
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithProposalDataProvider(consensusClient),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithProposalSubmitter(consensusClient),
+				standard.WithRANDAORevealSigner(signer),
+				standard.WithBeaconBlockSigner(signer),
+				standard.WithBlobSidecarSigner(signer),
+				standard.WithProposerConfirmationProviders(test.providers),
+			)
+			require.NoError(t, err)
+
+			err = s.Propose(ctx, duty(phase0.BLSSignature{0x01}, account))
+			if test.proposed {
+				require.NoError(t, err)
+				require.True(t, capture.HasLog(map[string]any{
+					"message": "Submitted proposal",
+				}))
+			} else {
+				require.EqualError(t, err, "failed to confirm proposer: "+test.err)
+				require.True(t, capture.HasLog(map[string]any{
+					"message": "Validator not confirmed as proposer for slot; not proposing",
+					"error":   test.err,
+				}))
+				require.False(t, capture.HasLog(map[string]any{
+					"message": "Submitted proposal",
+				}))
+			}
+		})
+	}
+}
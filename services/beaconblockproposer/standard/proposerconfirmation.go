@@ -0,0 +1,86 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"go.opentelemetry.io/otel"
+)
+
+type proposerConfirmationResponse struct {
+	provider  string
+	confirmed bool
+	err       error
+}
+
+// confirmProposer checks with the proposer confirmation providers that the
+// validator in the duty is still the proposer for the duty's slot.  A duty
+// obtained before a deep reorg can name a proposer that is no longer correct,
+// so the validator is confirmed only if more than half of the providers agree.
+func (s *Service) confirmProposer(ctx context.Context, duty *beaconblockproposer.Duty) error {
+	ctx, span := otel.Tracer("attestantio.vouch.services.beaconblockproposer.standard").Start(ctx, "confirmProposer")
+	defer span.End()
+
+	epoch := s.chainTime.SlotToEpoch(duty.Slot())
+	respCh := make(chan *proposerConfirmationResponse, len(s.proposerConfirmationProviders))
+	for name, provider := range s.proposerConfirmationProviders {
+		go func(name string, provider eth2client.ProposerDutiesProvider) {
+			dutiesResp, err := provider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+				Epoch: epoch,
+			})
+			if err != nil {
+				respCh <- &proposerConfirmationResponse{provider: name, err: err}
+				return
+			}
+			confirmed := false
+			for _, proposerDuty := range dutiesResp.Data {
+				if proposerDuty.Slot == duty.Slot() {
+					confirmed = proposerDuty.ValidatorIndex == duty.ValidatorIndex()
+					break
+				}
+			}
+			respCh <- &proposerConfirmationResponse{provider: name, confirmed: confirmed}
+		}(name, provider)
+	}
+
+	quorum := len(s.proposerConfirmationProviders)/2 + 1
+	confirmations := 0
+	for remaining := len(s.proposerConfirmationProviders); remaining > 0; remaining-- {
+		resp := <-respCh
+		switch {
+		case resp.err != nil:
+			log.Debug().Str("provider", resp.provider).Err(resp.err).Msg("Failed to obtain proposer duties to confirm proposer")
+		case resp.confirmed:
+			confirmations++
+		default:
+			log.Debug().Str("provider", resp.provider).Msg("Provider does not confirm proposer")
+		}
+		if confirmations >= quorum {
+			monitorProposerConfirmation("confirmed")
+			return nil
+		}
+		if confirmations+remaining-1 < quorum {
+			// Quorum can no longer be reached.
+			break
+		}
+	}
+
+	monitorProposerConfirmation("unconfirmed")
+	return fmt.Errorf("proposer not confirmed by quorum of %d of %d providers", quorum, len(s.proposerConfirmationProviders))
+}
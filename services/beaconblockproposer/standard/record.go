@@ -0,0 +1,111 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+)
+
+// recordProposal publishes the record of a proposal to the proposal record sinks.
+func (s *Service) recordProposal(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	method string,
+	builderBoostFactor uint64,
+	auctionResults *blockauctioneer.Results,
+	proposal *api.VersionedProposal,
+) {
+	if len(s.proposalRecordSinks) == 0 {
+		return
+	}
+
+	record := proposalRecord(duty, method, builderBoostFactor, auctionResults, proposal)
+	for _, sink := range s.proposalRecordSinks {
+		sink.ProposalRecord(ctx, record)
+	}
+}
+
+// proposalRecord creates the record of a proposal.
+//
+// The beacon node returns only the proposal that it selected, so the value of
+// the local candidate is known only if it was selected.
+func proposalRecord(duty *beaconblockproposer.Duty,
+	method string,
+	builderBoostFactor uint64,
+	auctionResults *blockauctioneer.Results,
+	proposal *api.VersionedProposal,
+) *beaconblockproposer.ProposalRecord {
+	record := &beaconblockproposer.ProposalRecord{
+		Slot:               duty.Slot(),
+		ValidatorIndex:     duty.ValidatorIndex(),
+		Method:             method,
+		BuilderBoostFactor: builderBoostFactor,
+		Candidates:         make([]*beaconblockproposer.ProposalCandidate, 0),
+		ExecutionValue:     weiString(proposal.ExecutionValue),
+		ConsensusValue:     weiString(proposal.ConsensusValue),
+	}
+	if root, err := proposal.Root(); err == nil {
+		record.BlockRoot = root
+	}
+
+	localCandidate := &beaconblockproposer.ProposalCandidate{
+		Source:   "local",
+		Selected: !proposal.Blinded,
+	}
+	if !proposal.Blinded {
+		localCandidate.Value = record.ExecutionValue
+	}
+	record.Candidates = append(record.Candidates, localCandidate)
+
+	if auctionResults == nil || method == "fallback" {
+		// Relays did not contribute to the proposal.
+		return record
+	}
+
+	winners := make(map[string]struct{}, len(auctionResults.Providers))
+	for _, provider := range auctionResults.Providers {
+		winners[strings.ToLower(provider.Address())] = struct{}{}
+	}
+	addresses := make([]string, 0, len(auctionResults.Values))
+	for address := range auctionResults.Values {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for _, address := range addresses {
+		_, winner := winners[strings.ToLower(address)]
+		record.Candidates = append(record.Candidates, &beaconblockproposer.ProposalCandidate{
+			Source:   "relay",
+			Address:  address,
+			Value:    weiString(auctionResults.Values[address]),
+			Selected: proposal.Blinded && winner,
+		})
+	}
+
+	return record
+}
+
+// weiString returns the decimal string of a value, or the empty string if it is not known.
+func weiString(value *big.Int) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.String()
+}
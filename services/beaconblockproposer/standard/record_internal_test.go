@@ -0,0 +1,151 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	builderclient "github.com/attestantio/go-builder-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/stretchr/testify/require"
+)
+
+// addressedRelay is a relay with a configurable address.
+type addressedRelay struct {
+	mock.BuilderClient
+	address string
+}
+
+func (r *addressedRelay) Address() string {
+	return r.address
+}
+
+func TestProposalRecord(t *testing.T) {
+	relay1 := &addressedRelay{address: "https://relay1.example.com/"}
+	relay2 := &addressedRelay{address: "https://relay2.example.com/"}
+	auctionResults := &blockauctioneer.Results{
+		Values: map[string]*big.Int{
+			"https://relay2.example.com/": big.NewInt(2000),
+			"https://relay1.example.com/": big.NewInt(3000),
+		},
+		AllProviders: []builderclient.BuilderBidProvider{relay1, relay2},
+		Providers:    []builderclient.BuilderBidProvider{relay1},
+	}
+	localProposal := &api.VersionedProposal{
+		Version:        spec.DataVersionDeneb,
+		ExecutionValue: big.NewInt(4000),
+		ConsensusValue: big.NewInt(100),
+	}
+	blindedProposal := &api.VersionedProposal{
+		Version:        spec.DataVersionDeneb,
+		Blinded:        true,
+		ExecutionValue: big.NewInt(3000),
+		ConsensusValue: big.NewInt(100),
+	}
+
+	tests := []struct {
+		name               string
+		method             string
+		builderBoostFactor uint64
+		auctionResults     *blockauctioneer.Results
+		proposal           *api.VersionedProposal
+		expected           *beaconblockproposer.ProposalRecord
+	}{
+		{
+			name:               "NoAuction",
+			method:             "direct",
+			builderBoostFactor: 91,
+			proposal:           localProposal,
+			expected: &beaconblockproposer.ProposalRecord{
+				Slot:               10,
+				ValidatorIndex:     5,
+				Method:             "direct",
+				BuilderBoostFactor: 91,
+				Candidates: []*beaconblockproposer.ProposalCandidate{
+					{Source: "local", Value: "4000", Selected: true},
+				},
+				ExecutionValue: "4000",
+				ConsensusValue: "100",
+			},
+		},
+		{
+			name:               "Direct",
+			method:             "direct",
+			builderBoostFactor: 91,
+			auctionResults:     auctionResults,
+			proposal:           localProposal,
+			expected: &beaconblockproposer.ProposalRecord{
+				Slot:               10,
+				ValidatorIndex:     5,
+				Method:             "direct",
+				BuilderBoostFactor: 91,
+				Candidates: []*beaconblockproposer.ProposalCandidate{
+					{Source: "local", Value: "4000", Selected: true},
+					{Source: "relay", Address: "https://relay1.example.com/", Value: "3000"},
+					{Source: "relay", Address: "https://relay2.example.com/", Value: "2000"},
+				},
+				ExecutionValue: "4000",
+				ConsensusValue: "100",
+			},
+		},
+		{
+			name:               "Auction",
+			method:             "auction",
+			builderBoostFactor: 91,
+			auctionResults:     auctionResults,
+			proposal:           blindedProposal,
+			expected: &beaconblockproposer.ProposalRecord{
+				Slot:               10,
+				ValidatorIndex:     5,
+				Method:             "auction",
+				BuilderBoostFactor: 91,
+				Candidates: []*beaconblockproposer.ProposalCandidate{
+					{Source: "local"},
+					{Source: "relay", Address: "https://relay1.example.com/", Value: "3000", Selected: true},
+					{Source: "relay", Address: "https://relay2.example.com/", Value: "2000"},
+				},
+				ExecutionValue: "3000",
+				ConsensusValue: "100",
+			},
+		},
+		{
+			name:           "Fallback",
+			method:         "fallback",
+			auctionResults: auctionResults,
+			proposal: &api.VersionedProposal{
+				Version: spec.DataVersionDeneb,
+			},
+			expected: &beaconblockproposer.ProposalRecord{
+				Slot:           10,
+				ValidatorIndex: 5,
+				Method:         "fallback",
+				Candidates: []*beaconblockproposer.ProposalCandidate{
+					{Source: "local", Selected: true},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record := proposalRecord(beaconblockproposer.NewDuty(10, 5), test.method, test.builderBoostFactor, test.auctionResults, test.proposal)
+			require.Equal(t, test.expected, record)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/accountmanager"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/blockrelay"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/graffitiprovider"
@@ -35,20 +36,37 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
+// defaultUnblindRetryRemainingFraction is the default fraction of the slot
+// that must remain for unblinding to be retried.  With 12-second slots this
+// allows retries for the first 3 seconds of the slot.
+const defaultUnblindRetryRemainingFraction = 0.75
+
+// unblindRetryInterval is the interval between attempts to unblind a proposal.
+const unblindRetryInterval = 250 * time.Millisecond
+
 // Service is a beacon block proposer.
 type Service struct {
-	chainTime                  chaintime.Service
-	blockAuctioneer            blockauctioneer.BlockAuctioneer
-	proposalProvider           eth2client.ProposalProvider
-	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
-	executionChainHeadProvider cache.ExecutionChainHeadProvider
-	graffitiProvider           graffitiprovider.Service
-	proposalSubmitter          submitter.ProposalSubmitter
-	randaoRevealSigner         signer.RANDAORevealSigner
-	beaconBlockSigner          signer.BeaconBlockSigner
-	blobSidecarSigner          signer.BlobSidecarSigner
-	unblindFromAllRelays       bool
-	builderBoostFactor         uint64
+	chainTime                     chaintime.Service
+	blockAuctioneer               blockauctioneer.BlockAuctioneer
+	proposalProvider              eth2client.ProposalProvider
+	fallbackProposalProvider      eth2client.ProposalProvider
+	fallbackProposalDeadline      time.Duration
+	validatingAccountsProvider    accountmanager.ValidatingAccountsProvider
+	executionChainHeadProvider    cache.ExecutionChainHeadProvider
+	proposedBlockRootSetter       cache.ProposedBlockRootSetter
+	graffitiProvider              graffitiprovider.Service
+	proposalSubmitter             submitter.ProposalSubmitter
+	randaoRevealSigner            signer.RANDAORevealSigner
+	beaconBlockSigner             signer.BeaconBlockSigner
+	blobSidecarSigner             signer.BlobSidecarSigner
+	unblindFromAllRelays          bool
+	builderBoostFactor            uint64
+	relayOnly                     bool
+	unblindRetryRemainingFraction float64
+	verifyDeliveredValue          bool
+	executionConfigProvider       blockrelay.ExecutionConfigProvider
+	proposerConfirmationProviders map[string]eth2client.ProposerDutiesProvider
+	proposalRecordSinks           []beaconblockproposer.ProposalRecordSink
 }
 
 // module-wide log.
@@ -72,18 +90,27 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	}
 
 	s := &Service{
-		chainTime:                  parameters.chainTime,
-		blockAuctioneer:            parameters.blockAuctioneer,
-		proposalProvider:           parameters.proposalProvider,
-		validatingAccountsProvider: parameters.validatingAccountsProvider,
-		executionChainHeadProvider: parameters.executionChainHeadProvider,
-		graffitiProvider:           parameters.graffitiProvider,
-		proposalSubmitter:          parameters.proposalSubmitter,
-		randaoRevealSigner:         parameters.randaoRevealSigner,
-		beaconBlockSigner:          parameters.beaconBlockSigner,
-		blobSidecarSigner:          parameters.blobSidecarSigner,
-		unblindFromAllRelays:       parameters.unblindFromAllRelays,
-		builderBoostFactor:         parameters.builderBoostFactor,
+		chainTime:                     parameters.chainTime,
+		blockAuctioneer:               parameters.blockAuctioneer,
+		proposalProvider:              parameters.proposalProvider,
+		fallbackProposalProvider:      parameters.fallbackProposalProvider,
+		fallbackProposalDeadline:      parameters.fallbackProposalDeadline,
+		validatingAccountsProvider:    parameters.validatingAccountsProvider,
+		executionChainHeadProvider:    parameters.executionChainHeadProvider,
+		proposedBlockRootSetter:       parameters.proposedBlockRootSetter,
+		graffitiProvider:              parameters.graffitiProvider,
+		proposalSubmitter:             parameters.proposalSubmitter,
+		randaoRevealSigner:            parameters.randaoRevealSigner,
+		beaconBlockSigner:             parameters.beaconBlockSigner,
+		blobSidecarSigner:             parameters.blobSidecarSigner,
+		unblindFromAllRelays:          parameters.unblindFromAllRelays,
+		builderBoostFactor:            parameters.builderBoostFactor,
+		relayOnly:                     parameters.relayOnly,
+		unblindRetryRemainingFraction: parameters.unblindRetryRemainingFraction,
+		verifyDeliveredValue:          parameters.verifyDeliveredValue,
+		executionConfigProvider:       parameters.executionConfigProvider,
+		proposerConfirmationProviders: parameters.proposerConfirmationProviders,
+		proposalRecordSinks:           parameters.proposalRecordSinks,
 	}
 
 	return s, nil
@@ -190,6 +190,40 @@ func TestService(t *testing.T) {
 				standard.WithBlobSidecarSigner(signer),
 			},
 		},
+		{
+			name: "FallbackProposalDeadlineNegative",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithProposalDataProvider(consensusClient),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithProposalSubmitter(consensusClient),
+				standard.WithRANDAORevealSigner(signer),
+				standard.WithBeaconBlockSigner(signer),
+				standard.WithBlobSidecarSigner(signer),
+				standard.WithFallbackProposalDataProvider(consensusClient),
+				standard.WithFallbackProposalDeadline(-1 * time.Second),
+			},
+			err: "problem with parameters: fallback proposal deadline cannot be negative",
+		},
+		{
+			name: "FallbackProposalDeadlineAfterSlot",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithProposalDataProvider(consensusClient),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithProposalSubmitter(consensusClient),
+				standard.WithRANDAORevealSigner(signer),
+				standard.WithBeaconBlockSigner(signer),
+				standard.WithBlobSidecarSigner(signer),
+				standard.WithFallbackProposalDataProvider(consensusClient),
+				standard.WithFallbackProposalDeadline(12 * time.Second),
+			},
+			err: "problem with parameters: fallback proposal deadline must be within the slot",
+		},
 		{
 			name: "ExecutionChainHeadProviderMissing",
 			params: []standard.Parameter{
@@ -222,6 +256,58 @@ func TestService(t *testing.T) {
 				standard.WithExecutionChainHeadProvider(cacheService.(cache.ExecutionChainHeadProvider)),
 			},
 		},
+		{
+			name: "RelayOnlyAuctioneerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithProposalDataProvider(consensusClient),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithProposalSubmitter(consensusClient),
+				standard.WithRANDAORevealSigner(signer),
+				standard.WithBeaconBlockSigner(signer),
+				standard.WithBlobSidecarSigner(signer),
+				standard.WithRelayOnly(true),
+			},
+			err: "problem with parameters: no block auctioneer specified for relay-only mode",
+		},
+		{
+			name: "RelayOnlyWithFallback",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithProposalDataProvider(consensusClient),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithProposalSubmitter(consensusClient),
+				standard.WithRANDAORevealSigner(signer),
+				standard.WithBeaconBlockSigner(signer),
+				standard.WithBlobSidecarSigner(signer),
+				standard.WithBlockAuctioneer(blockAuctioneer),
+				standard.WithExecutionChainHeadProvider(cacheService.(cache.ExecutionChainHeadProvider)),
+				standard.WithFallbackProposalDataProvider(consensusClient),
+				standard.WithRelayOnly(true),
+			},
+			err: "problem with parameters: fallback proposal provider cannot be used in relay-only mode",
+		},
+		{
+			name: "GoodRelayOnly",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithProposalDataProvider(consensusClient),
+				standard.WithChainTime(chainTime),
+				standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+				standard.WithProposalSubmitter(consensusClient),
+				standard.WithRANDAORevealSigner(signer),
+				standard.WithBeaconBlockSigner(signer),
+				standard.WithBlobSidecarSigner(signer),
+				standard.WithBlockAuctioneer(blockAuctioneer),
+				standard.WithExecutionChainHeadProvider(cacheService.(cache.ExecutionChainHeadProvider)),
+				standard.WithRelayOnly(true),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,143 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+)
+
+// transactionPayment returns the recipient and value of an execution
+// transaction.  It decodes only as much of the transaction as is required
+// to obtain these fields.  Transactions that create contracts have no
+// recipient, in which case the returned boolean is false.
+func transactionPayment(tx bellatrix.Transaction) (bellatrix.ExecutionAddress, *big.Int, bool, error) {
+	if len(tx) == 0 {
+		return bellatrix.ExecutionAddress{}, nil, false, errors.New("empty transaction")
+	}
+
+	// Position of the recipient in the transaction's fields; value follows it.
+	var toIndex int
+	payload := []byte(tx)
+	switch {
+	case tx[0] >= 0xc0:
+		// Legacy transaction: [nonce, gasPrice, gas, to, value, ...].
+		toIndex = 3
+	case tx[0] == 0x01:
+		// Access list transaction: [chainId, nonce, gasPrice, gas, to, value, ...].
+		toIndex = 4
+		payload = payload[1:]
+	case tx[0] == 0x02, tx[0] == 0x03, tx[0] == 0x04:
+		// Dynamic fee, blob and set code transactions: [chainId, nonce, maxPriorityFeePerGas, maxFeePerGas, gas, to, value, ...].
+		toIndex = 5
+		payload = payload[1:]
+	default:
+		return bellatrix.ExecutionAddress{}, nil, false, errors.New("unknown transaction type")
+	}
+
+	fields, isList, _, err := rlpItem(payload)
+	if err != nil {
+		return bellatrix.ExecutionAddress{}, nil, false, err
+	}
+	if !isList {
+		return bellatrix.ExecutionAddress{}, nil, false, errors.New("transaction is not a list")
+	}
+
+	var to []byte
+	var value []byte
+	for i := 0; i <= toIndex+1; i++ {
+		item, isList, rest, err := rlpItem(fields)
+		if err != nil {
+			return bellatrix.ExecutionAddress{}, nil, false, err
+		}
+		if isList {
+			return bellatrix.ExecutionAddress{}, nil, false, errors.New("unexpected list in transaction")
+		}
+		switch i {
+		case toIndex:
+			to = item
+		case toIndex + 1:
+			value = item
+		}
+		fields = rest
+	}
+
+	if len(to) == 0 {
+		// Contract creation.
+		return bellatrix.ExecutionAddress{}, new(big.Int).SetBytes(value), false, nil
+	}
+	if len(to) != bellatrix.ExecutionAddressLength {
+		return bellatrix.ExecutionAddress{}, nil, false, errors.New("invalid recipient length")
+	}
+	var recipient bellatrix.ExecutionAddress
+	copy(recipient[:], to)
+
+	return recipient, new(big.Int).SetBytes(value), true, nil
+}
+
+// rlpItem decodes the first RLP item in the data, returning its contents,
+// whether it is a list, and the data that follows it.
+func rlpItem(data []byte) ([]byte, bool, []byte, error) {
+	if len(data) == 0 {
+		return nil, false, nil, errors.New("no data")
+	}
+
+	var offset uint64
+	var length uint64
+	var isList bool
+	prefix := data[0]
+	switch {
+	case prefix < 0x80:
+		// Single byte.
+		return data[:1], false, data[1:], nil
+	case prefix < 0xb8:
+		offset = 1
+		length = uint64(prefix - 0x80)
+	case prefix < 0xc0:
+		lengthOfLength := uint64(prefix - 0xb7)
+		offset = 1 + lengthOfLength
+		length = rlpLength(data[1:], lengthOfLength)
+	case prefix < 0xf8:
+		isList = true
+		offset = 1
+		length = uint64(prefix - 0xc0)
+	default:
+		isList = true
+		lengthOfLength := uint64(prefix - 0xf7)
+		offset = 1 + lengthOfLength
+		length = rlpLength(data[1:], lengthOfLength)
+	}
+
+	if offset > uint64(len(data)) || length > uint64(len(data))-offset {
+		return nil, false, nil, errors.New("item exceeds data")
+	}
+
+	return data[offset : offset+length], isList, data[offset+length:], nil
+}
+
+// rlpLength decodes a big-endian length of the given number of bytes.
+// It returns a length that exceeds any valid data if the length cannot be decoded.
+func rlpLength(data []byte, lengthOfLength uint64) uint64 {
+	if lengthOfLength > 8 || uint64(len(data)) < lengthOfLength {
+		return ^uint64(0)
+	}
+	length := uint64(0)
+	for i := uint64(0); i < lengthOfLength; i++ {
+		length = length<<8 | uint64(data[i])
+	}
+
+	return length
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/attestantio/go-block-relay/services/blockauctioneer"
 	"github.com/attestantio/go-builder-client/api/deneb"
@@ -75,6 +76,14 @@ func (s *Service) auctionBlock(ctx context.Context,
 		}, nil
 	}
 
+	proposerConfig = s.freshProposerConfig(ctx, account, pubkey, proposerConfig)
+	if len(proposerConfig.Relays) == 0 {
+		log.Debug().Msg("No relays with fresh registrations")
+		return &blockauctioneer.Results{
+			Values: make(map[string]*big.Int),
+		}, nil
+	}
+
 	res, err := s.builderBidProvider.BuilderBid(ctx, slot, parentHash, pubkey, proposerConfig, s.excludedBuilders, s.privilegedBuilders)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain builder bid")
@@ -99,9 +108,12 @@ func (s *Service) auctionBlock(ctx context.Context,
 	log.Info().Str("key", key).Str("subkey", subKey).Msg("Caching bid")
 	s.builderBidsCacheMu.Lock()
 	if _, exists := s.builderBidsCache[key]; !exists {
-		s.builderBidsCache[key] = make(map[string]*builderspec.VersionedSignedBuilderBid)
+		s.builderBidsCache[key] = make(map[string]*cachedBuilderBid)
+	}
+	s.builderBidsCache[key][subKey] = &cachedBuilderBid{
+		bid:      bidToCache,
+		obtained: time.Now(),
 	}
-	s.builderBidsCache[key][subKey] = bidToCache
 	s.builderBidsCacheMu.Unlock()
 
 	selectedProviders := make(map[string]struct{})
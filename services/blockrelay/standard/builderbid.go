@@ -25,6 +25,13 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// cachedBuilderBid is a builder bid obtained from an auction, along with the
+// time at which it was obtained.
+type cachedBuilderBid struct {
+	bid      *spec.VersionedSignedBuilderBid
+	obtained time.Time
+}
+
 // BuilderBid provides a builder bid.
 func (s *Service) BuilderBid(ctx context.Context,
 	slot phase0.Slot,
@@ -134,12 +141,20 @@ func (s *Service) cachedBid(_ context.Context,
 		log.Debug().Str("key", key).Msg("Builder bid not known (slot)")
 		return nil, false
 	}
-	builderBid, exists := slotBuilderBids[subkey]
+	cachedBid, exists := slotBuilderBids[subkey]
 	s.builderBidsCacheMu.RUnlock()
 	if !exists {
 		log.Debug().Str("subkey", subkey).Msg("Builder bid not known (subkey)")
 		return nil, false
 	}
 
-	return builderBid, true
+	if s.maxBidAge > 0 {
+		age := time.Since(cachedBid.obtained)
+		if age > s.maxBidAge {
+			log.Debug().Str("subkey", subkey).Dur("age", age).Dur("max_age", s.maxBidAge).Msg("Builder bid stale; ignoring")
+			return nil, false
+		}
+	}
+
+	return cachedBid.bid, true
 }
@@ -0,0 +1,90 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-builder-client/api/deneb"
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderBidMaxAge(t *testing.T) {
+	ctx := context.Background()
+
+	slot := phase0.Slot(100)
+	parentHash := phase0.Hash32{0x01}
+	pubkey := phase0.BLSPubKey{0x02}
+	bid := &builderspec.VersionedSignedBuilderBid{
+		Version: spec.DataVersionDeneb,
+		Deneb: &deneb.SignedBuilderBid{
+			Message: &deneb.BuilderBid{
+				Value: uint256.NewInt(1),
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		maxBidAge time.Duration
+		age       time.Duration
+		expected  *builderspec.VersionedSignedBuilderBid
+	}{
+		{
+			name:     "NoMaxAge",
+			age:      time.Hour,
+			expected: bid,
+		},
+		{
+			name:      "Fresh",
+			maxBidAge: 12 * time.Second,
+			age:       time.Second,
+			expected:  bid,
+		},
+		{
+			name:      "Stale",
+			maxBidAge: 12 * time.Second,
+			age:       time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				maxBidAge: test.maxBidAge,
+				builderBidsCache: map[string]map[string]*cachedBuilderBid{
+					fmt.Sprintf("%d", slot): {
+						fmt.Sprintf("%x:%x", parentHash, pubkey): {
+							bid:      bid,
+							obtained: time.Now().Add(-test.age),
+						},
+					},
+				},
+			}
+
+			// A stale bid is ignored, so a fresh auction takes place.  With no
+			// execution configuration there are no relays, so no bid is returned.
+			res, err := s.BuilderBid(ctx, slot, parentHash, pubkey)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
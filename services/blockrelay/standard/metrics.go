@@ -31,6 +31,7 @@ var (
 	validatorRegistrationsCounter    *prometheus.CounterVec
 	validatorRegistrationsGeneration *prometheus.CounterVec
 	validatorRegistrationsTimer      prometheus.Histogram
+	registrationFreshnessCounter     *prometheus.CounterVec
 )
 
 func registerMetrics(ctx context.Context, monitor metrics.Service) error {
@@ -154,6 +155,16 @@ func registerPrometheusMetrics(_ context.Context) error {
 	validatorRegistrationsCounter.WithLabelValues("succeeded").Add(0)
 	validatorRegistrationsCounter.WithLabelValues("failed").Add(0)
 
+	registrationFreshnessCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "relay_validator_registrations",
+		Name:      "freshness_total",
+		Help:      "The number of checks of registration freshness before requesting bids from relays",
+	}, []string{"result"})
+	if err := prometheus.Register(registrationFreshnessCounter); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -215,3 +226,11 @@ func monitorBuilderBidDelta(source string, delta *big.Int) {
 	}
 	builderBidDeltas.WithLabelValues(source).Observe(float64(delta.Uint64()) / 1e15)
 }
+
+// monitorRegistrationFreshness provides the results of registration freshness checks.
+func monitorRegistrationFreshness(result string, relays int) {
+	if registrationFreshnessCounter == nil {
+		return
+	}
+	registrationFreshnessCounter.WithLabelValues(result).Add(float64(relays))
+}
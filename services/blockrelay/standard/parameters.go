@@ -15,7 +15,9 @@ package standard
 
 import (
 	"bytes"
+	"fmt"
 	"net"
+	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
@@ -31,6 +33,15 @@ import (
 	"github.com/wealdtech/go-majordomo"
 )
 
+const (
+	// StaleRegistrationActionReregister re-submits a stale registration to
+	// the relay before requesting a bid from it.
+	StaleRegistrationActionReregister = "reregister"
+	// StaleRegistrationActionSkip does not request a bid from a relay with
+	// a stale registration.
+	StaleRegistrationActionSkip = "skip"
+)
+
 type parameters struct {
 	logLevel                                  zerolog.Level
 	monitor                                   metrics.Service
@@ -54,6 +65,9 @@ type parameters struct {
 	builderBidProvider                        builderbid.Provider
 	excludedBuilders                          []phase0.BLSPubKey
 	privilegedBuilders                        []phase0.BLSPubKey
+	maxBidAge                                 time.Duration
+	maxRegistrationAge                        time.Duration
+	staleRegistrationAction                   string
 }
 
 // Parameter is the interface for service parameters.
@@ -220,13 +234,39 @@ func WithPrivilegedBuilders(builders []phase0.BLSPubKey) Parameter {
 	})
 }
 
+// WithMaxBidAge sets the maximum age of a cached builder bid for it to
+// be provided.  0 means that cached bids do not expire.
+func WithMaxBidAge(age time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxBidAge = age
+	})
+}
+
+// WithMaxRegistrationAge sets the maximum age of a validator's registration
+// with a relay for the relay to be used in an auction.  0 means that
+// registrations are not checked for freshness.
+func WithMaxRegistrationAge(age time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxRegistrationAge = age
+	})
+}
+
+// WithStaleRegistrationAction sets the action to take when a validator's
+// registration with a relay is older than the maximum registration age.
+func WithStaleRegistrationAction(action string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.staleRegistrationAction = action
+	})
+}
+
 // zeroExecutionAddress is used for comparison purposes.
 var zeroExecutionAddress bellatrix.ExecutionAddress
 
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:                zerolog.GlobalLevel(),
+		staleRegistrationAction: StaleRegistrationActionReregister,
 	}
 	for _, p := range params {
 		p.apply(&parameters)
@@ -275,6 +315,17 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.builderBidProvider == nil {
 		return nil, errors.New("no builder bid provider specified")
 	}
+	if parameters.maxBidAge < 0 {
+		return nil, errors.New("max bid age cannot be negative")
+	}
+	if parameters.maxRegistrationAge < 0 {
+		return nil, errors.New("max registration age cannot be negative")
+	}
+	switch parameters.staleRegistrationAction {
+	case StaleRegistrationActionReregister, StaleRegistrationActionSkip:
+	default:
+		return nil, fmt.Errorf("unknown stale registration action %q", parameters.staleRegistrationAction)
+	}
 
 	return &parameters, nil
 }
@@ -0,0 +1,123 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	builderapi "github.com/attestantio/go-builder-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"go.opentelemetry.io/otel"
+)
+
+// recordRelayRegistrations records the time at which a relay accepted registrations.
+func (s *Service) recordRelayRegistrations(relay string,
+	registrations []*builderapi.VersionedSignedValidatorRegistration,
+) {
+	now := time.Now()
+
+	s.relayRegistrationTimesMu.Lock()
+	defer s.relayRegistrationTimesMu.Unlock()
+	if _, exists := s.relayRegistrationTimes[relay]; !exists {
+		s.relayRegistrationTimes[relay] = make(map[phase0.BLSPubKey]time.Time)
+	}
+	for _, registration := range registrations {
+		pubkey, err := registration.PubKey()
+		if err != nil {
+			log.Warn().Str("relay", relay).Err(err).Msg("Failed to obtain public key of registration")
+			continue
+		}
+		s.relayRegistrationTimes[relay][pubkey] = now
+	}
+}
+
+// registrationFresh returns true if the validator's registration with the relay
+// was accepted within the maximum registration age.
+func (s *Service) registrationFresh(relay string, pubkey phase0.BLSPubKey) bool {
+	s.relayRegistrationTimesMu.RLock()
+	registered, exists := s.relayRegistrationTimes[relay][pubkey]
+	s.relayRegistrationTimesMu.RUnlock()
+
+	return exists && time.Since(registered) <= s.maxRegistrationAge
+}
+
+// freshProposerConfig returns a proposer configuration containing only those relays
+// with which the validator has a fresh registration.  Relays with stale registrations
+// are re-registered or removed, according to the stale registration action.
+func (s *Service) freshProposerConfig(ctx context.Context,
+	account e2wtypes.Account,
+	pubkey phase0.BLSPubKey,
+	proposerConfig *beaconblockproposer.ProposerConfig,
+) *beaconblockproposer.ProposerConfig {
+	if s.maxRegistrationAge == 0 {
+		return proposerConfig
+	}
+
+	ctx, span := otel.Tracer("attestantio.vouch.services.blockrelay.standard").Start(ctx, "freshProposerConfig")
+	defer span.End()
+
+	staleRelays := make(map[string]struct{})
+	for _, relay := range proposerConfig.Relays {
+		if !s.registrationFresh(relay.Address, pubkey) {
+			staleRelays[relay.Address] = struct{}{}
+		}
+	}
+	if len(staleRelays) == 0 {
+		monitorRegistrationFreshness("fresh", len(proposerConfig.Relays))
+		return proposerConfig
+	}
+
+	if s.staleRegistrationAction == StaleRegistrationActionReregister {
+		relayRegistrations := make(map[string][]*builderapi.VersionedSignedValidatorRegistration)
+		for _, relay := range proposerConfig.Relays {
+			if _, isStale := staleRelays[relay.Address]; !isStale {
+				continue
+			}
+			relayRegistration, _, err := s.generateValidatorRegistrationForRelay(ctx, account, pubkey, relay)
+			if err != nil {
+				log.Warn().Str("relay", relay.Address).Err(err).Msg("Failed to generate registration for stale relay")
+				continue
+			}
+			relayRegistrations[relay.Address] = []*builderapi.VersionedSignedValidatorRegistration{relayRegistration}
+		}
+		s.submitRelayRegistrations(ctx, relayRegistrations)
+		span.AddEvent("Re-registered with stale relays")
+	}
+
+	// Create a new configuration, to avoid altering that supplied.
+	config := &beaconblockproposer.ProposerConfig{
+		FeeRecipient: proposerConfig.FeeRecipient,
+		Relays:       make([]*beaconblockproposer.RelayConfig, 0, len(proposerConfig.Relays)),
+	}
+	for _, relay := range proposerConfig.Relays {
+		_, wasStale := staleRelays[relay.Address]
+		switch {
+		case !wasStale:
+			monitorRegistrationFreshness("fresh", 1)
+			config.Relays = append(config.Relays, relay)
+		case s.registrationFresh(relay.Address, pubkey):
+			log.Debug().Str("relay", relay.Address).Stringer("validator", pubkey).Msg("Re-registered with relay before requesting bid")
+			monitorRegistrationFreshness("reregistered", 1)
+			config.Relays = append(config.Relays, relay)
+		default:
+			log.Warn().Str("relay", relay.Address).Stringer("validator", pubkey).Msg("Registration with relay is stale; not requesting bid")
+			monitorRegistrationFreshness("skipped", 1)
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,165 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-block-relay/services/blockauctioneer"
+	apiv1 "github.com/attestantio/go-builder-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	v2 "github.com/attestantio/vouch/services/blockrelay/v2"
+	mocksigner "github.com/attestantio/vouch/services/signer/mock"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+// eventRecorder records relay registrations and bid requests in the order they occur.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []string
+	relays int
+}
+
+func (r *eventRecorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+// BuilderBid records the request for a bid.
+func (r *eventRecorder) BuilderBid(_ context.Context,
+	_ phase0.Slot,
+	_ phase0.Hash32,
+	_ phase0.BLSPubKey,
+	proposerConfig *beaconblockproposer.ProposerConfig,
+	_ []phase0.BLSPubKey,
+	_ []phase0.BLSPubKey,
+) (
+	*blockauctioneer.Results,
+	error,
+) {
+	r.record("bid")
+	r.mu.Lock()
+	r.relays = len(proposerConfig.Relays)
+	r.mu.Unlock()
+
+	return &blockauctioneer.Results{}, nil
+}
+
+func TestAuctionBlockRegistrationFreshness(t *testing.T) {
+	ctx := context.Background()
+
+	viper.Reset()
+	viper.Set("timeout", 5*time.Second)
+
+	pubkey := phase0.BLSPubKey{0x01}
+	feeRecipient := bellatrix.ExecutionAddress{0x02}
+
+	tests := []struct {
+		name             string
+		registrationAge  time.Duration
+		action           string
+		relayStatus      int
+		expectedEvents   []string
+		expectedRelays   int
+		expectedFreshAge bool
+	}{
+		{
+			name:             "Fresh",
+			registrationAge:  time.Second,
+			action:           StaleRegistrationActionReregister,
+			relayStatus:      http.StatusOK,
+			expectedEvents:   []string{"bid"},
+			expectedRelays:   1,
+			expectedFreshAge: true,
+		},
+		{
+			name:             "StaleReregister",
+			registrationAge:  time.Hour,
+			action:           StaleRegistrationActionReregister,
+			relayStatus:      http.StatusOK,
+			expectedEvents:   []string{"register", "bid"},
+			expectedRelays:   1,
+			expectedFreshAge: true,
+		},
+		{
+			name:            "StaleReregisterFailed",
+			registrationAge: time.Hour,
+			action:          StaleRegistrationActionReregister,
+			relayStatus:     http.StatusInternalServerError,
+			expectedEvents:  []string{"register"},
+		},
+		{
+			name:            "StaleSkip",
+			registrationAge: time.Hour,
+			action:          StaleRegistrationActionSkip,
+			relayStatus:     http.StatusOK,
+			expectedEvents:  []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := &eventRecorder{
+				events: make([]string, 0),
+			}
+			relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/eth/v1/builder/validators") {
+					recorder.record("register")
+				}
+				w.WriteHeader(test.relayStatus)
+			}))
+			defer relay.Close()
+
+			s := &Service{
+				fallbackFeeRecipient: feeRecipient,
+				fallbackGasLimit:     30000000,
+				releaseVersion:       "test",
+				executionConfig: &v2.ExecutionConfig{
+					Version: 2,
+					Relays: map[string]*v2.BaseRelayConfig{
+						relay.URL: {},
+					},
+				},
+				validatorRegistrationSigner:  mocksigner.New(),
+				latestValidatorRegistrations: make(map[phase0.BLSPubKey]phase0.Root),
+				signedValidatorRegistrations: make(map[phase0.Root]*apiv1.SignedValidatorRegistration),
+				builderBidsCache:             make(map[string]map[string]*cachedBuilderBid),
+				builderBidProvider:           recorder,
+				relayRegistrationTimes: map[string]map[phase0.BLSPubKey]time.Time{
+					relay.URL: {
+						pubkey: time.Now().Add(-test.registrationAge),
+					},
+				},
+				maxRegistrationAge:      time.Minute,
+				staleRegistrationAction: test.action,
+			}
+
+			_, err := s.auctionBlock(ctx, 1, phase0.Hash32{}, pubkey, nil)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedEvents, recorder.events)
+			require.Equal(t, test.expectedRelays, recorder.relays)
+			require.Equal(t, test.expectedFreshAge, s.registrationFresh(relay.URL, pubkey))
+		})
+	}
+}
@@ -16,10 +16,10 @@ package standard
 import (
 	"context"
 	"sync"
+	"time"
 
 	restdaemon "github.com/attestantio/go-block-relay/services/daemon/rest"
 	apiv1 "github.com/attestantio/go-builder-client/api/v1"
-	builderspec "github.com/attestantio/go-builder-client/spec"
 	consensusclient "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -52,8 +52,9 @@ type Service struct {
 	validatorsProvider                        consensusclient.ValidatorsProvider
 	validatingAccountsProvider                accountmanager.ValidatingAccountsProvider
 	validatorRegistrationSigner               signer.ValidatorRegistrationSigner
-	builderBidsCache                          map[string]map[string]*builderspec.VersionedSignedBuilderBid
+	builderBidsCache                          map[string]map[string]*cachedBuilderBid
 	builderBidsCacheMu                        sync.RWMutex
+	maxBidAge                                 time.Duration
 	latestValidatorRegistrations              map[phase0.BLSPubKey]phase0.Root
 	latestValidatorRegistrationsMu            sync.RWMutex
 	signedValidatorRegistrations              map[phase0.Root]*apiv1.SignedValidatorRegistration
@@ -79,6 +80,15 @@ type Service struct {
 	controlledValidators   map[phase0.BLSPubKey]struct{}
 	controlledValidatorsMu sync.RWMutex
 
+	// relayRegistrationTimes is a map of relay address to validator to
+	// the time at which the validator's registration was last accepted by
+	// the relay.  Used to ensure that registrations are fresh before
+	// requesting bids.
+	relayRegistrationTimes   map[string]map[phase0.BLSPubKey]time.Time
+	relayRegistrationTimesMu sync.RWMutex
+	maxRegistrationAge       time.Duration
+	staleRegistrationAction  string
+
 	activitySem *semaphore.Weighted
 }
 
@@ -119,15 +129,19 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		latestValidatorRegistrations: make(map[phase0.BLSPubKey]phase0.Root),
 		signedValidatorRegistrations: make(map[phase0.Root]*apiv1.SignedValidatorRegistration),
 		secondaryValidatorRegistrationsSubmitters: parameters.secondaryValidatorRegistrationsSubmitters,
-		logResults:           parameters.logResults,
-		releaseVersion:       parameters.releaseVersion,
-		builderBidsCache:     make(map[string]map[string]*builderspec.VersionedSignedBuilderBid),
-		executionConfig:      &v2.ExecutionConfig{Version: 2},
-		activitySem:          semaphore.NewWeighted(1),
-		builderBidProvider:   parameters.builderBidProvider,
-		excludedBuilders:     parameters.excludedBuilders,
-		controlledValidators: make(map[phase0.BLSPubKey]struct{}),
-		privilegedBuilders:   parameters.privilegedBuilders,
+		logResults:              parameters.logResults,
+		releaseVersion:          parameters.releaseVersion,
+		builderBidsCache:        make(map[string]map[string]*cachedBuilderBid),
+		maxBidAge:               parameters.maxBidAge,
+		executionConfig:         &v2.ExecutionConfig{Version: 2},
+		activitySem:             semaphore.NewWeighted(1),
+		builderBidProvider:      parameters.builderBidProvider,
+		excludedBuilders:        parameters.excludedBuilders,
+		controlledValidators:    make(map[phase0.BLSPubKey]struct{}),
+		privilegedBuilders:      parameters.privilegedBuilders,
+		relayRegistrationTimes:  make(map[string]map[phase0.BLSPubKey]time.Time),
+		maxRegistrationAge:      parameters.maxRegistrationAge,
+		staleRegistrationAction: parameters.staleRegistrationAction,
 	}
 
 	// Carry out initial fetch of execution configuration.
@@ -233,6 +233,7 @@ func (s *Service) submitRelayRegistrations(ctx context.Context,
 				log.Error().Err(err).Str("builder", builder).Msg("Failed to submit validator registrations")
 				return
 			}
+			s.recordRelayRegistrations(builder, providerRegistrations)
 		}(ctx, builder, providerRegistrations, s.monitor)
 	}
 	wg.Wait()
@@ -175,6 +175,12 @@ func (e *ExecutionConfig) setInitialRelayOptions(_ context.Context,
 	config *beaconblockproposer.ProposerConfig,
 	fallbackGasLimit uint64,
 ) {
+	// Relays without their own gas limit use the default gas limit, if present.
+	gasLimit := fallbackGasLimit
+	if e.GasLimit != nil {
+		gasLimit = *e.GasLimit
+	}
+
 	for address, baseRelayConfig := range e.Relays {
 		configRelay := &beaconblockproposer.RelayConfig{
 			Address: address,
@@ -189,7 +195,7 @@ func (e *ExecutionConfig) setInitialRelayOptions(_ context.Context,
 		} else {
 			configRelay.MinValue = *e.MinValue
 		}
-		setRelayConfig(configRelay, baseRelayConfig, config.FeeRecipient, fallbackGasLimit)
+		setRelayConfig(configRelay, baseRelayConfig, config.FeeRecipient, gasLimit)
 		config.Relays = append(config.Relays, configRelay)
 	}
 }
@@ -712,3 +712,130 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestRelayOverridePrecedence(t *testing.T) {
+	ctx := context.Background()
+
+	feeRecipient1 := bellatrix.ExecutionAddress{0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01}
+	feeRecipient2 := bellatrix.ExecutionAddress{0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02}
+	feeRecipient3 := bellatrix.ExecutionAddress{0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03, 0x03}
+	feeRecipient4 := bellatrix.ExecutionAddress{0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04}
+
+	gasLimit1 := uint64(1000000)
+	gasLimit2 := uint64(2000000)
+	gasLimit3 := uint64(3000000)
+	gasLimit4 := uint64(4000000)
+
+	pubkey1 := phase0.BLSPubKey{0x01}
+
+	type relayValues struct {
+		feeRecipient bellatrix.ExecutionAddress
+		gasLimit     uint64
+	}
+
+	tests := []struct {
+		name            string
+		executionConfig *v2.ExecutionConfig
+		expected        map[string]relayValues
+	}{
+		{
+			name: "FallbackValues",
+			executionConfig: &v2.ExecutionConfig{
+				Relays: map[string]*v2.BaseRelayConfig{
+					"https://relay1.com/": {},
+					"https://relay2.com/": {
+						FeeRecipient: &feeRecipient3,
+						GasLimit:     &gasLimit3,
+					},
+				},
+			},
+			expected: map[string]relayValues{
+				"https://relay1.com/": {feeRecipient: feeRecipient1, gasLimit: gasLimit1},
+				"https://relay2.com/": {feeRecipient: feeRecipient3, gasLimit: gasLimit3},
+			},
+		},
+		{
+			name: "DefaultValues",
+			executionConfig: &v2.ExecutionConfig{
+				FeeRecipient: &feeRecipient2,
+				GasLimit:     &gasLimit2,
+				Relays: map[string]*v2.BaseRelayConfig{
+					"https://relay1.com/": {},
+					"https://relay2.com/": {
+						FeeRecipient: &feeRecipient3,
+						GasLimit:     &gasLimit3,
+					},
+				},
+			},
+			expected: map[string]relayValues{
+				"https://relay1.com/": {feeRecipient: feeRecipient2, gasLimit: gasLimit2},
+				"https://relay2.com/": {feeRecipient: feeRecipient3, gasLimit: gasLimit3},
+			},
+		},
+		{
+			name: "PartialRelayOverride",
+			executionConfig: &v2.ExecutionConfig{
+				FeeRecipient: &feeRecipient2,
+				GasLimit:     &gasLimit2,
+				Relays: map[string]*v2.BaseRelayConfig{
+					"https://relay1.com/": {
+						FeeRecipient: &feeRecipient3,
+					},
+					"https://relay2.com/": {
+						GasLimit: &gasLimit3,
+					},
+				},
+			},
+			expected: map[string]relayValues{
+				"https://relay1.com/": {feeRecipient: feeRecipient3, gasLimit: gasLimit2},
+				"https://relay2.com/": {feeRecipient: feeRecipient2, gasLimit: gasLimit3},
+			},
+		},
+		{
+			name: "ProposerRelayOverride",
+			executionConfig: &v2.ExecutionConfig{
+				FeeRecipient: &feeRecipient2,
+				GasLimit:     &gasLimit2,
+				Relays: map[string]*v2.BaseRelayConfig{
+					"https://relay1.com/": {
+						FeeRecipient: &feeRecipient3,
+						GasLimit:     &gasLimit3,
+					},
+					"https://relay2.com/": {
+						FeeRecipient: &feeRecipient3,
+						GasLimit:     &gasLimit3,
+					},
+				},
+				Proposers: []*v2.ProposerConfig{
+					{
+						Validator: pubkey1,
+						Relays: map[string]*v2.ProposerRelayConfig{
+							"https://relay1.com/": {
+								FeeRecipient: &feeRecipient4,
+								GasLimit:     &gasLimit4,
+							},
+						},
+					},
+				},
+			},
+			expected: map[string]relayValues{
+				"https://relay1.com/": {feeRecipient: feeRecipient4, gasLimit: gasLimit4},
+				"https://relay2.com/": {feeRecipient: feeRecipient3, gasLimit: gasLimit3},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.executionConfig.ProposerConfig(ctx, nil, pubkey1, feeRecipient1, gasLimit1)
+			require.NoError(t, err)
+			require.Len(t, res.Relays, len(test.expected))
+			for _, relay := range res.Relays {
+				expected, exists := test.expected[relay.Address]
+				require.True(t, exists)
+				require.Equal(t, expected.feeRecipient, relay.FeeRecipient, relay.Address)
+				require.Equal(t, expected.gasLimit, relay.GasLimit, relay.Address)
+			}
+		})
+	}
+}
@@ -24,12 +24,14 @@ import (
 // Service is a mock.
 type Service struct {
 	blockRootToSlotMap map[phase0.Root]phase0.Slot
+	proposedBlockRoots map[phase0.Slot]phase0.Root
 }
 
 // New creates a new mock cache.
 func New(blockRootToSlotMap map[phase0.Root]phase0.Slot) cache.Service {
 	return &Service{
 		blockRootToSlotMap: blockRootToSlotMap,
+		proposedBlockRoots: make(map[phase0.Slot]phase0.Root),
 	}
 }
 
@@ -47,6 +49,17 @@ func (s *Service) SetBlockRootToSlot(root phase0.Root, slot phase0.Slot) {
 	s.blockRootToSlotMap[root] = slot
 }
 
+// ProposedBlockRoot provides the root of the block proposed at the given slot, if any.
+func (s *Service) ProposedBlockRoot(slot phase0.Slot) (phase0.Root, bool) {
+	root, exists := s.proposedBlockRoots[slot]
+	return root, exists
+}
+
+// SetProposedBlockRoot sets the root of the block proposed at the given slot.
+func (s *Service) SetProposedBlockRoot(slot phase0.Slot, root phase0.Root) {
+	s.proposedBlockRoots[slot] = root
+}
+
 // ExecutionChainHead provides the current execution chain head.
 func (*Service) ExecutionChainHead(_ context.Context) (phase0.Hash32, uint64) {
 	return phase0.Hash32{}, 0
@@ -34,6 +34,18 @@ type BlockRootToSlotSetter interface {
 	SetBlockRootToSlot(root phase0.Root, slot phase0.Slot)
 }
 
+// ProposedBlockRootProvider provides the roots of blocks proposed by our validators.
+type ProposedBlockRootProvider interface {
+	// ProposedBlockRoot provides the root of the block proposed by our validators at the given slot, if any.
+	ProposedBlockRoot(slot phase0.Slot) (phase0.Root, bool)
+}
+
+// ProposedBlockRootSetter sets the root of a block proposed by our validators.
+type ProposedBlockRootSetter interface {
+	// SetProposedBlockRoot sets the root of the block proposed by our validators at the given slot.
+	SetProposedBlockRoot(slot phase0.Slot, root phase0.Root)
+}
+
 // ExecutionChainHeadProvider provides the current execution chain head.
 type ExecutionChainHeadProvider interface {
 	// ExecutionChainHead provides the current execution chain head.
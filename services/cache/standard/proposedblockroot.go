@@ -0,0 +1,61 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ProposedBlockRoot provides the root of the block proposed by our validators at the given slot, if any.
+func (s *Service) ProposedBlockRoot(slot phase0.Slot) (phase0.Root, bool) {
+	s.proposedBlockRootsMu.RLock()
+	root, exists := s.proposedBlockRoots[slot]
+	s.proposedBlockRootsMu.RUnlock()
+
+	return root, exists
+}
+
+// SetProposedBlockRoot sets the root of the block proposed by our validators at the given slot.
+func (s *Service) SetProposedBlockRoot(slot phase0.Slot, root phase0.Root) {
+	s.proposedBlockRootsMu.Lock()
+	s.proposedBlockRoots[slot] = root
+	s.proposedBlockRootsMu.Unlock()
+
+	// We also know the slot of the block, so save a lookup later on.
+	s.SetBlockRootToSlot(root, slot)
+}
+
+// cleanProposedBlockRoots cleans out old entries in the cache.
+func (s *Service) cleanProposedBlockRoots(_ context.Context, _ interface{}) {
+	// Proposals are only of interest for a short while, so keep a couple of epochs.
+	safetyMargin := phase0.Epoch(2)
+	if s.chainTime.CurrentEpoch() <= safetyMargin {
+		return
+	}
+	minSlot := s.chainTime.FirstSlotOfEpoch(s.chainTime.CurrentEpoch() - safetyMargin)
+
+	s.proposedBlockRootsMu.Lock()
+	cleaned := 0
+	for slot := range s.proposedBlockRoots {
+		if slot < minSlot {
+			delete(s.proposedBlockRoots, slot)
+			cleaned++
+		}
+	}
+	s.proposedBlockRootsMu.Unlock()
+
+	log.Trace().Int("cleaned", cleaned).Msg("Cleaned proposed block root cache")
+}
@@ -35,6 +35,9 @@ type Service struct {
 	blockRootToSlotMu sync.RWMutex
 	blockRootToSlot   map[phase0.Root]phase0.Slot
 
+	proposedBlockRootsMu sync.RWMutex
+	proposedBlockRoots   map[phase0.Slot]phase0.Root
+
 	executionChainHeadMu     sync.RWMutex
 	executionChainHeadHeight uint64
 	executionChainHeadRoot   phase0.Hash32
@@ -64,6 +67,8 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		chainTime:       parameters.chainTime,
 		consensusClient: parameters.consensusClient,
 		blockRootToSlot: make(map[phase0.Root]phase0.Slot),
+
+		proposedBlockRoots: make(map[phase0.Slot]phase0.Root),
 	}
 
 	// Fetch the current execution head.
@@ -103,5 +108,16 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		log.Error().Err(err).Msg("Failed to schedule periodic clean of block root to slot cache")
 	}
 
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"Cache",
+		"Clean proposed block root cache",
+		runtimeFunc,
+		nil,
+		s.cleanProposedBlockRoots,
+		nil,
+	); err != nil {
+		log.Error().Err(err).Msg("Failed to schedule periodic clean of proposed block root cache")
+	}
+
 	return s, nil
 }
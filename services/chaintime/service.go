@@ -14,6 +14,7 @@
 package chaintime
 
 import (
+	"context"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -36,3 +37,9 @@ type Service interface {
 	// FirstSlotOfEpoch provides the first slot of the given epoch.
 	FirstSlotOfEpoch(epoch phase0.Epoch) phase0.Slot
 }
+
+// GenesisWaiter waits for genesis.
+type GenesisWaiter interface {
+	// WaitForGenesis waits until genesis if it is in the future.
+	WaitForGenesis(ctx context.Context) error
+}
@@ -14,6 +14,8 @@
 package standard
 
 import (
+	"time"
+
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -23,6 +25,7 @@ type parameters struct {
 	logLevel        zerolog.Level
 	genesisProvider eth2client.GenesisProvider
 	specProvider    eth2client.SpecProvider
+	maxGenesisWait  time.Duration
 }
 
 // Parameter is the interface for service parameters.
@@ -57,6 +60,14 @@ func WithSpecProvider(provider eth2client.SpecProvider) Parameter {
 	})
 }
 
+// WithMaxGenesisWait sets the maximum time to wait for genesis.  0 waits
+// however long it takes.
+func WithMaxGenesisWait(wait time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxGenesisWait = wait
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -74,6 +85,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.specProvider == nil {
 		return nil, errors.New("no spec provider specified")
 	}
+	if parameters.maxGenesisWait < 0 {
+		return nil, errors.New("max genesis wait cannot be negative")
+	}
 
 	return &parameters, nil
 }
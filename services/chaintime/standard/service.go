@@ -15,6 +15,7 @@ package standard
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
@@ -24,11 +25,15 @@ import (
 	zerologger "github.com/rs/zerolog/log"
 )
 
+// genesisWaitLogInterval is the interval between log messages whilst waiting for genesis.
+const genesisWaitLogInterval = time.Minute
+
 // Service provides chain time services.
 type Service struct {
-	genesisTime   time.Time
-	slotDuration  time.Duration
-	slotsPerEpoch uint64
+	genesisTime    time.Time
+	slotDuration   time.Duration
+	slotsPerEpoch  uint64
+	maxGenesisWait time.Duration
 }
 
 // module-wide log.
@@ -81,9 +86,10 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	log.Trace().Uint64("slots_per_epoch", slotsPerEpoch).Msg("Obtained slots per epoch")
 
 	s := &Service{
-		genesisTime:   genesisTime,
-		slotDuration:  slotDuration,
-		slotsPerEpoch: slotsPerEpoch,
+		genesisTime:    genesisTime,
+		slotDuration:   slotDuration,
+		slotsPerEpoch:  slotsPerEpoch,
+		maxGenesisWait: parameters.maxGenesisWait,
 	}
 
 	return s, nil
@@ -129,3 +135,39 @@ func (s *Service) SlotToEpoch(slot phase0.Slot) phase0.Epoch {
 func (s *Service) FirstSlotOfEpoch(epoch phase0.Epoch) phase0.Slot {
 	return phase0.Slot(uint64(epoch) * s.slotsPerEpoch)
 }
+
+// WaitForGenesis waits until genesis if it is in the future, logging the time
+// remaining periodically.  If genesis is further away than the maximum genesis
+// wait it returns an error immediately rather than waiting.
+func (s *Service) WaitForGenesis(ctx context.Context) error {
+	timeToGenesis := time.Until(s.genesisTime)
+	if timeToGenesis <= 0 {
+		return nil
+	}
+	if s.maxGenesisWait > 0 && timeToGenesis > s.maxGenesisWait {
+		return fmt.Errorf("genesis is %s away, more than the maximum wait of %s", timeToGenesis.Round(time.Second), s.maxGenesisWait)
+	}
+
+	log.Info().
+		Time("genesis_time", s.genesisTime).
+		Str("time_to_genesis", timeToGenesis.Round(time.Second).String()).
+		Msg("Waiting for genesis")
+
+	genesisTimer := time.NewTimer(timeToGenesis)
+	defer genesisTimer.Stop()
+	logTicker := time.NewTicker(genesisWaitLogInterval)
+	defer logTicker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "stopped waiting for genesis")
+		case <-logTicker.C:
+			log.Info().
+				Str("time_to_genesis", time.Until(s.genesisTime).Round(time.Second).String()).
+				Msg("Waiting for genesis")
+		case <-genesisTimer.C:
+			log.Info().Msg("Genesis time")
+			return nil
+		}
+	}
+}
@@ -53,6 +53,16 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no spec provider specified",
 		},
+		{
+			name: "MaxGenesisWaitNegative",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithGenesisProvider(mockGenesisProvider),
+				standard.WithSpecProvider(mockSpecProvider),
+				standard.WithMaxGenesisWait(-time.Second),
+			},
+			err: "problem with parameters: max genesis wait cannot be negative",
+		},
 		{
 			name: "Good",
 			params: []standard.Parameter{
@@ -227,3 +237,68 @@ func TestFirstSlotOfEpoch(t *testing.T) {
 		})
 	}
 }
+
+func TestWaitForGenesis(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		genesisOffset  time.Duration
+		maxGenesisWait time.Duration
+		cancelled      bool
+		err            string
+	}{
+		{
+			name:          "PastGenesis",
+			genesisOffset: -time.Minute,
+		},
+		{
+			name:          "FutureGenesis",
+			genesisOffset: 200 * time.Millisecond,
+		},
+		{
+			name:           "FutureGenesisWithinMaxWait",
+			genesisOffset:  200 * time.Millisecond,
+			maxGenesisWait: time.Minute,
+		},
+		{
+			name:           "FutureGenesisBeyondMaxWait",
+			genesisOffset:  time.Hour,
+			maxGenesisWait: time.Minute,
+			err:            "genesis is 1h0m0s away, more than the maximum wait of 1m0s",
+		},
+		{
+			name:          "Cancelled",
+			genesisOffset: time.Hour,
+			cancelled:     true,
+			err:           "stopped waiting for genesis: context canceled",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			genesisTime := time.Now().Add(test.genesisOffset)
+			s, err := standard.New(ctx,
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+				standard.WithSpecProvider(mock.NewSpecProvider()),
+				standard.WithMaxGenesisWait(test.maxGenesisWait),
+			)
+			require.NoError(t, err)
+
+			waitCtx, cancel := context.WithCancel(ctx)
+			if test.cancelled {
+				cancel()
+			} else {
+				defer cancel()
+			}
+			err = s.WaitForGenesis(waitCtx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.False(t, time.Now().Before(genesisTime))
+			}
+		})
+	}
+}
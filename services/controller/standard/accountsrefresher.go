@@ -28,6 +28,10 @@ func (s *Service) startAccountsRefresher(ctx context.Context) error {
 			log.Trace().Msg("No active validators; refreshing accounts next slot")
 			return time.Now().Add(s.slotDuration), nil
 		}
+		if s.activatingValidatorsCount > 0 {
+			log.Trace().Msg("Validators activating; refreshing accounts next slot")
+			return time.Now().Add(s.slotDuration), nil
+		}
 
 		// Schedule for the middle of the slot, quarter through the epoch.
 		currentEpoch := s.chainTimeService.CurrentEpoch()
@@ -61,13 +65,33 @@ func (s *Service) refreshAccounts(ctx context.Context, _ interface{}) {
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Refreshed accounts")
 
 	// Update active validators count.
-	_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, s.chainTimeService.CurrentEpoch())
+	currentEpoch := s.chainTimeService.CurrentEpoch()
+	_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, currentEpoch)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to obtain active validators on account refresh")
 		return
 	}
-	if len(validatorIndices) != s.activeValidators {
-		log.Info().Int("old_validators", s.activeValidators).Int("new_validators", len(validatorIndices)).Msg("Change in number of active validators")
-		s.activeValidators = len(validatorIndices)
+	s.updateActiveValidators(len(validatorIndices))
+	s.updateActivatingValidators(ctx, currentEpoch)
+}
+
+// updateActiveValidators updates the number of active validators, flagging
+// any decrease as it may imply that keys have been dropped from the account manager.
+// Returns true if the number of active validators decreased.
+func (s *Service) updateActiveValidators(activeValidators int) bool {
+	s.monitor.ActiveValidators(activeValidators)
+	if activeValidators == s.activeValidators {
+		return false
 	}
+
+	decreased := activeValidators < s.activeValidators
+	if decreased {
+		log.Warn().Int("old_validators", s.activeValidators).Int("new_validators", activeValidators).Msg("Decrease in number of active validators")
+		s.monitor.ActiveValidatorsDecreased()
+	} else {
+		log.Info().Int("old_validators", s.activeValidators).Int("new_validators", activeValidators).Msg("Change in number of active validators")
+	}
+	s.activeValidators = activeValidators
+
+	return decreased
 }
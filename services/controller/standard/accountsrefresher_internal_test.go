@@ -0,0 +1,76 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/testing/logger"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+)
+
+type activeValidatorsMonitor struct {
+	nullmetrics.Service
+	activeValidators int
+	decreases        int
+}
+
+func (m *activeValidatorsMonitor) ActiveValidators(count int) {
+	m.activeValidators = count
+}
+
+func (m *activeValidatorsMonitor) ActiveValidatorsDecreased() {
+	m.decreases++
+}
+
+func TestUpdateActiveValidators(t *testing.T) {
+	capture := logger.NewLogCapture()
+	log = zerologger.With().Logger()
+
+	monitor := &activeValidatorsMonitor{}
+	s := &Service{
+		monitor: monitor,
+	}
+
+	// Initial set.
+	require.False(t, s.updateActiveValidators(10))
+	require.Equal(t, 10, s.activeValidators)
+	require.Equal(t, 10, monitor.activeValidators)
+	require.Equal(t, 0, monitor.decreases)
+	capture.AssertHasEntry(t, "Change in number of active validators")
+
+	// No change.
+	require.False(t, s.updateActiveValidators(10))
+	require.Equal(t, 10, monitor.activeValidators)
+	require.Equal(t, 0, monitor.decreases)
+
+	// Increase.
+	require.False(t, s.updateActiveValidators(12))
+	require.Equal(t, 12, s.activeValidators)
+	require.Equal(t, 12, monitor.activeValidators)
+	require.Equal(t, 0, monitor.decreases)
+
+	// Decrease.
+	require.True(t, s.updateActiveValidators(8))
+	require.Equal(t, 8, s.activeValidators)
+	require.Equal(t, 8, monitor.activeValidators)
+	require.Equal(t, 1, monitor.decreases)
+	require.True(t, capture.HasLog(map[string]any{
+		"message":        "Decrease in number of active validators",
+		"old_validators": float64(12),
+		"new_validators": float64(8),
+	}))
+}
@@ -0,0 +1,73 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// activatingValidators returns the indices of validators that are not active
+// in the given epoch but will become active within the activation horizon.
+func (s *Service) activatingValidators(ctx context.Context,
+	epoch phase0.Epoch,
+) (
+	[]phase0.ValidatorIndex,
+	error,
+) {
+	if s.activationHorizon == 0 {
+		return []phase0.ValidatorIndex{}, nil
+	}
+
+	activeAccounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpoch(ctx, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain accounts for current epoch")
+	}
+	horizonAccounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpoch(ctx, epoch+s.activationHorizon)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain accounts for activation horizon")
+	}
+
+	validatorIndices := make([]phase0.ValidatorIndex, 0)
+	for index := range horizonAccounts {
+		if _, exists := activeAccounts[index]; !exists {
+			validatorIndices = append(validatorIndices, index)
+		}
+	}
+	sort.Slice(validatorIndices, func(i int, j int) bool {
+		return validatorIndices[i] < validatorIndices[j]
+	})
+
+	return validatorIndices, nil
+}
+
+// updateActivatingValidators updates the number of validators activating
+// within the activation horizon.  Whilst there are activating validators
+// accounts are refreshed every slot, so that activations are picked up in
+// time for the validators' first duties to be scheduled.
+func (s *Service) updateActivatingValidators(ctx context.Context, epoch phase0.Epoch) {
+	validatorIndices, err := s.activatingValidators(ctx, epoch)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain activating validators")
+		return
+	}
+
+	if len(validatorIndices) > 0 && len(validatorIndices) != s.activatingValidatorsCount {
+		log.Info().Int("validators", len(validatorIndices)).Uint64("horizon", uint64(s.activationHorizon)).Msg("Validators activating within horizon")
+	}
+	s.activatingValidatorsCount = len(validatorIndices)
+}
@@ -0,0 +1,127 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// activationAccountsProvider provides accounts that are active from their activation epoch.
+type activationAccountsProvider struct {
+	activationEpochs map[phase0.ValidatorIndex]phase0.Epoch
+}
+
+func (p *activationAccountsProvider) ValidatingAccountsForEpoch(_ context.Context,
+	epoch phase0.Epoch,
+) (
+	map[phase0.ValidatorIndex]e2wtypes.Account,
+	error,
+) {
+	accounts := make(map[phase0.ValidatorIndex]e2wtypes.Account)
+	for index, activationEpoch := range p.activationEpochs {
+		if activationEpoch <= epoch {
+			accounts[index] = nil
+		}
+	}
+
+	return accounts, nil
+}
+
+func (p *activationAccountsProvider) ValidatingAccountsForEpochByIndex(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) (
+	map[phase0.ValidatorIndex]e2wtypes.Account,
+	error,
+) {
+	accounts, err := p.ValidatingAccountsForEpoch(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[phase0.ValidatorIndex]e2wtypes.Account)
+	for _, index := range indices {
+		if account, exists := accounts[index]; exists {
+			res[index] = account
+		}
+	}
+
+	return res, nil
+}
+
+func TestActivatingValidators(t *testing.T) {
+	ctx := context.Background()
+
+	provider := &activationAccountsProvider{
+		activationEpochs: map[phase0.ValidatorIndex]phase0.Epoch{
+			1: 5,
+			2: 10,
+			3: 11,
+			4: 12,
+			5: 13,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		horizon  phase0.Epoch
+		epoch    phase0.Epoch
+		expected []phase0.ValidatorIndex
+	}{
+		{
+			name:     "Disabled",
+			horizon:  0,
+			epoch:    10,
+			expected: []phase0.ValidatorIndex{},
+		},
+		{
+			name:     "NextEpoch",
+			horizon:  1,
+			epoch:    10,
+			expected: []phase0.ValidatorIndex{3},
+		},
+		{
+			// Validator 4 activates exactly at the horizon edge; validator 5 beyond it.
+			name:     "HorizonEdge",
+			horizon:  2,
+			epoch:    10,
+			expected: []phase0.ValidatorIndex{3, 4},
+		},
+		{
+			name:     "NoneActivating",
+			horizon:  2,
+			epoch:    13,
+			expected: []phase0.ValidatorIndex{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				validatingAccountsProvider: provider,
+				activationHorizon:          test.horizon,
+			}
+			validatorIndices, err := s.activatingValidators(ctx, test.epoch)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, validatorIndices)
+
+			s.updateActivatingValidators(ctx, test.epoch)
+			require.Equal(t, len(test.expected), s.activatingValidatorsCount)
+		})
+	}
+}
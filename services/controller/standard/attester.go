@@ -23,6 +23,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/attestationaggregator"
 	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/metrics"
 )
 
 // scheduleAttestations schedules attestations for the given epoch and validator indices.
@@ -51,21 +52,16 @@ func (s *Service) scheduleAttestations(ctx context.Context,
 	log.Trace().Dur("elapsed", time.Since(started)).Int("duties", len(attesterDuties)).Msg("Fetched attester duties")
 
 	// Generate Vouch duties from the response.
-	filteredDuties := make([]*apiv1.AttesterDuty, 0, len(attesterDuties))
-	firstSlot := s.chainTimeService.FirstSlotOfEpoch(epoch)
-	lastSlot := s.chainTimeService.FirstSlotOfEpoch(epoch+1) - 1
-	for _, duty := range attesterDuties {
-		if duty.Slot < firstSlot || duty.Slot > lastSlot {
-			log.Warn().
-				Uint64("epoch", uint64(epoch)).
-				Uint64("duty_slot", uint64(duty.Slot)).
-				Msg("Attester duty has invalid slot for requested epoch; ignoring")
-			continue
-		}
-		filteredDuties = append(filteredDuties, duty)
-	}
+	filteredDuties := filterUnexpectedDuties(s, dutyTypeAttestation, epoch, validatorIndices, attesterDuties,
+		func(duty *apiv1.AttesterDuty) (phase0.Slot, phase0.ValidatorIndex) {
+			return duty.Slot, duty.ValidatorIndex
+		},
+	)
 	log.Trace().Dur("elapsed", time.Since(started)).Int("duties", len(filteredDuties)).Msg("Filtered attester duties")
 
+	currentSlot := s.chainTimeService.CurrentSlot()
+	filteredDuties = s.claimAttesterDuties(filteredDuties, currentSlot, notCurrentSlot)
+
 	duties, err := attester.MergeDuties(ctx, filteredDuties)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to merge attester duties")
@@ -83,7 +79,6 @@ func (s *Service) scheduleAttestations(ctx context.Context,
 		}
 	}
 
-	currentSlot := s.chainTimeService.CurrentSlot()
 	for _, duty := range duties {
 		// Do not schedule attestations for past slots (or the current slot if so instructed).
 		if duty.Slot() < currentSlot {
@@ -123,6 +118,32 @@ func (s *Service) scheduleAttestations(ctx context.Context,
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Scheduled attestations")
 }
 
+// claimAttesterDuties claims the attester duties that are due to be scheduled,
+// removing any that have already been scheduled.
+func (s *Service) claimAttesterDuties(duties []*apiv1.AttesterDuty,
+	currentSlot phase0.Slot,
+	notCurrentSlot bool,
+) []*apiv1.AttesterDuty {
+	res := make([]*apiv1.AttesterDuty, 0, len(duties))
+	for _, duty := range duties {
+		if duty.Slot < currentSlot || (duty.Slot == currentSlot && notCurrentSlot) {
+			// Duty will not be scheduled, so no need to claim it.
+			res = append(res, duty)
+			continue
+		}
+		if !s.dutyLedger.claim(duty.ValidatorIndex, dutyTypeAttestation, duty.Slot) {
+			log.Debug().
+				Uint64("validator_index", uint64(duty.ValidatorIndex)).
+				Uint64("attestation_slot", uint64(duty.Slot)).
+				Msg("Attester duty already scheduled; ignoring")
+			continue
+		}
+		res = append(res, duty)
+	}
+
+	return res
+}
+
 // AttestAndScheduleAggregate attests, then schedules aggregation jobs as required.
 func (s *Service) AttestAndScheduleAggregate(ctx context.Context, data interface{}) {
 	started := time.Now()
@@ -142,7 +163,18 @@ func (s *Service) AttestAndScheduleAggregate(ctx context.Context, data interface
 		s.pendingAttestationsMutex.Unlock()
 	}()
 
+	// If signing is held the attester still obtains the attestation data, so
+	// that an instance in standby is ready to sign as soon as it is promoted.
+	if s.signingHeld(duty.Slot()) {
+		if _, err := s.attester.Attest(attester.WithSigningHeld(ctx), duty); err != nil {
+			log.Debug().Err(err).Msg("Failed to obtain attestation data with signing held")
+		}
+
+		return
+	}
+
 	attestations, err := s.attester.Attest(ctx, duty)
+	s.publishDutyOutcome(ctx, "attestation", metrics.DutyOutcomeAttested, duty.Slot(), duty.ValidatorIndices(), err)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to attest")
 		return
@@ -165,7 +197,14 @@ func (s *Service) AttestAndScheduleAggregate(ctx context.Context, data interface
 		return
 	}
 
+	aggregatorDuties := make([]*attestationaggregator.Duty, 0)
+	aggregatingCommittees := make(map[phase0.CommitteeIndex]bool)
 	for _, attestation := range attestations {
+		if aggregatingCommittees[attestation.Data.Index] {
+			// We are already set up as an aggregator for this committee.  It is possible that another validator has also been
+			// assigned as an aggregator, but we're already carrying out the task so do not need to go any further.
+			continue
+		}
 		log := log.With().Uint64("attestation_slot", uint64(attestation.Data.Slot)).Uint64("committee_index", uint64(attestation.Data.Index)).Logger()
 		slotInfoMap, exists := subscriptionInfoMap[attestation.Data.Slot]
 		if !exists {
@@ -208,20 +247,23 @@ func (s *Service) AttestAndScheduleAggregate(ctx context.Context, data interface
 				ValidatorIndex:      info.Duty.ValidatorIndex,
 				SlotSignature:       info.Signature,
 			}
-			if err := s.scheduler.ScheduleJob(ctx,
-				"Aggregate attestations",
-				fmt.Sprintf("Beacon block attestation aggregation for slot %d committee %d", attestation.Data.Slot, attestation.Data.Index),
-				s.chainTimeService.StartOfSlot(attestation.Data.Slot).Add(s.attestationAggregationDelay),
-				s.attestationAggregator.Aggregate,
-				aggregatorDuty,
-			); err != nil {
-				// Don't return here; we want to try to set up as many aggregator jobs as possible.
-				log.Error().Err(err).Msg("Failed to schedule beacon block attestation aggregation job")
-				continue
-			}
-			// We are set up as an aggregator for this slot and committee.  It is possible that another validator has also been
-			// assigned as an aggregator, but we're already carrying out the task so do not need to go any further.
-			return
+			aggregatorDuties = append(aggregatorDuties, aggregatorDuty)
+			aggregatingCommittees[attestation.Data.Index] = true
 		}
 	}
+
+	if len(aggregatorDuties) == 0 {
+		return
+	}
+	// All aggregations for the slot are carried out by a single job, so that the
+	// aggregator can prioritise them if it is limited in the number it can carry out.
+	if err := s.scheduler.ScheduleJob(ctx,
+		"Aggregate attestations",
+		fmt.Sprintf("Beacon block attestation aggregation for slot %d", duty.Slot()),
+		s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.attestationAggregationDelay),
+		s.attestationAggregator.Aggregate,
+		aggregatorDuties,
+	); err != nil {
+		log.Error().Err(err).Msg("Failed to schedule beacon block attestation aggregation job")
+	}
 }
@@ -0,0 +1,169 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	mockattestationaggregator "github.com/attestantio/vouch/services/attestationaggregator/mock"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/beaconcommitteesubscriber"
+	"github.com/attestantio/vouch/services/scheduler"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// submittingAttester records the validators for which it submits attestations,
+// and the number of times it is called with signing held.
+type submittingAttester struct {
+	mu        sync.Mutex
+	submitted []phase0.ValidatorIndex
+	held      int
+}
+
+func (a *submittingAttester) Attest(ctx context.Context, data interface{}) ([]*phase0.Attestation, error) {
+	duty := data.(*attester.Duty)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if attester.SigningHeld(ctx) {
+		a.held++
+
+		return nil, nil
+	}
+	attestations := make([]*phase0.Attestation, 0, len(duty.ValidatorIndices()))
+	for i, validatorIndex := range duty.ValidatorIndices() {
+		a.submitted = append(a.submitted, validatorIndex)
+		attestations = append(attestations, &phase0.Attestation{
+			Data: &phase0.AttestationData{
+				Slot:   duty.Slot(),
+				Index:  duty.CommitteeIndices()[i],
+				Source: &phase0.Checkpoint{},
+				Target: &phase0.Checkpoint{},
+			},
+		})
+	}
+
+	return attestations, nil
+}
+
+// jobRecordingScheduler records the one-off jobs that are scheduled.
+type jobRecordingScheduler struct {
+	scheduler.Service
+	mu   sync.Mutex
+	jobs []string
+}
+
+func (s *jobRecordingScheduler) ScheduleJob(_ context.Context, _ string, name string, _ time.Time, _ scheduler.JobFunc, _ interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, name)
+
+	return nil
+}
+
+func (s *jobRecordingScheduler) ScheduleJobWithPastPolicy(ctx context.Context, class string, name string, runtime time.Time, _ scheduler.PastJobPolicy, jobFunc scheduler.JobFunc, data interface{}) error {
+	return s.ScheduleJob(ctx, class, name, runtime, jobFunc, data)
+}
+
+func TestAttestWithoutAggregators(t *testing.T) {
+	ctx := context.Background()
+	chainTime := standbyChainTime(t)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	validatingAccountsProvider.AddAccount(1, nil)
+	validatingAccountsProvider.AddAccount(2, nil)
+
+	subscriptionInfo := func(aggregator bool) map[phase0.Slot]map[phase0.CommitteeIndex]*beaconcommitteesubscriber.Subscription {
+		return map[phase0.Slot]map[phase0.CommitteeIndex]*beaconcommitteesubscriber.Subscription{
+			100: {
+				0: {
+					Duty:         &apiv1.AttesterDuty{Slot: 100, ValidatorIndex: 1, CommitteeIndex: 0},
+					IsAggregator: false,
+				},
+				1: {
+					Duty:         &apiv1.AttesterDuty{Slot: 100, ValidatorIndex: 2, CommitteeIndex: 1},
+					IsAggregator: aggregator,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		subscriptionInfo map[phase0.Slot]map[phase0.CommitteeIndex]*beaconcommitteesubscriber.Subscription
+		standby          bool
+		submitted        []phase0.ValidatorIndex
+		held             int
+		aggregations     int
+	}{
+		{
+			name:             "NoAggregators",
+			subscriptionInfo: subscriptionInfo(false),
+			submitted:        []phase0.ValidatorIndex{1, 2},
+		},
+		{
+			name:      "NoSubscriptionInfo",
+			submitted: []phase0.ValidatorIndex{1, 2},
+		},
+		{
+			name:             "Aggregator",
+			subscriptionInfo: subscriptionInfo(true),
+			submitted:        []phase0.ValidatorIndex{1, 2},
+			aggregations:     1,
+		},
+		{
+			name:             "Standby",
+			subscriptionInfo: subscriptionInfo(true),
+			standby:          true,
+			held:             1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attesterSvc := &submittingAttester{}
+			schedulerSvc := &jobRecordingScheduler{Service: mockscheduler.New()}
+			s := &Service{
+				chainTimeService:           chainTime,
+				attester:                   attesterSvc,
+				attestationAggregator:      mockattestationaggregator.New(),
+				scheduler:                  schedulerSvc,
+				validatingAccountsProvider: validatingAccountsProvider,
+				pendingAttestations:        make(map[phase0.Slot]bool),
+				subscriptionInfos:          make(map[phase0.Epoch]map[phase0.Slot]map[phase0.CommitteeIndex]*beaconcommitteesubscriber.Subscription),
+				standby:                    test.standby,
+			}
+			if test.subscriptionInfo != nil {
+				s.subscriptionInfos[chainTime.SlotToEpoch(100)] = test.subscriptionInfo
+			}
+
+			duty, err := attester.NewDuty(ctx, 100, 1, []phase0.ValidatorIndex{1, 2}, []phase0.CommitteeIndex{0, 1}, []uint64{0, 0}, map[phase0.CommitteeIndex]uint64{0: 128, 1: 128})
+			require.NoError(t, err)
+			s.AttestAndScheduleAggregate(ctx, duty)
+
+			// Attestations are submitted for all validators regardless of aggregation duties,
+			// and in standby the attester is still called but with signing held.
+			require.Equal(t, test.submitted, attesterSvc.submitted)
+			require.Equal(t, test.held, attesterSvc.held)
+			require.Len(t, schedulerSvc.jobs, test.aggregations)
+		})
+	}
+}
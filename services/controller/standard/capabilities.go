@@ -0,0 +1,114 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// altairEnabled returns true if Altair duties should be carried out, that is
+// if Altair was handled at start-up and the beacon node has not since withdrawn
+// the capability.
+func (s *Service) altairEnabled() bool {
+	if !s.handlingAltair {
+		return false
+	}
+
+	s.capabilitiesMu.RLock()
+	defer s.capabilitiesMu.RUnlock()
+
+	return !s.altairWithdrawn
+}
+
+// bellatrixEnabled returns true if Bellatrix duties should be carried out, that
+// is if Bellatrix was handled at start-up and the beacon node has not since
+// withdrawn the capability.
+func (s *Service) bellatrixEnabled() bool {
+	if !s.handlingBellatrix {
+		return false
+	}
+
+	return !s.bellatrixCapabilityWithdrawn()
+}
+
+// bellatrixCapabilityWithdrawn returns true if the beacon node has withdrawn
+// its Bellatrix capability since start-up.
+func (s *Service) bellatrixCapabilityWithdrawn() bool {
+	s.capabilitiesMu.RLock()
+	defer s.capabilitiesMu.RUnlock()
+
+	return s.bellatrixWithdrawn
+}
+
+// refreshCapabilities obtains the spec from the beacon node to confirm that it
+// still supports the forks handled at start-up.  A beacon node that has been
+// rolled back to an earlier version may no longer do so, in which case the
+// related duties are suspended rather than failing repeatedly, and resumed when
+// the capability returns.
+func (s *Service) refreshCapabilities(ctx context.Context,
+	currentEpoch phase0.Epoch,
+	validatorIndices []phase0.ValidatorIndex,
+) {
+	if !s.capabilityRefresh || s.specProvider == nil {
+		return
+	}
+	if !s.handlingAltair && !s.handlingBellatrix {
+		// Nothing that could be withdrawn.
+		return
+	}
+
+	specResponse, err := s.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		// An unavailable beacon node says nothing about its capabilities.
+		log.Debug().Err(err).Msg("Failed to obtain spec to refresh capabilities")
+		return
+	}
+	_, altairCapable := specResponse.Data["ALTAIR_FORK_EPOCH"]
+	_, bellatrixCapable := specResponse.Data["BELLATRIX_FORK_EPOCH"]
+
+	s.capabilitiesMu.Lock()
+	altairChanged := s.handlingAltair && s.altairWithdrawn == altairCapable
+	if s.handlingAltair {
+		s.altairWithdrawn = !altairCapable
+	}
+	bellatrixChanged := s.handlingBellatrix && s.bellatrixWithdrawn == bellatrixCapable
+	if s.handlingBellatrix {
+		s.bellatrixWithdrawn = !bellatrixCapable
+	}
+	s.capabilitiesMu.Unlock()
+
+	if altairChanged {
+		if altairCapable {
+			log.Info().Msg("Beacon node is Altair-capable again; resuming sync committee duties")
+			if currentEpoch > s.currentAltairForkEpoch() {
+				go s.scheduleSyncCommitteeMessages(ctx, currentEpoch, validatorIndices, true /* notCurrentSlot */)
+			}
+		} else {
+			log.Warn().Msg("Beacon node is no longer Altair-capable; suspending sync committee duties")
+			s.cancelSyncCommitteeJobs(ctx)
+		}
+	}
+
+	if bellatrixChanged {
+		if bellatrixCapable {
+			log.Info().Msg("Beacon node is Bellatrix-capable again; resuming proposal preparations")
+			go s.prepareProposals(ctx, nil)
+		} else {
+			log.Warn().Msg("Beacon node is no longer Bellatrix-capable; suspending proposal preparations")
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// capabilitySpecProvider provides a spec whose fork keys can change at runtime.
+type capabilitySpecProvider struct {
+	mu   sync.Mutex
+	spec map[string]any
+	err  error
+}
+
+func (p *capabilitySpecProvider) Spec(_ context.Context, _ *api.SpecOpts) (*api.Response[map[string]any], error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	spec := make(map[string]any, len(p.spec))
+	for k, v := range p.spec {
+		spec[k] = v
+	}
+
+	return &api.Response[map[string]any]{
+		Data:     spec,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func (p *capabilitySpecProvider) set(spec map[string]any, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.spec = spec
+	p.err = err
+}
+
+func TestRefreshCapabilities(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	// Current epoch is 3.
+	chainTime := standbyChainTime(t)
+
+	fullSpec := map[string]any{
+		"ALTAIR_FORK_EPOCH":    uint64(10),
+		"BELLATRIX_FORK_EPOCH": uint64(0),
+	}
+	specProvider := &capabilitySpecProvider{spec: fullSpec}
+	jobScheduler := &cancellingScheduler{Service: mockscheduler.New()}
+	preparer := &recordingProposalsPreparer{
+		prepared: make(map[phase0.Slot]phase0.ValidatorIndex),
+	}
+	s := &Service{
+		chainTimeService:             chainTime,
+		scheduler:                    jobScheduler,
+		specProvider:                 specProvider,
+		proposalsPreparer:            preparer,
+		capabilityRefresh:            true,
+		handlingAltair:               true,
+		altairForkEpoch:              10,
+		handlingBellatrix:            true,
+		epochsPerSyncCommitteePeriod: 256,
+		syncCommitteeLookaheadPeriod: 1,
+	}
+
+	// Capabilities unchanged.
+	s.refreshCapabilities(ctx, 3, nil)
+	require.True(t, s.altairEnabled())
+	require.True(t, s.bellatrixEnabled())
+	require.Empty(t, jobScheduler.prefixes)
+
+	// The beacon node is rolled back to a version without the forks.
+	specProvider.set(map[string]any{}, nil)
+	s.refreshCapabilities(ctx, 3, nil)
+	require.False(t, s.altairEnabled())
+	require.False(t, s.bellatrixEnabled())
+	require.Equal(t, []string{
+		"Prepare sync committee messages for slot ",
+		"Sync committee messages for slot ",
+		"Sync committee aggregation for slot ",
+	}, jobScheduler.prefixes)
+	require.Equal(t, uint64(0), s.syncCommitteeLookaheadPeriod)
+
+	// Duties for the withdrawn capabilities are not carried out.
+	s.prepareProposals(ctx, nil)
+	s.prepareProposer(ctx, beaconblockproposer.NewDuty(20, 5))
+	preparer.mu.Lock()
+	require.Equal(t, 0, preparer.updates)
+	require.Empty(t, preparer.prepared)
+	preparer.mu.Unlock()
+
+	// An unavailable beacon node leaves the capabilities as they were.
+	specProvider.set(fullSpec, errors.New("error"))
+	s.refreshCapabilities(ctx, 3, nil)
+	require.False(t, s.altairEnabled())
+	require.False(t, s.bellatrixEnabled())
+
+	// The beacon node is upgraded again.
+	specProvider.set(fullSpec, nil)
+	s.refreshCapabilities(ctx, 3, nil)
+	require.True(t, s.altairEnabled())
+	require.True(t, s.bellatrixEnabled())
+	require.Len(t, jobScheduler.prefixes, 3)
+	require.Eventually(t, func() bool {
+		preparer.mu.Lock()
+		defer preparer.mu.Unlock()
+
+		return preparer.updates == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRefreshCapabilitiesDisabled(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	specProvider := &capabilitySpecProvider{spec: map[string]any{}}
+	jobScheduler := &cancellingScheduler{Service: mockscheduler.New()}
+	s := &Service{
+		scheduler:         jobScheduler,
+		specProvider:      specProvider,
+		handlingAltair:    true,
+		handlingBellatrix: true,
+	}
+
+	s.refreshCapabilities(ctx, 3, nil)
+	require.True(t, s.altairEnabled())
+	require.True(t, s.bellatrixEnabled())
+	require.Empty(t, jobScheduler.prefixes)
+}
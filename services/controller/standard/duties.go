@@ -0,0 +1,88 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	dutyTypeAttestation = "attestation"
+	dutyTypeProposal    = "proposal"
+)
+
+// dutyKey uniquely identifies a duty.
+type dutyKey struct {
+	validatorIndex phase0.ValidatorIndex
+	dutyType       string
+	slot           phase0.Slot
+}
+
+// dutyLedger keeps track of scheduled duties, to ensure that a duty is not
+// scheduled more than once if scheduling for epochs overlaps.
+type dutyLedger struct {
+	mu     sync.Mutex
+	duties map[dutyKey]struct{}
+}
+
+// newDutyLedger creates a new duty ledger.
+func newDutyLedger() *dutyLedger {
+	return &dutyLedger{
+		duties: make(map[dutyKey]struct{}),
+	}
+}
+
+// claim marks a duty as scheduled.
+// Returns false if the duty was already scheduled.
+func (l *dutyLedger) claim(validatorIndex phase0.ValidatorIndex, dutyType string, slot phase0.Slot) bool {
+	key := dutyKey{
+		validatorIndex: validatorIndex,
+		dutyType:       dutyType,
+		slot:           slot,
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, exists := l.duties[key]; exists {
+		return false
+	}
+	l.duties[key] = struct{}{}
+
+	return true
+}
+
+// release removes all duties of the given type at the given slot, allowing
+// them to be scheduled again.  This is used when scheduled jobs are cancelled.
+func (l *dutyLedger) release(dutyType string, slot phase0.Slot) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key := range l.duties {
+		if key.dutyType == dutyType && key.slot == slot {
+			delete(l.duties, key)
+		}
+	}
+}
+
+// prune removes all duties prior to the given slot.
+func (l *dutyLedger) prune(slot phase0.Slot) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key := range l.duties {
+		if key.slot < slot {
+			delete(l.duties, key)
+		}
+	}
+}
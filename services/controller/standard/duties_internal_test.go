@@ -0,0 +1,88 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDutyLedger(t *testing.T) {
+	l := newDutyLedger()
+
+	require.True(t, l.claim(1, dutyTypeAttestation, 10))
+	require.False(t, l.claim(1, dutyTypeAttestation, 10))
+	// Different validator, type or slot is a different duty.
+	require.True(t, l.claim(2, dutyTypeAttestation, 10))
+	require.True(t, l.claim(1, dutyTypeProposal, 10))
+	require.True(t, l.claim(1, dutyTypeAttestation, 11))
+
+	// Release only affects the given type and slot.
+	l.release(dutyTypeAttestation, 10)
+	require.True(t, l.claim(1, dutyTypeAttestation, 10))
+	require.True(t, l.claim(2, dutyTypeAttestation, 10))
+	require.False(t, l.claim(1, dutyTypeProposal, 10))
+	require.False(t, l.claim(1, dutyTypeAttestation, 11))
+
+	// Prune removes earlier slots only.
+	l.prune(11)
+	require.True(t, l.claim(1, dutyTypeAttestation, 10))
+	require.True(t, l.claim(1, dutyTypeProposal, 10))
+	require.False(t, l.claim(1, dutyTypeAttestation, 11))
+}
+
+func TestClaimAttesterDutiesOverlapping(t *testing.T) {
+	s := &Service{
+		dutyLedger: newDutyLedger(),
+	}
+
+	epoch1Duties := []*apiv1.AttesterDuty{
+		{ValidatorIndex: 1, Slot: 31},
+		{ValidatorIndex: 2, Slot: 32},
+		{ValidatorIndex: 3, Slot: 33},
+	}
+	// Overlaps with the first set of duties for validators 2 and 3.
+	epoch2Duties := []*apiv1.AttesterDuty{
+		{ValidatorIndex: 2, Slot: 32},
+		{ValidatorIndex: 3, Slot: 33},
+		{ValidatorIndex: 4, Slot: 33},
+		{ValidatorIndex: 5, Slot: 34},
+	}
+
+	scheduled := make(map[dutyKey]int)
+	for _, duties := range [][]*apiv1.AttesterDuty{epoch1Duties, epoch2Duties} {
+		for _, duty := range s.claimAttesterDuties(duties, 32, true) {
+			if duty.Slot > 32 {
+				scheduled[dutyKey{validatorIndex: duty.ValidatorIndex, dutyType: dutyTypeAttestation, slot: duty.Slot}]++
+			}
+		}
+	}
+
+	require.Equal(t, map[dutyKey]int{
+		{validatorIndex: 3, dutyType: dutyTypeAttestation, slot: 33}: 1,
+		{validatorIndex: 4, dutyType: dutyTypeAttestation, slot: 33}: 1,
+		{validatorIndex: 5, dutyType: dutyTypeAttestation, slot: 34}: 1,
+	}, scheduled)
+
+	// Duties for past and (excluded) current slots are passed through unclaimed.
+	res := s.claimAttesterDuties([]*apiv1.AttesterDuty{
+		{ValidatorIndex: 1, Slot: 31},
+		{ValidatorIndex: 2, Slot: 32},
+	}, 32, true)
+	require.Len(t, res, 2)
+	require.True(t, s.dutyLedger.claim(2, dutyTypeAttestation, phase0.Slot(32)))
+}
@@ -188,7 +188,7 @@ func (s *Service) handleCurrentDependentRootChanged(ctx context.Context) {
 	go s.refreshProposerDutiesForEpoch(ctx, s.chainTimeService.CurrentEpoch())
 	// We need to refresh the sync committee duties for the next period if we are
 	// at the appropriate boundary.
-	if uint64(s.chainTimeService.CurrentEpoch())%s.epochsPerSyncCommitteePeriod == 0 {
+	if s.altairEnabled() && uint64(s.chainTimeService.CurrentEpoch())%s.epochsPerSyncCommitteePeriod == 0 {
 		go s.refreshSyncCommitteeDutiesForEpochPeriod(ctx, s.chainTimeService.CurrentEpoch()+phase0.Epoch(s.epochsPerSyncCommitteePeriod))
 	}
 	// We need to refresh the attester duties for the next epoch.
@@ -205,6 +205,7 @@ func (s *Service) refreshProposerDutiesForEpoch(ctx context.Context, epoch phase
 	for slot := s.chainTimeService.FirstSlotOfEpoch(epoch); slot < s.chainTimeService.FirstSlotOfEpoch(epoch+1); slot++ {
 		s.scheduler.CancelJobIfExists(ctx, fmt.Sprintf("Early beacon block proposal for slot %d", slot))
 		s.scheduler.CancelJobIfExists(ctx, fmt.Sprintf("Beacon block proposal for slot %d", slot))
+		s.dutyLedger.release(dutyTypeProposal, slot)
 	}
 
 	_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, epoch)
@@ -239,6 +240,7 @@ func (s *Service) refreshAttesterDutiesForEpoch(ctx context.Context, epoch phase
 	for slot := s.chainTimeService.FirstSlotOfEpoch(epoch); slot < s.chainTimeService.FirstSlotOfEpoch(epoch+1); slot++ {
 		if err := s.scheduler.CancelJob(ctx, fmt.Sprintf("Attestations for slot %d", slot)); err == nil {
 			cancelledJobs[slot] = true
+			s.dutyLedger.release(dutyTypeAttestation, slot)
 		}
 	}
 
@@ -271,7 +273,7 @@ func (s *Service) refreshSyncCommitteeDutiesForEpochPeriod(ctx context.Context,
 	))
 	defer span.End()
 
-	if !s.handlingAltair {
+	if !s.altairEnabled() {
 		// Not handling Altair, nothing to do.
 		return
 	}
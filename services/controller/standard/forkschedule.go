@@ -0,0 +1,83 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// currentAltairForkEpoch returns the Altair fork epoch as last obtained from the beacon node.
+func (s *Service) currentAltairForkEpoch() phase0.Epoch {
+	s.altairForkEpochMu.RLock()
+	defer s.altairForkEpochMu.RUnlock()
+
+	return s.altairForkEpoch
+}
+
+// refreshAltairForkEpoch obtains the Altair fork epoch from the beacon node, in
+// case the chain has been reconfigured since start-up.  If it has changed then
+// any scheduled sync committee jobs are cancelled and, if the new fork epoch has
+// passed, sync committee messages are rescheduled for the current period.
+func (s *Service) refreshAltairForkEpoch(ctx context.Context) {
+	if !s.altairEnabled() || s.specProvider == nil {
+		return
+	}
+
+	altairForkEpoch, err := fetchAltairForkEpoch(ctx, s.specProvider)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to refresh Altair fork epoch")
+		return
+	}
+
+	s.altairForkEpochMu.Lock()
+	previousAltairForkEpoch := s.altairForkEpoch
+	s.altairForkEpoch = altairForkEpoch
+	s.altairForkEpochMu.Unlock()
+	if altairForkEpoch == previousAltairForkEpoch {
+		return
+	}
+	log.Warn().
+		Uint64("previous_epoch", uint64(previousAltairForkEpoch)).
+		Uint64("epoch", uint64(altairForkEpoch)).
+		Msg("Altair fork epoch changed; rescheduling sync committee duties")
+
+	// Jobs scheduled against the previous fork epoch may cover the wrong slots.
+	s.cancelSyncCommitteeJobs(ctx)
+
+	currentEpoch := s.chainTimeService.CurrentEpoch()
+	if currentEpoch <= altairForkEpoch {
+		// The fork epoch itself is handled by the epoch ticker.
+		return
+	}
+
+	_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, currentEpoch)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain active validator indices to reschedule sync committee duties")
+		return
+	}
+	go s.scheduleSyncCommitteeMessages(ctx, currentEpoch, validatorIndices, true /* notCurrentSlot */)
+}
+
+// cancelSyncCommitteeJobs cancels all scheduled sync committee jobs, and resets
+// the look-ahead so that the next period is scheduled again when required.
+func (s *Service) cancelSyncCommitteeJobs(ctx context.Context) {
+	s.scheduler.CancelJobs(ctx, "Prepare sync committee messages for slot ")
+	s.scheduler.CancelJobs(ctx, "Sync committee messages for slot ")
+	s.scheduler.CancelJobs(ctx, "Sync committee aggregation for slot ")
+	s.syncCommitteeLookaheadMu.Lock()
+	s.syncCommitteeLookaheadPeriod = 0
+	s.syncCommitteeLookaheadMu.Unlock()
+}
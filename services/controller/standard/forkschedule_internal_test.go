@@ -0,0 +1,148 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/scheduler"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// forkSpecProvider provides a spec with a changeable Altair fork epoch.
+type forkSpecProvider struct {
+	mu              sync.Mutex
+	altairForkEpoch uint64
+	err             error
+}
+
+func (p *forkSpecProvider) Spec(_ context.Context, _ *api.SpecOpts) (*api.Response[map[string]any], error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &api.Response[map[string]any]{
+		Data: map[string]any{
+			"ALTAIR_FORK_EPOCH": p.altairForkEpoch,
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// cancellingScheduler records the prefixes of cancelled jobs.
+type cancellingScheduler struct {
+	scheduler.Service
+	mu       sync.Mutex
+	prefixes []string
+}
+
+func (s *cancellingScheduler) CancelJobs(_ context.Context, prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prefixes = append(s.prefixes, prefix)
+}
+
+func TestRefreshAltairForkEpoch(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	// Current epoch is 3.
+	chainTime := standbyChainTime(t)
+
+	tests := []struct {
+		name        string
+		newEpoch    uint64
+		specErr     error
+		epoch       phase0.Epoch
+		rescheduled bool
+	}{
+		{
+			name:     "Unchanged",
+			newEpoch: 1,
+			epoch:    1,
+		},
+		{
+			name:     "SpecError",
+			newEpoch: 10,
+			specErr:  errors.New("error"),
+			epoch:    1,
+		},
+		{
+			name:        "Future",
+			newEpoch:    10,
+			epoch:       10,
+			rescheduled: true,
+		},
+		{
+			name:        "Current",
+			newEpoch:    3,
+			epoch:       3,
+			rescheduled: true,
+		},
+		{
+			name:        "Past",
+			newEpoch:    2,
+			epoch:       2,
+			rescheduled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			specProvider := &forkSpecProvider{altairForkEpoch: 1}
+			jobScheduler := &cancellingScheduler{Service: mockscheduler.New()}
+			s := &Service{
+				chainTimeService:             chainTime,
+				scheduler:                    jobScheduler,
+				validatingAccountsProvider:   mockaccountmanager.NewValidatingAccountsProvider(),
+				specProvider:                 specProvider,
+				handlingAltair:               true,
+				altairForkEpoch:              1,
+				epochsPerSyncCommitteePeriod: 256,
+				syncCommitteeLookaheadPeriod: 1,
+			}
+
+			// The fork epoch changes after the service has started.
+			specProvider.mu.Lock()
+			specProvider.altairForkEpoch = test.newEpoch
+			specProvider.err = test.specErr
+			specProvider.mu.Unlock()
+
+			s.refreshAltairForkEpoch(ctx)
+			require.Equal(t, test.epoch, s.currentAltairForkEpoch())
+			require.Equal(t, test.epoch, s.firstEpochOfSyncPeriod(0))
+			if test.rescheduled {
+				require.Equal(t, []string{
+					"Prepare sync committee messages for slot ",
+					"Sync committee messages for slot ",
+					"Sync committee aggregation for slot ",
+				}, jobScheduler.prefixes)
+				require.Equal(t, uint64(0), s.syncCommitteeLookaheadPeriod)
+			} else {
+				require.Empty(t, jobScheduler.prefixes)
+				require.Equal(t, uint64(1), s.syncCommitteeLookaheadPeriod)
+			}
+		})
+	}
+}
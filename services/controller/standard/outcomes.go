@@ -0,0 +1,50 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/metrics"
+)
+
+// publishDutyOutcome publishes the outcome of a duty to the duty outcome sinks.
+// The outcome is the supplied success type, or missed if err is not nil.
+func (s *Service) publishDutyOutcome(ctx context.Context,
+	duty string,
+	success metrics.DutyOutcomeType,
+	slot phase0.Slot,
+	validatorIndices []phase0.ValidatorIndex,
+	err error,
+) {
+	if len(s.dutyOutcomeSinks) == 0 {
+		return
+	}
+
+	outcome := &metrics.DutyOutcome{
+		Type:             success,
+		Duty:             duty,
+		Slot:             slot,
+		ValidatorIndices: validatorIndices,
+		Err:              err,
+	}
+	if err != nil {
+		outcome.Type = metrics.DutyOutcomeMissed
+	}
+
+	for _, sink := range s.dutyOutcomeSinks {
+		sink.DutyOutcome(ctx, outcome)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	mockbeaconblockproposer "github.com/attestantio/vouch/services/beaconblockproposer/mock"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/synccommitteemessenger"
+	mocksynccommitteemessenger "github.com/attestantio/vouch/services/synccommitteemessenger/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// capturingSink captures the duty outcomes published to it.
+type capturingSink struct {
+	mu       sync.Mutex
+	outcomes []*metrics.DutyOutcome
+}
+
+func (c *capturingSink) DutyOutcome(_ context.Context, outcome *metrics.DutyOutcome) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.outcomes = append(c.outcomes, outcome)
+}
+
+// erroringAttester fails to attest.
+type erroringAttester struct{}
+
+func (*erroringAttester) Attest(_ context.Context, _ interface{}) ([]*phase0.Attestation, error) {
+	return nil, errors.New("failed")
+}
+
+func TestDutyOutcomes(t *testing.T) {
+	ctx := context.Background()
+
+	sink := &capturingSink{}
+	s := &Service{
+		chainTimeService:       standbyChainTime(t),
+		attester:               &countingAttester{},
+		beaconBlockProposer:    mockbeaconblockproposer.New(),
+		syncCommitteeMessenger: mocksynccommitteemessenger.New(),
+		pendingAttestations:    make(map[phase0.Slot]bool),
+		dutyOutcomeSinks:       []metrics.DutyOutcomeSink{sink},
+	}
+
+	attesterDuty, err := attester.NewDuty(ctx, 100, 1, []phase0.ValidatorIndex{1, 2}, []phase0.CommitteeIndex{0, 0}, []uint64{0, 1}, map[phase0.CommitteeIndex]uint64{0: 128})
+	require.NoError(t, err)
+	s.AttestAndScheduleAggregate(ctx, attesterDuty)
+
+	s.propose(ctx, beaconblockproposer.NewDuty(100, 3))
+
+	s.messageSyncCommittee(ctx, synccommitteemessenger.NewDuty(100, map[phase0.ValidatorIndex][]phase0.CommitteeIndex{4: {0}}))
+
+	s.attester = &erroringAttester{}
+	s.AttestAndScheduleAggregate(ctx, attesterDuty)
+
+	// Duties held due to standby are not published.
+	s.standby = true
+	s.AttestAndScheduleAggregate(ctx, attesterDuty)
+	s.propose(ctx, beaconblockproposer.NewDuty(100, 3))
+
+	require.Len(t, sink.outcomes, 4)
+	require.Equal(t, &metrics.DutyOutcome{
+		Type:             metrics.DutyOutcomeAttested,
+		Duty:             "attestation",
+		Slot:             100,
+		ValidatorIndices: []phase0.ValidatorIndex{1, 2},
+	}, sink.outcomes[0])
+	require.Equal(t, &metrics.DutyOutcome{
+		Type:             metrics.DutyOutcomeProposed,
+		Duty:             "proposal",
+		Slot:             100,
+		ValidatorIndices: []phase0.ValidatorIndex{3},
+	}, sink.outcomes[1])
+	require.Equal(t, &metrics.DutyOutcome{
+		Type:             metrics.DutyOutcomeSyncCommitteeMessaged,
+		Duty:             "sync committee message",
+		Slot:             100,
+		ValidatorIndices: []phase0.ValidatorIndex{4},
+	}, sink.outcomes[2])
+	require.Equal(t, metrics.DutyOutcomeMissed, sink.outcomes[3].Type)
+	require.Equal(t, "attestation", sink.outcomes[3].Duty)
+	require.EqualError(t, sink.outcomes[3].Err, "failed")
+}
@@ -15,10 +15,12 @@ package standard
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/accountmanager"
 	"github.com/attestantio/vouch/services/attestationaggregator"
 	"github.com/attestantio/vouch/services/attester"
@@ -61,6 +63,7 @@ type parameters struct {
 	accountsRefresher             accountmanager.Refresher
 	blockToSlotSetter             cache.BlockRootToSlotSetter
 	maxProposalDelay              time.Duration
+	proposalOffset                time.Duration
 	maxAttestationDelay           time.Duration
 	attestationAggregationDelay   time.Duration
 	maxSyncCommitteeMessageDelay  time.Duration
@@ -68,6 +71,20 @@ type parameters struct {
 	fastTrackAttestations         bool
 	fastTrackSyncCommittees       bool
 	fastTrackGrace                time.Duration
+	activationHorizon             phase0.Epoch
+	quarantineUntilSlot           phase0.Slot
+	quarantineSlots               uint64
+	proposalNotificationLead      uint64
+	proposalNotificationURL       string
+	proposalPreparationLead       uint64
+	standby                       bool
+	standbyPromotionSlots         uint64
+	standbyListenAddress          string
+	standbyPeerURL                string
+	standbyToken                  string
+	dutyOutcomeSinks              []metrics.DutyOutcomeSink
+	unexpectedDutiesPolicy        string
+	capabilityRefresh             bool
 }
 
 // Parameter is the interface for service parameters.
@@ -249,6 +266,13 @@ func WithMaxProposalDelay(delay time.Duration) Parameter {
 	})
 }
 
+// WithProposalOffset sets the offset from the start of the slot at which proposing starts.
+func WithProposalOffset(offset time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalOffset = offset
+	})
+}
+
 // WithMaxAttestationDelay sets the maximum delay before attesting.
 func WithMaxAttestationDelay(delay time.Duration) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -298,10 +322,125 @@ func WithFastTrackGrace(grace time.Duration) Parameter {
 	})
 }
 
+// WithActivationHorizon sets the number of epochs ahead to look for
+// activating validators.
+func WithActivationHorizon(horizon phase0.Epoch) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.activationHorizon = horizon
+	})
+}
+
+// WithQuarantineUntilSlot sets the slot before which no duties that
+// require signing are carried out.
+func WithQuarantineUntilSlot(slot phase0.Slot) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.quarantineUntilSlot = slot
+	})
+}
+
+// WithQuarantineSlots sets the number of slots after startup for which no
+// duties that require signing are carried out.
+func WithQuarantineSlots(slots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.quarantineSlots = slots
+	})
+}
+
+// WithProposalNotificationLead sets the number of slots ahead of a proposal
+// at which to notify of the upcoming proposal.  0 disables notification.
+func WithProposalNotificationLead(slots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalNotificationLead = slots
+	})
+}
+
+// WithProposalPreparationLead sets the number of slots ahead of a proposal
+// at which to submit the proposer's preparation to the beacon nodes.  0 disables
+// the submission, leaving only the periodic preparations.
+func WithProposalPreparationLead(slots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalPreparationLead = slots
+	})
+}
+
+// WithUnexpectedDutiesPolicy sets the policy for duties returned by beacon
+// nodes that are for an epoch or validator that was not requested.  'drop'
+// ignores the unexpected duties, and 'reject' ignores all duties in the
+// response.
+func WithUnexpectedDutiesPolicy(policy string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.unexpectedDutiesPolicy = policy
+	})
+}
+
+// WithCapabilityRefresh sets whether the capabilities of the beacon node are
+// checked every epoch, suspending duties that it no longer supports.
+func WithCapabilityRefresh(refresh bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.capabilityRefresh = refresh
+	})
+}
+
+// WithProposalNotificationURL sets the URL to which notifications of
+// upcoming proposals are posted.
+func WithProposalNotificationURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalNotificationURL = url
+	})
+}
+
+// WithStandby starts the controller in standby mode, in which duties are
+// tracked but none that require signing are carried out until promoted.
+func WithStandby(standby bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.standby = standby
+	})
+}
+
+// WithStandbyPromotionSlots sets the number of slots after the current slot
+// that a promoted standby waits before signing.
+func WithStandbyPromotionSlots(slots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.standbyPromotionSlots = slots
+	})
+}
+
+// WithStandbyListenAddress sets the address on which to listen for standby
+// promotion and demotion requests.
+func WithStandbyListenAddress(address string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.standbyListenAddress = address
+	})
+}
+
+// WithStandbyPeerURL sets the base URL of the standby endpoint of the peer
+// instance, which is demoted before this instance is promoted.
+func WithStandbyPeerURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.standbyPeerURL = url
+	})
+}
+
+// WithStandbyToken sets the token that promotion and demotion requests must
+// supply, and that is supplied when demoting the peer instance.
+func WithStandbyToken(token string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.standbyToken = token
+	})
+}
+
+// WithDutyOutcomeSinks sets the sinks to which the outcomes of duties are published.
+func WithDutyOutcomeSinks(sinks []metrics.DutyOutcomeSink) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dutyOutcomeSinks = sinks
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:               zerolog.GlobalLevel(),
+		unexpectedDutiesPolicy: unexpectedDutiesDrop,
 	}
 	for _, p := range params {
 		p.apply(&parameters)
@@ -371,7 +510,11 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if !ok {
 		return nil, errors.New("SECONDS_PER_SLOT of unexpected type")
 	}
-	// maxProposalDelay can be 0, so no check for it here.
+	// maxProposalDelay and proposalOffset can be 0, so no check for them here.
+	// A negative offset proposes towards the end of the prior slot.
+	if parameters.proposalOffset <= -slotDuration || parameters.proposalOffset+parameters.maxProposalDelay >= slotDuration {
+		return nil, errors.New("proposal offset must be within the slot")
+	}
 	if parameters.maxAttestationDelay == 0 {
 		parameters.maxAttestationDelay = slotDuration / 3
 	}
@@ -384,6 +527,29 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.syncCommitteeAggregationDelay == 0 {
 		parameters.syncCommitteeAggregationDelay = slotDuration * 2 / 3
 	}
+	// Aggregation must take place after the messages to be aggregated have been submitted.
+	if parameters.attestationAggregationDelay <= parameters.maxAttestationDelay {
+		return nil, errors.New("attestation aggregation delay must be greater than max attestation delay")
+	}
+	if parameters.attestationAggregationDelay >= slotDuration {
+		return nil, errors.New("attestation aggregation delay must be less than slot duration")
+	}
+	if parameters.syncCommitteeAggregationDelay <= parameters.maxSyncCommitteeMessageDelay {
+		return nil, errors.New("sync committee aggregation delay must be greater than max sync committee message delay")
+	}
+	if parameters.syncCommitteeAggregationDelay >= slotDuration {
+		return nil, errors.New("sync committee aggregation delay must be less than slot duration")
+	}
+	switch parameters.unexpectedDutiesPolicy {
+	case unexpectedDutiesDrop, unexpectedDutiesReject:
+	default:
+		return nil, fmt.Errorf("unknown unexpected duties policy %q", parameters.unexpectedDutiesPolicy)
+	}
+	// Without a token anyone who can reach the standby endpoint can promote the
+	// instance, so it must only be reachable locally.
+	if parameters.standbyListenAddress != "" && parameters.standbyToken == "" && !isLoopbackAddress(parameters.standbyListenAddress) {
+		return nil, errors.New("standby token required for non-loopback standby listen address")
+	}
 	// Sync committee duties provider/messenger/aggregator/subscriber are optional so no checks here.
 
 	return &parameters, nil
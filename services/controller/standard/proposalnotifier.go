@@ -0,0 +1,115 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/pkg/errors"
+)
+
+// proposalNotificationTimeout is the maximum time to wait for a notification
+// of an upcoming proposal to be accepted.
+const proposalNotificationTimeout = 5 * time.Second
+
+// proposalNotification is the body of a notification of an upcoming proposal.
+type proposalNotification struct {
+	Slot           phase0.Slot           `json:"slot,string"`
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index,string"`
+	LeadSlots      uint64                `json:"lead_slots,string"`
+}
+
+// scheduleProposalNotification schedules a notification of an upcoming
+// proposal, to run the configured number of slots ahead of the proposal.
+// If that time has already passed the notification runs immediately.
+func (s *Service) scheduleProposalNotification(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+) {
+	notificationSlot := phase0.Slot(0)
+	if uint64(duty.Slot()) > s.proposalNotificationLead {
+		notificationSlot = duty.Slot() - phase0.Slot(s.proposalNotificationLead)
+	}
+
+	if err := s.scheduler.ScheduleJob(ctx,
+		"Proposal notification",
+		fmt.Sprintf("Proposal notification for slot %d", duty.Slot()),
+		s.chainTimeService.StartOfSlot(notificationSlot),
+		s.notifyProposal,
+		duty,
+	); err != nil {
+		log.Error().Err(err).Msg("Failed to schedule proposal notification")
+	}
+}
+
+// notifyProposal notifies of an upcoming proposal.
+func (s *Service) notifyProposal(ctx context.Context, data interface{}) {
+	duty, ok := data.(*beaconblockproposer.Duty)
+	if !ok {
+		log.Error().Msg("Passed invalid data")
+		return
+	}
+
+	log.Info().
+		Uint64("proposal_slot", uint64(duty.Slot())).
+		Uint64("validator_index", uint64(duty.ValidatorIndex())).
+		Msg("Upcoming beacon block proposal")
+	s.monitor.UpcomingProposal()
+
+	if s.proposalNotificationURL == "" {
+		return
+	}
+	if err := s.postProposalNotification(ctx, duty); err != nil {
+		log.Warn().Uint64("proposal_slot", uint64(duty.Slot())).Err(err).Msg("Failed to post proposal notification")
+	}
+}
+
+// postProposalNotification posts a notification of an upcoming proposal to
+// the configured URL.
+func (s *Service) postProposalNotification(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+) error {
+	body, err := json.Marshal(&proposalNotification{
+		Slot:           duty.Slot(),
+		ValidatorIndex: duty.ValidatorIndex(),
+		LeadSlots:      s.proposalNotificationLead,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.proposalNotificationURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.proposalNotificationClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification returned status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
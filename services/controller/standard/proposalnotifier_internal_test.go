@@ -0,0 +1,126 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type upcomingProposalMonitor struct {
+	nullmetrics.Service
+	mu            sync.Mutex
+	notifications int
+}
+
+func (m *upcomingProposalMonitor) UpcomingProposal() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifications++
+}
+
+func TestScheduleProposalNotification(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	genesisTime := time.Now()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		lead    uint64
+		slot    phase0.Slot
+		runtime time.Time
+	}{
+		{
+			name:    "Lead",
+			lead:    4,
+			slot:    20,
+			runtime: chainTime.StartOfSlot(16),
+		},
+		{
+			name:    "LeadBeforeGenesis",
+			lead:    4,
+			slot:    2,
+			runtime: chainTime.StartOfSlot(0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			jobScheduler := &recordingScheduler{
+				Service: mockscheduler.New(),
+				jobs:    make(map[string]time.Time),
+			}
+			s := &Service{
+				chainTimeService:         chainTime,
+				scheduler:                jobScheduler,
+				proposalNotificationLead: test.lead,
+			}
+
+			s.scheduleProposalNotification(ctx, beaconblockproposer.NewDuty(test.slot, 1))
+			runtime, exists := jobScheduler.jobs[fmt.Sprintf("Proposal notification for slot %d", test.slot)]
+			require.True(t, exists)
+			require.Equal(t, test.runtime, runtime)
+		})
+	}
+}
+
+func TestNotifyProposal(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	var received *proposalNotification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = &proposalNotification{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	monitor := &upcomingProposalMonitor{}
+	s := &Service{
+		monitor:                    monitor,
+		proposalNotificationLead:   4,
+		proposalNotificationURL:    server.URL,
+		proposalNotificationClient: server.Client(),
+	}
+
+	s.notifyProposal(ctx, beaconblockproposer.NewDuty(20, 1))
+	require.Equal(t, 1, monitor.notifications)
+	require.NotNil(t, received)
+	require.Equal(t, phase0.Slot(20), received.Slot)
+	require.Equal(t, phase0.ValidatorIndex(1), received.ValidatorIndex)
+	require.Equal(t, uint64(4), received.LeadSlots)
+}
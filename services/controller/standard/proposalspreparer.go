@@ -15,8 +15,11 @@ package standard
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -57,6 +60,11 @@ func (s *Service) prepareProposals(ctx context.Context, _ interface{}) {
 
 	started := time.Now()
 
+	if s.bellatrixCapabilityWithdrawn() {
+		log.Trace().Dur("elapsed", time.Since(started)).Msg("Beacon node no longer Bellatrix-capable; not preparing proposals")
+		return
+	}
+
 	if s.chainTimeService.CurrentEpoch() < s.bellatrixForkEpoch {
 		log.Trace().Dur("elapsed", time.Since(started)).Msg("Not at bellatrix fork epoch; not preparing proposals")
 		return
@@ -69,3 +77,54 @@ func (s *Service) prepareProposals(ctx context.Context, _ interface{}) {
 
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Prepared proposals")
 }
+
+// scheduleProposerPreparation schedules a job to submit the preparation for
+// the proposer of an upcoming proposal, so that the beacon node can request
+// an execution payload to be built before the proposal slot.
+func (s *Service) scheduleProposerPreparation(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+) {
+	if s.chainTimeService.SlotToEpoch(duty.Slot()) < s.bellatrixForkEpoch {
+		// No execution payload, so nothing to prepare.
+		return
+	}
+
+	preparationSlot := phase0.Slot(0)
+	if uint64(duty.Slot()) > s.proposalPreparationLead {
+		preparationSlot = duty.Slot() - phase0.Slot(s.proposalPreparationLead)
+	}
+
+	if err := s.scheduler.ScheduleJob(ctx,
+		"Proposer preparation",
+		fmt.Sprintf("Proposer preparation for slot %d", duty.Slot()),
+		s.chainTimeService.StartOfSlot(preparationSlot),
+		s.prepareProposer,
+		duty,
+	); err != nil {
+		log.Error().Err(err).Msg("Failed to schedule proposer preparation")
+	}
+}
+
+// prepareProposer submits the preparation for the proposer of an upcoming proposal.
+func (s *Service) prepareProposer(ctx context.Context, data interface{}) {
+	ctx, span := otel.Tracer("attestantio.vouch.services.controller.standard").Start(ctx, "prepareProposer")
+	defer span.End()
+
+	duty, ok := data.(*beaconblockproposer.Duty)
+	if !ok {
+		log.Error().Msg("Passed invalid data")
+		return
+	}
+	span.SetAttributes(attribute.Int64("slot", int64(duty.Slot())))
+
+	if s.bellatrixCapabilityWithdrawn() {
+		log.Trace().Uint64("proposal_slot", uint64(duty.Slot())).Msg("Beacon node no longer Bellatrix-capable; not submitting proposer preparation")
+		return
+	}
+
+	if err := s.proposalsPreparer.PrepareProposer(ctx, duty.Slot(), duty.ValidatorIndex()); err != nil {
+		log.Warn().Uint64("proposal_slot", uint64(duty.Slot())).Uint64("validator_index", uint64(duty.ValidatorIndex())).Err(err).Msg("Failed to submit proposer preparation")
+		return
+	}
+	log.Trace().Uint64("proposal_slot", uint64(duty.Slot())).Uint64("validator_index", uint64(duty.ValidatorIndex())).Msg("Submitted proposer preparation")
+}
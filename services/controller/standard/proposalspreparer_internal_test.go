@@ -0,0 +1,199 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	mockproposalpreparer "github.com/attestantio/vouch/services/proposalpreparer/mock"
+	"github.com/attestantio/vouch/services/scheduler"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingProposalsPreparer records the proposers that are prepared.
+type recordingProposalsPreparer struct {
+	mockproposalpreparer.Service
+	mu       sync.Mutex
+	prepared map[phase0.Slot]phase0.ValidatorIndex
+	updates  int
+}
+
+func (p *recordingProposalsPreparer) UpdatePreparations(_ context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.updates++
+
+	return nil
+}
+
+func (p *recordingProposalsPreparer) PrepareProposer(_ context.Context, slot phase0.Slot, validatorIndex phase0.ValidatorIndex) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prepared[slot] = validatorIndex
+
+	return nil
+}
+
+// periodicRecordingScheduler records the periodic jobs that are scheduled.
+type periodicRecordingScheduler struct {
+	scheduler.Service
+	runtimeFuncs map[string]scheduler.RuntimeFunc
+	jobFuncs     map[string]scheduler.JobFunc
+}
+
+func (s *periodicRecordingScheduler) SchedulePeriodicJob(_ context.Context,
+	_ string,
+	name string,
+	runtimeFunc scheduler.RuntimeFunc,
+	_ interface{},
+	jobFunc scheduler.JobFunc,
+	_ interface{},
+) error {
+	s.runtimeFuncs[name] = runtimeFunc
+	s.jobFuncs[name] = jobFunc
+
+	return nil
+}
+
+func TestStartProposalsPreparer(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	// Start part way through an epoch.
+	genesisTime := time.Now().Add(-100 * 12 * time.Second)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	jobScheduler := &periodicRecordingScheduler{
+		Service:      mockscheduler.New(),
+		runtimeFuncs: make(map[string]scheduler.RuntimeFunc),
+		jobFuncs:     make(map[string]scheduler.JobFunc),
+	}
+	preparer := &recordingProposalsPreparer{}
+	s := &Service{
+		chainTimeService:  chainTime,
+		scheduler:         jobScheduler,
+		proposalsPreparer: preparer,
+	}
+	require.NoError(t, s.startProposalsPreparer(ctx))
+
+	// Preparations are submitted once during each epoch.
+	runtimeFunc, exists := jobScheduler.runtimeFuncs["Prepare proposals ticker"]
+	require.True(t, exists)
+	runtime, err := runtimeFunc(ctx, nil)
+	require.NoError(t, err)
+	nextEpoch := chainTime.CurrentEpoch() + 1
+	require.False(t, runtime.Before(chainTime.StartOfEpoch(nextEpoch)))
+	require.True(t, runtime.Before(chainTime.StartOfEpoch(nextEpoch+1)))
+
+	// Running the job submits preparations for all validators.
+	jobScheduler.jobFuncs["Prepare proposals ticker"](ctx, nil)
+	require.Equal(t, 1, preparer.updates)
+}
+
+func TestScheduleProposerPreparation(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	genesisTime := time.Now()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		lead           uint64
+		bellatrixEpoch phase0.Epoch
+		slot           phase0.Slot
+		runtime        time.Time
+		scheduled      bool
+	}{
+		{
+			name:      "Lead",
+			lead:      1,
+			slot:      20,
+			runtime:   chainTime.StartOfSlot(19),
+			scheduled: true,
+		},
+		{
+			name:      "LeadBeforeGenesis",
+			lead:      4,
+			slot:      2,
+			runtime:   chainTime.StartOfSlot(0),
+			scheduled: true,
+		},
+		{
+			name:           "PreBellatrix",
+			lead:           1,
+			bellatrixEpoch: 10,
+			slot:           20,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			jobScheduler := &recordingScheduler{
+				Service: mockscheduler.New(),
+				jobs:    make(map[string]time.Time),
+			}
+			s := &Service{
+				chainTimeService:        chainTime,
+				scheduler:               jobScheduler,
+				bellatrixForkEpoch:      test.bellatrixEpoch,
+				proposalPreparationLead: test.lead,
+			}
+
+			s.scheduleProposerPreparation(ctx, beaconblockproposer.NewDuty(test.slot, 1))
+			runtime, exists := jobScheduler.jobs[fmt.Sprintf("Proposer preparation for slot %d", test.slot)]
+			require.Equal(t, test.scheduled, exists)
+			if test.scheduled {
+				// The preparation must be submitted before the proposal slot starts.
+				require.Equal(t, test.runtime, runtime)
+				require.True(t, runtime.Before(chainTime.StartOfSlot(test.slot)))
+			}
+		})
+	}
+}
+
+func TestPrepareProposer(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	preparer := &recordingProposalsPreparer{
+		prepared: make(map[phase0.Slot]phase0.ValidatorIndex),
+	}
+	s := &Service{
+		proposalsPreparer: preparer,
+	}
+
+	s.prepareProposer(ctx, beaconblockproposer.NewDuty(20, 5))
+	require.Equal(t, map[phase0.Slot]phase0.ValidatorIndex{20: 5}, preparer.prepared)
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
 	"go.opentelemetry.io/otel"
@@ -51,17 +52,13 @@ func (s *Service) scheduleProposals(ctx context.Context,
 	log.Trace().Dur("elapsed", time.Since(started)).Int("duties", len(proposerDuties)).Msg("Fetched proposer duties")
 
 	// Generate Vouch duties from the response.
-	duties := make([]*beaconblockproposer.Duty, 0, len(proposerDuties))
-	firstSlot := s.chainTimeService.FirstSlotOfEpoch(epoch)
-	lastSlot := s.chainTimeService.FirstSlotOfEpoch(epoch+1) - 1
-	for _, respDuty := range proposerDuties {
-		if respDuty.Slot < firstSlot || respDuty.Slot > lastSlot {
-			log.Warn().
-				Uint64("epoch", uint64(epoch)).
-				Uint64("duty_slot", uint64(respDuty.Slot)).
-				Msg("Proposer duty has invalid slot for requested epoch; ignoring")
-			continue
-		}
+	filteredDuties := filterUnexpectedDuties(s, dutyTypeProposal, epoch, validatorIndices, proposerDuties,
+		func(duty *apiv1.ProposerDuty) (phase0.Slot, phase0.ValidatorIndex) {
+			return duty.Slot, duty.ValidatorIndex
+		},
+	)
+	duties := make([]*beaconblockproposer.Duty, 0, len(filteredDuties))
+	for _, respDuty := range filteredDuties {
 		duties = append(duties, beaconblockproposer.NewDuty(respDuty.Slot, respDuty.ValidatorIndex))
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Int("duties", len(duties)).Msg("Filtered proposer duties")
@@ -83,7 +80,23 @@ func (s *Service) scheduleProposals(ctx context.Context,
 				Msg("Beacon block proposal for the current slot; not scheduling")
 			continue
 		}
+		if !s.dutyLedger.claim(duty.ValidatorIndex(), dutyTypeProposal, duty.Slot()) {
+			log.Debug().
+				Uint64("proposal_slot", uint64(duty.Slot())).
+				Msg("Beacon block proposal already scheduled; not scheduling")
+			continue
+		}
+		if s.quarantined(duty.Slot()) {
+			continue
+		}
+		if s.proposalNotificationLead > 0 {
+			s.scheduleProposalNotification(ctx, duty)
+		}
+		if s.proposalPreparationLead > 0 {
+			s.scheduleProposerPreparation(ctx, duty)
+		}
 		go func(duty *beaconblockproposer.Duty) {
+			proposeCheckTime, proposeTime := s.proposalTimes(duty.Slot())
 			if err := s.beaconBlockProposer.Prepare(ctx, duty); err != nil {
 				log.Error().Uint64("proposal_slot", uint64(duty.Slot())).Err(err).Msg("Failed to prepare beacon block proposal")
 				return
@@ -93,7 +106,7 @@ func (s *Service) scheduleProposals(ctx context.Context,
 				if err := s.scheduler.ScheduleJob(ctx,
 					"Propose check",
 					fmt.Sprintf("Early beacon block proposal for slot %d", duty.Slot()),
-					s.chainTimeService.StartOfSlot(duty.Slot()),
+					proposeCheckTime,
 					s.proposeEarly,
 					duty,
 				); err != nil {
@@ -104,8 +117,8 @@ func (s *Service) scheduleProposals(ctx context.Context,
 			if err := s.scheduler.ScheduleJob(ctx,
 				"Propose",
 				fmt.Sprintf("Beacon block proposal for slot %d", duty.Slot()),
-				s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.maxProposalDelay),
-				s.beaconBlockProposer.Propose,
+				proposeTime,
+				s.propose,
 				duty,
 			); err != nil {
 				// Don't return here; we want to try to set up as many proposer jobs as possible.
@@ -116,6 +129,14 @@ func (s *Service) scheduleProposals(ctx context.Context,
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Scheduled beacon block proposals")
 }
 
+// proposalTimes returns the time at which to check if a proposal can go early,
+// and the time at which the proposal will go regardless, for the given slot.
+func (s *Service) proposalTimes(slot phase0.Slot) (time.Time, time.Time) {
+	proposeCheckTime := s.chainTimeService.StartOfSlot(slot).Add(s.proposalOffset)
+
+	return proposeCheckTime, proposeCheckTime.Add(s.maxProposalDelay)
+}
+
 // proposeEarly attempts to propose as soon as the slot starts, as long
 // as the head of the chain is up-to-date.
 func (s *Service) proposeEarly(ctx context.Context, data interface{}) {
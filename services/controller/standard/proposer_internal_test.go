@@ -0,0 +1,93 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposalTimes(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now().Truncate(time.Second)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	slot := phase0.Slot(10)
+	startOfSlot := genesisTime.Add(120 * time.Second)
+
+	tests := []struct {
+		name             string
+		proposalOffset   time.Duration
+		maxProposalDelay time.Duration
+		proposeCheckTime time.Time
+		proposeTime      time.Time
+	}{
+		{
+			name:             "Default",
+			proposeCheckTime: startOfSlot,
+			proposeTime:      startOfSlot,
+		},
+		{
+			name:             "MaxProposalDelay",
+			maxProposalDelay: time.Second,
+			proposeCheckTime: startOfSlot,
+			proposeTime:      startOfSlot.Add(time.Second),
+		},
+		{
+			name:             "Early",
+			proposalOffset:   -300 * time.Millisecond,
+			proposeCheckTime: startOfSlot.Add(-300 * time.Millisecond),
+			proposeTime:      startOfSlot.Add(-300 * time.Millisecond),
+		},
+		{
+			name:             "EarlyWithMaxProposalDelay",
+			proposalOffset:   -300 * time.Millisecond,
+			maxProposalDelay: time.Second,
+			proposeCheckTime: startOfSlot.Add(-300 * time.Millisecond),
+			proposeTime:      startOfSlot.Add(700 * time.Millisecond),
+		},
+		{
+			name:             "Late",
+			proposalOffset:   500 * time.Millisecond,
+			proposeCheckTime: startOfSlot.Add(500 * time.Millisecond),
+			proposeTime:      startOfSlot.Add(500 * time.Millisecond),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				chainTimeService: chainTime,
+				proposalOffset:   test.proposalOffset,
+				maxProposalDelay: test.maxProposalDelay,
+			}
+			proposeCheckTime, proposeTime := s.proposalTimes(slot)
+			require.Equal(t, test.proposeCheckTime, proposeCheckTime)
+			require.Equal(t, test.proposeTime, proposeTime)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// quarantineEndSlot returns the first slot after the startup quarantine,
+// given the slot at startup.  A value of 0 means that there is no quarantine.
+func quarantineEndSlot(startupSlot phase0.Slot,
+	untilSlot phase0.Slot,
+	slots uint64,
+) phase0.Slot {
+	endSlot := untilSlot
+	if slots > 0 && startupSlot+phase0.Slot(slots) > endSlot {
+		endSlot = startupSlot + phase0.Slot(slots)
+	}
+
+	return endSlot
+}
+
+// quarantined returns true if duties for the given slot fall within the
+// startup quarantine, in which case they must not be signed.
+func (s *Service) quarantined(slot phase0.Slot) bool {
+	if slot < s.quarantineEndSlot {
+		log.Debug().Uint64("slot", uint64(slot)).Uint64("quarantine_end_slot", uint64(s.quarantineEndSlot)).Msg("Duty within startup quarantine; not signing")
+		return true
+	}
+
+	if s.quarantineEndSlot > 0 {
+		s.quarantineEndedOnce.Do(func() {
+			log.Info().Uint64("slot", uint64(slot)).Msg("Startup quarantine over; normal operation begins")
+		})
+	}
+
+	return false
+}
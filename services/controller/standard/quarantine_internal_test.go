@@ -0,0 +1,110 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/stretchr/testify/require"
+)
+
+type countingAttester struct {
+	calls atomic.Uint64
+}
+
+func (a *countingAttester) Attest(ctx context.Context, _ interface{}) ([]*phase0.Attestation, error) {
+	if attester.SigningHeld(ctx) {
+		return nil, nil
+	}
+	a.calls.Add(1)
+
+	return make([]*phase0.Attestation, 0), nil
+}
+
+func TestQuarantineEndSlot(t *testing.T) {
+	tests := []struct {
+		name        string
+		startupSlot phase0.Slot
+		untilSlot   phase0.Slot
+		slots       uint64
+		expected    phase0.Slot
+	}{
+		{
+			name:        "None",
+			startupSlot: 100,
+			expected:    0,
+		},
+		{
+			name:        "UntilSlot",
+			startupSlot: 100,
+			untilSlot:   150,
+			expected:    150,
+		},
+		{
+			name:        "Slots",
+			startupSlot: 100,
+			slots:       10,
+			expected:    110,
+		},
+		{
+			name:        "UntilSlotLater",
+			startupSlot: 100,
+			untilSlot:   150,
+			slots:       10,
+			expected:    150,
+		},
+		{
+			name:        "SlotsLater",
+			startupSlot: 100,
+			untilSlot:   105,
+			slots:       10,
+			expected:    110,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, quarantineEndSlot(test.startupSlot, test.untilSlot, test.slots))
+		})
+	}
+}
+
+func TestQuarantineNoSigning(t *testing.T) {
+	ctx := context.Background()
+
+	attesterSvc := &countingAttester{}
+	s := &Service{
+		attester:            attesterSvc,
+		pendingAttestations: make(map[phase0.Slot]bool),
+		quarantineEndSlot:   110,
+	}
+
+	// Attestations during the quarantine should not be signed.
+	for slot := phase0.Slot(100); slot < 110; slot++ {
+		duty, err := attester.NewDuty(ctx, slot, 1, []phase0.ValidatorIndex{1}, []phase0.CommitteeIndex{0}, []uint64{0}, map[phase0.CommitteeIndex]uint64{0: 128})
+		require.NoError(t, err)
+		s.AttestAndScheduleAggregate(ctx, duty)
+	}
+	require.Equal(t, uint64(0), attesterSvc.calls.Load())
+
+	// Attestations after the quarantine should be signed.
+	duty, err := attester.NewDuty(ctx, 110, 1, []phase0.ValidatorIndex{1}, []phase0.CommitteeIndex{0}, []uint64{0}, map[phase0.CommitteeIndex]uint64{0: 128})
+	require.NoError(t, err)
+	s.AttestAndScheduleAggregate(ctx, duty)
+	require.Equal(t, uint64(1), attesterSvc.calls.Load())
+}
@@ -16,6 +16,7 @@ package standard
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
@@ -45,6 +46,25 @@ import (
 // period change at which to prepare the relevant jobs.
 var syncCommitteePreparationEpochs = uint64(5)
 
+// syncCommitteeDutiesAttempts is the number of times to request sync committee
+// duties if the response appears to be incomplete.
+const syncCommitteeDutiesAttempts = 3
+
+// defaultSyncCommitteeDutiesRetryInterval is the time to wait before requesting
+// sync committee duties again if the response appears to be incomplete.
+const defaultSyncCommitteeDutiesRetryInterval = time.Second
+
+// OverdueSkippableJobClasses returns the classes of job scheduled by the
+// controller that are of no use once their slot has passed, and so can be
+// skipped by the scheduler if they are triggered long after their time.
+func OverdueSkippableJobClasses() []string {
+	return []string{
+		"Attest",
+		"Aggregate attestations",
+		"Aggregate sync committee messages",
+	}
+}
+
 // Service is the co-ordination system for vouch.
 // It runs purely against clock events, setting up jobs for the validator's processes of block proposal, attestation
 // creation and attestation aggregation.
@@ -76,6 +96,7 @@ type Service struct {
 	accountsRefresher             accountmanager.Refresher
 	blockToSlotSetter             cache.BlockRootToSlotSetter
 	maxProposalDelay              time.Duration
+	proposalOffset                time.Duration
 	maxAttestationDelay           time.Duration
 	attestationAggregationDelay   time.Duration
 	maxSyncCommitteeMessageDelay  time.Duration
@@ -83,14 +104,42 @@ type Service struct {
 	fastTrackAttestations         bool
 	fastTrackSyncCommittees       bool
 	fastTrackGrace                time.Duration
+	activationHorizon             phase0.Epoch
+	activatingValidatorsCount     int
+
+	// Notification of upcoming proposals.
+	proposalNotificationLead   uint64
+	proposalNotificationURL    string
+	proposalNotificationClient *http.Client
+
+	// Submission of preparations ahead of proposals.
+	proposalPreparationLead uint64
+
+	// Handling of duties for epochs or validators that were not requested.
+	unexpectedDutiesPolicy string
+
+	// Tracking for sync committee look-ahead scheduling.
+	syncCommitteeLookaheadPeriod uint64
+	syncCommitteeLookaheadMu     sync.Mutex
+
+	// Retrying of incomplete sync committee duties.
+	syncCommitteeDutiesRetryInterval time.Duration
 
 	// Hard fork control
+	specProvider       eth2client.SpecProvider
 	handlingAltair     bool
 	altairForkEpoch    phase0.Epoch
+	altairForkEpochMu  sync.RWMutex
 	handlingBellatrix  bool
 	bellatrixForkEpoch phase0.Epoch
 	capellaForkEpoch   phase0.Epoch
 
+	// Tracking for capabilities withdrawn by the beacon node after start-up.
+	capabilityRefresh  bool
+	altairWithdrawn    bool
+	bellatrixWithdrawn bool
+	capabilitiesMu     sync.RWMutex
+
 	// Tracking for reorgs.
 	lastBlockRoot             phase0.Root
 	lastBlockEpoch            phase0.Epoch
@@ -100,6 +149,25 @@ type Service struct {
 	// Tracking for attestations.
 	pendingAttestations      map[phase0.Slot]bool
 	pendingAttestationsMutex sync.RWMutex
+
+	// Tracking for scheduled duties.
+	dutyLedger *dutyLedger
+
+	// Startup quarantine.
+	quarantineEndSlot   phase0.Slot
+	quarantineEndedOnce sync.Once
+
+	// Warm standby.
+	standby               bool
+	standbyEndSlot        phase0.Slot
+	standbyMu             sync.RWMutex
+	standbyPromotionSlots uint64
+	standbyPeerURL        string
+	standbyToken          string
+	standbyClient         *http.Client
+
+	// Sinks for the outcomes of duties.
+	dutyOutcomeSinks []metrics.DutyOutcomeSink
 }
 
 // module-wide log.
@@ -189,6 +257,7 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		accountsRefresher:             parameters.accountsRefresher,
 		blockToSlotSetter:             parameters.blockToSlotSetter,
 		maxProposalDelay:              parameters.maxProposalDelay,
+		proposalOffset:                parameters.proposalOffset,
 		maxAttestationDelay:           parameters.maxAttestationDelay,
 		attestationAggregationDelay:   parameters.attestationAggregationDelay,
 		maxSyncCommitteeMessageDelay:  parameters.maxSyncCommitteeMessageDelay,
@@ -196,13 +265,50 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		fastTrackAttestations:         parameters.fastTrackAttestations,
 		fastTrackSyncCommittees:       parameters.fastTrackSyncCommittees,
 		fastTrackGrace:                parameters.fastTrackGrace,
+		activationHorizon:             parameters.activationHorizon,
 		subscriptionInfos:             make(map[phase0.Epoch]map[phase0.Slot]map[phase0.CommitteeIndex]*beaconcommitteesubscriber.Subscription),
 		handlingAltair:                handlingAltair,
+		specProvider:                  parameters.specProvider,
 		altairForkEpoch:               altairForkEpoch,
 		handlingBellatrix:             handlingBellatrix,
 		bellatrixForkEpoch:            bellatrixForkEpoch,
 		capellaForkEpoch:              capellaForkEpoch,
+		capabilityRefresh:             parameters.capabilityRefresh,
 		pendingAttestations:           make(map[phase0.Slot]bool),
+		dutyLedger:                    newDutyLedger(),
+		proposalNotificationLead:      parameters.proposalNotificationLead,
+		proposalNotificationURL:       parameters.proposalNotificationURL,
+		proposalPreparationLead:       parameters.proposalPreparationLead,
+		unexpectedDutiesPolicy:        parameters.unexpectedDutiesPolicy,
+	}
+	s.syncCommitteeDutiesRetryInterval = defaultSyncCommitteeDutiesRetryInterval
+	if s.proposalNotificationURL != "" {
+		s.proposalNotificationClient = &http.Client{
+			Timeout: proposalNotificationTimeout,
+		}
+	}
+
+	s.quarantineEndSlot = quarantineEndSlot(parameters.chainTimeService.CurrentSlot(), parameters.quarantineUntilSlot, parameters.quarantineSlots)
+	if s.quarantineEndSlot > parameters.chainTimeService.CurrentSlot() {
+		log.Info().Uint64("quarantine_end_slot", uint64(s.quarantineEndSlot)).Msg("Startup quarantine in place; no duties will be signed until the quarantine ends")
+	}
+
+	s.dutyOutcomeSinks = parameters.dutyOutcomeSinks
+
+	s.standby = parameters.standby
+	s.standbyPromotionSlots = parameters.standbyPromotionSlots
+	s.standbyPeerURL = parameters.standbyPeerURL
+	s.standbyToken = parameters.standbyToken
+	if s.standbyPeerURL != "" {
+		s.standbyClient = &http.Client{
+			Timeout: standbyPeerTimeout,
+		}
+	}
+	if s.standby {
+		log.Info().Msg("Starting in standby; no duties will be signed until promoted")
+	}
+	if parameters.standbyListenAddress != "" {
+		go s.serveStandby(ctx, parameters.standbyListenAddress)
 	}
 
 	// Subscribe to head events.  This allows us to go early for attestations if a block arrives, as well as
@@ -228,10 +334,7 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain active validator indices for the current epoch")
 	}
-	if len(validatorIndices) != s.activeValidators {
-		log.Info().Int("old_validators", s.activeValidators).Int("new_validators", len(validatorIndices)).Msg("Change in number of active validators")
-		s.activeValidators = len(validatorIndices)
-	}
+	s.updateActiveValidators(len(validatorIndices))
 	nextEpochAccounts, nextEpochValidatorIndices, err := s.accountsAndIndicesForEpoch(ctx, epoch+1)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain active validator indices for the next epoch")
@@ -241,8 +344,7 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	if handlingAltair {
 		thisSyncCommitteePeriodStartEpoch := s.firstEpochOfSyncPeriod(uint64(epoch) / s.epochsPerSyncCommitteePeriod)
 		go s.scheduleSyncCommitteeMessages(ctx, thisSyncCommitteePeriodStartEpoch, validatorIndices, true /* notCurrentSlot */)
-		nextSyncCommitteePeriodStartEpoch := s.firstEpochOfSyncPeriod(uint64(epoch)/s.epochsPerSyncCommitteePeriod + 1)
-		if uint64(nextSyncCommitteePeriodStartEpoch-epoch) <= syncCommitteePreparationEpochs {
+		if nextSyncCommitteePeriodStartEpoch, required := s.syncCommitteeLookahead(epoch); required {
 			go s.scheduleSyncCommitteeMessages(ctx, nextSyncCommitteePeriodStartEpoch, validatorIndices, true /* notCurrentSlot */)
 		}
 	}
@@ -332,6 +434,7 @@ func (s *Service) epochTicker(ctx context.Context, data interface{}) {
 	epochTickerData.latestEpochRan = int64(currentEpoch)
 	epochTickerData.mutex.Unlock()
 	s.monitor.NewEpoch()
+	s.dutyLedger.prune(s.chainTimeService.FirstSlotOfEpoch(currentEpoch))
 
 	// We wait for the beacon node to update, but keep ourselves busy in the meantime.
 	waitCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
@@ -354,20 +457,27 @@ func (s *Service) epochTicker(ctx context.Context, data interface{}) {
 	cancel()
 
 	go s.scheduleProposals(ctx, currentEpoch, validatorIndices, false /* notCurrentSlot */)
-	if s.handlingAltair {
+
+	// Pick up any change to the capabilities of the beacon node before relying on them.
+	s.refreshCapabilities(ctx, currentEpoch, validatorIndices)
+
+	if s.altairEnabled() {
+		// Pick up any change to the Altair fork epoch before relying on it.
+		s.refreshAltairForkEpoch(ctx)
+
 		// Handle the Altair hard fork transition epoch.
-		if currentEpoch == s.altairForkEpoch {
+		if currentEpoch == s.currentAltairForkEpoch() {
 			log.Info().Msg("At Altair fork epoch")
 			go s.handleAltairForkEpoch(ctx)
 		}
 
 		// Update the _next_ period if we close to an EPOCHS_PER_SYNC_COMMITTEE_PERIOD boundary.
-		if uint64(currentEpoch)%s.epochsPerSyncCommitteePeriod == s.epochsPerSyncCommitteePeriod-syncCommitteePreparationEpochs {
-			go s.scheduleSyncCommitteeMessages(ctx, currentEpoch+phase0.Epoch(syncCommitteePreparationEpochs), validatorIndices, false /* notCurrentSlot */)
+		if nextSyncCommitteePeriodStartEpoch, required := s.syncCommitteeLookahead(currentEpoch); required {
+			go s.scheduleSyncCommitteeMessages(ctx, nextSyncCommitteePeriodStartEpoch, validatorIndices, false /* notCurrentSlot */)
 		}
 	}
 
-	if s.handlingBellatrix {
+	if s.bellatrixEnabled() {
 		// Handle the Bellatrix hard fork transition epoch.
 		if currentEpoch == s.bellatrixForkEpoch {
 			log.Info().Msg("At Bellatrix fork epoch")
@@ -520,22 +630,24 @@ func fetchCapellaForkEpoch(ctx context.Context,
 
 // handleAltairForkEpoch handles changes that need to take place at the Altair hard fork boundary.
 func (s *Service) handleAltairForkEpoch(ctx context.Context) {
-	if !s.handlingAltair {
+	if !s.altairEnabled() {
 		return
 	}
 
+	altairForkEpoch := s.currentAltairForkEpoch()
+
 	go func() {
-		_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, s.altairForkEpoch)
+		_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, altairForkEpoch)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to obtain active validator indices for the Altair fork epoch")
 			return
 		}
-		go s.scheduleSyncCommitteeMessages(ctx, s.altairForkEpoch, validatorIndices, false /* notCurrentSlot */)
+		go s.scheduleSyncCommitteeMessages(ctx, altairForkEpoch, validatorIndices, false /* notCurrentSlot */)
 	}()
 
 	go func() {
-		nextPeriodEpoch := phase0.Epoch((uint64(s.altairForkEpoch)/s.epochsPerSyncCommitteePeriod + 1) * s.epochsPerSyncCommitteePeriod)
-		if uint64(nextPeriodEpoch-s.altairForkEpoch) <= syncCommitteePreparationEpochs {
+		nextPeriodEpoch := phase0.Epoch((uint64(altairForkEpoch)/s.epochsPerSyncCommitteePeriod + 1) * s.epochsPerSyncCommitteePeriod)
+		if uint64(nextPeriodEpoch-altairForkEpoch) <= syncCommitteePreparationEpochs {
 			_, validatorIndices, err := s.accountsAndIndicesForEpoch(ctx, nextPeriodEpoch)
 			if err != nil {
 				log.Error().Err(err).Msg("Failed to obtain active validator indices for the period following the Altair fork epoch")
@@ -548,7 +660,7 @@ func (s *Service) handleAltairForkEpoch(ctx context.Context) {
 
 // handleBellatrixForkEpoch handles changes that need to take place at the Bellatrix hard fork boundary.
 func (s *Service) handleBellatrixForkEpoch(ctx context.Context) {
-	if !s.handlingBellatrix {
+	if !s.bellatrixEnabled() {
 		return
 	}
 
@@ -599,6 +711,9 @@ func obtainSpecValues(ctx context.Context,
 	if !ok {
 		return 0, 0, 0, errors.New("SLOTS_PER_EPOCH of unexpected type")
 	}
+	if slotsPerEpoch == 0 {
+		return 0, 0, 0, errors.New("SLOTS_PER_EPOCH cannot be zero")
+	}
 
 	var epochsPerSyncCommitteePeriod uint64
 	if tmp, exists := spec["EPOCHS_PER_SYNC_COMMITTEE_PERIOD"]; exists {
@@ -606,6 +721,9 @@ func obtainSpecValues(ctx context.Context,
 		if !ok {
 			return 0, 0, 0, errors.New("EPOCHS_PER_SYNC_COMMITTEE_PERIOD of unexpected type")
 		}
+		if tmp2 == 0 {
+			return 0, 0, 0, errors.New("EPOCHS_PER_SYNC_COMMITTEE_PERIOD cannot be zero")
+		}
 		epochsPerSyncCommitteePeriod = tmp2
 	}
 
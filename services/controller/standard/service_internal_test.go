@@ -0,0 +1,99 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/stretchr/testify/require"
+)
+
+type specProvider struct {
+	spec map[string]any
+}
+
+func (p *specProvider) Spec(_ context.Context, _ *api.SpecOpts) (*api.Response[map[string]any], error) {
+	return &api.Response[map[string]any]{
+		Data: p.spec,
+	}, nil
+}
+
+func TestObtainSpecValues(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name                         string
+		spec                         map[string]any
+		slotDuration                 time.Duration
+		slotsPerEpoch                uint64
+		epochsPerSyncCommitteePeriod uint64
+		err                          string
+	}{
+		{
+			name: "SlotsPerEpochZero",
+			spec: map[string]any{
+				"SECONDS_PER_SLOT":                 12 * time.Second,
+				"SLOTS_PER_EPOCH":                  uint64(0),
+				"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": uint64(256),
+			},
+			err: "SLOTS_PER_EPOCH cannot be zero",
+		},
+		{
+			name: "EpochsPerSyncCommitteePeriodZero",
+			spec: map[string]any{
+				"SECONDS_PER_SLOT":                 12 * time.Second,
+				"SLOTS_PER_EPOCH":                  uint64(32),
+				"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": uint64(0),
+			},
+			err: "EPOCHS_PER_SYNC_COMMITTEE_PERIOD cannot be zero",
+		},
+		{
+			name: "EpochsPerSyncCommitteePeriodMissing",
+			spec: map[string]any{
+				"SECONDS_PER_SLOT": 12 * time.Second,
+				"SLOTS_PER_EPOCH":  uint64(32),
+			},
+			slotDuration:  12 * time.Second,
+			slotsPerEpoch: 32,
+		},
+		{
+			name: "Good",
+			spec: map[string]any{
+				"SECONDS_PER_SLOT":                 12 * time.Second,
+				"SLOTS_PER_EPOCH":                  uint64(32),
+				"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": uint64(256),
+			},
+			slotDuration:                 12 * time.Second,
+			slotsPerEpoch:                32,
+			epochsPerSyncCommitteePeriod: 256,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slotDuration, slotsPerEpoch, epochsPerSyncCommitteePeriod, err := obtainSpecValues(ctx, &specProvider{spec: test.spec})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.slotDuration, slotDuration)
+				require.Equal(t, test.slotsPerEpoch, slotsPerEpoch)
+				require.Equal(t, test.epochsPerSyncCommitteePeriod, epochsPerSyncCommitteePeriod)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"testing"
 	"time"
 
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
 	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
@@ -40,6 +42,21 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// slotDurationSpecProvider provides the mock spec with the given slot duration.
+type slotDurationSpecProvider struct {
+	slotDuration time.Duration
+}
+
+func (p *slotDurationSpecProvider) Spec(ctx context.Context, opts *api.SpecOpts) (*api.Response[map[string]any], error) {
+	response, err := mock.NewSpecProvider().Spec(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.Data["SECONDS_PER_SLOT"] = p.slotDuration
+
+	return response, nil
+}
+
 func TestService(t *testing.T) {
 	ctx := context.Background()
 
@@ -658,6 +675,146 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no signed beacon block provider specified",
 		},
+		{
+			name: "ProposalOffsetAfterSlot",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithSpecProvider(specProvider),
+				standard.WithChainTimeService(chainTime),
+				standard.WithProposerDutiesProvider(proposerDutiesProvider),
+				standard.WithAttesterDutiesProvider(attesterDutiesProvider),
+				standard.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				standard.WithEventsProvider(mockEventsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithProposalsPreparer(mockProposalsPreparer),
+				standard.WithScheduler(mockScheduler),
+				standard.WithAttester(mockAttester),
+				standard.WithSyncCommitteeMessenger(mockSyncCommitteeMessenger),
+				standard.WithSyncCommitteeAggregator(mockSyncCommitteeAggregator),
+				standard.WithSyncCommitteeSubscriber(mockSyncCommitteeSubscriber),
+				standard.WithBeaconBlockProposer(mockBeaconBlockProposer),
+				standard.WithBeaconCommitteeSubscriber(mockBeaconCommitteeSubscriber),
+				standard.WithAttestationAggregator(mockAttestationAggregator),
+				standard.WithAccountsRefresher(mockAccountsRefresher),
+				standard.WithBlockToSlotSetter(mockBlockToSlotSetter),
+				standard.WithBeaconBlockHeadersProvider(mockBlockHeadersProvider),
+				standard.WithSignedBeaconBlockProvider(mockSignedBeaconBlockProvider),
+				standard.WithMaxAttestationDelay(4 * time.Second),
+				standard.WithMaxProposalDelay(4 * time.Second),
+				standard.WithMaxSyncCommitteeMessageDelay(4 * time.Second),
+				standard.WithMaxSyncCommitteeMessageDelay(4 * time.Second),
+				standard.WithAttestationAggregationDelay(8 * time.Second),
+				standard.WithSyncCommitteeAggregationDelay(10 * time.Second),
+				standard.WithProposalOffset(9 * time.Second),
+			},
+			err: "problem with parameters: proposal offset must be within the slot",
+		},
+		{
+			name: "ProposalOffsetBeforeSlot",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithSpecProvider(specProvider),
+				standard.WithChainTimeService(chainTime),
+				standard.WithProposerDutiesProvider(proposerDutiesProvider),
+				standard.WithAttesterDutiesProvider(attesterDutiesProvider),
+				standard.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				standard.WithEventsProvider(mockEventsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithProposalsPreparer(mockProposalsPreparer),
+				standard.WithScheduler(mockScheduler),
+				standard.WithAttester(mockAttester),
+				standard.WithSyncCommitteeMessenger(mockSyncCommitteeMessenger),
+				standard.WithSyncCommitteeAggregator(mockSyncCommitteeAggregator),
+				standard.WithSyncCommitteeSubscriber(mockSyncCommitteeSubscriber),
+				standard.WithBeaconBlockProposer(mockBeaconBlockProposer),
+				standard.WithBeaconCommitteeSubscriber(mockBeaconCommitteeSubscriber),
+				standard.WithAttestationAggregator(mockAttestationAggregator),
+				standard.WithAccountsRefresher(mockAccountsRefresher),
+				standard.WithBlockToSlotSetter(mockBlockToSlotSetter),
+				standard.WithBeaconBlockHeadersProvider(mockBlockHeadersProvider),
+				standard.WithSignedBeaconBlockProvider(mockSignedBeaconBlockProvider),
+				standard.WithMaxAttestationDelay(4 * time.Second),
+				standard.WithMaxProposalDelay(4 * time.Second),
+				standard.WithMaxSyncCommitteeMessageDelay(4 * time.Second),
+				standard.WithMaxSyncCommitteeMessageDelay(4 * time.Second),
+				standard.WithAttestationAggregationDelay(8 * time.Second),
+				standard.WithSyncCommitteeAggregationDelay(10 * time.Second),
+				standard.WithProposalOffset(-12 * time.Second),
+			},
+			err: "problem with parameters: proposal offset must be within the slot",
+		},
+		{
+			name: "UnexpectedDutiesPolicyUnknown",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithSpecProvider(specProvider),
+				standard.WithChainTimeService(chainTime),
+				standard.WithProposerDutiesProvider(proposerDutiesProvider),
+				standard.WithAttesterDutiesProvider(attesterDutiesProvider),
+				standard.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				standard.WithEventsProvider(mockEventsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithProposalsPreparer(mockProposalsPreparer),
+				standard.WithScheduler(mockScheduler),
+				standard.WithAttester(mockAttester),
+				standard.WithSyncCommitteeMessenger(mockSyncCommitteeMessenger),
+				standard.WithSyncCommitteeAggregator(mockSyncCommitteeAggregator),
+				standard.WithSyncCommitteeSubscriber(mockSyncCommitteeSubscriber),
+				standard.WithBeaconBlockProposer(mockBeaconBlockProposer),
+				standard.WithBeaconCommitteeSubscriber(mockBeaconCommitteeSubscriber),
+				standard.WithAttestationAggregator(mockAttestationAggregator),
+				standard.WithAccountsRefresher(mockAccountsRefresher),
+				standard.WithBlockToSlotSetter(mockBlockToSlotSetter),
+				standard.WithBeaconBlockHeadersProvider(mockBlockHeadersProvider),
+				standard.WithSignedBeaconBlockProvider(mockSignedBeaconBlockProvider),
+				standard.WithMaxAttestationDelay(4 * time.Second),
+				standard.WithMaxProposalDelay(4 * time.Second),
+				standard.WithMaxSyncCommitteeMessageDelay(4 * time.Second),
+				standard.WithMaxSyncCommitteeMessageDelay(4 * time.Second),
+				standard.WithAttestationAggregationDelay(8 * time.Second),
+				standard.WithSyncCommitteeAggregationDelay(8 * time.Second),
+				standard.WithUnexpectedDutiesPolicy("ignore"),
+			},
+			err: `problem with parameters: unknown unexpected duties policy "ignore"`,
+		},
+		{
+			name: "StandbyNonLoopbackWithoutToken",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(ctx)),
+				standard.WithSpecProvider(specProvider),
+				standard.WithChainTimeService(chainTime),
+				standard.WithProposerDutiesProvider(proposerDutiesProvider),
+				standard.WithAttesterDutiesProvider(attesterDutiesProvider),
+				standard.WithSyncCommitteeDutiesProvider(syncCommitteeDutiesProvider),
+				standard.WithEventsProvider(mockEventsProvider),
+				standard.WithValidatingAccountsProvider(mockValidatingAccountsProvider),
+				standard.WithProposalsPreparer(mockProposalsPreparer),
+				standard.WithScheduler(mockScheduler),
+				standard.WithAttester(mockAttester),
+				standard.WithSyncCommitteeMessenger(mockSyncCommitteeMessenger),
+				standard.WithSyncCommitteeAggregator(mockSyncCommitteeAggregator),
+				standard.WithSyncCommitteeSubscriber(mockSyncCommitteeSubscriber),
+				standard.WithBeaconBlockProposer(mockBeaconBlockProposer),
+				standard.WithBeaconCommitteeSubscriber(mockBeaconCommitteeSubscriber),
+				standard.WithAttestationAggregator(mockAttestationAggregator),
+				standard.WithAccountsRefresher(mockAccountsRefresher),
+				standard.WithBlockToSlotSetter(mockBlockToSlotSetter),
+				standard.WithBeaconBlockHeadersProvider(mockBlockHeadersProvider),
+				standard.WithSignedBeaconBlockProvider(mockSignedBeaconBlockProvider),
+				standard.WithStandbyListenAddress("0.0.0.0:8446"),
+				standard.WithMaxAttestationDelay(4 * time.Second),
+				standard.WithMaxProposalDelay(4 * time.Second),
+				standard.WithMaxSyncCommitteeMessageDelay(4 * time.Second),
+				standard.WithMaxSyncCommitteeMessageDelay(4 * time.Second),
+				standard.WithAttestationAggregationDelay(8 * time.Second),
+				standard.WithSyncCommitteeAggregationDelay(8 * time.Second),
+			},
+			err: "problem with parameters: standby token required for non-loopback standby listen address",
+		},
 		{
 			name: "Good",
 			params: []standard.Parameter{
@@ -735,3 +892,132 @@ func TestService(t *testing.T) {
 		})
 	}
 }
+
+// baseServiceParams returns a full set of valid parameters for the service
+// with the given spec provider, which tests extend to override individual
+// parameters.
+func baseServiceParams(ctx context.Context, t *testing.T, specProvider eth2client.SpecProvider) []standard.Parameter {
+	t.Helper()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	return []standard.Parameter{
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithSpecProvider(specProvider),
+		standard.WithChainTimeService(chainTime),
+		standard.WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+		standard.WithAttesterDutiesProvider(mock.NewAttesterDutiesProvider()),
+		standard.WithSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+		standard.WithEventsProvider(mock.NewEventsProvider()),
+		standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+		standard.WithProposalsPreparer(mockproposalpreparer.New()),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithAttester(mockattester.New()),
+		standard.WithSyncCommitteeMessenger(mocksynccommitteemessenger.New()),
+		standard.WithSyncCommitteeAggregator(mocksynccommitteeaggregator.New()),
+		standard.WithSyncCommitteeSubscriber(mocksynccommitteesubscriber.New()),
+		standard.WithBeaconBlockProposer(mockbeaconblockproposer.New()),
+		standard.WithBeaconCommitteeSubscriber(mockbeaconcommitteesubscriber.New()),
+		standard.WithAttestationAggregator(mockattestationaggregator.New()),
+		standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+		standard.WithBlockToSlotSetter(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotSetter)),
+		standard.WithBeaconBlockHeadersProvider(mock.NewBeaconBlockHeadersProvider()),
+		standard.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+	}
+}
+
+func TestServiceDelays(t *testing.T) {
+	ctx := context.Background()
+
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	tests := []struct {
+		name         string
+		slotDuration time.Duration
+		params       []standard.Parameter
+		err          string
+	}{
+		{
+			name:         "AttestationAggregationBeforeAttestation",
+			slotDuration: 12 * time.Second,
+			params: []standard.Parameter{
+				standard.WithMaxAttestationDelay(4 * time.Second),
+				standard.WithAttestationAggregationDelay(3 * time.Second),
+			},
+			err: "problem with parameters: attestation aggregation delay must be greater than max attestation delay",
+		},
+		{
+			name:         "AttestationAggregationAfterSlot",
+			slotDuration: 12 * time.Second,
+			params: []standard.Parameter{
+				standard.WithAttestationAggregationDelay(12 * time.Second),
+			},
+			err: "problem with parameters: attestation aggregation delay must be less than slot duration",
+		},
+		{
+			name:         "SyncCommitteeAggregationBeforeMessage",
+			slotDuration: 12 * time.Second,
+			params: []standard.Parameter{
+				standard.WithMaxSyncCommitteeMessageDelay(4 * time.Second),
+				standard.WithSyncCommitteeAggregationDelay(4 * time.Second),
+			},
+			err: "problem with parameters: sync committee aggregation delay must be greater than max sync committee message delay",
+		},
+		{
+			name:         "SyncCommitteeAggregationAfterSlot",
+			slotDuration: 12 * time.Second,
+			params: []standard.Parameter{
+				standard.WithSyncCommitteeAggregationDelay(13 * time.Second),
+			},
+			err: "problem with parameters: sync committee aggregation delay must be less than slot duration",
+		},
+		{
+			name:         "ShortSlotAttestationAggregationAfterSlot",
+			slotDuration: 4 * time.Second,
+			params: []standard.Parameter{
+				standard.WithAttestationAggregationDelay(8 * time.Second),
+			},
+			err: "problem with parameters: attestation aggregation delay must be less than slot duration",
+		},
+		{
+			name:         "ShortSlotSyncCommitteeAggregationAfterSlot",
+			slotDuration: 4 * time.Second,
+			params: []standard.Parameter{
+				standard.WithSyncCommitteeAggregationDelay(8 * time.Second),
+			},
+			err: "problem with parameters: sync committee aggregation delay must be less than slot duration",
+		},
+		{
+			name:         "ShortSlotDefaultDelays",
+			slotDuration: 4 * time.Second,
+		},
+		{
+			name:         "ShortSlot",
+			slotDuration: 4 * time.Second,
+			params: []standard.Parameter{
+				standard.WithMaxAttestationDelay(time.Second),
+				standard.WithMaxSyncCommitteeMessageDelay(time.Second),
+				standard.WithAttestationAggregationDelay(3 * time.Second),
+				standard.WithSyncCommitteeAggregationDelay(3 * time.Second),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := baseServiceParams(ctx, t, &slotDurationSpecProvider{slotDuration: test.slotDuration})
+			_, err := standard.New(ctx, append(params, test.params...)...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
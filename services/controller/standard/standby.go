@@ -0,0 +1,249 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/pkg/errors"
+)
+
+// standbyPeerTimeout is the timeout for requests to the peer instance.
+const standbyPeerTimeout = 5 * time.Second
+
+// standbyStatus is the JSON representation of the standby state.
+type standbyStatus struct {
+	Standby         bool        `json:"standby"`
+	SigningFromSlot phase0.Slot `json:"signing_from_slot,string"`
+}
+
+// standingBy returns true if duties for the given slot must not be signed
+// because the controller is in standby, or has been promoted but is yet to
+// reach the slot at which it can start signing.
+func (s *Service) standingBy(slot phase0.Slot) bool {
+	s.standbyMu.RLock()
+	defer s.standbyMu.RUnlock()
+
+	if s.standby {
+		log.Debug().Uint64("slot", uint64(slot)).Msg("In standby; not signing")
+		return true
+	}
+	if slot < s.standbyEndSlot {
+		log.Debug().Uint64("slot", uint64(slot)).Uint64("signing_from_slot", uint64(s.standbyEndSlot)).Msg("Promotion from standby pending; not signing")
+		return true
+	}
+
+	return false
+}
+
+// signingHeld returns true if duties for the given slot must not be signed,
+// either due to the startup quarantine or due to standby.
+func (s *Service) signingHeld(slot phase0.Slot) bool {
+	return s.quarantined(slot) || s.standingBy(slot)
+}
+
+// Promote promotes the controller from standby to active.  If a peer is
+// configured it is demoted first, and promotion fails if the peer cannot be
+// demoted unless force is set.  Signing starts after the promotion slots have
+// passed, to give any in-flight duties on the peer time to complete.
+func (s *Service) Promote(ctx context.Context, force bool) error {
+	if standby, _ := s.StandbyStatus(); !standby {
+		return errors.New("not in standby")
+	}
+
+	// The peer is demoted without holding the lock, so that duties are not
+	// blocked while waiting for it to respond.
+	if s.standbyPeerURL != "" {
+		if err := s.demotePeer(ctx); err != nil {
+			if !force {
+				return errors.Wrap(err, "failed to demote peer")
+			}
+			log.Warn().Err(err).Msg("Failed to demote peer; promoting regardless as forced")
+		}
+	}
+
+	s.standbyMu.Lock()
+	defer s.standbyMu.Unlock()
+	if !s.standby {
+		return errors.New("not in standby")
+	}
+	s.standby = false
+	s.standbyEndSlot = s.chainTimeService.CurrentSlot() + 1 + phase0.Slot(s.standbyPromotionSlots)
+	log.Info().Uint64("signing_from_slot", uint64(s.standbyEndSlot)).Msg("Promoted from standby")
+
+	return nil
+}
+
+// Demote places the controller in standby.  This takes effect immediately.
+func (s *Service) Demote() {
+	s.standbyMu.Lock()
+	defer s.standbyMu.Unlock()
+
+	if s.standby {
+		return
+	}
+	s.standby = true
+	log.Info().Msg("Demoted to standby; no duties will be signed until promoted")
+}
+
+// StandbyStatus returns true if the controller is in standby, and the first
+// slot for which duties will be signed once active.
+func (s *Service) StandbyStatus() (bool, phase0.Slot) {
+	s.standbyMu.RLock()
+	defer s.standbyMu.RUnlock()
+
+	return s.standby, s.standbyEndSlot
+}
+
+// demotePeer requests that the peer instance moves to standby.
+func (s *Service) demotePeer(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/standby/demote", s.standbyPeerURL), http.NoBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	if s.standbyToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.standbyToken)
+	}
+	resp, err := s.standbyClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("peer returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// standbyHandler returns the handler for standby requests.
+func (s *Service) standbyHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/standby", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.writeStandbyStatus(w)
+	})
+	mux.HandleFunc("/standby/promote", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !s.standbyAuthorized(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if err := s.Promote(r.Context(), r.URL.Query().Get("force") == "true"); err != nil {
+			log.Warn().Err(err).Msg("Failed to promote from standby")
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		s.writeStandbyStatus(w)
+	})
+	mux.HandleFunc("/standby/demote", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !s.standbyAuthorized(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		s.Demote()
+		s.writeStandbyStatus(w)
+	})
+
+	return mux
+}
+
+// standbyAuthorized returns true if the request supplies the standby token, or
+// if no token is configured.
+func (s *Service) standbyAuthorized(r *http.Request) bool {
+	if s.standbyToken == "" {
+		return true
+	}
+
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.standbyToken)) == 1
+}
+
+// isLoopbackAddress returns true if the listen address only accepts
+// connections from the local host.
+func isLoopbackAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// writeStandbyStatus writes the standby status as JSON.
+func (s *Service) writeStandbyStatus(w http.ResponseWriter) {
+	standby, signingFromSlot := s.StandbyStatus()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&standbyStatus{
+		Standby:         standby,
+		SigningFromSlot: signingFromSlot,
+	}); err != nil {
+		log.Warn().Err(err).Msg("Failed to write standby status")
+	}
+}
+
+// serveStandby serves standby requests over HTTP.
+func (s *Service) serveStandby(ctx context.Context, address string) {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           s.standbyHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			log.Debug().Err(err).Msg("Failed to close standby server")
+		}
+	}()
+
+	log.Info().Str("listen_address", address).Msg("Starting standby server")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Warn().Str("listen_address", address).Err(err).Msg("Failed to run standby server")
+	}
+}
+
+// propose proposes a beacon block, as long as signing is not held due to standby.
+func (s *Service) propose(ctx context.Context, data interface{}) {
+	duty, ok := data.(*beaconblockproposer.Duty)
+	if ok && s.standingBy(duty.Slot()) {
+		return
+	}
+
+	err := s.beaconBlockProposer.Propose(ctx, data)
+	if ok {
+		s.publishDutyOutcome(ctx, "proposal", metrics.DutyOutcomeProposed, duty.Slot(), []phase0.ValidatorIndex{duty.ValidatorIndex()}, err)
+	}
+}
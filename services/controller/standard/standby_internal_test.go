@@ -0,0 +1,406 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/attester"
+	"github.com/attestantio/vouch/services/chaintime"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// slotRecordingAttester records the number of times it signs attestations for each slot.
+type slotRecordingAttester struct {
+	mu    sync.Mutex
+	slots map[phase0.Slot]int
+}
+
+func (a *slotRecordingAttester) Attest(ctx context.Context, data interface{}) ([]*phase0.Attestation, error) {
+	if attester.SigningHeld(ctx) {
+		return nil, nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.slots[data.(*attester.Duty).Slot()]++
+
+	return make([]*phase0.Attestation, 0), nil
+}
+
+// standbyChainTime returns a chain time service for which the current slot is 100.
+func standbyChainTime(t *testing.T) chaintime.Service {
+	t.Helper()
+
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-100*12*time.Second))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(100), chainTime.CurrentSlot())
+
+	return chainTime
+}
+
+func standbyAttest(ctx context.Context, t *testing.T, s *Service, slot phase0.Slot) {
+	t.Helper()
+
+	duty, err := attester.NewDuty(ctx, slot, 1, []phase0.ValidatorIndex{1}, []phase0.CommitteeIndex{0}, []uint64{0}, map[phase0.CommitteeIndex]uint64{0: 128})
+	require.NoError(t, err)
+	s.AttestAndScheduleAggregate(ctx, duty)
+}
+
+func TestStandbyNoSigning(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	attesterSvc := &countingAttester{}
+	s := &Service{
+		chainTimeService:      standbyChainTime(t),
+		attester:              attesterSvc,
+		pendingAttestations:   make(map[phase0.Slot]bool),
+		standby:               true,
+		standbyPromotionSlots: 2,
+	}
+
+	// Attestations in standby should not be signed.
+	for slot := phase0.Slot(100); slot < 105; slot++ {
+		standbyAttest(ctx, t, s, slot)
+	}
+	require.Equal(t, uint64(0), attesterSvc.calls.Load())
+
+	require.NoError(t, s.Promote(ctx, false))
+	standby, signingFromSlot := s.StandbyStatus()
+	require.False(t, standby)
+	require.Equal(t, phase0.Slot(103), signingFromSlot)
+
+	// Attestations before the promotion completes should not be signed.
+	standbyAttest(ctx, t, s, 102)
+	require.Equal(t, uint64(0), attesterSvc.calls.Load())
+
+	// Attestations after the promotion completes should be signed.
+	standbyAttest(ctx, t, s, 103)
+	require.Equal(t, uint64(1), attesterSvc.calls.Load())
+
+	// Demotion takes effect immediately.
+	s.Demote()
+	standbyAttest(ctx, t, s, 104)
+	require.Equal(t, uint64(1), attesterSvc.calls.Load())
+}
+
+func TestStandbyPromote(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	failingPeer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failingPeer.Close()
+
+	tests := []struct {
+		name    string
+		standby bool
+		peerURL string
+		force   bool
+		err     string
+	}{
+		{
+			name: "NotStandby",
+			err:  "not in standby",
+		},
+		{
+			name:    "NoPeer",
+			standby: true,
+		},
+		{
+			name:    "PeerFails",
+			standby: true,
+			peerURL: failingPeer.URL,
+			err:     "failed to demote peer: peer returned status 500",
+		},
+		{
+			name:    "PeerFailsForced",
+			standby: true,
+			peerURL: failingPeer.URL,
+			force:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				chainTimeService: standbyChainTime(t),
+				standby:          test.standby,
+				standbyPeerURL:   test.peerURL,
+				standbyClient:    &http.Client{Timeout: time.Second},
+			}
+			err := s.Promote(ctx, test.force)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				standby, _ := s.StandbyStatus()
+				require.Equal(t, test.standby, standby)
+			} else {
+				require.NoError(t, err)
+				standby, _ := s.StandbyStatus()
+				require.False(t, standby)
+			}
+		})
+	}
+}
+
+func TestStandbyHandover(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	chainTime := standbyChainTime(t)
+
+	activeAttester := &slotRecordingAttester{slots: make(map[phase0.Slot]int)}
+	active := &Service{
+		chainTimeService:    chainTime,
+		attester:            activeAttester,
+		pendingAttestations: make(map[phase0.Slot]bool),
+	}
+	activeServer := httptest.NewServer(active.standbyHandler())
+	defer activeServer.Close()
+
+	standbyAttester := &slotRecordingAttester{slots: make(map[phase0.Slot]int)}
+	standby := &Service{
+		chainTimeService:      chainTime,
+		attester:              standbyAttester,
+		pendingAttestations:   make(map[phase0.Slot]bool),
+		standby:               true,
+		standbyPromotionSlots: 1,
+		standbyPeerURL:        activeServer.URL,
+		standbyClient:         &http.Client{Timeout: time.Second},
+	}
+
+	// Both instances see the duty for the current slot; only the active one signs.
+	standbyAttest(ctx, t, active, 100)
+	standbyAttest(ctx, t, standby, 100)
+
+	// Promote the standby, which demotes the active instance.
+	require.NoError(t, standby.Promote(ctx, false))
+	activeStandby, _ := active.StandbyStatus()
+	require.True(t, activeStandby)
+
+	// Both instances continue to see duties.
+	for slot := phase0.Slot(100); slot < 105; slot++ {
+		standbyAttest(ctx, t, active, slot)
+		standbyAttest(ctx, t, standby, slot)
+	}
+
+	// No slot should have been signed more than once across the two instances.
+	for slot := phase0.Slot(100); slot < 105; slot++ {
+		require.LessOrEqual(t, activeAttester.slots[slot]+standbyAttester.slots[slot], 1, "slot %d signed more than once", slot)
+	}
+	require.Equal(t, 1, activeAttester.slots[100])
+	require.Equal(t, 0, standbyAttester.slots[101])
+	require.Equal(t, 1, standbyAttester.slots[102])
+	require.Equal(t, 1, standbyAttester.slots[104])
+}
+
+func TestStandbyHandler(t *testing.T) {
+	log = zerolog.Nop()
+
+	s := &Service{
+		chainTimeService: standbyChainTime(t),
+		standby:          true,
+	}
+	server := httptest.NewServer(s.standbyHandler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/standby")
+	require.NoError(t, err)
+	status := &standbyStatus{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(status))
+	resp.Body.Close()
+	require.True(t, status.Standby)
+
+	resp, err = http.Get(server.URL + "/standby/promote")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/standby/promote", "application/json", http.NoBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(status))
+	resp.Body.Close()
+	require.False(t, status.Standby)
+	require.Equal(t, phase0.Slot(101), status.SigningFromSlot)
+
+	// A second promotion fails as the instance is no longer in standby.
+	resp, err = http.Post(server.URL+"/standby/promote", "application/json", http.NoBody)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/standby/demote", "application/json", http.NoBody)
+	require.NoError(t, err)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(status))
+	resp.Body.Close()
+	require.True(t, status.Standby)
+}
+
+func TestStandbyHandlerToken(t *testing.T) {
+	log = zerolog.Nop()
+
+	s := &Service{
+		chainTimeService: standbyChainTime(t),
+		standby:          true,
+		standbyToken:     "secret",
+	}
+	server := httptest.NewServer(s.standbyHandler())
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		statusCode    int
+	}{
+		{
+			name:       "PromoteMissing",
+			path:       "/standby/promote",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "PromoteWrong",
+			path:          "/standby/promote",
+			authorization: "Bearer wrong",
+			statusCode:    http.StatusUnauthorized,
+		},
+		{
+			name:          "DemoteWrong",
+			path:          "/standby/demote",
+			authorization: "secret",
+			statusCode:    http.StatusUnauthorized,
+		},
+		{
+			name:          "Promote",
+			path:          "/standby/promote",
+			authorization: "Bearer secret",
+			statusCode:    http.StatusOK,
+		},
+		{
+			name:          "Demote",
+			path:          "/standby/demote",
+			authorization: "Bearer secret",
+			statusCode:    http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			standbyBefore, _ := s.StandbyStatus()
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+test.path, http.NoBody)
+			require.NoError(t, err)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, test.statusCode, resp.StatusCode)
+			if test.statusCode != http.StatusOK {
+				// Unauthorized requests must not change the state.
+				standbyAfter, _ := s.StandbyStatus()
+				require.Equal(t, standbyBefore, standbyAfter)
+			}
+		})
+	}
+}
+
+func TestStandbyHandoverToken(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	chainTime := standbyChainTime(t)
+
+	active := &Service{
+		chainTimeService: chainTime,
+		standbyToken:     "secret",
+	}
+	activeServer := httptest.NewServer(active.standbyHandler())
+	defer activeServer.Close()
+
+	tests := []struct {
+		name  string
+		token string
+		err   string
+	}{
+		{
+			name:  "WrongToken",
+			token: "wrong",
+			err:   "failed to demote peer: peer returned status 401",
+		},
+		{
+			name:  "Good",
+			token: "secret",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			standby := &Service{
+				chainTimeService: chainTime,
+				standby:          true,
+				standbyPeerURL:   activeServer.URL,
+				standbyToken:     test.token,
+				standbyClient:    &http.Client{Timeout: time.Second},
+			}
+			err := standby.Promote(ctx, false)
+			activeStandby, _ := active.StandbyStatus()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				require.False(t, activeStandby)
+			} else {
+				require.NoError(t, err)
+				require.True(t, activeStandby)
+			}
+		})
+	}
+}
+
+func TestIsLoopbackAddress(t *testing.T) {
+	tests := []struct {
+		address  string
+		loopback bool
+	}{
+		{address: "127.0.0.1:8446", loopback: true},
+		{address: "[::1]:8446", loopback: true},
+		{address: "localhost:8446", loopback: true},
+		{address: ":8446", loopback: false},
+		{address: "0.0.0.0:8446", loopback: false},
+		{address: "10.0.0.1:8446", loopback: false},
+		{address: "vouch:8446", loopback: false},
+		{address: "invalid", loopback: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			require.Equal(t, test.loopback, isLoopbackAddress(test.address))
+		})
+	}
+}
@@ -19,9 +19,13 @@ import (
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/scheduler"
 	"github.com/attestantio/vouch/services/synccommitteeaggregator"
 	"github.com/attestantio/vouch/services/synccommitteemessenger"
+	"github.com/pkg/errors"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -35,7 +39,7 @@ func (s *Service) scheduleSyncCommitteeMessages(ctx context.Context,
 		// Nothing to do.
 		return
 	}
-	if s.chainTimeService.CurrentEpoch() < s.altairForkEpoch {
+	if s.chainTimeService.CurrentEpoch() < s.currentAltairForkEpoch() {
 		// Not yet at the Altair epoch; don't schedule anything.
 		return
 	}
@@ -45,29 +49,22 @@ func (s *Service) scheduleSyncCommitteeMessages(ctx context.Context,
 	if firstEpoch < s.chainTimeService.CurrentEpoch() {
 		firstEpoch = s.chainTimeService.CurrentEpoch()
 	}
-	// If we are in the sync committee that starts at slot x we need to generate a message during slot x-1
-	// for it to be included in slot x, hence -1.
-	firstSlot := s.chainTimeService.FirstSlotOfEpoch(firstEpoch) - 1
+	firstSlot, lastSlot := s.syncCommitteeMessageSlots(period)
 	if firstSlot < s.chainTimeService.CurrentSlot() {
 		firstSlot = s.chainTimeService.CurrentSlot()
 	}
 	lastEpoch := s.firstEpochOfSyncPeriod(period+1) - 1
-	// If we are in the sync committee that ends at slot x we do not generate a message during slot x-1
-	// as it will never be included, hence -1.
-	lastSlot := s.chainTimeService.FirstSlotOfEpoch(lastEpoch+1) - 2
 
 	started := time.Now()
 	log.Trace().Uint64("period", period).Uint64("first_epoch", uint64(firstEpoch)).Uint64("last_epoch", uint64(lastEpoch)).Msg("Scheduling sync committee messages")
 
-	dutiesResponse, err := s.syncCommitteeDutiesProvider.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
-		Epoch:   firstEpoch,
-		Indices: validatorIndices,
-	})
+	duties, err := s.fetchSyncCommitteeDuties(ctx, firstEpoch, validatorIndices)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to fetch sync committee message duties")
+		s.monitor.SyncCommitteePeriodOperation("duties", "failed")
 		return
 	}
-	duties := dutiesResponse.Data
+	s.monitor.SyncCommitteePeriodOperation("duties", "succeeded")
 	log.Trace().Dur("elapsed", time.Since(started)).Int("duties", len(duties)).Msg("Fetched sync committee message duties")
 	if len(duties) == 0 {
 		// No duties; nothing to do.
@@ -84,8 +81,10 @@ func (s *Service) scheduleSyncCommitteeMessages(ctx context.Context,
 	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpochByIndex(ctx, firstEpoch, validatorIndices)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to obtain validating accounts for epoch")
+		s.monitor.SyncCommitteePeriodOperation("accounts", "failed")
 		return
 	}
+	s.monitor.SyncCommitteePeriodOperation("accounts", "succeeded")
 
 	// Now we have the messages we can subscribe to the relevant subnets.
 	log.Trace().
@@ -108,12 +107,14 @@ func (s *Service) scheduleSyncCommitteeMessages(ctx context.Context,
 				}
 			}
 
-			// Schedule for 1.5 slots ahead of time.
+			// Schedule for 1.5 slots ahead of time.  This time can be in the past, for
+			// example at startup, in which case preparation must still take place.
 			prepareJobTime := s.chainTimeService.StartOfSlot(duty.Slot()).Add(-s.slotDuration * 6 / 4)
-			if err := s.scheduler.ScheduleJob(ctx,
+			if err := s.scheduler.ScheduleJobWithPastPolicy(ctx,
 				"Prepare for sync committee messages",
 				fmt.Sprintf("Prepare sync committee messages for slot %d", duty.Slot()),
 				prepareJobTime,
+				scheduler.PastJobRun,
 				s.prepareMessageSyncCommittee,
 				duty,
 			); err != nil {
@@ -122,15 +123,86 @@ func (s *Service) scheduleSyncCommitteeMessages(ctx context.Context,
 			}
 		}(synccommitteemessenger.NewDuty(slot, messageIndices), accounts)
 	}
+	s.monitor.SyncCommitteeValidatorsScheduled(len(messageIndices))
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Scheduled sync committee messages")
 
 	if err := s.syncCommitteesSubscriber.Subscribe(ctx, lastEpoch+1, duties); err != nil {
 		log.Error().Err(err).Msg("Failed to submit sync committee subscribers")
+		s.monitor.SyncCommitteePeriodOperation("subscription", "failed")
 		return
 	}
+	s.monitor.SyncCommitteePeriodOperation("subscription", "succeeded")
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Submitted sync committee subscribers")
 }
 
+// fetchSyncCommitteeDuties fetches the sync committee duties for the given
+// validators.  Validators that are not in the sync committee are legitimately
+// absent from the response, but a response that is internally inconsistent
+// suggests that it is incomplete, in which case the request is retried.
+func (s *Service) fetchSyncCommitteeDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	validatorIndices []phase0.ValidatorIndex,
+) (
+	[]*apiv1.SyncCommitteeDuty,
+	error,
+) {
+	for attempt := 1; ; attempt++ {
+		dutiesResponse, err := s.syncCommitteeDutiesProvider.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkSyncCommitteeDuties(validatorIndices, dutiesResponse.Data)
+		if err == nil {
+			return dutiesResponse.Data, nil
+		}
+		s.monitor.SyncCommitteePeriodOperation("duties", "incomplete")
+		if attempt == syncCommitteeDutiesAttempts {
+			return nil, errors.Wrap(err, "sync committee duties incomplete")
+		}
+		log.Debug().Err(err).Int("attempt", attempt).Msg("Sync committee duties incomplete; retrying")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(s.syncCommitteeDutiesRetryInterval):
+		}
+	}
+}
+
+// checkSyncCommitteeDuties checks that the sync committee duties are
+// consistent with the validators for which they were requested.
+func checkSyncCommitteeDuties(validatorIndices []phase0.ValidatorIndex,
+	duties []*apiv1.SyncCommitteeDuty,
+) error {
+	requested := make(map[phase0.ValidatorIndex]struct{}, len(validatorIndices))
+	for _, validatorIndex := range validatorIndices {
+		requested[validatorIndex] = struct{}{}
+	}
+
+	seen := make(map[phase0.ValidatorIndex]struct{}, len(duties))
+	for _, duty := range duties {
+		if duty == nil {
+			return errors.New("nil duty")
+		}
+		if _, exists := requested[duty.ValidatorIndex]; !exists {
+			return fmt.Errorf("duty for unrequested validator %d", duty.ValidatorIndex)
+		}
+		if _, exists := seen[duty.ValidatorIndex]; exists {
+			return fmt.Errorf("duplicate duty for validator %d", duty.ValidatorIndex)
+		}
+		seen[duty.ValidatorIndex] = struct{}{}
+		if len(duty.ValidatorSyncCommitteeIndices) == 0 {
+			return fmt.Errorf("duty for validator %d has no sync committee indices", duty.ValidatorIndex)
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) prepareMessageSyncCommittee(ctx context.Context, data interface{}) {
 	started := time.Now()
 	duty, ok := data.(*synccommitteemessenger.Duty)
@@ -140,17 +212,23 @@ func (s *Service) prepareMessageSyncCommittee(ctx context.Context, data interfac
 	}
 	log := log.With().Uint64("slot", uint64(s.chainTimeService.CurrentSlot())).Logger()
 
+	if s.signingHeld(duty.Slot()) {
+		return
+	}
+
 	if err := s.syncCommitteeMessenger.Prepare(ctx, duty); err != nil {
 		log.Error().Uint64("sync_committee_slot", uint64(duty.Slot())).Err(err).Msg("Failed to prepare sync committee message")
 		return
 	}
 
-	// At this point we can schedule the message job.
+	// At this point we can schedule the message job.  If preparation finished
+	// after the job time the messages are still of use, so run immediately.
 	jobTime := s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.maxSyncCommitteeMessageDelay)
-	if err := s.scheduler.ScheduleJob(ctx,
+	if err := s.scheduler.ScheduleJobWithPastPolicy(ctx,
 		"Generate sync committee messages",
 		fmt.Sprintf("Sync committee messages for slot %d", duty.Slot()),
 		jobTime,
+		scheduler.PastJobRun,
 		s.messageSyncCommittee,
 		duty,
 	); err != nil {
@@ -170,7 +248,12 @@ func (s *Service) messageSyncCommittee(ctx context.Context, data interface{}) {
 	}
 	log := log.With().Uint64("slot", uint64(s.chainTimeService.CurrentSlot())).Logger()
 
+	if s.signingHeld(duty.Slot()) {
+		return
+	}
+
 	_, err := s.syncCommitteeMessenger.Message(ctx, duty)
+	s.publishDutyOutcome(ctx, "sync committee message", metrics.DutyOutcomeSyncCommitteeMessaged, duty.Slot(), duty.ValidatorIndices(), err)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to submit sync committee message")
 		return
@@ -193,10 +276,13 @@ func (s *Service) messageSyncCommittee(ctx context.Context, data interface{}) {
 			SelectionProofs:  selectionProofs,
 			Accounts:         duty.Accounts(),
 		}
-		if err := s.scheduler.ScheduleJob(ctx,
+		// Aggregating after the aggregation time risks the contribution arriving
+		// too late for the next proposal, so skip it if that time has passed.
+		if err := s.scheduler.ScheduleJobWithPastPolicy(ctx,
 			"Aggregate sync committee messages",
 			fmt.Sprintf("Sync committee aggregation for slot %d", duty.Slot()),
 			s.chainTimeService.StartOfSlot(duty.Slot()).Add(s.syncCommitteeAggregationDelay),
+			scheduler.PastJobSkip,
 			s.syncCommitteeAggregator.Aggregate,
 			aggregatorDuty,
 		); err != nil {
@@ -207,11 +293,56 @@ func (s *Service) messageSyncCommittee(ctx context.Context, data interface{}) {
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Messaged")
 }
 
+// syncCommitteeLookahead returns the first epoch of the next sync committee
+// period if the given epoch is within the preparation window for that period
+// and the period has not already been scheduled.  Any epoch within the window
+// will do, so that validators entering the sync committee at the next period
+// are still scheduled in time if the first epoch of the window is missed.
+func (s *Service) syncCommitteeLookahead(epoch phase0.Epoch) (phase0.Epoch, bool) {
+	nextPeriod := uint64(epoch)/s.epochsPerSyncCommitteePeriod + 1
+	nextPeriodStartEpoch := s.firstEpochOfSyncPeriod(nextPeriod)
+	if nextPeriodStartEpoch <= epoch || uint64(nextPeriodStartEpoch-epoch) > syncCommitteePreparationEpochs {
+		return 0, false
+	}
+
+	s.syncCommitteeLookaheadMu.Lock()
+	defer s.syncCommitteeLookaheadMu.Unlock()
+	if s.syncCommitteeLookaheadPeriod >= nextPeriod {
+		// Already scheduled.
+		return 0, false
+	}
+	s.syncCommitteeLookaheadPeriod = nextPeriod
+
+	return nextPeriodStartEpoch, true
+}
+
+// syncCommitteeMessageSlots returns the first and last slots in which members
+// of the sync committee for the given period generate messages.
+//
+// A sync committee message generated during slot x is included in the block for
+// slot x+1, and is signed by the sync committee for the period containing slot
+// x+1.  As such, a committee whose first slot is x generates its first message
+// during slot x-1, and a committee whose last slot is y generates its last
+// message during slot y-1; a message generated during slot y would be included
+// in a block signed for by the following committee.  If the Altair fork occurs
+// part-way through the period the committee starts at the fork epoch.  A
+// committee that starts at genesis has no slot before its first slot, so
+// generates its first message during its first slot.
+func (s *Service) syncCommitteeMessageSlots(period uint64) (phase0.Slot, phase0.Slot) {
+	firstSlot := s.chainTimeService.FirstSlotOfEpoch(s.firstEpochOfSyncPeriod(period))
+	if firstSlot > 0 {
+		firstSlot--
+	}
+	lastSlot := s.chainTimeService.FirstSlotOfEpoch(s.firstEpochOfSyncPeriod(period+1)) - 2
+
+	return firstSlot, lastSlot
+}
+
 // firstEpochOfSyncPeriod calculates the first epoch of the given sync period.
 func (s *Service) firstEpochOfSyncPeriod(period uint64) phase0.Epoch {
 	epoch := phase0.Epoch(period * s.epochsPerSyncCommitteePeriod)
-	if epoch < s.altairForkEpoch {
-		epoch = s.altairForkEpoch
+	if altairForkEpoch := s.currentAltairForkEpoch(); epoch < altairForkEpoch {
+		epoch = altairForkEpoch
 	}
 	return epoch
 }
@@ -0,0 +1,676 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/scheduler"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	mocksynccommitteeaggregator "github.com/attestantio/vouch/services/synccommitteeaggregator/mock"
+	"github.com/attestantio/vouch/services/synccommitteemessenger"
+	mocksynccommitteemessenger "github.com/attestantio/vouch/services/synccommitteemessenger/mock"
+	"github.com/attestantio/vouch/services/synccommitteesubscriber"
+	mocksynccommitteesubscriber "github.com/attestantio/vouch/services/synccommitteesubscriber/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type syncCommitteePeriodMonitor struct {
+	nullmetrics.Service
+	mu                  sync.Mutex
+	operations          map[string]int
+	validatorsScheduled int
+}
+
+func (m *syncCommitteePeriodMonitor) SyncCommitteePeriodOperation(operation string, result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operations[operation+":"+result]++
+}
+
+func (m *syncCommitteePeriodMonitor) SyncCommitteeValidatorsScheduled(count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.validatorsScheduled += count
+}
+
+type syncCommitteeDutiesProvider struct {
+	err error
+}
+
+func (p *syncCommitteeDutiesProvider) SyncCommitteeDuties(_ context.Context,
+	opts *api.SyncCommitteeDutiesOpts,
+) (
+	*api.Response[[]*apiv1.SyncCommitteeDuty],
+	error,
+) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	duties := make([]*apiv1.SyncCommitteeDuty, 0, len(opts.Indices))
+	for i, index := range opts.Indices {
+		duties = append(duties, &apiv1.SyncCommitteeDuty{
+			ValidatorIndex:                index,
+			ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{phase0.CommitteeIndex(i)},
+		})
+	}
+
+	return &api.Response[[]*apiv1.SyncCommitteeDuty]{
+		Data:     duties,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+type recordingScheduler struct {
+	scheduler.Service
+	mu   sync.Mutex
+	jobs map[string]time.Time
+}
+
+func (s *recordingScheduler) ScheduleJob(_ context.Context,
+	_ string,
+	name string,
+	runtime time.Time,
+	_ scheduler.JobFunc,
+	_ interface{},
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[name] = runtime
+
+	return nil
+}
+
+func (s *recordingScheduler) ScheduleJobWithPastPolicy(ctx context.Context,
+	class string,
+	name string,
+	runtime time.Time,
+	_ scheduler.PastJobPolicy,
+	jobFunc scheduler.JobFunc,
+	data interface{},
+) error {
+	return s.ScheduleJob(ctx, class, name, runtime, jobFunc, data)
+}
+
+func (s *recordingScheduler) jobCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.jobs)
+}
+
+type recordingSyncCommitteesSubscriber struct {
+	mu     sync.Mutex
+	epochs []phase0.Epoch
+	duties []*apiv1.SyncCommitteeDuty
+}
+
+func (s *recordingSyncCommitteesSubscriber) Subscribe(_ context.Context,
+	epoch phase0.Epoch,
+	duties []*apiv1.SyncCommitteeDuty,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.epochs = append(s.epochs, epoch)
+	s.duties = append(s.duties, duties...)
+
+	return nil
+}
+
+type erroringSyncCommitteesSubscriber struct{}
+
+func (*erroringSyncCommitteesSubscriber) Subscribe(_ context.Context,
+	_ phase0.Epoch,
+	_ []*apiv1.SyncCommitteeDuty,
+) error {
+	return errors.New("error")
+}
+
+func TestScheduleSyncCommitteeMessagesMetrics(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	// Genesis is set such that the chain is in epoch 2.
+	genesisTime := time.Now().Add(-2 * 32 * 12 * time.Second)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                string
+		dutiesProvider      *syncCommitteeDutiesProvider
+		subscriber          synccommitteesubscriber.Service
+		validatorIndices    []phase0.ValidatorIndex
+		operations          map[string]int
+		validatorsScheduled int
+	}{
+		{
+			name:             "NoValidators",
+			dutiesProvider:   &syncCommitteeDutiesProvider{},
+			subscriber:       mocksynccommitteesubscriber.New(),
+			validatorIndices: []phase0.ValidatorIndex{},
+			operations:       map[string]int{},
+		},
+		{
+			name:             "DutiesFailed",
+			dutiesProvider:   &syncCommitteeDutiesProvider{err: errors.New("error")},
+			subscriber:       mocksynccommitteesubscriber.New(),
+			validatorIndices: []phase0.ValidatorIndex{1, 2},
+			operations: map[string]int{
+				"duties:failed": 1,
+			},
+		},
+		{
+			name:             "SubscriptionFailed",
+			dutiesProvider:   &syncCommitteeDutiesProvider{},
+			subscriber:       &erroringSyncCommitteesSubscriber{},
+			validatorIndices: []phase0.ValidatorIndex{1, 2},
+			operations: map[string]int{
+				"duties:succeeded":    1,
+				"accounts:succeeded":  1,
+				"subscription:failed": 1,
+			},
+			validatorsScheduled: 2,
+		},
+		{
+			name:             "Good",
+			dutiesProvider:   &syncCommitteeDutiesProvider{},
+			subscriber:       mocksynccommitteesubscriber.New(),
+			validatorIndices: []phase0.ValidatorIndex{1, 2, 3},
+			operations: map[string]int{
+				"duties:succeeded":       1,
+				"accounts:succeeded":     1,
+				"subscription:succeeded": 1,
+			},
+			validatorsScheduled: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			monitor := &syncCommitteePeriodMonitor{
+				operations: make(map[string]int),
+			}
+			s := &Service{
+				monitor:                      monitor,
+				slotDuration:                 12 * time.Second,
+				slotsPerEpoch:                32,
+				epochsPerSyncCommitteePeriod: 4,
+				chainTimeService:             chainTime,
+				syncCommitteeDutiesProvider:  test.dutiesProvider,
+				validatingAccountsProvider:   mockaccountmanager.NewValidatingAccountsProvider(),
+				scheduler:                    mockscheduler.New(),
+				syncCommitteesSubscriber:     test.subscriber,
+			}
+
+			// Schedule for the next period.
+			s.scheduleSyncCommitteeMessages(ctx, 4, test.validatorIndices, false)
+
+			require.Equal(t, test.operations, monitor.operations)
+			require.Equal(t, test.validatorsScheduled, monitor.validatorsScheduled)
+		})
+	}
+}
+
+func TestSyncCommitteeLookahead(t *testing.T) {
+	s := &Service{
+		epochsPerSyncCommitteePeriod: 256,
+	}
+
+	// Outside of the preparation window.
+	_, required := s.syncCommitteeLookahead(100)
+	require.False(t, required)
+
+	// First epoch of the preparation window.
+	epoch, required := s.syncCommitteeLookahead(251)
+	require.True(t, required)
+	require.Equal(t, phase0.Epoch(256), epoch)
+
+	// Already scheduled.
+	_, required = s.syncCommitteeLookahead(252)
+	require.False(t, required)
+
+	// Missed first epoch of the preparation window for the following period.
+	epoch, required = s.syncCommitteeLookahead(509)
+	require.True(t, required)
+	require.Equal(t, phase0.Epoch(512), epoch)
+
+	// Period boundary itself is not look-ahead.
+	_, required = s.syncCommitteeLookahead(512)
+	require.False(t, required)
+}
+
+func TestScheduleSyncCommitteeMessagesNextPeriod(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	// Genesis is set such that the chain is in epoch 3, within the preparation
+	// window for the period starting at epoch 8.
+	genesisTime := time.Now().Add(-3 * 32 * 12 * time.Second)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	jobScheduler := &recordingScheduler{
+		Service: mockscheduler.New(),
+		jobs:    make(map[string]time.Time),
+	}
+	subscriber := &recordingSyncCommitteesSubscriber{}
+	s := &Service{
+		monitor:                      nullmetrics.New(ctx),
+		slotDuration:                 12 * time.Second,
+		slotsPerEpoch:                32,
+		epochsPerSyncCommitteePeriod: 8,
+		chainTimeService:             chainTime,
+		syncCommitteeDutiesProvider:  &syncCommitteeDutiesProvider{},
+		validatingAccountsProvider:   mockaccountmanager.NewValidatingAccountsProvider(),
+		scheduler:                    jobScheduler,
+		syncCommitteesSubscriber:     subscriber,
+	}
+
+	epoch, required := s.syncCommitteeLookahead(chainTime.CurrentEpoch())
+	require.True(t, required)
+	require.Equal(t, phase0.Epoch(8), epoch)
+
+	// Validator 1 enters the sync committee at the start of the next period.
+	s.scheduleSyncCommitteeMessages(ctx, epoch, []phase0.ValidatorIndex{1}, false)
+
+	// Subscription is for the validator, through to the end of the next period.
+	require.Equal(t, []phase0.Epoch{16}, subscriber.epochs)
+	require.Len(t, subscriber.duties, 1)
+	require.Equal(t, phase0.ValidatorIndex(1), subscriber.duties[0].ValidatorIndex)
+
+	// Messages are scheduled from the slot before the period starts to two
+	// slots before the period ends.
+	firstSlot := chainTime.FirstSlotOfEpoch(8) - 1
+	lastSlot := chainTime.FirstSlotOfEpoch(16) - 2
+	require.Eventually(t, func() bool {
+		return jobScheduler.jobCount() == int(lastSlot-firstSlot+1)
+	}, time.Second, 10*time.Millisecond)
+	jobScheduler.mu.Lock()
+	defer jobScheduler.mu.Unlock()
+	runtime, exists := jobScheduler.jobs[fmt.Sprintf("Prepare sync committee messages for slot %d", firstSlot)]
+	require.True(t, exists)
+	require.True(t, runtime.Before(chainTime.StartOfSlot(firstSlot)))
+	_, exists = jobScheduler.jobs[fmt.Sprintf("Prepare sync committee messages for slot %d", firstSlot-1)]
+	require.False(t, exists)
+	_, exists = jobScheduler.jobs[fmt.Sprintf("Prepare sync committee messages for slot %d", lastSlot+1)]
+	require.False(t, exists)
+}
+
+func TestSyncCommitteeMessageSlots(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		altairForkEpoch phase0.Epoch
+		period          uint64
+		firstSlot       phase0.Slot
+		lastSlot        phase0.Slot
+	}{
+		{
+			name:      "GenesisPeriod",
+			period:    0,
+			firstSlot: 0,
+			lastSlot:  126,
+		},
+		{
+			name:      "SecondPeriod",
+			period:    1,
+			firstSlot: 127,
+			lastSlot:  254,
+		},
+		{
+			name:            "ForkMidPeriod",
+			altairForkEpoch: 2,
+			period:          0,
+			firstSlot:       63,
+			lastSlot:        126,
+		},
+		{
+			name:            "ForkAtPeriodStart",
+			altairForkEpoch: 4,
+			period:          1,
+			firstSlot:       127,
+			lastSlot:        254,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				epochsPerSyncCommitteePeriod: 4,
+				chainTimeService:             chainTime,
+				altairForkEpoch:              test.altairForkEpoch,
+			}
+			firstSlot, lastSlot := s.syncCommitteeMessageSlots(test.period)
+			require.Equal(t, test.firstSlot, firstSlot)
+			require.Equal(t, test.lastSlot, lastSlot)
+
+			// The last message of this period must be immediately followed by the
+			// first message of the next period.
+			nextFirstSlot, _ := s.syncCommitteeMessageSlots(test.period + 1)
+			require.Equal(t, lastSlot+1, nextFirstSlot)
+		})
+	}
+}
+
+func TestScheduleSyncCommitteeMessagesPeriodBoundaries(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	tests := []struct {
+		name string
+		// slot is the current slot.
+		slot phase0.Slot
+		// epoch is the epoch for which messages are scheduled.
+		epoch     phase0.Epoch
+		firstSlot phase0.Slot
+		lastSlot  phase0.Slot
+		jobs      int
+	}{
+		{
+			name:      "FirstSlotOfGenesisPeriod",
+			slot:      0,
+			epoch:     0,
+			firstSlot: 0,
+			lastSlot:  126,
+			jobs:      127,
+		},
+		{
+			name:      "FirstSlotOfPeriod",
+			slot:      128,
+			epoch:     4,
+			firstSlot: 128,
+			lastSlot:  254,
+			jobs:      127,
+		},
+		{
+			name:      "PenultimateSlotOfPeriod",
+			slot:      126,
+			epoch:     0,
+			firstSlot: 126,
+			lastSlot:  126,
+			jobs:      1,
+		},
+		{
+			name:  "LastSlotOfPeriod",
+			slot:  127,
+			epoch: 0,
+			jobs:  0,
+		},
+		{
+			name:      "LastSlotOfPeriodNextPeriod",
+			slot:      127,
+			epoch:     4,
+			firstSlot: 127,
+			lastSlot:  254,
+			jobs:      128,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Genesis is set such that the chain is half way through the current slot.
+			genesisTime := time.Now().Add(-time.Duration(test.slot)*12*time.Second - 6*time.Second)
+			chainTime, err := standardchaintime.New(ctx,
+				standardchaintime.WithLogLevel(zerolog.Disabled),
+				standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+				standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+			)
+			require.NoError(t, err)
+			require.Equal(t, test.slot, chainTime.CurrentSlot())
+
+			jobScheduler := &recordingScheduler{
+				Service: mockscheduler.New(),
+				jobs:    make(map[string]time.Time),
+			}
+			s := &Service{
+				monitor:                      nullmetrics.New(ctx),
+				slotDuration:                 12 * time.Second,
+				slotsPerEpoch:                32,
+				epochsPerSyncCommitteePeriod: 4,
+				chainTimeService:             chainTime,
+				syncCommitteeDutiesProvider:  &syncCommitteeDutiesProvider{},
+				validatingAccountsProvider:   mockaccountmanager.NewValidatingAccountsProvider(),
+				scheduler:                    jobScheduler,
+				syncCommitteesSubscriber:     &recordingSyncCommitteesSubscriber{},
+			}
+
+			s.scheduleSyncCommitteeMessages(ctx, test.epoch, []phase0.ValidatorIndex{1}, false)
+
+			require.Eventually(t, func() bool {
+				return jobScheduler.jobCount() == test.jobs
+			}, time.Second, 10*time.Millisecond)
+			// Allow time for any unexpected additional jobs to be scheduled.
+			time.Sleep(50 * time.Millisecond)
+			require.Equal(t, test.jobs, jobScheduler.jobCount())
+			if test.jobs == 0 {
+				return
+			}
+
+			jobScheduler.mu.Lock()
+			defer jobScheduler.mu.Unlock()
+			_, exists := jobScheduler.jobs[fmt.Sprintf("Prepare sync committee messages for slot %d", test.firstSlot)]
+			require.True(t, exists)
+			_, exists = jobScheduler.jobs[fmt.Sprintf("Prepare sync committee messages for slot %d", test.lastSlot)]
+			require.True(t, exists)
+			_, exists = jobScheduler.jobs[fmt.Sprintf("Prepare sync committee messages for slot %d", test.lastSlot+1)]
+			require.False(t, exists)
+		})
+	}
+}
+
+// sequencedSyncCommitteeDutiesProvider returns each of its responses in turn,
+// repeating the last response once all others have been returned.
+type sequencedSyncCommitteeDutiesProvider struct {
+	mu        sync.Mutex
+	responses [][]*apiv1.SyncCommitteeDuty
+	calls     int
+}
+
+func (p *sequencedSyncCommitteeDutiesProvider) SyncCommitteeDuties(_ context.Context,
+	_ *api.SyncCommitteeDutiesOpts,
+) (
+	*api.Response[[]*apiv1.SyncCommitteeDuty],
+	error,
+) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	response := p.responses[min(p.calls, len(p.responses)-1)]
+	p.calls++
+
+	return &api.Response[[]*apiv1.SyncCommitteeDuty]{
+		Data:     response,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestFetchSyncCommitteeDuties(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+
+	validatorIndices := []phase0.ValidatorIndex{1, 2, 3}
+	complete := []*apiv1.SyncCommitteeDuty{
+		{ValidatorIndex: 1, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{10}},
+		{ValidatorIndex: 3, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{30}},
+	}
+	missingIndices := []*apiv1.SyncCommitteeDuty{
+		{ValidatorIndex: 1, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{10}},
+		{ValidatorIndex: 3},
+	}
+	unrequested := []*apiv1.SyncCommitteeDuty{
+		{ValidatorIndex: 1, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{10}},
+		{ValidatorIndex: 4, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{40}},
+	}
+	duplicate := []*apiv1.SyncCommitteeDuty{
+		{ValidatorIndex: 1, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{10}},
+		{ValidatorIndex: 1, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{10}},
+	}
+
+	tests := []struct {
+		name       string
+		responses  [][]*apiv1.SyncCommitteeDuty
+		duties     []*apiv1.SyncCommitteeDuty
+		calls      int
+		operations map[string]int
+		err        string
+	}{
+		{
+			name:       "NotInCommittee",
+			responses:  [][]*apiv1.SyncCommitteeDuty{{}},
+			duties:     []*apiv1.SyncCommitteeDuty{},
+			calls:      1,
+			operations: map[string]int{},
+		},
+		{
+			name:       "SomeNotInCommittee",
+			responses:  [][]*apiv1.SyncCommitteeDuty{complete},
+			duties:     complete,
+			calls:      1,
+			operations: map[string]int{},
+		},
+		{
+			name:      "IncompleteThenComplete",
+			responses: [][]*apiv1.SyncCommitteeDuty{missingIndices, complete},
+			duties:    complete,
+			calls:     2,
+			operations: map[string]int{
+				"duties:incomplete": 1,
+			},
+		},
+		{
+			name:      "UnrequestedThenComplete",
+			responses: [][]*apiv1.SyncCommitteeDuty{unrequested, complete},
+			duties:    complete,
+			calls:     2,
+			operations: map[string]int{
+				"duties:incomplete": 1,
+			},
+		},
+		{
+			name:      "AlwaysIncomplete",
+			responses: [][]*apiv1.SyncCommitteeDuty{duplicate},
+			calls:     syncCommitteeDutiesAttempts,
+			operations: map[string]int{
+				"duties:incomplete": syncCommitteeDutiesAttempts,
+			},
+			err: "sync committee duties incomplete: duplicate duty for validator 1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider := &sequencedSyncCommitteeDutiesProvider{
+				responses: test.responses,
+			}
+			monitor := &syncCommitteePeriodMonitor{
+				operations: make(map[string]int),
+			}
+			s := &Service{
+				monitor:                          monitor,
+				syncCommitteeDutiesProvider:      provider,
+				syncCommitteeDutiesRetryInterval: time.Millisecond,
+			}
+
+			duties, err := s.fetchSyncCommitteeDuties(ctx, 1, validatorIndices)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.duties, duties)
+			}
+			require.Equal(t, test.calls, provider.calls)
+			require.Equal(t, test.operations, monitor.operations)
+		})
+	}
+}
+
+func TestSyncCommitteeAggregationDelay(t *testing.T) {
+	ctx := context.Background()
+	log = zerolog.Nop()
+	chainTime := standbyChainTime(t)
+
+	tests := []struct {
+		name             string
+		aggregationDelay time.Duration
+	}{
+		{
+			name:             "Default",
+			aggregationDelay: 8 * time.Second,
+		},
+		{
+			name:             "Configured",
+			aggregationDelay: 10 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			jobScheduler := &recordingScheduler{
+				Service: mockscheduler.New(),
+				jobs:    make(map[string]time.Time),
+			}
+			s := &Service{
+				chainTimeService:              chainTime,
+				scheduler:                     jobScheduler,
+				syncCommitteeMessenger:        mocksynccommitteemessenger.New(),
+				syncCommitteeAggregator:       mocksynccommitteeaggregator.New(),
+				maxSyncCommitteeMessageDelay:  4 * time.Second,
+				syncCommitteeAggregationDelay: test.aggregationDelay,
+			}
+
+			duty := synccommitteemessenger.NewDuty(101, map[phase0.ValidatorIndex][]phase0.CommitteeIndex{
+				1: {0},
+			})
+			duty.SetAggregatorSubcommittees(1, 0, phase0.BLSSignature{})
+			s.messageSyncCommittee(ctx, duty)
+
+			// The aggregation job runs at the configured offset within the slot,
+			// after the messages to be aggregated have been sent.
+			require.Equal(t, 1, jobScheduler.jobCount())
+			runtime, exists := jobScheduler.jobs["Sync committee aggregation for slot 101"]
+			require.True(t, exists)
+			require.Equal(t, chainTime.StartOfSlot(101).Add(test.aggregationDelay), runtime)
+			require.True(t, runtime.After(chainTime.StartOfSlot(101).Add(s.maxSyncCommitteeMessageDelay)))
+		})
+	}
+}
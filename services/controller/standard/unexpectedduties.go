@@ -0,0 +1,91 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// unexpectedDutiesDrop drops individual unexpected duties, scheduling the remainder.
+	unexpectedDutiesDrop = "drop"
+	// unexpectedDutiesReject drops all duties in a response containing an unexpected duty.
+	unexpectedDutiesReject = "reject"
+)
+
+// unexpectedDutyReason returns the reason that a duty returned by a beacon
+// node is unexpected given the epoch and validators for which duties were
+// requested, or an empty string if the duty is as expected.
+func (s *Service) unexpectedDutyReason(epoch phase0.Epoch,
+	requested map[phase0.ValidatorIndex]struct{},
+	slot phase0.Slot,
+	validatorIndex phase0.ValidatorIndex,
+) string {
+	if s.chainTimeService.SlotToEpoch(slot) != epoch {
+		return "slot not in requested epoch"
+	}
+	if _, exists := requested[validatorIndex]; !exists {
+		return "validator not requested"
+	}
+
+	return ""
+}
+
+// filterUnexpectedDuties removes duties that are unexpected given the epoch
+// and validators for which they were requested.  Under the 'reject' policy a
+// single unexpected duty suggests that the response cannot be trusted, and
+// no duties are returned.
+func filterUnexpectedDuties[T any](s *Service,
+	dutyType string,
+	epoch phase0.Epoch,
+	validatorIndices []phase0.ValidatorIndex,
+	duties []T,
+	dutyDetails func(duty T) (phase0.Slot, phase0.ValidatorIndex),
+) []T {
+	requested := make(map[phase0.ValidatorIndex]struct{}, len(validatorIndices))
+	for _, validatorIndex := range validatorIndices {
+		requested[validatorIndex] = struct{}{}
+	}
+
+	filtered := make([]T, 0, len(duties))
+	for _, duty := range duties {
+		slot, validatorIndex := dutyDetails(duty)
+		reason := s.unexpectedDutyReason(epoch, requested, slot, validatorIndex)
+		if reason == "" {
+			filtered = append(filtered, duty)
+			continue
+		}
+
+		if s.unexpectedDutiesPolicy == unexpectedDutiesReject {
+			log.Warn().
+				Str("duty_type", dutyType).
+				Uint64("epoch", uint64(epoch)).
+				Uint64("duty_slot", uint64(slot)).
+				Uint64("validator_index", uint64(validatorIndex)).
+				Str("reason", reason).
+				Int("duties", len(duties)).
+				Msg("Unexpected duty returned by beacon node; rejecting all duties in response")
+			return make([]T, 0)
+		}
+		log.Warn().
+			Str("duty_type", dutyType).
+			Uint64("epoch", uint64(epoch)).
+			Uint64("duty_slot", uint64(slot)).
+			Uint64("validator_index", uint64(validatorIndex)).
+			Str("reason", reason).
+			Msg("Unexpected duty returned by beacon node; ignoring")
+	}
+
+	return filtered
+}
@@ -0,0 +1,149 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterUnexpectedAttesterDuties(t *testing.T) {
+	chainTime := standbyChainTime(t)
+
+	// Duties are requested for epoch 4 (slots 128 to 159) for validators 1 and 2.
+	epoch := phase0.Epoch(4)
+	validatorIndices := []phase0.ValidatorIndex{1, 2}
+
+	expected := []*apiv1.AttesterDuty{
+		{ValidatorIndex: 1, Slot: 130},
+		{ValidatorIndex: 2, Slot: 159},
+	}
+	// Validator 2 has a duty in the previous epoch.
+	previousEpoch := []*apiv1.AttesterDuty{
+		{ValidatorIndex: 1, Slot: 130},
+		{ValidatorIndex: 2, Slot: 127},
+	}
+	// Validator 1 has a duty in the next epoch.
+	nextEpoch := []*apiv1.AttesterDuty{
+		{ValidatorIndex: 1, Slot: 160},
+		{ValidatorIndex: 2, Slot: 159},
+	}
+	// Validator 3 was not requested.
+	unrequested := []*apiv1.AttesterDuty{
+		{ValidatorIndex: 1, Slot: 130},
+		{ValidatorIndex: 3, Slot: 131},
+	}
+
+	tests := []struct {
+		name       string
+		policy     string
+		duties     []*apiv1.AttesterDuty
+		validators []phase0.ValidatorIndex
+	}{
+		{
+			name:       "Empty",
+			policy:     unexpectedDutiesDrop,
+			duties:     []*apiv1.AttesterDuty{},
+			validators: []phase0.ValidatorIndex{},
+		},
+		{
+			name:       "ExpectedDrop",
+			policy:     unexpectedDutiesDrop,
+			duties:     expected,
+			validators: []phase0.ValidatorIndex{1, 2},
+		},
+		{
+			name:       "ExpectedReject",
+			policy:     unexpectedDutiesReject,
+			duties:     expected,
+			validators: []phase0.ValidatorIndex{1, 2},
+		},
+		{
+			name:       "PreviousEpochDrop",
+			policy:     unexpectedDutiesDrop,
+			duties:     previousEpoch,
+			validators: []phase0.ValidatorIndex{1},
+		},
+		{
+			name:       "PreviousEpochReject",
+			policy:     unexpectedDutiesReject,
+			duties:     previousEpoch,
+			validators: []phase0.ValidatorIndex{},
+		},
+		{
+			name:       "NextEpochDrop",
+			policy:     unexpectedDutiesDrop,
+			duties:     nextEpoch,
+			validators: []phase0.ValidatorIndex{2},
+		},
+		{
+			name:       "NextEpochReject",
+			policy:     unexpectedDutiesReject,
+			duties:     nextEpoch,
+			validators: []phase0.ValidatorIndex{},
+		},
+		{
+			name:       "UnrequestedDrop",
+			policy:     unexpectedDutiesDrop,
+			duties:     unrequested,
+			validators: []phase0.ValidatorIndex{1},
+		},
+		{
+			name:       "UnrequestedReject",
+			policy:     unexpectedDutiesReject,
+			duties:     unrequested,
+			validators: []phase0.ValidatorIndex{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				chainTimeService:       chainTime,
+				unexpectedDutiesPolicy: test.policy,
+			}
+			filtered := filterUnexpectedDuties(s, dutyTypeAttestation, epoch, validatorIndices, test.duties,
+				func(duty *apiv1.AttesterDuty) (phase0.Slot, phase0.ValidatorIndex) {
+					return duty.Slot, duty.ValidatorIndex
+				},
+			)
+			validators := make([]phase0.ValidatorIndex, 0, len(filtered))
+			for _, duty := range filtered {
+				validators = append(validators, duty.ValidatorIndex)
+			}
+			require.Equal(t, test.validators, validators)
+		})
+	}
+}
+
+func TestFilterUnexpectedProposerDuties(t *testing.T) {
+	s := &Service{
+		chainTimeService:       standbyChainTime(t),
+		unexpectedDutiesPolicy: unexpectedDutiesDrop,
+	}
+
+	// Duties are requested for epoch 4, but one is returned for epoch 5.
+	filtered := filterUnexpectedDuties(s, dutyTypeProposal, 4, []phase0.ValidatorIndex{1, 2}, []*apiv1.ProposerDuty{
+		{ValidatorIndex: 1, Slot: 140},
+		{ValidatorIndex: 2, Slot: 170},
+	},
+		func(duty *apiv1.ProposerDuty) (phase0.Slot, phase0.ValidatorIndex) {
+			return duty.Slot, duty.ValidatorIndex
+		},
+	)
+	require.Equal(t, []*apiv1.ProposerDuty{{ValidatorIndex: 1, Slot: 140}}, filtered)
+}
@@ -0,0 +1,178 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exportsink
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// export is the exported summary of duty outcomes.
+type export struct {
+	// Since is the time from which outcomes have been summarised.
+	Since time.Time `json:"since"`
+	// Timestamp is the time at which the summary was exported.
+	Timestamp time.Time `json:"timestamp"`
+	// Validators is the performance of each validator, in index order.
+	Validators []*validatorPerformance `json:"validators"`
+}
+
+// validatorPerformance is the exported performance of a validator.
+// Rates are the fraction of duties that were carried out, and are 0 if the
+// validator had no duties of that type.  They do not show whether the
+// resultant messages were included on chain.
+type validatorPerformance struct {
+	ValidatorIndex              phase0.ValidatorIndex `json:"validator_index"`
+	Attestations                uint64                `json:"attestations"`
+	MissedAttestations          uint64                `json:"missed_attestations"`
+	AttestationRate             float64               `json:"attestation_rate"`
+	Proposals                   uint64                `json:"proposals"`
+	MissedProposals             uint64                `json:"missed_proposals"`
+	SyncCommitteeMessages       uint64                `json:"sync_committee_messages"`
+	MissedSyncCommitteeMessages uint64                `json:"missed_sync_committee_messages"`
+	SyncCommitteeMessageRate    float64               `json:"sync_committee_message_rate"`
+}
+
+// csvHeader is the header row of the CSV export.
+var csvHeader = []string{
+	"validator_index",
+	"attestations",
+	"missed_attestations",
+	"attestation_rate",
+	"proposals",
+	"missed_proposals",
+	"sync_committee_messages",
+	"missed_sync_committee_messages",
+	"sync_committee_message_rate",
+}
+
+// export writes the summary to the export file.
+// The file is replaced atomically, so readers never see a partial export.
+func (s *Service) export() error {
+	data, err := s.exportData(time.Now())
+	if err != nil {
+		return err
+	}
+
+	tmpPath := fmt.Sprintf("%s.tmp", s.path)
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write export file")
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return errors.Wrap(err, "failed to move export file into place")
+	}
+	log.Trace().Str("path", s.path).Msg("Exported duty outcome summary")
+
+	return nil
+}
+
+// exportData returns the summary in the configured format.
+func (s *Service) exportData(timestamp time.Time) ([]byte, error) {
+	res := &export{
+		Since:      s.since,
+		Timestamp:  timestamp,
+		Validators: s.performances(),
+	}
+
+	switch s.format {
+	case formatCSV:
+		return exportCSV(res)
+	default:
+		data, err := json.Marshal(res)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal summary")
+		}
+
+		return append(data, '\n'), nil
+	}
+}
+
+// performances returns the performance of each validator, in index order.
+func (s *Service) performances() []*validatorPerformance {
+	s.summaryMu.Lock()
+	res := make([]*validatorPerformance, 0, len(s.summary))
+	for validatorIndex, summary := range s.summary {
+		res = append(res, &validatorPerformance{
+			ValidatorIndex:              validatorIndex,
+			Attestations:                summary.attestations,
+			MissedAttestations:          summary.missedAttestations,
+			AttestationRate:             rate(summary.attestations, summary.missedAttestations),
+			Proposals:                   summary.proposals,
+			MissedProposals:             summary.missedProposals,
+			SyncCommitteeMessages:       summary.syncCommitteeMessages,
+			MissedSyncCommitteeMessages: summary.missedSyncCommitteeMessages,
+			SyncCommitteeMessageRate:    rate(summary.syncCommitteeMessages, summary.missedSyncCommitteeMessages),
+		})
+	}
+	s.summaryMu.Unlock()
+
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i].ValidatorIndex < res[j].ValidatorIndex
+	})
+
+	return res
+}
+
+// exportCSV returns the summary as CSV, one row per validator.
+func exportCSV(summary *export) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	writer := csv.NewWriter(buf)
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, errors.Wrap(err, "failed to write CSV header")
+	}
+	for _, performance := range summary.Validators {
+		if err := writer.Write([]string{
+			strconv.FormatUint(uint64(performance.ValidatorIndex), 10),
+			strconv.FormatUint(performance.Attestations, 10),
+			strconv.FormatUint(performance.MissedAttestations, 10),
+			strconv.FormatFloat(performance.AttestationRate, 'f', 4, 64),
+			strconv.FormatUint(performance.Proposals, 10),
+			strconv.FormatUint(performance.MissedProposals, 10),
+			strconv.FormatUint(performance.SyncCommitteeMessages, 10),
+			strconv.FormatUint(performance.MissedSyncCommitteeMessages, 10),
+			strconv.FormatFloat(performance.SyncCommitteeMessageRate, 'f', 4, 64),
+		}); err != nil {
+			return nil, errors.Wrap(err, "failed to write CSV row")
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, errors.Wrap(err, "failed to flush CSV")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// rate returns the fraction of duties that were carried out.
+func rate(carriedOut uint64, missed uint64) float64 {
+	if carriedOut+missed == 0 {
+		return 0
+	}
+
+	return float64(carriedOut) / float64(carriedOut+missed)
+}
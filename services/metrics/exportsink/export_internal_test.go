@@ -0,0 +1,106 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exportsink
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportData(t *testing.T) {
+	ctx := context.Background()
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timestamp := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	outcomes := []*metrics.DutyOutcome{
+		{
+			Type:             metrics.DutyOutcomeAttested,
+			Duty:             "attestation",
+			ValidatorIndices: []phase0.ValidatorIndex{5, 3},
+		},
+		{
+			Type:             metrics.DutyOutcomeAttested,
+			Duty:             "attestation",
+			ValidatorIndices: []phase0.ValidatorIndex{3},
+		},
+		{
+			Type:             metrics.DutyOutcomeMissed,
+			Duty:             "attestation",
+			ValidatorIndices: []phase0.ValidatorIndex{3},
+		},
+		{
+			Type:             metrics.DutyOutcomeMissed,
+			Duty:             "proposal",
+			ValidatorIndices: []phase0.ValidatorIndex{5},
+		},
+		{
+			Type:             metrics.DutyOutcomeSyncCommitteeMessaged,
+			Duty:             "sync committee message",
+			ValidatorIndices: []phase0.ValidatorIndex{5},
+		},
+		{
+			Type:             metrics.DutyOutcomeMissed,
+			Duty:             "sync committee message",
+			ValidatorIndices: []phase0.ValidatorIndex{5},
+		},
+		{
+			Type:             metrics.DutyOutcomeAttested,
+			Duty:             "unknown",
+			ValidatorIndices: []phase0.ValidatorIndex{7},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:   "JSON",
+			format: formatJSON,
+			expected: `{"since":"2024-01-01T00:00:00Z","timestamp":"2024-01-01T01:00:00Z","validators":[{"validator_index":"3","attestations":2,"missed_attestations":1,"attestation_rate":0.6666666666666666,"proposals":0,"missed_proposals":0,"sync_committee_messages":0,"missed_sync_committee_messages":0,"sync_committee_message_rate":0},{"validator_index":"5","attestations":1,"missed_attestations":0,"attestation_rate":1,"proposals":0,"missed_proposals":1,"sync_committee_messages":1,"missed_sync_committee_messages":1,"sync_committee_message_rate":0.5}]}
+`,
+		},
+		{
+			name:   "CSV",
+			format: formatCSV,
+			expected: `validator_index,attestations,missed_attestations,attestation_rate,proposals,missed_proposals,sync_committee_messages,missed_sync_committee_messages,sync_committee_message_rate
+3,2,1,0.6667,0,0,0,0,0.0000
+5,1,0,1.0000,0,1,1,1,0.5000
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				format:  test.format,
+				since:   since,
+				summary: make(map[phase0.ValidatorIndex]*validatorSummary),
+			}
+			for _, outcome := range outcomes {
+				s.DutyOutcome(ctx, outcome)
+			}
+
+			data, err := s.exportData(timestamp)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, string(data))
+		})
+	}
+}
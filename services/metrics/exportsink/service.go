@@ -0,0 +1,130 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exportsink is a duty outcome sink that summarises the outcomes of
+// duties for each validator, and periodically exports the summary to a file.
+package exportsink
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a duty outcome sink that exports a per-validator summary.
+type Service struct {
+	path      string
+	format    string
+	since     time.Time
+	summary   map[phase0.ValidatorIndex]*validatorSummary
+	summaryMu sync.Mutex
+}
+
+// validatorSummary is the summary of the duty outcomes for a validator.
+type validatorSummary struct {
+	attestations                uint64
+	missedAttestations          uint64
+	proposals                   uint64
+	missedProposals             uint64
+	syncCommitteeMessages       uint64
+	missedSyncCommitteeMessages uint64
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new export duty outcome sink.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "dutyoutcomes").Str("impl", "export").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		path:    parameters.path,
+		format:  parameters.format,
+		since:   time.Now(),
+		summary: make(map[phase0.ValidatorIndex]*validatorSummary),
+	}
+
+	go s.run(ctx, parameters.interval)
+
+	return s, nil
+}
+
+// run exports the summary at each interval, and a final time on exit.
+func (s *Service) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := s.export(); err != nil {
+				log.Warn().Err(err).Msg("Failed to export duty outcome summary on exit")
+			}
+
+			return
+		case <-ticker.C:
+			if err := s.export(); err != nil {
+				log.Warn().Err(err).Msg("Failed to export duty outcome summary")
+			}
+		}
+	}
+}
+
+// DutyOutcome is called with the outcome of a duty.
+func (s *Service) DutyOutcome(_ context.Context, outcome *metrics.DutyOutcome) {
+	switch outcome.Duty {
+	case "attestation", "proposal", "sync committee message":
+	default:
+		log.Trace().Str("duty", outcome.Duty).Msg("Unknown duty; ignoring")
+		return
+	}
+	missed := outcome.Type == metrics.DutyOutcomeMissed
+
+	s.summaryMu.Lock()
+	defer s.summaryMu.Unlock()
+	for _, validatorIndex := range outcome.ValidatorIndices {
+		summary, exists := s.summary[validatorIndex]
+		if !exists {
+			summary = &validatorSummary{}
+			s.summary[validatorIndex] = summary
+		}
+		switch {
+		case outcome.Duty == "attestation" && missed:
+			summary.missedAttestations++
+		case outcome.Duty == "attestation":
+			summary.attestations++
+		case outcome.Duty == "proposal" && missed:
+			summary.missedProposals++
+		case outcome.Duty == "proposal":
+			summary.proposals++
+		case missed:
+			summary.missedSyncCommitteeMessages++
+		default:
+			summary.syncCommitteeMessages++
+		}
+	}
+}
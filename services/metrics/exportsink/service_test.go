@@ -0,0 +1,139 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exportsink_test
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/metrics/exportsink"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []exportsink.Parameter
+		err    string
+	}{
+		{
+			name: "PathMissing",
+			params: []exportsink.Parameter{
+				exportsink.WithLogLevel(zerolog.Disabled),
+				exportsink.WithInterval(time.Minute),
+			},
+			err: "problem with parameters: no path specified",
+		},
+		{
+			name: "FormatBad",
+			params: []exportsink.Parameter{
+				exportsink.WithLogLevel(zerolog.Disabled),
+				exportsink.WithPath(filepath.Join(t.TempDir(), "summary.xml")),
+				exportsink.WithFormat("xml"),
+				exportsink.WithInterval(time.Minute),
+			},
+			err: "problem with parameters: format must be json or csv",
+		},
+		{
+			name: "IntervalMissing",
+			params: []exportsink.Parameter{
+				exportsink.WithLogLevel(zerolog.Disabled),
+				exportsink.WithPath(filepath.Join(t.TempDir(), "summary.json")),
+			},
+			err: "problem with parameters: interval must be greater than 0",
+		},
+		{
+			name: "Good",
+			params: []exportsink.Parameter{
+				exportsink.WithLogLevel(zerolog.Disabled),
+				exportsink.WithPath(filepath.Join(t.TempDir(), "summary.json")),
+				exportsink.WithInterval(time.Minute),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := exportsink.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "summary.csv")
+	s, err := exportsink.New(ctx,
+		exportsink.WithLogLevel(zerolog.Disabled),
+		exportsink.WithPath(path),
+		exportsink.WithFormat("csv"),
+		exportsink.WithInterval(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	s.DutyOutcome(ctx, &metrics.DutyOutcome{
+		Type:             metrics.DutyOutcomeAttested,
+		Duty:             "attestation",
+		Slot:             1,
+		ValidatorIndices: []phase0.ValidatorIndex{2, 1},
+	})
+	s.DutyOutcome(ctx, &metrics.DutyOutcome{
+		Type:             metrics.DutyOutcomeMissed,
+		Duty:             "attestation",
+		Slot:             2,
+		ValidatorIndices: []phase0.ValidatorIndex{1},
+	})
+	s.DutyOutcome(ctx, &metrics.DutyOutcome{
+		Type:             metrics.DutyOutcomeProposed,
+		Duty:             "proposal",
+		Slot:             3,
+		ValidatorIndices: []phase0.ValidatorIndex{2},
+	})
+
+	// Exports are periodic, so wait for one that contains all of the outcomes.
+	expected := [][]string{
+		{"validator_index", "attestations", "missed_attestations", "attestation_rate", "proposals", "missed_proposals", "sync_committee_messages", "missed_sync_committee_messages", "sync_committee_message_rate"},
+		{"1", "1", "1", "0.5000", "0", "0", "0", "0", "0.0000"},
+		{"2", "1", "0", "1.0000", "1", "0", "0", "0", "0.0000"},
+	}
+	require.Eventually(t, func() bool {
+		file, err := os.Open(path)
+		if err != nil {
+			return false
+		}
+		defer file.Close()
+		records, err := csv.NewReader(file).ReadAll()
+		if err != nil {
+			return false
+		}
+
+		return reflect.DeepEqual(expected, records)
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,65 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logsink is a duty outcome sink that writes outcomes to the log.
+package logsink
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a duty outcome sink that logs outcomes.
+type Service struct{}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new log duty outcome sink.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "dutyoutcomes").Str("impl", "log").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{}, nil
+}
+
+// DutyOutcome is called with the outcome of a duty.
+func (*Service) DutyOutcome(_ context.Context, outcome *metrics.DutyOutcome) {
+	e := log.Debug()
+	if outcome.Type == metrics.DutyOutcomeMissed {
+		e = log.Info().Err(outcome.Err)
+	}
+
+	validatorIndices := make([]uint64, len(outcome.ValidatorIndices))
+	for i := range outcome.ValidatorIndices {
+		validatorIndices[i] = uint64(outcome.ValidatorIndices[i])
+	}
+
+	e.Str("duty", outcome.Duty).
+		Str("outcome", string(outcome.Type)).
+		Uint64("slot", uint64(outcome.Slot)).
+		Uints64("validator_indices", validatorIndices).
+		Msg("Duty outcome")
+}
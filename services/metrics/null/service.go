@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/metrics"
 )
 
 // Service is a metrics service that drops metrics.
@@ -52,6 +53,21 @@ func (*Service) NewEpoch() {}
 // BlockDelay provides the delay between the start of a slot and vouch receiving its block.
 func (*Service) BlockDelay(_ uint, _ time.Duration) {}
 
+// ActiveValidators sets the number of active validators managed by vouch.
+func (*Service) ActiveValidators(_ int) {}
+
+// ActiveValidatorsDecreased is called when the number of active validators managed by vouch decreases.
+func (*Service) ActiveValidatorsDecreased() {}
+
+// SyncCommitteePeriodOperation is called when an operation to schedule sync committee duties for a period completes.
+func (*Service) SyncCommitteePeriodOperation(_ string, _ string) {}
+
+// SyncCommitteeValidatorsScheduled is called when validators are scheduled for sync committee duties for a period.
+func (*Service) SyncCommitteeValidatorsScheduled(_ int) {}
+
+// UpcomingProposal is called when notifying of an upcoming block proposal.
+func (*Service) UpcomingProposal() {}
+
 // BeaconBlockProposalCompleted is called when a block proposal process has completed.
 func (*Service) BeaconBlockProposalCompleted(_ time.Time, _ phase0.Slot, _ string) {}
 
@@ -69,6 +85,9 @@ func (*Service) AttestationAggregationCompleted(_ time.Time, _ phase0.Slot, _ st
 // AttestationAggregationCoverage measures the attestation ratio of the attestation aggregation.
 func (*Service) AttestationAggregationCoverage(_ float64) {}
 
+// AttestationAggregationRejected is called when a beacon node rejects an aggregate attestation.
+func (*Service) AttestationAggregationRejected(_ string) {}
+
 // BeaconCommitteeSubscriptionCompleted is called when an beacon committee subscription process has completed.
 func (*Service) BeaconCommitteeSubscriptionCompleted(_ time.Time, _ string) {}
 
@@ -89,6 +108,10 @@ func (*Service) ClientOperation(_ string, _ string, _ bool, _ time.Duration) {
 func (*Service) StrategyOperation(_ string, _ string, _ string, _ time.Duration) {
 }
 
+// SignerOperation is called when a signing operation completes.
+func (*Service) SignerOperation(_ string, _ bool, _ time.Duration) {
+}
+
 // SyncCommitteeAggregationsCompleted is called when a sync committee aggregation process has completed.
 func (*Service) SyncCommitteeAggregationsCompleted(_ time.Time, _ phase0.Slot, _ int, _ string) {
 }
@@ -108,3 +131,6 @@ func (*Service) SyncCommitteeSubscriptionCompleted(_ time.Time, _ string) {
 // SyncCommitteeSubscribers sets the number of sync committees to which our validators are subscribed.
 func (*Service) SyncCommitteeSubscribers(_ int) {
 }
+
+// DutyOutcome is called with the outcome of a duty.
+func (*Service) DutyOutcome(_ context.Context, _ *metrics.DutyOutcome) {}
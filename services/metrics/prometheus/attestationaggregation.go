@@ -116,6 +116,21 @@ func (s *Service) setupAttestationAggregationMetrics() error {
 		}
 	}
 
+	s.attestationAggregationRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "attestationaggregation",
+		Name:      "rejections_total",
+		Help:      "The number of aggregate attestations rejected by beacon nodes.",
+	}, []string{"reason"})
+	if err := prometheus.Register(s.attestationAggregationRejections); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.attestationAggregationRejections = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -136,3 +151,8 @@ func (s *Service) AttestationAggregationCompleted(started time.Time, slot phase0
 func (s *Service) AttestationAggregationCoverage(frac float64) {
 	s.attestationAggregationCoverageRatio.Observe(frac)
 }
+
+// AttestationAggregationRejected is called when a beacon node rejects an aggregate attestation.
+func (s *Service) AttestationAggregationRejected(reason string) {
+	s.attestationAggregationRejections.WithLabelValues(reason).Inc()
+}
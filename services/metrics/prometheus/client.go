@@ -103,6 +103,7 @@ func (s *Service) ClientOperation(provider string, operation string, succeeded b
 	} else {
 		s.clientOperationCounter.WithLabelValues(provider, operation, "failed").Add(1)
 	}
+	s.recordStreak(provider, succeeded)
 }
 
 // StrategyOperation provides a generic monitor for strategy operations.
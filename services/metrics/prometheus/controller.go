@@ -64,6 +64,76 @@ func (s *Service) setupControllerMetrics() error {
 		}
 	}
 
+	s.activeValidators = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Name:      "active_validators",
+		Help:      "The number of active validators managed by vouch.",
+	})
+	if err := prometheus.Register(s.activeValidators); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.activeValidators = alreadyRegisteredError.ExistingCollector.(prometheus.Gauge)
+		} else {
+			return err
+		}
+	}
+
+	s.activeValidatorsDecreases = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Name:      "active_validators_decreases_total",
+		Help:      "The number of times the number of active validators managed by vouch has decreased.",
+	})
+	if err := prometheus.Register(s.activeValidatorsDecreases); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.activeValidatorsDecreases = alreadyRegisteredError.ExistingCollector.(prometheus.Counter)
+		} else {
+			return err
+		}
+	}
+
+	s.syncCommitteePeriodOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Name:      "sync_committee_period_operations_total",
+		Help:      "The number of operations carried out when scheduling sync committee duties for a period.",
+	}, []string{"operation", "result"})
+	if err := prometheus.Register(s.syncCommitteePeriodOperations); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.syncCommitteePeriodOperations = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return err
+		}
+	}
+
+	s.syncCommitteeValidatorsScheduled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Name:      "sync_committee_validators_scheduled_total",
+		Help:      "The number of validators scheduled for sync committee duties.",
+	})
+	if err := prometheus.Register(s.syncCommitteeValidatorsScheduled); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.syncCommitteeValidatorsScheduled = alreadyRegisteredError.ExistingCollector.(prometheus.Counter)
+		} else {
+			return err
+		}
+	}
+
+	s.upcomingProposals = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Name:      "upcoming_proposals_total",
+		Help:      "The number of notifications of upcoming block proposals.",
+	})
+	if err := prometheus.Register(s.upcomingProposals); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.upcomingProposals = alreadyRegisteredError.ExistingCollector.(prometheus.Counter)
+		} else {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -76,3 +146,28 @@ func (s *Service) NewEpoch() {
 func (s *Service) BlockDelay(epochSlot uint, delay time.Duration) {
 	s.blockReceiptDelay.WithLabelValues(fmt.Sprintf("%d", epochSlot)).Observe(delay.Seconds())
 }
+
+// ActiveValidators sets the number of active validators managed by vouch.
+func (s *Service) ActiveValidators(count int) {
+	s.activeValidators.Set(float64(count))
+}
+
+// ActiveValidatorsDecreased is called when the number of active validators managed by vouch decreases.
+func (s *Service) ActiveValidatorsDecreased() {
+	s.activeValidatorsDecreases.Inc()
+}
+
+// SyncCommitteePeriodOperation is called when an operation to schedule sync committee duties for a period completes.
+func (s *Service) SyncCommitteePeriodOperation(operation string, result string) {
+	s.syncCommitteePeriodOperations.WithLabelValues(operation, result).Inc()
+}
+
+// SyncCommitteeValidatorsScheduled is called when validators are scheduled for sync committee duties for a period.
+func (s *Service) SyncCommitteeValidatorsScheduled(count int) {
+	s.syncCommitteeValidatorsScheduled.Add(float64(count))
+}
+
+// UpcomingProposal is called when notifying of an upcoming block proposal.
+func (s *Service) UpcomingProposal() {
+	s.upcomingProposals.Inc()
+}
@@ -0,0 +1,45 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"errors"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func (s *Service) setupDutyOutcomeMetrics() error {
+	s.dutyOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Name:      "duty_outcomes_total",
+		Help:      "The number of validator duty outcomes.",
+	}, []string{"duty", "outcome"})
+	if err := prometheus.Register(s.dutyOutcomes); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.dutyOutcomes = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DutyOutcome is called with the outcome of a duty.
+func (s *Service) DutyOutcome(_ context.Context, outcome *metrics.DutyOutcome) {
+	s.dutyOutcomes.WithLabelValues(outcome.Duty, string(outcome.Type)).Add(float64(len(outcome.ValidatorIndices)))
+}
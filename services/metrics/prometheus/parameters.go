@@ -21,10 +21,11 @@ import (
 )
 
 type parameters struct {
-	logLevel     zerolog.Level
-	address      string
-	chainTime    chaintime.Service
-	createServer bool
+	logLevel               zerolog.Level
+	address                string
+	chainTime              chaintime.Service
+	createServer           bool
+	failureStreakThreshold uint64
 }
 
 // Parameter is the interface for service parameters.
@@ -66,10 +67,19 @@ func WithCreateServer(createServer bool) Parameter {
 	})
 }
 
+// WithFailureStreakThreshold sets the number of consecutive failed operations
+// for a provider after which a warning is logged.  0 disables the warning.
+func WithFailureStreakThreshold(threshold uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.failureStreakThreshold = threshold
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:               zerolog.GlobalLevel(),
+		failureStreakThreshold: 5,
 	}
 	for _, p := range params {
 		if params != nil {
@@ -16,6 +16,7 @@ package prometheus
 import (
 	"context"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/attestantio/vouch/services/chaintime"
@@ -34,8 +35,13 @@ type Service struct {
 	schedulerJobsCancelled *prometheus.CounterVec
 	schedulerJobsStarted   *prometheus.CounterVec
 
-	epochsProcessed   prometheus.Counter
-	blockReceiptDelay *prometheus.HistogramVec
+	epochsProcessed                  prometheus.Counter
+	blockReceiptDelay                *prometheus.HistogramVec
+	activeValidators                 prometheus.Gauge
+	activeValidatorsDecreases        prometheus.Counter
+	syncCommitteePeriodOperations    *prometheus.CounterVec
+	syncCommitteeValidatorsScheduled prometheus.Counter
+	upcomingProposals                prometheus.Counter
 
 	attestationProcessTimer      prometheus.Histogram
 	attestationProcessRequests   *prometheus.CounterVec
@@ -47,6 +53,7 @@ type Service struct {
 	attestationAggregationCoverageRatio     prometheus.Histogram
 	attestationAggregationMarkTimer         prometheus.Histogram
 	attestationAggregationProcessLatestSlot prometheus.Gauge
+	attestationAggregationRejections        *prometheus.CounterVec
 
 	syncCommitteeMessageProcessTimer      prometheus.Histogram
 	syncCommitteeMessageProcessRequests   *prometheus.CounterVec
@@ -74,6 +81,17 @@ type Service struct {
 	clientOperationTimer     *prometheus.HistogramVec
 	strategyOperationCounter *prometheus.CounterVec
 	strategyOperationTimer   *prometheus.HistogramVec
+
+	signerOperationCounter *prometheus.CounterVec
+	signerOperationTimer   *prometheus.HistogramVec
+
+	dutyOutcomes *prometheus.CounterVec
+
+	failureStreakThreshold uint64
+	streaksMu              sync.Mutex
+	streaks                map[string]*providerStreak
+	clientOperationStreaks *prometheus.GaugeVec
+	clientFailureStreaks   *prometheus.CounterVec
 }
 
 // module-wide log.
@@ -93,7 +111,9 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 	}
 
 	s := &Service{
-		chainTime: parameters.chainTime,
+		chainTime:              parameters.chainTime,
+		failureStreakThreshold: parameters.failureStreakThreshold,
+		streaks:                make(map[string]*providerStreak),
 	}
 
 	if err := s.setupSchedulerMetrics(); err != nil {
@@ -126,6 +146,15 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 	if err := s.setupClientMetrics(); err != nil {
 		return nil, errors.Wrap(err, "failed to set up client metrics")
 	}
+	if err := s.setupStreakMetrics(); err != nil {
+		return nil, errors.Wrap(err, "failed to set up streak metrics")
+	}
+	if err := s.setupSignerMetrics(); err != nil {
+		return nil, errors.Wrap(err, "failed to set up signer metrics")
+	}
+	if err := s.setupDutyOutcomeMetrics(); err != nil {
+		return nil, errors.Wrap(err, "failed to set up duty outcome metrics")
+	}
 
 	if parameters.createServer {
 		go func() {
@@ -0,0 +1,70 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func (s *Service) setupSignerMetrics() error {
+	s.signerOperationCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "signer_operation",
+		Name:      "requests_total",
+		Help:      "The number of signing operations.",
+	}, []string{"operation", "result"})
+	if err := prometheus.Register(s.signerOperationCounter); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.signerOperationCounter = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return err
+		}
+	}
+
+	s.signerOperationTimer = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "signer_operation",
+		Name:      "duration_seconds",
+		Help:      "The time vouch spends in signing operations.",
+		Buckets: []float64{
+			0.01, 0.02, 0.03, 0.04, 0.05, 0.06, 0.07, 0.08, 0.09, 0.1,
+			0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0,
+			1.5, 2.0, 2.5, 3.0, 3.5, 4.0,
+		},
+	}, []string{"operation"})
+	if err := prometheus.Register(s.signerOperationTimer); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.signerOperationTimer = alreadyRegisteredError.ExistingCollector.(*prometheus.HistogramVec)
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SignerOperation registers a signing operation.
+func (s *Service) SignerOperation(operation string, succeeded bool, duration time.Duration) {
+	if succeeded {
+		s.signerOperationCounter.WithLabelValues(operation, "succeeded").Add(1)
+		s.signerOperationTimer.WithLabelValues(operation).Observe(duration.Seconds())
+	} else {
+		s.signerOperationCounter.WithLabelValues(operation, "failed").Add(1)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// providerStreak is the current run of identical results for a provider.
+type providerStreak struct {
+	succeeded bool
+	length    uint64
+}
+
+func (s *Service) setupStreakMetrics() error {
+	s.clientOperationStreaks = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "client_operation",
+		Name:      "streak",
+		Help:      "The number of consecutive client operations with the same result.",
+	}, []string{"provider", "result"})
+	if err := prometheus.Register(s.clientOperationStreaks); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.clientOperationStreaks = alreadyRegisteredError.ExistingCollector.(*prometheus.GaugeVec)
+		} else {
+			return err
+		}
+	}
+
+	s.clientFailureStreaks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "client_operation",
+		Name:      "failure_streaks_total",
+		Help:      "The number of times consecutive client operation failures reached the threshold.",
+	}, []string{"provider"})
+	if err := prometheus.Register(s.clientFailureStreaks); err != nil {
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if ok := errors.As(err, &alreadyRegisteredError); ok {
+			s.clientFailureStreaks = alreadyRegisteredError.ExistingCollector.(*prometheus.CounterVec)
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordStreak updates the streak for the provider with the result of an operation.
+func (s *Service) recordStreak(provider string, succeeded bool) {
+	s.streaksMu.Lock()
+	defer s.streaksMu.Unlock()
+
+	streak, exists := s.streaks[provider]
+	if !exists {
+		streak = &providerStreak{}
+		s.streaks[provider] = streak
+	}
+
+	if streak.succeeded != succeeded || streak.length == 0 {
+		if succeeded && streak.length >= s.failureStreakThreshold && s.failureStreakThreshold > 0 {
+			log.Info().Str("provider", provider).Uint64("failures", streak.length).Msg("Provider succeeded after failure streak")
+		}
+		streak.succeeded = succeeded
+		streak.length = 0
+	}
+	streak.length++
+
+	if succeeded {
+		s.clientOperationStreaks.WithLabelValues(provider, "succeeded").Set(float64(streak.length))
+		s.clientOperationStreaks.WithLabelValues(provider, "failed").Set(0)
+		return
+	}
+
+	s.clientOperationStreaks.WithLabelValues(provider, "failed").Set(float64(streak.length))
+	s.clientOperationStreaks.WithLabelValues(provider, "succeeded").Set(0)
+	if streak.length == s.failureStreakThreshold {
+		log.Warn().Str("provider", provider).Uint64("failures", streak.length).Msg("Provider failure streak reached threshold")
+		s.clientFailureStreaks.WithLabelValues(provider).Inc()
+	}
+}
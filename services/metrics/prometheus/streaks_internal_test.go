@@ -0,0 +1,86 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/testing/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func gaugeValue(t *testing.T, gauge prometheus.Gauge) float64 {
+	t.Helper()
+	metric := &dto.Metric{}
+	require.NoError(t, gauge.Write(metric))
+
+	return metric.GetGauge().GetValue()
+}
+
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	t.Helper()
+	metric := &dto.Metric{}
+	require.NoError(t, counter.Write(metric))
+
+	return metric.GetCounter().GetValue()
+}
+
+func TestStreaks(t *testing.T) {
+	ctx := context.Background()
+
+	capture := logger.NewLogCapture()
+	s, err := New(ctx,
+		WithLogLevel(zerolog.TraceLevel),
+		WithAddress("localhost:12345"),
+		WithFailureStreakThreshold(3),
+	)
+	require.NoError(t, err)
+
+	provider := "streak test"
+	results := []struct {
+		succeeded bool
+		succeeds  float64
+		failures  float64
+		alerts    float64
+	}{
+		{succeeded: true, succeeds: 1, failures: 0, alerts: 0},
+		{succeeded: true, succeeds: 2, failures: 0, alerts: 0},
+		{succeeded: false, succeeds: 0, failures: 1, alerts: 0},
+		{succeeded: false, succeeds: 0, failures: 2, alerts: 0},
+		{succeeded: false, succeeds: 0, failures: 3, alerts: 1},
+		{succeeded: false, succeeds: 0, failures: 4, alerts: 1},
+		{succeeded: true, succeeds: 1, failures: 0, alerts: 1},
+		{succeeded: false, succeeds: 0, failures: 1, alerts: 1},
+		{succeeded: false, succeeds: 0, failures: 2, alerts: 1},
+		{succeeded: false, succeeds: 0, failures: 3, alerts: 2},
+	}
+	for i, result := range results {
+		s.ClientOperation(provider, "test", result.succeeded, time.Millisecond)
+		require.Equal(t, result.succeeds, gaugeValue(t, s.clientOperationStreaks.WithLabelValues(provider, "succeeded")), "succeeded streak at %d", i)
+		require.Equal(t, result.failures, gaugeValue(t, s.clientOperationStreaks.WithLabelValues(provider, "failed")), "failed streak at %d", i)
+		require.Equal(t, result.alerts, counterValue(t, s.clientFailureStreaks.WithLabelValues(provider)), "failure streaks at %d", i)
+	}
+
+	require.True(t, capture.HasLog(map[string]any{
+		"message":  "Provider failure streak reached threshold",
+		"provider": provider,
+		"failures": 3,
+	}))
+	capture.AssertHasEntry(t, "Provider succeeded after failure streak")
+}
@@ -15,6 +15,7 @@
 package metrics
 
 import (
+	"context"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -44,6 +45,16 @@ type ControllerMonitor interface {
 	NewEpoch()
 	// BlockDelay provides the delay between the start of a slot and vouch receiving its block.
 	BlockDelay(epochSlot uint, delay time.Duration)
+	// ActiveValidators sets the number of active validators managed by vouch.
+	ActiveValidators(count int)
+	// ActiveValidatorsDecreased is called when the number of active validators managed by vouch decreases.
+	ActiveValidatorsDecreased()
+	// SyncCommitteePeriodOperation is called when an operation to schedule sync committee duties for a period completes.
+	SyncCommitteePeriodOperation(operation string, result string)
+	// SyncCommitteeValidatorsScheduled is called when validators are scheduled for sync committee duties for a period.
+	SyncCommitteeValidatorsScheduled(count int)
+	// UpcomingProposal is called when notifying of an upcoming block proposal.
+	UpcomingProposal()
 }
 
 // BeaconBlockProposalMonitor provides methods to monitor the block proposal process.
@@ -68,6 +79,9 @@ type AttestationAggregationMonitor interface {
 
 	// AttestationAggregationCoverage measures the attestation ratio of the attestation aggregation.
 	AttestationAggregationCoverage(frac float64)
+
+	// AttestationAggregationRejected is called when a beacon node rejects an aggregate attestation.
+	AttestationAggregationRejected(reason string)
 }
 
 // SyncCommitteeMessageMonitor provides methods to monitor the sync committee message process.
@@ -121,4 +135,42 @@ type ClientMonitor interface {
 type ValidatorsManagerMonitor interface{}
 
 // SignerMonitor provides methods to monitor signers.
-type SignerMonitor interface{}
+type SignerMonitor interface {
+	// SignerOperation is called when a signing operation completes.
+	SignerOperation(operation string, succeeded bool, duration time.Duration)
+}
+
+// DutyOutcomeType is the type of a duty outcome.
+type DutyOutcomeType string
+
+const (
+	// DutyOutcomeAttested is the outcome of a successful attestation.
+	DutyOutcomeAttested DutyOutcomeType = "attested"
+	// DutyOutcomeProposed is the outcome of a successful beacon block proposal.
+	DutyOutcomeProposed DutyOutcomeType = "proposed"
+	// DutyOutcomeSyncCommitteeMessaged is the outcome of a successful sync committee message.
+	DutyOutcomeSyncCommitteeMessaged DutyOutcomeType = "sync committee messaged"
+	// DutyOutcomeMissed is the outcome of any duty that was not carried out.
+	DutyOutcomeMissed DutyOutcomeType = "missed"
+)
+
+// DutyOutcome is the outcome of a duty.
+type DutyOutcome struct {
+	// Type is the type of the outcome.
+	Type DutyOutcomeType
+	// Duty is the name of the duty, for example "attestation".
+	Duty string
+	// Slot is the slot of the duty.
+	Slot phase0.Slot
+	// ValidatorIndices are the validators carrying out the duty.
+	ValidatorIndices []phase0.ValidatorIndex
+	// Err is the reason a duty was missed, if known.
+	Err error
+}
+
+// DutyOutcomeSink receives the outcomes of duties.
+type DutyOutcomeSink interface {
+	// DutyOutcome is called with the outcome of a duty.
+	// It is called synchronously, so must not block.
+	DutyOutcome(ctx context.Context, outcome *DutyOutcome)
+}
@@ -16,6 +16,7 @@ package mock
 import (
 	"context"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/proposalpreparer"
 )
 
@@ -31,3 +32,8 @@ func New() proposalpreparer.Service {
 func (s *Service) UpdatePreparations(_ context.Context) error {
 	return nil
 }
+
+// PrepareProposer submits the preparation for a single validator to the beacon nodes.
+func (s *Service) PrepareProposer(_ context.Context, _ phase0.Slot, _ phase0.ValidatorIndex) error {
+	return nil
+}
@@ -15,10 +15,16 @@ package proposalpreparer
 
 import (
 	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
 // Service is the proposal preparer service.
 type Service interface {
 	// UpdatePreparations updates the preparations for validators on the beacon nodes.
 	UpdatePreparations(ctx context.Context) error
+
+	// PrepareProposer submits the preparation for a single validator to the beacon nodes
+	// ahead of its proposal at the given slot, so that they can start building a payload.
+	PrepareProposer(ctx context.Context, slot phase0.Slot, validatorIndex phase0.ValidatorIndex) error
 }
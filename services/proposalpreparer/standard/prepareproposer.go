@@ -0,0 +1,74 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PrepareProposer submits the preparation for a single validator to the beacon nodes
+// ahead of its proposal at the given slot, so that they can start building a payload.
+func (s *Service) PrepareProposer(ctx context.Context, slot phase0.Slot, validatorIndex phase0.ValidatorIndex) error {
+	ctx, span := otel.Tracer("attestantio.vouch.services.proposalpreparer.standard").Start(ctx, "PrepareProposer", trace.WithAttributes(
+		attribute.Int64("slot", int64(slot)),
+		attribute.Int64("validator_index", int64(validatorIndex)),
+	))
+	defer span.End()
+
+	started := time.Now()
+
+	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpochByIndex(ctx,
+		s.chainTimeService.SlotToEpoch(slot),
+		[]phase0.ValidatorIndex{validatorIndex},
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validating account")
+	}
+	account, exists := accounts[validatorIndex]
+	if !exists {
+		return fmt.Errorf("no validating account for validator %d", validatorIndex)
+	}
+
+	pubkey := util.ValidatorPubkey(account)
+	proposerConfig, err := s.executionConfigProvider.ProposerConfig(ctx, account, pubkey)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain proposer configuration")
+	}
+	if proposerConfig == nil {
+		return errors.New("obtained nil proposer configuration")
+	}
+
+	proposalPreparations := []*apiv1.ProposalPreparation{
+		{
+			ValidatorIndex: validatorIndex,
+			FeeRecipient:   proposerConfig.FeeRecipient,
+		},
+	}
+	if failed := s.submitProposalPreparations(ctx, proposalPreparations); failed == len(s.proposalPreparationsSubmitters) && failed > 0 {
+		return errors.New("failed to submit proposer preparation to any beacon node")
+	}
+	log.Trace().Uint64("slot", uint64(slot)).Uint64("validator_index", uint64(validatorIndex)).Dur("elapsed", time.Since(started)).Msg("Submitted proposer preparation")
+
+	return nil
+}
@@ -95,6 +95,19 @@ func (s *Service) updateProposalPreparations(ctx context.Context,
 	))
 	defer span.End()
 
+	if failed := s.submitProposalPreparations(ctx, proposalPreparations); failed > 0 {
+		proposalPreparationCompleted(started, epoch, "failed")
+	} else {
+		log.Trace().Dur("elapsed", time.Since(started)).Msg("Submitted proposal preparations")
+		proposalPreparationCompleted(started, epoch, "succeeded")
+	}
+}
+
+// submitProposalPreparations submits the proposal preparations to all beacon nodes,
+// returning the number of beacon nodes to which the submission failed.
+func (s *Service) submitProposalPreparations(ctx context.Context,
+	proposalPreparations []*apiv1.ProposalPreparation,
+) int {
 	failed := 0
 	for _, proposalPreparationsSubmitter := range s.proposalPreparationsSubmitters {
 		if err := proposalPreparationsSubmitter.SubmitProposalPreparations(ctx, proposalPreparations); err != nil {
@@ -109,10 +122,5 @@ func (s *Service) updateProposalPreparations(ctx context.Context,
 		}
 	}
 
-	if failed > 0 {
-		proposalPreparationCompleted(started, epoch, "failed")
-	} else {
-		log.Trace().Dur("elapsed", time.Since(started)).Msg("Submitted proposal preparations")
-		proposalPreparationCompleted(started, epoch, "succeeded")
-	}
+	return failed
 }
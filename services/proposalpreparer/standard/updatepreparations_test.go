@@ -15,18 +15,31 @@ package standard_test
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
 	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
 	mockblockrelay "github.com/attestantio/vouch/services/blockrelay/mock"
 	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
 	"github.com/attestantio/vouch/services/proposalpreparer/standard"
 	"github.com/attestantio/vouch/testing/logger"
+	"github.com/attestantio/vouch/testutil"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 func TestUpdatePreparations(t *testing.T) {
@@ -90,3 +103,112 @@ func TestUpdatePreparations(t *testing.T) {
 		})
 	}
 }
+
+// recordingPreparationsSubmitter records the proposal preparations it is sent.
+type recordingPreparationsSubmitter struct {
+	mu           sync.Mutex
+	preparations []*apiv1.ProposalPreparation
+}
+
+func (s *recordingPreparationsSubmitter) SubmitProposalPreparations(_ context.Context, preparations []*apiv1.ProposalPreparation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.preparations = append(s.preparations, preparations...)
+
+	return nil
+}
+
+func (s *recordingPreparationsSubmitter) recipients() map[phase0.ValidatorIndex]bellatrix.ExecutionAddress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recipients := make(map[phase0.ValidatorIndex]bellatrix.ExecutionAddress, len(s.preparations))
+	for _, preparation := range s.preparations {
+		recipients[preparation.ValidatorIndex] = preparation.FeeRecipient
+	}
+
+	return recipients
+}
+
+// feeRecipientsProvider provides a fee recipient per validator public key.
+type feeRecipientsProvider struct {
+	mockblockrelay.Service
+	feeRecipients map[phase0.BLSPubKey]bellatrix.ExecutionAddress
+}
+
+func (p *feeRecipientsProvider) ProposerConfig(_ context.Context,
+	_ e2wtypes.Account,
+	pubkey phase0.BLSPubKey,
+) (
+	*beaconblockproposer.ProposerConfig,
+	error,
+) {
+	return &beaconblockproposer.ProposerConfig{
+		FeeRecipient: p.feeRecipients[pubkey],
+	}, nil
+}
+
+func TestUpdatePreparationsFeeRecipients(t *testing.T) {
+	ctx := context.Background()
+
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	testWallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, testWallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	executionConfigProvider := &feeRecipientsProvider{
+		feeRecipients: make(map[phase0.BLSPubKey]bellatrix.ExecutionAddress),
+	}
+	keys := []string{
+		"0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+		"0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000",
+	}
+	expected := make(map[phase0.ValidatorIndex]bellatrix.ExecutionAddress)
+	for i, key := range keys {
+		account, err := testWallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx,
+			fmt.Sprintf("Interop %d", i),
+			testutil.HexToBytes(key),
+			[]byte("pass"),
+		)
+		require.NoError(t, err)
+		index := phase0.ValidatorIndex(i + 1)
+		validatingAccountsProvider.AddAccount(index, account)
+
+		var pubkey phase0.BLSPubKey
+		copy(pubkey[:], account.PublicKey().Marshal())
+		feeRecipient := bellatrix.ExecutionAddress{byte(i + 1)}
+		executionConfigProvider.feeRecipients[pubkey] = feeRecipient
+		expected[index] = feeRecipient
+	}
+
+	submitter := &recordingPreparationsSubmitter{}
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithChainTimeService(chainTime),
+		standard.WithValidatingAccountsProvider(validatingAccountsProvider),
+		standard.WithProposalPreparationsSubmitters([]eth2client.ProposalPreparationsSubmitter{submitter}),
+		standard.WithExecutionConfigProvider(executionConfigProvider),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, s.UpdatePreparations(ctx))
+	// Submission takes place in the background.
+	require.Eventually(t, func() bool {
+		return len(submitter.recipients()) == len(expected)
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, expected, submitter.recipients())
+}
@@ -15,6 +15,7 @@ package advanced
 
 import (
 	"errors"
+	"time"
 
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
@@ -22,8 +23,10 @@ import (
 )
 
 type parameters struct {
-	logLevel zerolog.Level
-	monitor  metrics.SchedulerMonitor
+	logLevel           zerolog.Level
+	monitor            metrics.SchedulerMonitor
+	maxJobDelay        time.Duration
+	overdueSkipClasses []string
 }
 
 // Parameter is the interface for service parameters.
@@ -51,6 +54,23 @@ func WithMonitor(monitor metrics.SchedulerMonitor) Parameter {
 	})
 }
 
+// WithMaxJobDelay sets the maximum delay past its scheduled time after which
+// a one-off job will no longer be run.  0 means that jobs always run.
+func WithMaxJobDelay(delay time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxJobDelay = delay
+	})
+}
+
+// WithOverdueSkipClasses sets the classes of one-off job that are skipped if
+// they are triggered more than the maximum job delay past their scheduled time.
+// Jobs of other classes, for example block proposals, always run.
+func WithOverdueSkipClasses(classes []string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.overdueSkipClasses = classes
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -66,6 +86,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.monitor == nil {
 		return nil, errors.New("no monitor specified")
 	}
+	if parameters.maxJobDelay < 0 {
+		return nil, errors.New("max job delay cannot be negative")
+	}
 
 	return &parameters, nil
 }
@@ -16,6 +16,7 @@ package advanced
 import (
 	"context"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/attestantio/vouch/services/metrics"
@@ -24,12 +25,23 @@ import (
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
 	"github.com/sasha-s/go-deadlock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/atomic"
 )
 
 // module-wide log.
 var log zerolog.Logger
 
+// overdueWindow is the period of time within which multiple overdue jobs are
+// considered to be part of the same cluster.
+const overdueWindow = time.Second
+
+// overdueDriftThreshold is the number of overdue jobs in a single cluster at
+// which the scheduler is considered to have drifted.
+const overdueDriftThreshold = 3
+
 // job contains control points for a job.
 type job struct {
 	// stateLock is required for active or finalised.
@@ -45,9 +57,15 @@ type job struct {
 // the state of each job, in an attempt to ensure additional robustness in the face
 // of high concurrent load.
 type Service struct {
-	monitor   metrics.SchedulerMonitor
-	jobs      map[string]*job
-	jobsMutex deadlock.RWMutex
+	monitor            metrics.SchedulerMonitor
+	jobs               map[string]*job
+	jobsMutex          deadlock.RWMutex
+	maxJobDelay        time.Duration
+	overdueSkipClasses map[string]struct{}
+
+	overdueMu          sync.Mutex
+	overdueWindowStart time.Time
+	overdueJobs        int
 }
 
 // New creates a new scheduling service.
@@ -63,13 +81,21 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		log = log.Level(parameters.logLevel)
 	}
 
+	overdueSkipClasses := make(map[string]struct{}, len(parameters.overdueSkipClasses))
+	for _, class := range parameters.overdueSkipClasses {
+		overdueSkipClasses[class] = struct{}{}
+	}
+
 	return &Service{
-		jobs:    make(map[string]*job),
-		monitor: parameters.monitor,
+		jobs:               make(map[string]*job),
+		monitor:            parameters.monitor,
+		maxJobDelay:        parameters.maxJobDelay,
+		overdueSkipClasses: overdueSkipClasses,
 	}, nil
 }
 
 // ScheduleJob schedules a one-off job for a given time.
+// A job scheduled for the past runs immediately.
 // Note that if the parent context is cancelled the job wil not run.
 func (s *Service) ScheduleJob(ctx context.Context,
 	class string,
@@ -77,6 +103,20 @@ func (s *Service) ScheduleJob(ctx context.Context,
 	runtime time.Time,
 	jobFunc scheduler.JobFunc,
 	data interface{},
+) error {
+	return s.ScheduleJobWithPastPolicy(ctx, class, name, runtime, scheduler.PastJobRun, jobFunc, data)
+}
+
+// ScheduleJobWithPastPolicy schedules a one-off job for a given time,
+// handling a time in the past according to the policy.
+// Note that if the parent context is cancelled the job wil not run.
+func (s *Service) ScheduleJobWithPastPolicy(ctx context.Context,
+	class string,
+	name string,
+	runtime time.Time,
+	policy scheduler.PastJobPolicy,
+	jobFunc scheduler.JobFunc,
+	data interface{},
 ) error {
 	if name == "" {
 		return scheduler.ErrNoJobName
@@ -84,6 +124,10 @@ func (s *Service) ScheduleJob(ctx context.Context,
 	if jobFunc == nil {
 		return scheduler.ErrNoJobFunc
 	}
+	if policy == scheduler.PastJobSkip && time.Until(runtime) < 0 {
+		log.Debug().Str("job", name).Time("scheduled", runtime).Msg("Job scheduled for the past; not running")
+		return nil
+	}
 
 	s.jobsMutex.Lock()
 	_, exists := s.jobs[name]
@@ -100,6 +144,8 @@ func (s *Service) ScheduleJob(ctx context.Context,
 	s.jobsMutex.Unlock()
 	s.monitor.JobScheduled(class)
 
+	checkOverdue := s.overdueCheckRequired(class, runtime)
+
 	log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Scheduled job")
 	go func() {
 		select {
@@ -121,7 +167,7 @@ func (s *Service) ScheduleJob(ctx context.Context,
 			// If we receive this signal the job has already been deleted from the jobs list so no need to
 			// do so again here.
 			s.monitor.JobStartedOnSignal(class)
-			jobFunc(ctx, data)
+			runJobFunc(ctx, class, name, runtime, jobFunc, data)
 			log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Job complete")
 			finaliseJob(job)
 			job.active.Store(false)
@@ -134,10 +180,17 @@ func (s *Service) ScheduleJob(ctx context.Context,
 			s.jobsMutex.Lock()
 			delete(s.jobs, name)
 			s.jobsMutex.Unlock()
+			if checkOverdue {
+				if delay := time.Since(runtime); jobOverdue(delay, s.maxJobDelay) {
+					s.skipOverdueJob(class, name, runtime, delay)
+					finaliseJob(job)
+					break
+				}
+			}
 			log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Timer triggered; job running")
 			job.active.Store(true)
 			s.monitor.JobStartedOnTimer(class)
-			jobFunc(ctx, data)
+			runJobFunc(ctx, class, name, runtime, jobFunc, data)
 			log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Job complete")
 			job.active.Store(false)
 			finaliseJob(job)
@@ -224,7 +277,7 @@ func (s *Service) SchedulePeriodicJob(ctx context.Context,
 			case <-job.runCh:
 				log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Run triggered; job running")
 				s.monitor.JobStartedOnSignal(class)
-				jobFunc(ctx, jobData)
+				runJobFunc(ctx, class, name, runtime, jobFunc, jobData)
 				log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Job complete")
 				job.active.Store(false)
 			case <-time.After(time.Until(runtime)):
@@ -235,7 +288,7 @@ func (s *Service) SchedulePeriodicJob(ctx context.Context,
 				job.active.Store(true)
 				log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Timer triggered; job running")
 				s.monitor.JobStartedOnTimer(class)
-				jobFunc(ctx, jobData)
+				runJobFunc(ctx, class, name, runtime, jobFunc, jobData)
 				log.Trace().Str("job", name).Time("scheduled", runtime).Msg("Job complete")
 				job.active.Store(false)
 			}
@@ -354,6 +407,25 @@ func (s *Service) CancelJobs(ctx context.Context, prefix string) {
 	}
 }
 
+// runJobFunc runs a job function within its own span, so that the work
+// carried out by the job is traced back to the job that scheduled it.
+func runJobFunc(ctx context.Context,
+	class string,
+	name string,
+	runtime time.Time,
+	jobFunc scheduler.JobFunc,
+	data interface{},
+) {
+	ctx, span := otel.Tracer("attestantio.vouch.services.scheduler.advanced").Start(ctx, "Job", trace.WithAttributes(
+		attribute.String("class", class),
+		attribute.String("job", name),
+		attribute.Int64("delay_ms", time.Since(runtime).Milliseconds()),
+	))
+	defer span.End()
+
+	jobFunc(ctx, data)
+}
+
 // finaliseJob tidies up a job that is no longer in use.
 func finaliseJob(job *job) {
 	job.stateLock.Lock()
@@ -385,3 +457,51 @@ func (*Service) runJob(_ context.Context, job *job) error {
 
 	return nil
 }
+
+// overdueCheckRequired returns true if a one-off job of the given class
+// scheduled for the given time should be skipped if it becomes overdue.
+// Only jobs of the configured classes, which are no longer useful once their
+// slot has passed, are skipped; others such as block proposals always run.
+// Only jobs scheduled for the future can become overdue; jobs scheduled for
+// the past are expected to run immediately.
+func (s *Service) overdueCheckRequired(class string, runtime time.Time) bool {
+	if s.maxJobDelay == 0 {
+		return false
+	}
+	if _, exists := s.overdueSkipClasses[class]; !exists {
+		return false
+	}
+
+	return time.Until(runtime) > 0
+}
+
+// jobOverdue returns true if a job triggered with the given delay past its
+// scheduled time should no longer be run.
+func jobOverdue(delay time.Duration, maxDelay time.Duration) bool {
+	return maxDelay > 0 && delay > maxDelay
+}
+
+// skipOverdueJob handles a job that has been triggered too late to be run.
+func (s *Service) skipOverdueJob(class string, name string, runtime time.Time, delay time.Duration) {
+	log.Warn().Str("job", name).Time("scheduled", runtime).Dur("delay", delay).Msg("Job overdue; not running")
+	s.monitor.JobCancelled(class)
+
+	if s.noteOverdueJob(time.Now()) == overdueDriftThreshold {
+		log.Warn().Int("overdue_jobs", overdueDriftThreshold).Msg("Multiple jobs overdue; scheduler has drifted, possibly due to a long pause")
+	}
+}
+
+// noteOverdueJob records an overdue job at the given time, and returns the
+// number of overdue jobs seen within the current window.
+func (s *Service) noteOverdueJob(now time.Time) int {
+	s.overdueMu.Lock()
+	defer s.overdueMu.Unlock()
+
+	if now.Sub(s.overdueWindowStart) > overdueWindow {
+		s.overdueWindowStart = now
+		s.overdueJobs = 0
+	}
+	s.overdueJobs++
+
+	return s.overdueJobs
+}
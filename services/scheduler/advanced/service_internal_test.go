@@ -0,0 +1,157 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advanced
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/testing/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobOverdue(t *testing.T) {
+	tests := []struct {
+		name     string
+		delay    time.Duration
+		maxDelay time.Duration
+		overdue  bool
+	}{
+		{
+			name:  "Disabled",
+			delay: time.Hour,
+		},
+		{
+			name:     "OnTime",
+			delay:    time.Millisecond,
+			maxDelay: time.Second,
+		},
+		{
+			name:     "AtLimit",
+			delay:    time.Second,
+			maxDelay: time.Second,
+		},
+		{
+			name:     "Overdue",
+			delay:    1500 * time.Millisecond,
+			maxDelay: time.Second,
+			overdue:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.overdue, jobOverdue(test.delay, test.maxDelay))
+		})
+	}
+}
+
+func TestOverdueJobCluster(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(ctx,
+		WithMonitor(&nullmetrics.Service{}),
+		WithMaxJobDelay(time.Second),
+	)
+	require.NoError(t, err)
+
+	// Simulate a long pause, after which a cluster of jobs all fire together.
+	pauseEnd := time.Now()
+	counts := make([]int, 0)
+	for i := range 5 {
+		counts = append(counts, s.noteOverdueJob(pauseEnd.Add(time.Duration(i)*time.Millisecond)))
+	}
+	require.Equal(t, []int{1, 2, 3, 4, 5}, counts)
+
+	// A later overdue job is not part of the same cluster.
+	require.Equal(t, 1, s.noteOverdueJob(pauseEnd.Add(time.Minute)))
+}
+
+func TestSkipOverdueJob(t *testing.T) {
+	ctx := context.Background()
+	capture := logger.NewLogCapture()
+	s, err := New(ctx,
+		WithMonitor(&nullmetrics.Service{}),
+		WithMaxJobDelay(time.Second),
+	)
+	require.NoError(t, err)
+
+	runtime := time.Now().Add(-5 * time.Second)
+	for range overdueDriftThreshold {
+		s.skipOverdueJob("Attest", "Attest slot", runtime, 5*time.Second)
+	}
+	capture.AssertHasEntry(t, "Job overdue; not running")
+	capture.AssertHasEntry(t, "Multiple jobs overdue; scheduler has drifted, possibly due to a long pause")
+}
+
+func TestOverdueCheckRequired(t *testing.T) {
+	ctx := context.Background()
+
+	future := time.Now().Add(time.Minute)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		params   []Parameter
+		class    string
+		runtime  time.Time
+		required bool
+	}{
+		{
+			name:    "Disabled",
+			class:   "Attest",
+			runtime: future,
+			params: []Parameter{
+				WithOverdueSkipClasses([]string{"Attest"}),
+			},
+		},
+		{
+			name:     "Attest",
+			class:    "Attest",
+			runtime:  future,
+			required: true,
+			params: []Parameter{
+				WithMaxJobDelay(time.Second),
+				WithOverdueSkipClasses([]string{"Attest"}),
+			},
+		},
+		{
+			name:    "AttestPast",
+			class:   "Attest",
+			runtime: past,
+			params: []Parameter{
+				WithMaxJobDelay(time.Second),
+				WithOverdueSkipClasses([]string{"Attest"}),
+			},
+		},
+		{
+			name:    "Propose",
+			class:   "Propose",
+			runtime: future,
+			params: []Parameter{
+				WithMaxJobDelay(time.Second),
+				WithOverdueSkipClasses([]string{"Attest"}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := New(ctx, append([]Parameter{WithMonitor(&nullmetrics.Service{})}, test.params...)...)
+			require.NoError(t, err)
+			require.Equal(t, test.required, s.overdueCheckRequired(test.class, test.runtime))
+		})
+	}
+}
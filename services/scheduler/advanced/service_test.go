@@ -29,6 +29,10 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNew(t *testing.T) {
@@ -48,6 +52,19 @@ func TestNew(t *testing.T) {
 				advanced.WithLogLevel(zerolog.Disabled),
 			},
 		},
+		{
+			name: "MaxJobDelayNegative",
+			options: []advanced.Parameter{
+				advanced.WithMaxJobDelay(-1 * time.Second),
+			},
+			err: "problem with parameters: max job delay cannot be negative",
+		},
+		{
+			name: "GoodMaxJobDelay",
+			options: []advanced.Parameter{
+				advanced.WithMaxJobDelay(time.Second),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -63,6 +80,86 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestPastJobWithMaxJobDelay(t *testing.T) {
+	ctx := context.Background()
+	s, err := advanced.New(ctx,
+		advanced.WithLogLevel(zerolog.Disabled),
+		advanced.WithMonitor(&nullmetrics.Service{}),
+		advanced.WithMaxJobDelay(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run uint32
+	runFunc := func(ctx context.Context, data interface{}) {
+		atomic.AddUint32(&run, 1)
+	}
+
+	// A job scheduled in the past is not considered overdue, and runs immediately.
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job", time.Now().Add(-time.Minute), runFunc, nil))
+	time.Sleep(time.Duration(50) * time.Millisecond)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&run))
+}
+
+func TestPastJobPolicy(t *testing.T) {
+	ctx := context.Background()
+	s, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled), advanced.WithMonitor(&nullmetrics.Service{}))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	var run uint32
+	runFunc := func(ctx context.Context, data interface{}) {
+		atomic.AddUint32(&run, 1)
+	}
+
+	// A past job with the skip policy is not run, and is not an error.
+	require.NoError(t, s.ScheduleJobWithPastPolicy(ctx, "Test", "Skipped job", time.Now().Add(-time.Minute), scheduler.PastJobSkip, runFunc, nil))
+	time.Sleep(time.Duration(50) * time.Millisecond)
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&run))
+	require.False(t, s.JobExists(ctx, "Skipped job"))
+
+	// A past job with the run policy runs immediately.
+	require.NoError(t, s.ScheduleJobWithPastPolicy(ctx, "Test", "Run job", time.Now().Add(-time.Minute), scheduler.PastJobRun, runFunc, nil))
+	time.Sleep(time.Duration(50) * time.Millisecond)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&run))
+
+	// A future job with the skip policy runs as normal.
+	require.NoError(t, s.ScheduleJobWithPastPolicy(ctx, "Test", "Future job", time.Now().Add(20*time.Millisecond), scheduler.PastJobSkip, runFunc, nil))
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&run))
+	time.Sleep(time.Duration(50) * time.Millisecond)
+	assert.Equal(t, uint32(2), atomic.LoadUint32(&run))
+}
+
+func TestOverdueJobClasses(t *testing.T) {
+	ctx := context.Background()
+	s, err := advanced.New(ctx,
+		advanced.WithLogLevel(zerolog.Disabled),
+		advanced.WithMonitor(&nullmetrics.Service{}),
+		// Any delay at all in triggering a job makes it overdue.
+		advanced.WithMaxJobDelay(time.Nanosecond),
+		advanced.WithOverdueSkipClasses([]string{"Attest"}),
+	)
+	require.NoError(t, err)
+
+	var attestRun uint32
+	attestFunc := func(_ context.Context, _ interface{}) {
+		atomic.AddUint32(&attestRun, 1)
+	}
+	var proposeRun uint32
+	proposeFunc := func(_ context.Context, _ interface{}) {
+		atomic.AddUint32(&proposeRun, 1)
+	}
+
+	runtime := time.Now().Add(10 * time.Millisecond)
+	require.NoError(t, s.ScheduleJob(ctx, "Attest", "Attest job", runtime, attestFunc, nil))
+	require.NoError(t, s.ScheduleJob(ctx, "Propose", "Propose job", runtime, proposeFunc, nil))
+	time.Sleep(time.Duration(50) * time.Millisecond)
+
+	// The overdue attestation is skipped, but the overdue proposal still runs.
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&attestRun))
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&proposeRun))
+}
+
 func TestJob(t *testing.T) {
 	ctx := context.Background()
 	s, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled), advanced.WithMonitor(&nullmetrics.Service{}))
@@ -81,6 +178,40 @@ func TestJob(t *testing.T) {
 	require.Len(t, s.ListJobs(ctx), 0)
 }
 
+func TestJobTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prevTracerProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(prevTracerProvider)
+
+	ctx := context.Background()
+	s, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled), advanced.WithMonitor(&nullmetrics.Service{}))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	// The job emulates the stages of a duty, each of which is a child span.
+	runFunc := func(ctx context.Context, _ interface{}) {
+		for _, stage := range []string{"fetch", "select", "sign", "submit"} {
+			_, span := otel.Tracer("test").Start(ctx, stage)
+			span.End()
+		}
+	}
+
+	require.NoError(t, s.ScheduleJob(ctx, "Test", "Test job", time.Now().Add(20*time.Millisecond), runFunc, nil))
+	time.Sleep(time.Duration(50) * time.Millisecond)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 5)
+	jobSpan := spans[len(spans)-1]
+	require.Equal(t, "Job", jobSpan.Name())
+	require.Contains(t, jobSpan.Attributes(), attribute.String("class", "Test"))
+	require.Contains(t, jobSpan.Attributes(), attribute.String("job", "Test job"))
+	for i, stage := range []string{"fetch", "select", "sign", "submit"} {
+		require.Equal(t, stage, spans[i].Name())
+		require.Equal(t, jobSpan.SpanContext().SpanID(), spans[i].Parent().SpanID())
+	}
+}
+
 func TestJobExists(t *testing.T) {
 	ctx := context.Background()
 	s, err := advanced.New(ctx, advanced.WithLogLevel(zerolog.Disabled), advanced.WithMonitor(&nullmetrics.Service{}))
@@ -33,6 +33,11 @@ func (*service) ScheduleJob(_ context.Context, _ string, _ string, _ time.Time,
 	return nil
 }
 
+// ScheduleJobWithPastPolicy schedules a one-off job for a given time.
+func (*service) ScheduleJobWithPastPolicy(_ context.Context, _ string, _ string, _ time.Time, _ scheduler.PastJobPolicy, _ scheduler.JobFunc, _ interface{}) error {
+	return nil
+}
+
 // SchedulePeriodicJob schedules a job to run in a loop.
 func (*service) SchedulePeriodicJob(_ context.Context, _ string, _ string, _ scheduler.RuntimeFunc, _ interface{}, _ scheduler.JobFunc, _ interface{}) error {
 	return nil
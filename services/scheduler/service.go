@@ -49,6 +49,16 @@ var ErrNoJobFunc = errors.New("no job function")
 // ErrNoRuntimeFunc is returned when an attempt is made to run a periodic job without a runtime function.
 var ErrNoRuntimeFunc = errors.New("no runtime function")
 
+// PastJobPolicy defines how a one-off job scheduled for a time that has already passed is handled.
+type PastJobPolicy int
+
+const (
+	// PastJobRun runs a job scheduled for the past immediately.
+	PastJobRun PastJobPolicy = iota
+	// PastJobSkip does not run a job scheduled for the past.
+	PastJobSkip
+)
+
 // Service is the interface for schedulers.
 type Service interface {
 	// ScheduleJob schedules a one-off job for a given time.
@@ -57,6 +67,10 @@ type Service interface {
 	// Note that if the parent context is cancelled the job wil not run.
 	ScheduleJob(ctx context.Context, class string, name string, runtime time.Time, job JobFunc, data interface{}) error
 
+	// ScheduleJobWithPastPolicy schedules a one-off job for a given time, handling a time in the past according to the policy.
+	// A job skipped due to the policy is not an error.
+	ScheduleJobWithPastPolicy(ctx context.Context, class string, name string, runtime time.Time, policy PastJobPolicy, job JobFunc, data interface{}) error
+
 	// SchedulePeriodicJob schedules a job to run in a loop.
 	// The loop starts by calling runtimeFunc, which sets the time for the first run.
 	// Once the time as specified by runtimeFunc is met, jobFunc is called.
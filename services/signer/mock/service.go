@@ -1,4 +1,4 @@
-// Copyright © 2021 Attestant Limited.
+// Copyright © 2021, 2024 Attestant Limited.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -15,6 +15,7 @@ package mock
 
 import (
 	"context"
+	"sync"
 
 	"github.com/attestantio/go-builder-client/api"
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -22,29 +23,64 @@ import (
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
-// Service is a mock.
-type Service struct{}
+// Request is a signing request received by the mock signer.
+type Request struct {
+	// Operation is the name of the signing function called.
+	Operation string
+	// Accounts are the accounts requested to sign.
+	Accounts []e2wtypes.Account
+}
+
+// Service is a mock signer.  It does not produce real signatures, but records
+// the signing requests it receives.
+type Service struct {
+	mu       sync.Mutex
+	requests []*Request
+}
 
 // New provides a mock signer.
 func New() *Service {
 	return &Service{}
 }
 
+// Requests returns the signing requests received by the signer, in order.
+func (s *Service) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]*Request, len(s.requests))
+	copy(requests, s.requests)
+
+	return requests
+}
+
+// record records a signing request.
+func (s *Service) record(operation string, accounts ...e2wtypes.Account) {
+	s.mu.Lock()
+	s.requests = append(s.requests, &Request{
+		Operation: operation,
+		Accounts:  accounts,
+	})
+	s.mu.Unlock()
+}
+
 // SignAggregateAndProof signs an aggregate attestation for given slot and root.
-func (*Service) SignAggregateAndProof(_ context.Context,
-	_ e2wtypes.Account,
+func (s *Service) SignAggregateAndProof(_ context.Context,
+	account e2wtypes.Account,
 	_ phase0.Slot,
 	_ phase0.Root,
 ) (
 	phase0.BLSSignature,
 	error,
 ) {
+	s.record("SignAggregateAndProof", account)
+
 	return phase0.BLSSignature{}, nil
 }
 
 // SignBeaconAttestation signs a beacon attestation.
-func (*Service) SignBeaconAttestation(_ context.Context,
-	_ e2wtypes.Account,
+func (s *Service) SignBeaconAttestation(_ context.Context,
+	account e2wtypes.Account,
 	_ phase0.Slot,
 	_ phase0.CommitteeIndex,
 	_ phase0.Root,
@@ -56,12 +92,14 @@ func (*Service) SignBeaconAttestation(_ context.Context,
 	phase0.BLSSignature,
 	error,
 ) {
+	s.record("SignBeaconAttestation", account)
+
 	return phase0.BLSSignature{}, nil
 }
 
 // SignBeaconAttestations signs multiple beacon attestations.
-func (*Service) SignBeaconAttestations(_ context.Context,
-	_ []e2wtypes.Account,
+func (s *Service) SignBeaconAttestations(_ context.Context,
+	accounts []e2wtypes.Account,
 	_ phase0.Slot,
 	_ []phase0.CommitteeIndex,
 	_ phase0.Root,
@@ -73,12 +111,14 @@ func (*Service) SignBeaconAttestations(_ context.Context,
 	[]phase0.BLSSignature,
 	error,
 ) {
-	return []phase0.BLSSignature{}, nil
+	s.record("SignBeaconAttestations", accounts...)
+
+	return make([]phase0.BLSSignature, len(accounts)), nil
 }
 
 // SignBeaconBlockProposal signs a beacon block proposal.
-func (*Service) SignBeaconBlockProposal(_ context.Context,
-	_ e2wtypes.Account,
+func (s *Service) SignBeaconBlockProposal(_ context.Context,
+	account e2wtypes.Account,
 	_ phase0.Slot,
 	_ phase0.ValidatorIndex,
 	_ phase0.Root,
@@ -88,89 +128,105 @@ func (*Service) SignBeaconBlockProposal(_ context.Context,
 	phase0.BLSSignature,
 	error,
 ) {
+	s.record("SignBeaconBlockProposal", account)
+
 	return phase0.BLSSignature{}, nil
 }
 
 // SignRANDAOReveal returns a RANDAO signature.
 // This signs an epoch with the "RANDAO" domain.
-func (*Service) SignRANDAOReveal(_ context.Context,
-	_ e2wtypes.Account,
+func (s *Service) SignRANDAOReveal(_ context.Context,
+	account e2wtypes.Account,
 	_ phase0.Slot,
 ) (
 	phase0.BLSSignature,
 	error,
 ) {
+	s.record("SignRANDAOReveal", account)
+
 	return phase0.BLSSignature{}, nil
 }
 
 // SignSlotSelection returns a slot selection signature.
 // This signs a slot with the "selection proof" domain.
-func (*Service) SignSlotSelection(_ context.Context,
-	_ e2wtypes.Account,
+func (s *Service) SignSlotSelection(_ context.Context,
+	account e2wtypes.Account,
 	_ phase0.Slot,
 ) (
 	phase0.BLSSignature,
 	error,
 ) {
+	s.record("SignSlotSelection", account)
+
 	return phase0.BLSSignature{}, nil
 }
 
 // SignContributionAndProof signs a sync committee contribution for given slot and root.
-func (*Service) SignContributionAndProof(_ context.Context,
-	_ e2wtypes.Account,
+func (s *Service) SignContributionAndProof(_ context.Context,
+	account e2wtypes.Account,
 	_ *altair.ContributionAndProof,
 ) (
 	phase0.BLSSignature,
 	error,
 ) {
+	s.record("SignContributionAndProof", account)
+
 	return phase0.BLSSignature{}, nil
 }
 
 // SignSyncCommitteeRoot returns a root signature.
 // This signs a beacon block root with the "sync committee" domain.
-func (*Service) SignSyncCommitteeRoot(_ context.Context,
-	_ e2wtypes.Account,
+func (s *Service) SignSyncCommitteeRoot(_ context.Context,
+	account e2wtypes.Account,
 	_ phase0.Epoch,
 	_ phase0.Root,
 ) (
 	phase0.BLSSignature,
 	error,
 ) {
+	s.record("SignSyncCommitteeRoot", account)
+
 	return phase0.BLSSignature{}, nil
 }
 
 // SignSyncCommitteeSelection returns a sync committee selection signature.
 // This signs a slot and subcommittee with the "sync committee selection proof" domain.
-func (*Service) SignSyncCommitteeSelection(_ context.Context,
-	_ e2wtypes.Account,
+func (s *Service) SignSyncCommitteeSelection(_ context.Context,
+	account e2wtypes.Account,
 	_ phase0.Slot,
 	_ uint64,
 ) (
 	phase0.BLSSignature,
 	error,
 ) {
+	s.record("SignSyncCommitteeSelection", account)
+
 	return phase0.BLSSignature{}, nil
 }
 
 // SignValidatorRegistration signs a validator registration.
-func (*Service) SignValidatorRegistration(_ context.Context,
-	_ e2wtypes.Account,
+func (s *Service) SignValidatorRegistration(_ context.Context,
+	account e2wtypes.Account,
 	_ *api.VersionedValidatorRegistration,
 ) (
 	phase0.BLSSignature,
 	error,
 ) {
+	s.record("SignValidatorRegistration", account)
+
 	return phase0.BLSSignature{}, nil
 }
 
 // SignBlobSidecar signs a blob sidecar proposal.
-func (*Service) SignBlobSidecar(_ context.Context,
-	_ e2wtypes.Account,
+func (s *Service) SignBlobSidecar(_ context.Context,
+	account e2wtypes.Account,
 	_ phase0.Slot,
 	_ phase0.Root,
 ) (
 	phase0.BLSSignature,
 	error,
 ) {
+	s.record("SignBlobSidecar", account)
+
 	return phase0.BLSSignature{}, nil
 }
@@ -0,0 +1,104 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock_test
+
+import (
+	"context"
+	"testing"
+
+	builderapi "github.com/attestantio/go-builder-client/api"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/signer"
+	"github.com/attestantio/vouch/services/signer/mock"
+	"github.com/stretchr/testify/require"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// account is a minimal account.  Any attempt to use it to sign will panic.
+type account struct {
+	e2wtypes.Account
+}
+
+func TestInterfaces(t *testing.T) {
+	s := mock.New()
+	require.Implements(t, (*signer.AggregateAndProofSigner)(nil), s)
+	require.Implements(t, (*signer.BeaconAttestationSigner)(nil), s)
+	require.Implements(t, (*signer.BeaconAttestationsSigner)(nil), s)
+	require.Implements(t, (*signer.BeaconBlockSigner)(nil), s)
+	require.Implements(t, (*signer.BlobSidecarSigner)(nil), s)
+	require.Implements(t, (*signer.RANDAORevealSigner)(nil), s)
+	require.Implements(t, (*signer.SlotSelectionSigner)(nil), s)
+	require.Implements(t, (*signer.SyncCommitteeRootSigner)(nil), s)
+	require.Implements(t, (*signer.SyncCommitteeSelectionSigner)(nil), s)
+	require.Implements(t, (*signer.ContributionAndProofSigner)(nil), s)
+	require.Implements(t, (*signer.ValidatorRegistrationSigner)(nil), s)
+}
+
+func TestRequests(t *testing.T) {
+	ctx := context.Background()
+	account1 := &account{}
+	account2 := &account{}
+
+	s := mock.New()
+	require.Empty(t, s.Requests())
+
+	sig, err := s.SignAggregateAndProof(ctx, account1, 1, phase0.Root{})
+	require.NoError(t, err)
+	require.Equal(t, phase0.BLSSignature{}, sig)
+	_, err = s.SignBeaconAttestation(ctx, account1, 1, 0, phase0.Root{}, 0, phase0.Root{}, 0, phase0.Root{})
+	require.NoError(t, err)
+	sigs, err := s.SignBeaconAttestations(ctx, []e2wtypes.Account{account1, account2}, 1, []phase0.CommitteeIndex{0, 1}, phase0.Root{}, 0, phase0.Root{}, 0, phase0.Root{})
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+	_, err = s.SignBeaconBlockProposal(ctx, account2, 1, 2, phase0.Root{}, phase0.Root{}, phase0.Root{})
+	require.NoError(t, err)
+	_, err = s.SignBlobSidecar(ctx, account2, 1, phase0.Root{})
+	require.NoError(t, err)
+	_, err = s.SignRANDAOReveal(ctx, account2, 1)
+	require.NoError(t, err)
+	_, err = s.SignSlotSelection(ctx, account1, 1)
+	require.NoError(t, err)
+	_, err = s.SignSyncCommitteeRoot(ctx, account1, 0, phase0.Root{})
+	require.NoError(t, err)
+	_, err = s.SignSyncCommitteeSelection(ctx, account1, 1, 0)
+	require.NoError(t, err)
+	_, err = s.SignContributionAndProof(ctx, account1, &altair.ContributionAndProof{})
+	require.NoError(t, err)
+	_, err = s.SignValidatorRegistration(ctx, account2, &builderapi.VersionedValidatorRegistration{})
+	require.NoError(t, err)
+
+	requests := s.Requests()
+	require.Len(t, requests, 11)
+	expected := []*mock.Request{
+		{Operation: "SignAggregateAndProof", Accounts: []e2wtypes.Account{account1}},
+		{Operation: "SignBeaconAttestation", Accounts: []e2wtypes.Account{account1}},
+		{Operation: "SignBeaconAttestations", Accounts: []e2wtypes.Account{account1, account2}},
+		{Operation: "SignBeaconBlockProposal", Accounts: []e2wtypes.Account{account2}},
+		{Operation: "SignBlobSidecar", Accounts: []e2wtypes.Account{account2}},
+		{Operation: "SignRANDAOReveal", Accounts: []e2wtypes.Account{account2}},
+		{Operation: "SignSlotSelection", Accounts: []e2wtypes.Account{account1}},
+		{Operation: "SignSyncCommitteeRoot", Accounts: []e2wtypes.Account{account1}},
+		{Operation: "SignSyncCommitteeSelection", Accounts: []e2wtypes.Account{account1}},
+		{Operation: "SignContributionAndProof", Accounts: []e2wtypes.Account{account1}},
+		{Operation: "SignValidatorRegistration", Accounts: []e2wtypes.Account{account2}},
+	}
+	for i := range expected {
+		require.Equal(t, expected[i].Operation, requests[i].Operation)
+		require.Len(t, requests[i].Accounts, len(expected[i].Accounts))
+		for j := range expected[i].Accounts {
+			require.Same(t, expected[i].Accounts[j], requests[i].Accounts[j])
+		}
+	}
+}
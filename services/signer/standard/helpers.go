@@ -15,6 +15,7 @@ package standard
 
 import (
 	"context"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
@@ -23,7 +24,8 @@ import (
 )
 
 // sign signs a root, using protected methods if possible.
-func (*Service) sign(ctx context.Context,
+func (s *Service) sign(ctx context.Context,
+	operation string,
 	account e2wtypes.Account,
 	root phase0.Root,
 	domain phase0.Domain,
@@ -35,9 +37,11 @@ func (*Service) sign(ctx context.Context,
 		return phase0.BLSSignature{}, errors.New("account is nil; cannot sign")
 	}
 	var sig e2types.Signature
+	started := time.Now()
 	if protectingSigner, isProtectingSigner := account.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
 		var err error
 		sig, err = protectingSigner.SignGeneric(ctx, root[:], domain[:])
+		s.monitor.SignerOperation(operation, err == nil, time.Since(started))
 		if err != nil {
 			return phase0.BLSSignature{}, err
 		}
@@ -51,6 +55,7 @@ func (*Service) sign(ctx context.Context,
 			return phase0.BLSSignature{}, errors.Wrap(err, "failed to generate hash tree root")
 		}
 		sig, err = account.(e2wtypes.AccountSigner).Sign(ctx, root[:])
+		s.monitor.SignerOperation(operation, err == nil, time.Since(started))
 		if err != nil {
 			return phase0.BLSSignature{}, err
 		}
@@ -0,0 +1,109 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/mock"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/signer/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type signerOperation struct {
+	operation string
+	succeeded bool
+}
+
+// signerMonitor records signer operations.
+type signerMonitor struct {
+	operations []signerOperation
+}
+
+func (m *signerMonitor) SignerOperation(operation string, succeeded bool, _ time.Duration) {
+	m.operations = append(m.operations, signerOperation{
+		operation: operation,
+		succeeded: succeeded,
+	})
+}
+
+func TestSignerOperationMonitor(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	unlockedAccount, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "unlocked account", []byte("pass"))
+	require.NoError(t, err)
+	lockedAccount, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "locked account", []byte("pass"))
+	require.NoError(t, err)
+	require.NoError(t, lockedAccount.(e2wtypes.AccountLocker).Lock(ctx))
+
+	tests := []struct {
+		name     string
+		account  e2wtypes.Account
+		err      bool
+		expected []signerOperation
+	}{
+		{
+			name:    "Succeeded",
+			account: unlockedAccount,
+			expected: []signerOperation{
+				{operation: "randao reveal", succeeded: true},
+			},
+		},
+		{
+			name:    "Failed",
+			account: lockedAccount,
+			err:     true,
+			expected: []signerOperation{
+				{operation: "randao reveal", succeeded: false},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			monitor := &signerMonitor{}
+			s, err := standard.New(ctx,
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(monitor),
+				standard.WithClientMonitor(nullmetrics.New(ctx)),
+				standard.WithSpecProvider(mock.NewSpecProvider()),
+				standard.WithDomainProvider(mock.NewDomainProvider()),
+			)
+			require.NoError(t, err)
+
+			_, err = s.SignRANDAOReveal(ctx, test.account, 1)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.expected, monitor.operations)
+		})
+	}
+}
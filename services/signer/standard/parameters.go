@@ -15,6 +15,7 @@ package standard
 
 import (
 	"context"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/metrics"
@@ -24,11 +25,12 @@ import (
 )
 
 type parameters struct {
-	logLevel       zerolog.Level
-	monitor        metrics.SignerMonitor
-	clientMonitor  metrics.ClientMonitor
-	specProvider   eth2client.SpecProvider
-	domainProvider eth2client.DomainProvider
+	logLevel                zerolog.Level
+	monitor                 metrics.SignerMonitor
+	clientMonitor           metrics.ClientMonitor
+	specProvider            eth2client.SpecProvider
+	domainProvider          eth2client.DomainProvider
+	attestationPriorityWait time.Duration
 }
 
 // Parameter is the interface for service parameters.
@@ -77,6 +79,14 @@ func WithDomainProvider(provider eth2client.DomainProvider) Parameter {
 	})
 }
 
+// WithAttestationPriorityWait sets the longest that aggregation signing is held
+// back whilst attestations are being signed.  0 disables prioritisation.
+func WithAttestationPriorityWait(wait time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attestationPriorityWait = wait
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -102,6 +112,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.domainProvider == nil {
 		return nil, errors.New("no domain provider specified")
 	}
+	if parameters.attestationPriorityWait < 0 {
+		return nil, errors.New("attestation priority wait cannot be negative")
+	}
 
 	return &parameters, nil
 }
@@ -0,0 +1,87 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// attestationPriority holds back less time-critical signing operations whilst
+// attestations are being signed, so that they do not contend for the signer.
+type attestationPriority struct {
+	// maxWait is the longest that an operation is held back; 0 disables.
+	maxWait time.Duration
+
+	mu     sync.Mutex
+	active int
+	// idle is closed when the last active attestation signing completes.
+	idle chan struct{}
+}
+
+// start notes that attestation signing has started.  It must be followed by
+// a call to done.
+func (p *attestationPriority) start() {
+	if p.maxWait == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.active == 0 {
+		p.idle = make(chan struct{})
+	}
+	p.active++
+}
+
+// done notes that attestation signing has completed.
+func (p *attestationPriority) done() {
+	if p.maxWait == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.active--
+	if p.active == 0 {
+		close(p.idle)
+	}
+}
+
+// wait waits until no attestations are being signed, or until the maximum
+// wait has passed.  It returns true if the caller was held back.
+func (p *attestationPriority) wait(ctx context.Context) bool {
+	if p.maxWait == 0 {
+		return false
+	}
+
+	p.mu.Lock()
+	if p.active == 0 {
+		p.mu.Unlock()
+		return false
+	}
+	idle := p.idle
+	p.mu.Unlock()
+
+	timer := time.NewTimer(p.maxWait)
+	defer timer.Stop()
+	select {
+	case <-idle:
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	return true
+}
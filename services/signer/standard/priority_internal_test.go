@@ -0,0 +1,154 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// orderedMonitor records the order of completed signer operations.
+type orderedMonitor struct {
+	mu         sync.Mutex
+	operations []string
+}
+
+func (m *orderedMonitor) SignerOperation(operation string, _ bool, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operations = append(m.operations, operation)
+}
+
+func (m *orderedMonitor) completed() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string{}, m.operations...)
+}
+
+// slowAccount is an account that takes time to sign.
+type slowAccount struct {
+	e2wtypes.Account
+	delay time.Duration
+}
+
+func (a *slowAccount) Sign(ctx context.Context, data []byte) (e2types.Signature, error) {
+	time.Sleep(a.delay)
+
+	return a.Account.(e2wtypes.AccountSigner).Sign(ctx, data)
+}
+
+func TestAttestationPriority(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account", []byte("pass"))
+	require.NoError(t, err)
+	require.NoError(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte("pass")))
+
+	tests := []struct {
+		name             string
+		maxWait          time.Duration
+		attestationDelay time.Duration
+		expected         []string
+		minTime          time.Duration
+		maxTime          time.Duration
+	}{
+		{
+			name:             "Disabled",
+			attestationDelay: 200 * time.Millisecond,
+			expected:         []string{"aggregate and proof", "beacon attestation"},
+			maxTime:          100 * time.Millisecond,
+		},
+		{
+			name:             "Prioritised",
+			maxWait:          time.Second,
+			attestationDelay: 200 * time.Millisecond,
+			expected:         []string{"beacon attestation", "aggregate and proof"},
+			minTime:          150 * time.Millisecond,
+			maxTime:          time.Second,
+		},
+		{
+			name:             "MaxWait",
+			maxWait:          100 * time.Millisecond,
+			attestationDelay: time.Second,
+			expected:         []string{"aggregate and proof", "beacon attestation"},
+			minTime:          100 * time.Millisecond,
+			maxTime:          500 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			monitor := &orderedMonitor{}
+			s, err := New(ctx,
+				WithLogLevel(zerolog.Disabled),
+				WithMonitor(monitor),
+				WithClientMonitor(nullmetrics.New(ctx)),
+				WithSpecProvider(mock.NewSpecProvider()),
+				WithDomainProvider(mock.NewDomainProvider()),
+				WithAttestationPriorityWait(test.maxWait),
+			)
+			require.NoError(t, err)
+
+			// Start a slow attestation signing, and contend with it by
+			// signing an aggregate and proof.
+			attested := make(chan struct{})
+			started := time.Now()
+			go func() {
+				defer close(attested)
+				_, err := s.SignBeaconAttestation(ctx,
+					&slowAccount{Account: account, delay: test.attestationDelay},
+					1, 0, phase0.Root{}, 0, phase0.Root{}, 0, phase0.Root{},
+				)
+				require.NoError(t, err)
+			}()
+			if test.maxWait > 0 {
+				require.Eventually(t, func() bool {
+					s.attestationPriority.mu.Lock()
+					defer s.attestationPriority.mu.Unlock()
+
+					return s.attestationPriority.active > 0
+				}, time.Second, time.Millisecond)
+			}
+
+			_, err = s.SignAggregateAndProof(ctx, account, 1, phase0.Root{0x01})
+			require.NoError(t, err)
+			elapsed := time.Since(started)
+			<-attested
+
+			require.Equal(t, test.expected, monitor.completed())
+			require.GreaterOrEqual(t, elapsed, test.minTime)
+			require.Less(t, elapsed, test.maxTime)
+		})
+	}
+}
@@ -42,6 +42,7 @@ type Service struct {
 	applicationBuilderDomainType          *phase0.DomainType
 	blobSidecarDomainType                 *phase0.DomainType
 	domainProvider                        eth2client.DomainProvider
+	attestationPriority                   attestationPriority
 }
 
 // module-wide log.
@@ -141,6 +142,9 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		applicationBuilderDomainType:          applicationBuilderDomainType,
 		blobSidecarDomainType:                 blobSidecarDomainType,
 		domainProvider:                        parameters.domainProvider,
+		attestationPriority: attestationPriority{
+			maxWait: parameters.attestationPriorityWait,
+		},
 	}
 
 	return s, nil
@@ -16,6 +16,7 @@ package standard_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/attestantio/vouch/mock"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
@@ -78,6 +79,18 @@ func TestService(t *testing.T) {
 			},
 			err: "failed to obtain spec: error",
 		},
+		{
+			name: "AttestationPriorityWaitNegative",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nullmetrics.New(context.Background())),
+				standard.WithClientMonitor(nullmetrics.New(context.Background())),
+				standard.WithSpecProvider(specProvider),
+				standard.WithDomainProvider(domainProvider),
+				standard.WithAttestationPriorityWait(-time.Second),
+			},
+			err: "problem with parameters: attestation priority wait cannot be negative",
+		},
 		{
 			name: "Good",
 			params: []standard.Parameter{
@@ -42,7 +42,11 @@ func (s *Service) SignAggregateAndProof(ctx context.Context,
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to obtain signature domain for beacon aggregate and proof")
 	}
 
-	sig, err := s.sign(ctx, account, aggregateAndProofRoot, domain)
+	if s.attestationPriority.wait(ctx) {
+		log.Trace().Msg("Held back aggregate and proof signing for attestations")
+	}
+
+	sig, err := s.sign(ctx, "aggregate and proof", account, aggregateAndProofRoot, domain)
 	if err != nil {
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to aggregate and proof")
 	}
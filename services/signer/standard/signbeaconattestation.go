@@ -15,6 +15,7 @@ package standard
 
 import (
 	"context"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
@@ -39,6 +40,9 @@ func (s *Service) SignBeaconAttestation(ctx context.Context,
 	ctx, span := otel.Tracer("attestantio.vouch.services.signer.standard").Start(ctx, "SignBeaconAttestation")
 	defer span.End()
 
+	s.attestationPriority.start()
+	defer s.attestationPriority.done()
+
 	domain, err := s.domainProvider.Domain(ctx,
 		s.beaconAttesterDomainType,
 		phase0.Epoch(slot/s.slotsPerEpoch))
@@ -48,6 +52,7 @@ func (s *Service) SignBeaconAttestation(ctx context.Context,
 
 	var sig phase0.BLSSignature
 	if protectingSigner, isProtectingSigner := account.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
+		started := time.Now()
 		signature, err := protectingSigner.SignBeaconAttestation(ctx,
 			uint64(slot),
 			uint64(committeeIndex),
@@ -57,6 +62,7 @@ func (s *Service) SignBeaconAttestation(ctx context.Context,
 			uint64(targetEpoch),
 			targetRoot[:],
 			domain[:])
+		s.monitor.SignerOperation("beacon attestation", err == nil, time.Since(started))
 		if err != nil {
 			return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign beacon attestation")
 		}
@@ -79,7 +85,7 @@ func (s *Service) SignBeaconAttestation(ctx context.Context,
 		if err != nil {
 			return phase0.BLSSignature{}, errors.Wrap(err, "failed to generate hash tree root")
 		}
-		sig, err = s.sign(ctx, account, root, domain)
+		sig, err = s.sign(ctx, "beacon attestation", account, root, domain)
 		if err != nil {
 			return phase0.BLSSignature{}, err
 		}
@@ -16,6 +16,7 @@ package standard
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
@@ -48,6 +49,9 @@ func (s *Service) SignBeaconAttestations(ctx context.Context,
 		return nil, errors.New("no accounts supplied")
 	}
 
+	s.attestationPriority.start()
+	defer s.attestationPriority.done()
+
 	signatureDomain, err := s.domainProvider.Domain(ctx,
 		s.beaconAttesterDomainType,
 		phase0.Epoch(slot/s.slotsPerEpoch))
@@ -124,6 +128,7 @@ func (s *Service) signBeaconAttestations(ctx context.Context,
 	}
 
 	if multiSigner, isMultiSigner := accounts[0].(e2wtypes.AccountProtectingMultiSigner); isMultiSigner {
+		started := time.Now()
 		signatures, err := multiSigner.SignBeaconAttestations(ctx,
 			uint64(slot),
 			accounts,
@@ -135,6 +140,7 @@ func (s *Service) signBeaconAttestations(ctx context.Context,
 			targetRoot[:],
 			signatureDomain[:],
 		)
+		s.monitor.SignerOperation("beacon attestations", err == nil, time.Since(started))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to multisign beacon attestation")
 		}
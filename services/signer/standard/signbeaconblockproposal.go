@@ -15,6 +15,7 @@ package standard
 
 import (
 	"context"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
@@ -47,6 +48,7 @@ func (s *Service) SignBeaconBlockProposal(ctx context.Context,
 
 	var sig phase0.BLSSignature
 	if protectingSigner, isProtectingSigner := account.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
+		started := time.Now()
 		signature, err := protectingSigner.SignBeaconProposal(ctx,
 			uint64(slot),
 			uint64(proposerIndex),
@@ -54,6 +56,7 @@ func (s *Service) SignBeaconBlockProposal(ctx context.Context,
 			stateRoot[:],
 			bodyRoot[:],
 			domain[:])
+		s.monitor.SignerOperation("beacon block proposal", err == nil, time.Since(started))
 		if err != nil {
 			return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign beacon block proposal")
 		}
@@ -70,7 +73,7 @@ func (s *Service) SignBeaconBlockProposal(ctx context.Context,
 		if err != nil {
 			return phase0.BLSSignature{}, errors.Wrap(err, "failed to generate hash tree root")
 		}
-		sig, err = s.sign(ctx, account, root, domain)
+		sig, err = s.sign(ctx, "beacon block proposal", account, root, domain)
 		if err != nil {
 			return phase0.BLSSignature{}, err
 		}
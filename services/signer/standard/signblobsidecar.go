@@ -42,7 +42,7 @@ func (s *Service) SignBlobSidecar(ctx context.Context,
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to obtain signature domain for blob sidecar")
 	}
 
-	sig, err := s.sign(ctx, account, sidecarRoot, domain)
+	sig, err := s.sign(ctx, "blob sidecar", account, sidecarRoot, domain)
 	if err != nil {
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign blob sidecar")
 	}
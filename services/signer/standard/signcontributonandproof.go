@@ -50,7 +50,11 @@ func (s *Service) SignContributionAndProof(ctx context.Context,
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to obtain signature domain for contribution and proof")
 	}
 
-	sig, err := s.sign(ctx, account, root, domain)
+	if s.attestationPriority.wait(ctx) {
+		log.Trace().Msg("Held back contribution and proof signing for attestations")
+	}
+
+	sig, err := s.sign(ctx, "contribution and proof", account, root, domain)
 	if err != nil {
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign contribution and proof")
 	}
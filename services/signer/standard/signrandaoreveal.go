@@ -50,7 +50,7 @@ func (s *Service) SignRANDAOReveal(ctx context.Context,
 	var epochBytes phase0.Root
 	binary.LittleEndian.PutUint64(epochBytes[:], uint64(epoch))
 
-	sig, err := s.sign(ctx, account, epochBytes, domain)
+	sig, err := s.sign(ctx, "randao reveal", account, epochBytes, domain)
 	if err != nil {
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign RANDAO reveal")
 	}
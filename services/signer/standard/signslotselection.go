@@ -49,7 +49,7 @@ func (s *Service) SignSlotSelection(ctx context.Context,
 	var slotBytes phase0.Root
 	binary.LittleEndian.PutUint64(slotBytes[:], uint64(slot))
 
-	sig, err := s.sign(ctx, account, slotBytes, domain)
+	sig, err := s.sign(ctx, "slot selection", account, slotBytes, domain)
 	if err != nil {
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign slot selection proof")
 	}
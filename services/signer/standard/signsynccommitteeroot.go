@@ -45,7 +45,7 @@ func (s *Service) SignSyncCommitteeRoot(ctx context.Context,
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to obtain signature domain for sync committee")
 	}
 
-	sig, err := s.sign(ctx, account, root, domain)
+	sig, err := s.sign(ctx, "sync committee root", account, root, domain)
 	if err != nil {
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign sync committee root")
 	}
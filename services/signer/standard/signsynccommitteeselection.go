@@ -57,7 +57,7 @@ func (s *Service) SignSyncCommitteeSelection(ctx context.Context,
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to obtain hash tree root of sync aggregator selection data")
 	}
 
-	sig, err := s.sign(ctx, account, root, domain)
+	sig, err := s.sign(ctx, "sync committee selection", account, root, domain)
 	if err != nil {
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign sync committee selection proof")
 	}
@@ -65,7 +65,7 @@ func (s *Service) SignValidatorRegistration(ctx context.Context,
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to obtain signature domain for builder")
 	}
 
-	sig, err := s.sign(ctx, account, root, domain)
+	sig, err := s.sign(ctx, "validator registration", account, root, domain)
 	if err != nil {
 		return phase0.BLSSignature{}, errors.Wrap(err, "failed to sign builder")
 	}
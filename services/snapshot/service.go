@@ -0,0 +1,124 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Service is the snapshot service.
+type Service interface{}
+
+// Provider provides snapshots of Vouch's internal state.
+type Provider interface {
+	// Snapshot returns a snapshot of Vouch's internal state.
+	Snapshot(ctx context.Context) (*Snapshot, error)
+}
+
+// ProposalExplainer explains the selection of beacon block proposals.
+type ProposalExplainer interface {
+	// LatestProposalExplanation returns the explanation for the most recently
+	// selected beacon block proposal, or nil if none has been selected.
+	LatestProposalExplanation() *ProposalExplanation
+}
+
+// Snapshot is a redacted snapshot of Vouch's internal state, for debugging.
+type Snapshot struct {
+	// Timestamp is the time at which the snapshot was taken.
+	Timestamp time.Time `json:"timestamp"`
+	// Version is the release version of Vouch.
+	Version string `json:"version"`
+	// Epoch is the current epoch.
+	Epoch phase0.Epoch `json:"epoch"`
+	// Slot is the current slot.
+	Slot phase0.Slot `json:"slot"`
+	// BeaconNodes are the beacon nodes known to Vouch.
+	BeaconNodes []*BeaconNode `json:"beacon_nodes"`
+	// ActiveValidators is the number of validators active in the current epoch.
+	ActiveValidators int `json:"active_validators"`
+	// Jobs are the names of the jobs currently scheduled.
+	Jobs []string `json:"jobs"`
+	// DutyOutcomes are the outcomes of duty processes, keyed by process and result.
+	DutyOutcomes map[string]map[string]uint64 `json:"duty_outcomes"`
+	// ProviderOperations are the statistics for operations carried out against providers.
+	ProviderOperations []*ProviderOperation `json:"provider_operations"`
+	// ProposalExplanation explains the selection of the most recent beacon block proposal, if any.
+	ProposalExplanation *ProposalExplanation `json:"proposal_explanation,omitempty"`
+}
+
+// BeaconNode contains information about a beacon node.
+type BeaconNode struct {
+	// Address is the redacted address of the beacon node.
+	Address string `json:"address"`
+	// Name is the name of the client implementation.
+	Name string `json:"name"`
+	// Active is true if the beacon node is active.
+	Active bool `json:"active"`
+	// Synced is true if the beacon node is synced.
+	Synced bool `json:"synced"`
+}
+
+// ProviderOperation contains statistics for an operation carried out against a provider.
+type ProviderOperation struct {
+	// Provider is the redacted address of the provider.
+	Provider string `json:"provider"`
+	// Operation is the name of the operation.
+	Operation string `json:"operation"`
+	// Succeeded is the number of successful operations.
+	Succeeded uint64 `json:"succeeded"`
+	// Failed is the number of failed operations.
+	Failed uint64 `json:"failed"`
+	// AverageDuration is the average duration of successful operations, in seconds.
+	AverageDuration float64 `json:"average_duration_seconds"`
+}
+
+// ProposalExplanation explains why a beacon block proposal was selected.
+type ProposalExplanation struct {
+	// Slot is the slot of the proposal.
+	Slot phase0.Slot `json:"slot"`
+	// SelectedProvider is the provider of the selected proposal.
+	SelectedProvider string `json:"selected_provider"`
+	// Reason is the reason that the selected proposal was chosen.
+	Reason string `json:"reason"`
+	// Candidates are the scored candidate proposals, highest score first.
+	Candidates []*ProposalCandidate `json:"candidates"`
+}
+
+// ProposalCandidate contains the scoring breakdown of a candidate beacon block proposal.
+type ProposalCandidate struct {
+	// Provider is the provider of the candidate.
+	Provider string `json:"provider"`
+	// ConsensusValue is the consensus value of the candidate, in Wei.
+	// This includes the rewards for attestations, sync aggregates and slashings.
+	ConsensusValue string `json:"consensus_value"`
+	// ExecutionValue is the execution value of the candidate, in Wei.
+	ExecutionValue string `json:"execution_value"`
+	// ExecutionValueWeight is the weight applied to the execution value.
+	ExecutionValueWeight float64 `json:"execution_value_weight"`
+	// ExecutionPayloadScore is the contribution of the execution payload contents.
+	ExecutionPayloadScore float64 `json:"execution_payload_score"`
+	// SyncParticipation is the fraction of the sync committee in the sync aggregate, if present.
+	SyncParticipation *float64 `json:"sync_participation,omitempty"`
+	// SlashesManagedValidators is true if the candidate slashes managed validators.
+	SlashesManagedValidators bool `json:"slashes_managed_validators"`
+	// Scale is the multiplier applied to the summed values.
+	Scale float64 `json:"scale"`
+	// Empty is true if the candidate contains neither attestations nor transactions.
+	Empty bool `json:"empty"`
+	// Score is the final score of the candidate.
+	Score float64 `json:"score"`
+}
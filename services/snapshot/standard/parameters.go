@@ -0,0 +1,141 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/attestantio/vouch/services/snapshot"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                   zerolog.Level
+	listenAddress              string
+	releaseVersion             string
+	chainTime                  chaintime.Service
+	scheduler                  scheduler.Service
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
+	beaconNodes                []eth2client.Service
+	gatherer                   prometheus.Gatherer
+	proposalExplainer          snapshot.ProposalExplainer
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithListenAddress sets the address on which to serve snapshots.
+// If not set snapshots are not served.
+func WithListenAddress(address string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.listenAddress = address
+	})
+}
+
+// WithReleaseVersion sets the release version of Vouch.
+func WithReleaseVersion(version string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.releaseVersion = version
+	})
+}
+
+// WithChainTime sets the chaintime service.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithScheduler sets the scheduler.
+func WithScheduler(scheduler scheduler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduler = scheduler
+	})
+}
+
+// WithValidatingAccountsProvider sets the account manager.
+func WithValidatingAccountsProvider(provider accountmanager.ValidatingAccountsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatingAccountsProvider = provider
+	})
+}
+
+// WithBeaconNodes sets the beacon nodes to report on.
+func WithBeaconNodes(beaconNodes []eth2client.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.beaconNodes = beaconNodes
+	})
+}
+
+// WithGatherer sets the gatherer from which to obtain metrics.
+func WithGatherer(gatherer prometheus.Gatherer) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.gatherer = gatherer
+	})
+}
+
+// WithProposalExplainer sets the explainer for beacon block proposal selections.
+// If not set proposal explanations are not available.
+func WithProposalExplainer(explainer snapshot.ProposalExplainer) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalExplainer = explainer
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		gatherer: prometheus.DefaultGatherer,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified")
+	}
+	if parameters.scheduler == nil {
+		return nil, errors.New("no scheduler specified")
+	}
+	if parameters.validatingAccountsProvider == nil {
+		return nil, errors.New("no validating accounts provider specified")
+	}
+	if parameters.gatherer == nil {
+		return nil, errors.New("no gatherer specified")
+	}
+
+	return &parameters, nil
+}
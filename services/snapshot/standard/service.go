@@ -0,0 +1,120 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/attestantio/vouch/services/snapshot"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service provides snapshots of Vouch's internal state.
+type Service struct {
+	releaseVersion             string
+	chainTime                  chaintime.Service
+	scheduler                  scheduler.Service
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
+	beaconNodes                []eth2client.Service
+	gatherer                   prometheus.Gatherer
+	proposalExplainer          snapshot.ProposalExplainer
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new snapshot service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "snapshot").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		releaseVersion:             parameters.releaseVersion,
+		chainTime:                  parameters.chainTime,
+		scheduler:                  parameters.scheduler,
+		validatingAccountsProvider: parameters.validatingAccountsProvider,
+		beaconNodes:                parameters.beaconNodes,
+		gatherer:                   parameters.gatherer,
+		proposalExplainer:          parameters.proposalExplainer,
+	}
+
+	if parameters.listenAddress != "" {
+		go s.serve(ctx, parameters.listenAddress)
+	}
+
+	return s, nil
+}
+
+// serve serves snapshots over HTTP.
+func (s *Service) serve(ctx context.Context, address string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		snapshot, err := s.Snapshot(r.Context())
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to generate snapshot")
+			http.Error(w, "failed to generate snapshot", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			log.Warn().Err(err).Msg("Failed to write snapshot")
+		}
+	})
+	mux.HandleFunc("/explain/proposal", func(w http.ResponseWriter, _ *http.Request) {
+		explanation := s.ProposalExplanation()
+		if explanation == nil {
+			http.Error(w, "no proposal explanation available", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(explanation); err != nil {
+			log.Warn().Err(err).Msg("Failed to write proposal explanation")
+		}
+	})
+
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			log.Debug().Err(err).Msg("Failed to close snapshot server")
+		}
+	}()
+
+	log.Info().Str("listen_address", address).Msg("Starting snapshot server")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Warn().Str("listen_address", address).Err(err).Msg("Failed to run snapshot server")
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/vouch/services/snapshot"
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	dutyOutcomePrefix       = "vouch_"
+	dutyOutcomeSuffix       = "_process_requests_total"
+	clientOperationRequests = "vouch_client_operation_requests_total"
+	clientOperationDuration = "vouch_client_operation_duration_seconds"
+	unparseableAddress      = "<unparseable>"
+)
+
+// Snapshot returns a snapshot of Vouch's internal state.
+func (s *Service) Snapshot(ctx context.Context) (*snapshot.Snapshot, error) {
+	currentEpoch := s.chainTime.CurrentEpoch()
+
+	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpoch(ctx, currentEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validating accounts")
+	}
+
+	jobs := s.scheduler.ListJobs(ctx)
+	sort.Strings(jobs)
+
+	beaconNodes := make([]*snapshot.BeaconNode, 0, len(s.beaconNodes))
+	for _, beaconNode := range s.beaconNodes {
+		beaconNodes = append(beaconNodes, &snapshot.BeaconNode{
+			Address: redactAddress(beaconNode.Address()),
+			Name:    beaconNode.Name(),
+			Active:  beaconNode.IsActive(),
+			Synced:  beaconNode.IsSynced(),
+		})
+	}
+	sort.Slice(beaconNodes, func(i int, j int) bool {
+		return beaconNodes[i].Address < beaconNodes[j].Address
+	})
+
+	metricFamilies, err := s.gatherer.Gather()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to gather metrics")
+	}
+
+	return &snapshot.Snapshot{
+		Timestamp:           time.Now(),
+		Version:             s.releaseVersion,
+		Epoch:               currentEpoch,
+		Slot:                s.chainTime.CurrentSlot(),
+		BeaconNodes:         beaconNodes,
+		ActiveValidators:    len(accounts),
+		Jobs:                jobs,
+		DutyOutcomes:        dutyOutcomes(metricFamilies),
+		ProviderOperations:  providerOperations(metricFamilies),
+		ProposalExplanation: s.ProposalExplanation(),
+	}, nil
+}
+
+// ProposalExplanation returns the explanation for the most recent beacon
+// block proposal selection, or nil if none is available.
+func (s *Service) ProposalExplanation() *snapshot.ProposalExplanation {
+	if s.proposalExplainer == nil {
+		return nil
+	}
+
+	return s.proposalExplainer.LatestProposalExplanation()
+}
+
+// dutyOutcomes obtains the outcomes of duty processes from the process request metrics.
+func dutyOutcomes(metricFamilies []*dto.MetricFamily) map[string]map[string]uint64 {
+	res := make(map[string]map[string]uint64)
+	for _, metricFamily := range metricFamilies {
+		name := metricFamily.GetName()
+		if !strings.HasPrefix(name, dutyOutcomePrefix) || !strings.HasSuffix(name, dutyOutcomeSuffix) {
+			continue
+		}
+		process := strings.TrimSuffix(strings.TrimPrefix(name, dutyOutcomePrefix), dutyOutcomeSuffix)
+		for _, metric := range metricFamily.GetMetric() {
+			result := labelValue(metric, "result")
+			if result == "" {
+				continue
+			}
+			if _, exists := res[process]; !exists {
+				res[process] = make(map[string]uint64)
+			}
+			res[process][result] += uint64(metric.GetCounter().GetValue())
+		}
+	}
+
+	return res
+}
+
+// providerOperations obtains statistics for provider operations from the client operation metrics.
+func providerOperations(metricFamilies []*dto.MetricFamily) []*snapshot.ProviderOperation {
+	type key struct {
+		provider  string
+		operation string
+	}
+	operations := make(map[key]*snapshot.ProviderOperation)
+	operation := func(metric *dto.Metric) *snapshot.ProviderOperation {
+		k := key{
+			provider:  redactAddress(labelValue(metric, "provider")),
+			operation: labelValue(metric, "operation"),
+		}
+		if _, exists := operations[k]; !exists {
+			operations[k] = &snapshot.ProviderOperation{
+				Provider:  k.provider,
+				Operation: k.operation,
+			}
+		}
+
+		return operations[k]
+	}
+
+	for _, metricFamily := range metricFamilies {
+		switch metricFamily.GetName() {
+		case clientOperationRequests:
+			for _, metric := range metricFamily.GetMetric() {
+				switch labelValue(metric, "result") {
+				case "succeeded":
+					operation(metric).Succeeded += uint64(metric.GetCounter().GetValue())
+				case "failed":
+					operation(metric).Failed += uint64(metric.GetCounter().GetValue())
+				}
+			}
+		case clientOperationDuration:
+			for _, metric := range metricFamily.GetMetric() {
+				histogram := metric.GetHistogram()
+				if histogram.GetSampleCount() > 0 {
+					operation(metric).AverageDuration = histogram.GetSampleSum() / float64(histogram.GetSampleCount())
+				}
+			}
+		}
+	}
+
+	res := make([]*snapshot.ProviderOperation, 0, len(operations))
+	for _, operation := range operations {
+		res = append(res, operation)
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		if res[i].Provider != res[j].Provider {
+			return res[i].Provider < res[j].Provider
+		}
+		return res[i].Operation < res[j].Operation
+	})
+
+	return res
+}
+
+// labelValue returns the value of the named label for the metric.
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+
+	return ""
+}
+
+// redactAddress removes any credentials, query parameters and fragments from an address.
+func redactAddress(address string) string {
+	if !strings.Contains(address, "://") {
+		// Not a URL, but could still contain user information.
+		if idx := strings.LastIndex(address, "@"); idx != -1 {
+			return address[idx+1:]
+		}
+
+		return address
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return unparseableAddress
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String()
+}
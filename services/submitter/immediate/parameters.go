@@ -18,6 +18,7 @@ import (
 	"context"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	"github.com/pkg/errors"
@@ -35,6 +36,12 @@ type parameters struct {
 	syncCommitteeMessagesSubmitter        eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionsSubmitter   eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitter   eth2client.SyncCommitteeContributionsSubmitter
+	fallbackProposalSubmitters            map[string]eth2client.ProposalSubmitter
+	verifyAttestations                    bool
+	fallbackAttestationsSubmitters        map[string]eth2client.AttestationsSubmitter
+	fallbackAggregateSubmitters           map[string]eth2client.AggregateAttestationsSubmitter
+	recoverLateAttestationsEnabled        bool
+	chainTime                             chaintime.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -69,6 +76,14 @@ func WithProposalSubmitter(submitter eth2client.ProposalSubmitter) Parameter {
 	})
 }
 
+// WithFallbackProposalSubmitters sets the proposal submitters to try if
+// the main proposal submitter fails.
+func WithFallbackProposalSubmitters(submitters map[string]eth2client.ProposalSubmitter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackProposalSubmitters = submitters
+	})
+}
+
 // WithAttestationsSubmitter sets the attestation submitter.
 func WithAttestationsSubmitter(submitter eth2client.AttestationsSubmitter) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -76,6 +91,45 @@ func WithAttestationsSubmitter(submitter eth2client.AttestationsSubmitter) Param
 	})
 }
 
+// WithVerifyAttestations sets whether to confirm that submitted attestations
+// are present in the beacon node's attestation pool.
+func WithVerifyAttestations(verify bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.verifyAttestations = verify
+	})
+}
+
+// WithFallbackAttestationsSubmitters sets the attestations submitters to try
+// if attestations are not accepted by the main attestations submitter.
+func WithFallbackAttestationsSubmitters(submitters map[string]eth2client.AttestationsSubmitter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackAttestationsSubmitters = submitters
+	})
+}
+
+// WithFallbackAggregateAttestationsSubmitters sets the aggregate attestations
+// submitters to try if the main aggregate attestations submitter fails.
+func WithFallbackAggregateAttestationsSubmitters(submitters map[string]eth2client.AggregateAttestationsSubmitter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fallbackAggregateSubmitters = submitters
+	})
+}
+
+// WithRecoverLateAttestations sets whether to submit attestations that have
+// not been accepted by any beacon node again at the start of the following slot.
+func WithRecoverLateAttestations(enabled bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.recoverLateAttestationsEnabled = enabled
+	})
+}
+
+// WithChainTime sets the chain time service.
+func WithChainTime(chainTime chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = chainTime
+	})
+}
+
 // WithSyncCommitteeMessagesSubmitter sets the sync committee messages submitter.
 func WithSyncCommitteeMessagesSubmitter(submitter eth2client.SyncCommitteeMessagesSubmitter) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -158,5 +212,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 		return nil, errors.New("no proposal preparations submitter specified")
 	}
 
+	if parameters.recoverLateAttestationsEnabled && parameters.chainTime == nil {
+		return nil, errors.New("no chain time specified")
+	}
+
 	return &parameters, nil
 }
@@ -0,0 +1,124 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package immediate
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// scheduleLateAttestationRecovery waits until the start of the slot after
+// the attestations and then submits them again.  Attestations remain
+// includable for much longer than this, but their value to the validator
+// falls with each slot of inclusion delay, so recovery is only attempted in
+// the following slot, and abandoned once that slot has passed.
+//
+// The context passed must be detached from that of the original submission,
+// which will usually have finished by the time recovery takes place.
+func (s *Service) scheduleLateAttestationRecovery(ctx context.Context,
+	attestations []*phase0.Attestation,
+) {
+	latestSlot := phase0.Slot(0)
+	for _, attestation := range attestations {
+		if attestation.Data.Slot > latestSlot {
+			latestSlot = attestation.Data.Slot
+		}
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, s.chainTime.StartOfSlot(latestSlot+2))
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(time.Until(s.chainTime.StartOfSlot(latestSlot + 1))):
+	}
+
+	s.recoverLateAttestations(ctx, attestations)
+}
+
+// recoverLateAttestations submits again to all beacon nodes those of the
+// given attestations that are no more than a slot old.
+// Attestations are only ever submitted as originally signed, and each at
+// most once, so recovery cannot create a slashable attestation.
+func (s *Service) recoverLateAttestations(ctx context.Context,
+	attestations []*phase0.Attestation,
+) {
+	currentSlot := s.chainTime.CurrentSlot()
+
+	recoverable := make([]*phase0.Attestation, 0, len(attestations))
+	s.recoveredMu.Lock()
+	for root, slot := range s.recovered {
+		if slot+1 < currentSlot {
+			delete(s.recovered, root)
+		}
+	}
+	for _, attestation := range attestations {
+		if attestation.Data.Slot+1 < currentSlot {
+			log.Debug().Uint64("slot", uint64(attestation.Data.Slot)).Msg("Attestation outside recovery window; not recovering")
+			continue
+		}
+		root, err := attestation.HashTreeRoot()
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to obtain attestation root; not recovering")
+			continue
+		}
+		if _, exists := s.recovered[root]; exists {
+			continue
+		}
+		s.recovered[root] = attestation.Data.Slot
+		recoverable = append(recoverable, attestation)
+	}
+	s.recoveredMu.Unlock()
+	if len(recoverable) == 0 {
+		return
+	}
+
+	submitters := map[string]eth2client.AttestationsSubmitter{}
+	for name, submitter := range s.fallbackAttestationsSubmitters {
+		submitters[name] = submitter
+	}
+	if service, isService := s.attestationsSubmitter.(eth2client.Service); isService {
+		submitters[service.Address()] = s.attestationsSubmitter
+	} else {
+		submitters["<unknown>"] = s.attestationsSubmitter
+	}
+	names := make([]string, 0, len(submitters))
+	for name := range submitters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	accepted := 0
+	for _, name := range names {
+		started := time.Now()
+		err := submitters[name].SubmitAttestations(ctx, recoverable)
+		s.clientMonitor.ClientOperation(name, "late attestation recovery", err == nil, time.Since(started))
+		if err != nil {
+			log.Debug().Str("beacon_node_address", name).Err(err).Msg("Failed to submit late attestations")
+			continue
+		}
+		accepted++
+	}
+
+	if accepted == 0 {
+		log.Warn().Int("attestations", len(recoverable)).Msg("Late attestations not accepted by any beacon node")
+		return
+	}
+	log.Info().Int("attestations", len(recoverable)).Int("beacon_nodes", accepted).Msg("Submitted late attestations")
+}
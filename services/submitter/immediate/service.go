@@ -16,6 +16,8 @@ package immediate
 import (
 	"context"
 	"encoding/json"
+	"sort"
+	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -23,6 +25,7 @@ import (
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -41,6 +44,16 @@ type Service struct {
 	syncCommitteeMessagesSubmitter        eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionsSubmitter   eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitter   eth2client.SyncCommitteeContributionsSubmitter
+	fallbackProposalSubmitters            map[string]eth2client.ProposalSubmitter
+	verifyAttestations                    bool
+	fallbackAttestationsSubmitters        map[string]eth2client.AttestationsSubmitter
+	fallbackAggregateSubmitters           map[string]eth2client.AggregateAttestationsSubmitter
+
+	// Late recovery of attestations not accepted by any beacon node.
+	recoverLateAttestationsEnabled bool
+	chainTime                      chaintime.Service
+	recoveredMu                    sync.Mutex
+	recovered                      map[phase0.Root]phase0.Slot
 }
 
 // module-wide log.
@@ -69,6 +82,13 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		syncCommitteeMessagesSubmitter:        parameters.syncCommitteeMessagesSubmitter,
 		syncCommitteeSubscriptionsSubmitter:   parameters.syncCommitteeSubscriptionsSubmitter,
 		syncCommitteeContributionsSubmitter:   parameters.syncCommitteeContributionsSubmitter,
+		fallbackProposalSubmitters:            parameters.fallbackProposalSubmitters,
+		verifyAttestations:                    parameters.verifyAttestations,
+		fallbackAttestationsSubmitters:        parameters.fallbackAttestationsSubmitters,
+		fallbackAggregateSubmitters:           parameters.fallbackAggregateSubmitters,
+		recoverLateAttestationsEnabled:        parameters.recoverLateAttestationsEnabled,
+		chainTime:                             parameters.chainTime,
+		recovered:                             make(map[phase0.Root]phase0.Slot),
 	}
 
 	return s, nil
@@ -83,14 +103,11 @@ func (s *Service) SubmitProposal(ctx context.Context, proposal *api.VersionedSig
 		return errors.New("no proposal supplied")
 	}
 
-	started := time.Now()
-	err := s.proposalSubmitter.SubmitProposal(ctx, &api.SubmitProposalOpts{
-		Proposal: proposal,
-	})
-	if service, isService := s.proposalSubmitter.(eth2client.Service); isService {
-		s.clientMonitor.ClientOperation(service.Address(), "submit proposal", err == nil, time.Since(started))
-	} else {
-		s.clientMonitor.ClientOperation("<unknown>", "submit proposal", err == nil, time.Since(started))
+	err := s.submitProposal(ctx, s.proposalSubmitter, proposal)
+	if err != nil && len(s.fallbackProposalSubmitters) > 0 {
+		// The beacon node may have failed since providing the proposal; try the alternatives.
+		log.Warn().Err(err).Msg("Failed to submit proposal; trying alternative beacon nodes")
+		err = s.submitProposalToFallbacks(ctx, proposal)
 	}
 	if err != nil {
 		return errors.Wrap(err, "failed to submit proposal")
@@ -106,6 +123,48 @@ func (s *Service) SubmitProposal(ctx context.Context, proposal *api.VersionedSig
 	return nil
 }
 
+// submitProposal submits a proposal to the given submitter.
+func (s *Service) submitProposal(ctx context.Context,
+	submitter eth2client.ProposalSubmitter,
+	proposal *api.VersionedSignedProposal,
+) error {
+	started := time.Now()
+	err := submitter.SubmitProposal(ctx, &api.SubmitProposalOpts{
+		Proposal: proposal,
+	})
+	if service, isService := submitter.(eth2client.Service); isService {
+		s.clientMonitor.ClientOperation(service.Address(), "submit proposal", err == nil, time.Since(started))
+	} else {
+		s.clientMonitor.ClientOperation("<unknown>", "submit proposal", err == nil, time.Since(started))
+	}
+
+	return err
+}
+
+// submitProposalToFallbacks submits a proposal to each of the fallback
+// submitters in turn, until one succeeds.
+func (s *Service) submitProposalToFallbacks(ctx context.Context,
+	proposal *api.VersionedSignedProposal,
+) error {
+	names := make([]string, 0, len(s.fallbackProposalSubmitters))
+	for name := range s.fallbackProposalSubmitters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var err error
+	for _, name := range names {
+		err = s.submitProposal(ctx, s.fallbackProposalSubmitters[name], proposal)
+		if err == nil {
+			log.Debug().Str("beacon_node_address", name).Msg("Submitted proposal to alternative beacon node")
+			return nil
+		}
+		log.Debug().Str("beacon_node_address", name).Err(err).Msg("Failed to submit proposal to alternative beacon node")
+	}
+
+	return err
+}
+
 // SubmitAttestations submits multiple attestations.
 func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
 	ctx, span := otel.Tracer("attestantio.vouch.services.submitter.immediate").Start(ctx, "SubmitAttestations")
@@ -133,6 +192,18 @@ func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0
 		}
 	}
 
+	if s.verifyAttestations {
+		// Verification makes further requests of the beacon nodes, so is
+		// carried out in the background rather than delaying the caller.
+		// The caller's context may be cancelled as soon as we return, so
+		// verification has its own context bounded by its own timeout.
+		go func(ctx context.Context) {
+			ctx, cancel := context.WithTimeout(ctx, attestationVerificationTimeout)
+			defer cancel()
+			s.verifySubmittedAttestations(ctx, attestations)
+		}(context.WithoutCancel(ctx))
+	}
+
 	return nil
 }
 
@@ -183,12 +254,11 @@ func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregates []
 		return errors.New("no aggregate attestations supplied")
 	}
 
-	started := time.Now()
-	err := s.aggregateAttestationsSubmitter.SubmitAggregateAttestations(ctx, aggregates)
-	if service, isService := s.aggregateAttestationsSubmitter.(eth2client.Service); isService {
-		s.clientMonitor.ClientOperation(service.Address(), "submit aggregate attestation", err == nil, time.Since(started))
-	} else {
-		s.clientMonitor.ClientOperation("<unknown>", "submit aggregate attestation", err == nil, time.Since(started))
+	err := s.submitAggregateAttestations(ctx, s.aggregateAttestationsSubmitter, aggregates)
+	if err != nil && len(s.fallbackAggregateSubmitters) > 0 {
+		// The beacon node may be unable to accept the aggregate; try the alternatives.
+		log.Warn().Err(err).Msg("Failed to submit aggregate attestations; trying alternative beacon nodes")
+		err = s.submitAggregateAttestationsToFallbacks(ctx, aggregates)
 	}
 	if err != nil {
 		return errors.Wrap(err, "failed to submit aggregate attestation")
@@ -204,6 +274,46 @@ func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregates []
 	return nil
 }
 
+// submitAggregateAttestations submits aggregate attestations to the given submitter.
+func (s *Service) submitAggregateAttestations(ctx context.Context,
+	submitter eth2client.AggregateAttestationsSubmitter,
+	aggregates []*phase0.SignedAggregateAndProof,
+) error {
+	started := time.Now()
+	err := submitter.SubmitAggregateAttestations(ctx, aggregates)
+	if service, isService := submitter.(eth2client.Service); isService {
+		s.clientMonitor.ClientOperation(service.Address(), "submit aggregate attestation", err == nil, time.Since(started))
+	} else {
+		s.clientMonitor.ClientOperation("<unknown>", "submit aggregate attestation", err == nil, time.Since(started))
+	}
+
+	return err
+}
+
+// submitAggregateAttestationsToFallbacks submits aggregate attestations to each
+// of the fallback submitters in turn, until one succeeds.
+func (s *Service) submitAggregateAttestationsToFallbacks(ctx context.Context,
+	aggregates []*phase0.SignedAggregateAndProof,
+) error {
+	names := make([]string, 0, len(s.fallbackAggregateSubmitters))
+	for name := range s.fallbackAggregateSubmitters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var err error
+	for _, name := range names {
+		err = s.submitAggregateAttestations(ctx, s.fallbackAggregateSubmitters[name], aggregates)
+		if err == nil {
+			log.Debug().Str("beacon_node_address", name).Msg("Submitted aggregate attestations to alternative beacon node")
+			return nil
+		}
+		log.Debug().Str("beacon_node_address", name).Err(err).Msg("Failed to submit aggregate attestations to alternative beacon node")
+	}
+
+	return err
+}
+
 // SubmitProposalPreparations submits proposal preparations.
 func (s *Service) SubmitProposalPreparations(ctx context.Context, preparations []*apiv1.ProposalPreparation) error {
 	ctx, span := otel.Tracer("attestantio.vouch.services.submitter.immediate").Start(ctx, "SubmitProposalPreparations")
@@ -17,6 +17,7 @@ import (
 	"context"
 	"testing"
 
+	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -275,6 +276,45 @@ func TestSubmitProposal(t *testing.T) {
 			proposal: &api.VersionedSignedProposal{},
 			err:      "failed to submit proposal: error",
 		},
+		{
+			name: "ErroringWithErroringFallbacks",
+			params: []immediate.Parameter{
+				immediate.WithLogLevel(zerolog.Disabled),
+				immediate.WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				immediate.WithProposalSubmitter(mock.NewErroringProposalSubmitter()),
+				immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediate.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+				immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+				immediate.WithFallbackProposalSubmitters(map[string]eth2client.ProposalSubmitter{
+					"1": mock.NewErroringProposalSubmitter(),
+					"2": mock.NewErroringProposalSubmitter(),
+				}),
+			},
+			proposal: &api.VersionedSignedProposal{},
+			err:      "failed to submit proposal: error",
+		},
+		{
+			name: "ErroringWithFallbacks",
+			params: []immediate.Parameter{
+				immediate.WithLogLevel(zerolog.Disabled),
+				immediate.WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				immediate.WithProposalSubmitter(mock.NewErroringProposalSubmitter()),
+				immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediate.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+				immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+				immediate.WithFallbackProposalSubmitters(map[string]eth2client.ProposalSubmitter{
+					"1": mock.NewErroringProposalSubmitter(),
+					"2": mock.NewProposalSubmitter(),
+				}),
+			},
+			proposal: &api.VersionedSignedProposal{},
+		},
 		{
 			name: "Good",
 			params: []immediate.Parameter{
@@ -449,6 +489,49 @@ func TestSubmitAggregateAttestations(t *testing.T) {
 			},
 			err: "failed to submit aggregate attestation: error",
 		},
+		{
+			name: "ErroringWithErroringFallbacks",
+			params: []immediate.Parameter{
+				immediate.WithLogLevel(zerolog.Disabled),
+				immediate.WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				immediate.WithProposalSubmitter(mock.NewProposalSubmitter()),
+				immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediate.WithAggregateAttestationsSubmitter(mock.NewErroringAggregateAttestationsSubmitter()),
+				immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+				immediate.WithFallbackAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+					"1": mock.NewErroringAggregateAttestationsSubmitter(),
+					"2": mock.NewErroringAggregateAttestationsSubmitter(),
+				}),
+			},
+			aggregates: []*phase0.SignedAggregateAndProof{
+				{},
+			},
+			err: "failed to submit aggregate attestation: error",
+		},
+		{
+			name: "ErroringWithFallbacks",
+			params: []immediate.Parameter{
+				immediate.WithLogLevel(zerolog.Disabled),
+				immediate.WithAttestationsSubmitter(mock.NewAttestationsSubmitter()),
+				immediate.WithProposalSubmitter(mock.NewProposalSubmitter()),
+				immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediate.WithAggregateAttestationsSubmitter(mock.NewErroringAggregateAttestationsSubmitter()),
+				immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+				immediate.WithFallbackAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+					"1": mock.NewErroringAggregateAttestationsSubmitter(),
+					"2": mock.NewAggregateAttestationsSubmitter(),
+				}),
+			},
+			aggregates: []*phase0.SignedAggregateAndProof{
+				{},
+			},
+		},
 		{
 			name: "Good",
 			params: []immediate.Parameter{
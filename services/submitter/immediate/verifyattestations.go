@@ -0,0 +1,182 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package immediate
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// attestationVerificationTimeout is the maximum time spent verifying a set of
+// submitted attestations, including submitting them to fallback beacon nodes.
+const attestationVerificationTimeout = 8 * time.Second
+
+// verifySubmittedAttestations confirms that the submitted attestations are
+// present in the attestation pool of the beacon node to which they were
+// submitted.  Any that are not present are submitted to each of the fallback
+// attestations submitters in turn, until all are accepted.
+func (s *Service) verifySubmittedAttestations(ctx context.Context,
+	attestations []*phase0.Attestation,
+) {
+	missing := s.unacceptedAttestations(ctx, "", s.attestationsSubmitter, attestations)
+	if len(missing) == 0 {
+		return
+	}
+	log.Warn().Int("missing", len(missing)).Msg("Submitted attestations not found in beacon node's attestation pool")
+
+	names := make([]string, 0, len(s.fallbackAttestationsSubmitters))
+	for name := range s.fallbackAttestationsSubmitters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		submitter := s.fallbackAttestationsSubmitters[name]
+		started := time.Now()
+		err := submitter.SubmitAttestations(ctx, missing)
+		s.clientMonitor.ClientOperation(name, "submit attestations", err == nil, time.Since(started))
+		if err != nil {
+			log.Debug().Str("beacon_node_address", name).Err(err).Msg("Failed to submit attestations to alternative beacon node")
+			continue
+		}
+		missing = s.unacceptedAttestations(ctx, name, submitter, missing)
+		if len(missing) == 0 {
+			log.Debug().Str("beacon_node_address", name).Msg("Attestations accepted by alternative beacon node")
+			return
+		}
+	}
+
+	log.Warn().Int("missing", len(missing)).Msg("Attestations not accepted by any beacon node")
+
+	if s.recoverLateAttestationsEnabled {
+		// Recovery takes place after verification has finished, so must
+		// not be cancelled along with it; it sets its own deadline.
+		go s.scheduleLateAttestationRecovery(context.WithoutCancel(ctx), missing)
+	}
+}
+
+// unacceptedAttestations returns the attestations that are not present in
+// the attestation pool of the given submitter.  If the pool cannot be
+// obtained then the attestations are assumed to have been accepted.
+func (s *Service) unacceptedAttestations(ctx context.Context,
+	name string,
+	submitter eth2client.AttestationsSubmitter,
+	attestations []*phase0.Attestation,
+) []*phase0.Attestation {
+	if name == "" {
+		name = "<unknown>"
+		if service, isService := submitter.(eth2client.Service); isService {
+			name = service.Address()
+		}
+	}
+
+	poolProvider, isProvider := submitter.(eth2client.AttestationPoolProvider)
+	if !isProvider {
+		log.Debug().Str("beacon_node_address", name).Msg("Beacon node cannot provide attestation pool; cannot verify attestations")
+		return nil
+	}
+
+	started := time.Now()
+	pools := make(map[phase0.Slot][]*phase0.Attestation)
+	missing := make([]*phase0.Attestation, 0)
+	for _, attestation := range attestations {
+		slot := attestation.Data.Slot
+		if _, exists := pools[slot]; !exists {
+			pool, err := s.attestationPool(ctx, name, poolProvider, slot)
+			if err != nil {
+				log.Debug().Str("beacon_node_address", name).Uint64("slot", uint64(slot)).Err(err).Msg("Failed to obtain attestation pool; cannot verify attestations")
+				return nil
+			}
+			pools[slot] = pool
+		}
+		accepted, err := attestationInPool(attestation, pools[slot])
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to check attestation against pool")
+			continue
+		}
+		if !accepted {
+			missing = append(missing, attestation)
+		}
+	}
+	s.clientMonitor.ClientOperation(name, "attestation acceptance", len(missing) == 0, time.Since(started))
+
+	return missing
+}
+
+// attestationPool obtains the attestation pool for the given slot.
+func (s *Service) attestationPool(ctx context.Context,
+	name string,
+	provider eth2client.AttestationPoolProvider,
+	slot phase0.Slot,
+) (
+	[]*phase0.Attestation,
+	error,
+) {
+	started := time.Now()
+	poolResponse, err := provider.AttestationPool(ctx, &api.AttestationPoolOpts{
+		Slot: slot,
+	})
+	s.clientMonitor.ClientOperation(name, "attestation pool", err == nil, time.Since(started))
+	if err != nil {
+		return nil, err
+	}
+
+	return poolResponse.Data, nil
+}
+
+// attestationInPool returns true if the pool contains an attestation with the
+// same data that includes all of the attesters of the given attestation.
+func attestationInPool(attestation *phase0.Attestation,
+	pool []*phase0.Attestation,
+) (
+	bool,
+	error,
+) {
+	dataRoot, err := attestation.Data.HashTreeRoot()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain attestation data root")
+	}
+
+	for _, poolAttestation := range pool {
+		if poolAttestation.Data == nil || poolAttestation.AggregationBits.Len() != attestation.AggregationBits.Len() {
+			continue
+		}
+		poolDataRoot, err := poolAttestation.Data.HashTreeRoot()
+		if err != nil {
+			continue
+		}
+		if !bytes.Equal(dataRoot[:], poolDataRoot[:]) {
+			continue
+		}
+		contained := true
+		for _, index := range attestation.AggregationBits.BitIndices() {
+			if !poolAttestation.AggregationBits.BitAt(uint64(index)) {
+				contained = false
+				break
+			}
+		}
+		if contained {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
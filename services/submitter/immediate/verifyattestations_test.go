@@ -0,0 +1,272 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package immediate_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/submitter/immediate"
+	"github.com/attestantio/vouch/testing/logger"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// poolingAttestationsSubmitter is an attestations submitter that also
+// provides its attestation pool.  If dropping is set then submissions
+// succeed but the attestations are never added to the pool.
+type poolingAttestationsSubmitter struct {
+	mu           sync.Mutex
+	dropping     bool
+	submitted    int
+	lastDeadline time.Time
+	pool         []*phase0.Attestation
+}
+
+func (m *poolingAttestationsSubmitter) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.submitted += len(attestations)
+	m.lastDeadline, _ = ctx.Deadline()
+	if !m.dropping {
+		m.pool = append(m.pool, attestations...)
+	}
+
+	return nil
+}
+
+func (m *poolingAttestationsSubmitter) submissions() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.submitted
+}
+
+func (m *poolingAttestationsSubmitter) AttestationPool(_ context.Context,
+	opts *api.AttestationPoolOpts,
+) (
+	*api.Response[[]*phase0.Attestation],
+	error,
+) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data := make([]*phase0.Attestation, 0)
+	for _, attestation := range m.pool {
+		if attestation.Data.Slot == opts.Slot {
+			data = append(data, attestation)
+		}
+	}
+
+	return &api.Response[[]*phase0.Attestation]{
+		Data:     data,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func testAttestation(slot phase0.Slot, index uint64) *phase0.Attestation {
+	aggregationBits := bitfield.NewBitlist(128)
+	aggregationBits.SetBitAt(index, true)
+
+	return &phase0.Attestation{
+		AggregationBits: aggregationBits,
+		Data: &phase0.AttestationData{
+			Slot:            slot,
+			BeaconBlockRoot: phase0.Root{0x01},
+			Source:          &phase0.Checkpoint{Root: phase0.Root{0x02}},
+			Target:          &phase0.Checkpoint{Root: phase0.Root{0x03}},
+		},
+	}
+}
+
+func TestVerifyAttestations(t *testing.T) {
+	tests := []struct {
+		name              string
+		main              *poolingAttestationsSubmitter
+		fallbacks         map[string]*poolingAttestationsSubmitter
+		verify            bool
+		fallbackSubmitted map[string]int
+		logEntries        []string
+	}{
+		{
+			name:              "Accepted",
+			main:              &poolingAttestationsSubmitter{},
+			fallbacks:         map[string]*poolingAttestationsSubmitter{"1": {}},
+			verify:            true,
+			fallbackSubmitted: map[string]int{"1": 0},
+		},
+		{
+			name:              "DroppedNotVerified",
+			main:              &poolingAttestationsSubmitter{dropping: true},
+			fallbacks:         map[string]*poolingAttestationsSubmitter{"1": {}},
+			fallbackSubmitted: map[string]int{"1": 0},
+		},
+		{
+			name:              "DroppedAcceptedByFallback",
+			main:              &poolingAttestationsSubmitter{dropping: true},
+			fallbacks:         map[string]*poolingAttestationsSubmitter{"1": {}, "2": {}},
+			verify:            true,
+			fallbackSubmitted: map[string]int{"1": 2, "2": 0},
+			logEntries: []string{
+				"Submitted attestations not found in beacon node's attestation pool",
+				"Attestations accepted by alternative beacon node",
+			},
+		},
+		{
+			name:              "DroppedByFirstFallback",
+			main:              &poolingAttestationsSubmitter{dropping: true},
+			fallbacks:         map[string]*poolingAttestationsSubmitter{"1": {dropping: true}, "2": {}},
+			verify:            true,
+			fallbackSubmitted: map[string]int{"1": 2, "2": 2},
+			logEntries: []string{
+				"Submitted attestations not found in beacon node's attestation pool",
+				"Attestations accepted by alternative beacon node",
+			},
+		},
+		{
+			name:              "DroppedByAll",
+			main:              &poolingAttestationsSubmitter{dropping: true},
+			fallbacks:         map[string]*poolingAttestationsSubmitter{"1": {dropping: true}},
+			verify:            true,
+			fallbackSubmitted: map[string]int{"1": 2},
+			logEntries: []string{
+				"Submitted attestations not found in beacon node's attestation pool",
+				"Attestations not accepted by any beacon node",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+
+			fallbacks := make(map[string]eth2client.AttestationsSubmitter, len(test.fallbacks))
+			for name, fallback := range test.fallbacks {
+				fallbacks[name] = fallback
+			}
+			s, err := immediate.New(context.Background(),
+				immediate.WithLogLevel(zerolog.TraceLevel),
+				immediate.WithAttestationsSubmitter(test.main),
+				immediate.WithVerifyAttestations(test.verify),
+				immediate.WithFallbackAttestationsSubmitters(fallbacks),
+				immediate.WithProposalSubmitter(mock.NewProposalSubmitter()),
+				immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+				immediate.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+				immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+				immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+				immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+				immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+			)
+			require.NoError(t, err)
+
+			err = s.SubmitAttestations(context.Background(), []*phase0.Attestation{
+				testAttestation(1, 0),
+				testAttestation(2, 5),
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, 2, test.main.submissions())
+
+			// Verification takes place in the background.
+			for _, entry := range test.logEntries {
+				require.Eventually(t, func() bool {
+					return capture.HasLog(map[string]any{"message": entry})
+				}, time.Second, 10*time.Millisecond, entry)
+			}
+			time.Sleep(50 * time.Millisecond)
+			for name, submitted := range test.fallbackSubmitted {
+				require.Equal(t, submitted, test.fallbacks[name].submissions(), name)
+			}
+		})
+	}
+}
+
+func TestRecoverLateAttestations(t *testing.T) {
+	ctx := context.Background()
+
+	// Current slot is 10.
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().Add(-10*12*time.Second))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(10), chainTime.CurrentSlot())
+
+	capture := logger.NewLogCapture()
+	primary := &poolingAttestationsSubmitter{dropping: true}
+	fallback := &poolingAttestationsSubmitter{dropping: true}
+	s, err := immediate.New(ctx,
+		immediate.WithLogLevel(zerolog.TraceLevel),
+		immediate.WithAttestationsSubmitter(primary),
+		immediate.WithVerifyAttestations(true),
+		immediate.WithFallbackAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{"1": fallback}),
+		immediate.WithRecoverLateAttestations(true),
+		immediate.WithChainTime(chainTime),
+		immediate.WithProposalSubmitter(mock.NewProposalSubmitter()),
+		immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+		immediate.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+		immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+		immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+		immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+		immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+	)
+	require.NoError(t, err)
+
+	// The attestation for slot 9 is within the recovery window, but that for slot 5 is not.
+	attestations := []*phase0.Attestation{
+		testAttestation(9, 0),
+		testAttestation(5, 1),
+	}
+	require.NoError(t, s.SubmitAttestations(ctx, attestations))
+	require.Eventually(t, func() bool {
+		return primary.submissions() == 3 && fallback.submissions() == 3
+	}, time.Second, 10*time.Millisecond)
+	capture.AssertHasEntry(t, "Submitted late attestations")
+
+	// Recovery is abandoned at the end of the slot after the attestations.
+	fallback.mu.Lock()
+	require.Equal(t, chainTime.StartOfSlot(11), fallback.lastDeadline)
+	fallback.mu.Unlock()
+
+	// A recovered attestation is not recovered a second time.
+	require.NoError(t, s.SubmitAttestations(ctx, attestations))
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 5, primary.submissions())
+	require.Equal(t, 5, fallback.submissions())
+}
+
+func TestRecoverLateAttestationsParameters(t *testing.T) {
+	_, err := immediate.New(context.Background(),
+		immediate.WithLogLevel(zerolog.Disabled),
+		immediate.WithAttestationsSubmitter(&poolingAttestationsSubmitter{}),
+		immediate.WithVerifyAttestations(true),
+		immediate.WithRecoverLateAttestations(true),
+		immediate.WithProposalSubmitter(mock.NewProposalSubmitter()),
+		immediate.WithBeaconCommitteeSubscriptionsSubmitter(mock.NewBeaconCommitteeSubscriptionsSubmitter()),
+		immediate.WithAggregateAttestationsSubmitter(mock.NewAggregateAttestationsSubmitter()),
+		immediate.WithProposalPreparationsSubmitter(mock.NewProposalPreparationsSubmitter()),
+		immediate.WithSyncCommitteeSubscriptionsSubmitter(mock.NewSyncCommitteeSubscriptionsSubmitter()),
+		immediate.WithSyncCommitteeMessagesSubmitter(mock.NewSyncCommitteeMessagesSubmitter()),
+		immediate.WithSyncCommitteeContributionsSubmitter(mock.NewSyncCommitteeContributionsSubmitter()),
+	)
+	require.EqualError(t, err, "problem with parameters: no chain time specified")
+}
@@ -17,6 +17,7 @@ package multinode
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -39,6 +40,11 @@ type parameters struct {
 	syncCommitteeMessagesSubmitter         map[string]eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionsSubmitters   map[string]eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitters   map[string]eth2client.SyncCommitteeContributionsSubmitter
+	proposalQuorum                         int
+	attestationsQuorum                     int
+	aggregateAttestationsQuorum            int
+	syncCommitteeMessagesQuorum            int
+	syncCommitteeContributionsQuorum       int
 }
 
 // Parameter is the interface for service parameters.
@@ -136,11 +142,51 @@ func WithSyncCommitteeContributionsSubmitters(submitters map[string]eth2client.S
 	})
 }
 
+// WithProposalQuorum sets the number of successful submissions of proposals required before returning.
+func WithProposalQuorum(quorum int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalQuorum = quorum
+	})
+}
+
+// WithAttestationsQuorum sets the number of successful submissions of attestations required before returning.
+func WithAttestationsQuorum(quorum int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attestationsQuorum = quorum
+	})
+}
+
+// WithAggregateAttestationsQuorum sets the number of successful submissions of aggregate attestations required before returning.
+func WithAggregateAttestationsQuorum(quorum int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.aggregateAttestationsQuorum = quorum
+	})
+}
+
+// WithSyncCommitteeMessagesQuorum sets the number of successful submissions of sync committee messages required before returning.
+func WithSyncCommitteeMessagesQuorum(quorum int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.syncCommitteeMessagesQuorum = quorum
+	})
+}
+
+// WithSyncCommitteeContributionsQuorum sets the number of successful submissions of sync committee contributions required before returning.
+func WithSyncCommitteeContributionsQuorum(quorum int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.syncCommitteeContributionsQuorum = quorum
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel:      zerolog.GlobalLevel(),
-		clientMonitor: nullmetrics.New(context.Background()),
+		logLevel:                         zerolog.GlobalLevel(),
+		clientMonitor:                    nullmetrics.New(context.Background()),
+		proposalQuorum:                   1,
+		attestationsQuorum:               1,
+		aggregateAttestationsQuorum:      1,
+		syncCommitteeMessagesQuorum:      1,
+		syncCommitteeContributionsQuorum: 1,
 	}
 	for _, p := range params {
 		if params != nil {
@@ -181,6 +227,33 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if len(parameters.syncCommitteeContributionsSubmitters) == 0 {
 		return nil, errors.New("no sync committee contributions submitters specified")
 	}
+	if err := checkQuorum("proposal", parameters.proposalQuorum, len(parameters.proposalSubmitters)); err != nil {
+		return nil, err
+	}
+	if err := checkQuorum("attestations", parameters.attestationsQuorum, len(parameters.attestationsSubmitters)); err != nil {
+		return nil, err
+	}
+	if err := checkQuorum("aggregate attestations", parameters.aggregateAttestationsQuorum, len(parameters.aggregateAttestationsSubmitters)); err != nil {
+		return nil, err
+	}
+	if err := checkQuorum("sync committee messages", parameters.syncCommitteeMessagesQuorum, len(parameters.syncCommitteeMessagesSubmitter)); err != nil {
+		return nil, err
+	}
+	if err := checkQuorum("sync committee contributions", parameters.syncCommitteeContributionsQuorum, len(parameters.syncCommitteeContributionsSubmitters)); err != nil {
+		return nil, err
+	}
 
 	return &parameters, nil
 }
+
+// checkQuorum checks that a quorum can be met by the supplied submitters.
+func checkQuorum(name string, quorum int, submitters int) error {
+	if quorum < 1 {
+		return fmt.Errorf("%s quorum must be at least 1", name)
+	}
+	if quorum > submitters {
+		return fmt.Errorf("%s quorum of %d greater than %d submitters", name, quorum, submitters)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multinode
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// quorum tracks successful submissions to beacon nodes, allowing the caller to
+// wait until the required number have succeeded.
+type quorum struct {
+	mu        sync.Mutex
+	required  int
+	succeeded int
+	reached   chan struct{}
+}
+
+// newQuorum creates a quorum requiring the given number of successful submissions.
+func newQuorum(required int) *quorum {
+	return &quorum{
+		required: required,
+		reached:  make(chan struct{}),
+	}
+}
+
+// success records a successful submission.
+func (q *quorum) success() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.succeeded++
+	if q.succeeded == q.required {
+		close(q.reached)
+	}
+}
+
+// wait waits for the quorum to be reached, returning an error if it is not
+// reached before the timeout.
+func (q *quorum) wait(timeout time.Duration) error {
+	select {
+	case <-q.reached:
+		return nil
+	case <-time.After(timeout):
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.succeeded >= q.required {
+		// Reached just as the timeout fired.
+		return nil
+	}
+	if q.succeeded == 0 {
+		return errors.New("no successful submissions before timeout")
+	}
+
+	return fmt.Errorf("%d of %d required successful submissions before timeout", q.succeeded, q.required)
+}
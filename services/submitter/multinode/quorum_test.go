@@ -0,0 +1,268 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multinode_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/submitter/multinode"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// quorumService creates a service with two submitters for each duty type.
+// If erroring is true the second submitter for each duty type fails.
+func quorumService(erroring bool, params ...multinode.Parameter) (*multinode.Service, error) {
+	proposalSubmitter := mock.NewProposalSubmitter()
+	attestationsSubmitter := mock.NewAttestationsSubmitter()
+	aggregateAttestationsSubmitter := mock.NewAggregateAttestationsSubmitter()
+	syncCommitteeMessagesSubmitter := mock.NewSyncCommitteeMessagesSubmitter()
+	syncCommitteeContributionsSubmitter := mock.NewSyncCommitteeContributionsSubmitter()
+	if erroring {
+		proposalSubmitter = mock.NewErroringProposalSubmitter()
+		attestationsSubmitter = mock.NewErroringAttestationsSubmitter()
+		aggregateAttestationsSubmitter = mock.NewErroringAggregateAttestationsSubmitter()
+		syncCommitteeMessagesSubmitter = mock.NewErroringSyncCommitteeMessagesSubmitter()
+		syncCommitteeContributionsSubmitter = mock.NewErroringSyncCommitteeContributionsSubmitter()
+	}
+
+	return multinode.New(context.Background(), append([]multinode.Parameter{
+		multinode.WithLogLevel(zerolog.Disabled),
+		multinode.WithTimeout(100 * time.Millisecond),
+		multinode.WithProcessConcurrency(2),
+		multinode.WithProposalSubmitters(map[string]eth2client.ProposalSubmitter{
+			"1": mock.NewProposalSubmitter(),
+			"2": proposalSubmitter,
+		}),
+		multinode.WithAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{
+			"1": mock.NewAttestationsSubmitter(),
+			"2": attestationsSubmitter,
+		}),
+		multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+			"1": mock.NewAggregateAttestationsSubmitter(),
+			"2": aggregateAttestationsSubmitter,
+		}),
+		multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+			"1": mock.NewSyncCommitteeMessagesSubmitter(),
+			"2": syncCommitteeMessagesSubmitter,
+		}),
+		multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+			"1": mock.NewSyncCommitteeContributionsSubmitter(),
+			"2": syncCommitteeContributionsSubmitter,
+		}),
+		multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+			"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+			"1": mock.NewProposalPreparationsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+			"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+		}),
+	}, params...)...)
+}
+
+func TestQuorumParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []multinode.Parameter
+		err    string
+	}{
+		{
+			name:   "ProposalQuorumZero",
+			params: []multinode.Parameter{multinode.WithProposalQuorum(0)},
+			err:    "problem with parameters: proposal quorum must be at least 1",
+		},
+		{
+			name:   "ProposalQuorumTooHigh",
+			params: []multinode.Parameter{multinode.WithProposalQuorum(3)},
+			err:    "problem with parameters: proposal quorum of 3 greater than 2 submitters",
+		},
+		{
+			name:   "AttestationsQuorumZero",
+			params: []multinode.Parameter{multinode.WithAttestationsQuorum(0)},
+			err:    "problem with parameters: attestations quorum must be at least 1",
+		},
+		{
+			name:   "AttestationsQuorumTooHigh",
+			params: []multinode.Parameter{multinode.WithAttestationsQuorum(3)},
+			err:    "problem with parameters: attestations quorum of 3 greater than 2 submitters",
+		},
+		{
+			name:   "AggregateAttestationsQuorumZero",
+			params: []multinode.Parameter{multinode.WithAggregateAttestationsQuorum(0)},
+			err:    "problem with parameters: aggregate attestations quorum must be at least 1",
+		},
+		{
+			name:   "AggregateAttestationsQuorumTooHigh",
+			params: []multinode.Parameter{multinode.WithAggregateAttestationsQuorum(3)},
+			err:    "problem with parameters: aggregate attestations quorum of 3 greater than 2 submitters",
+		},
+		{
+			name:   "SyncCommitteeMessagesQuorumZero",
+			params: []multinode.Parameter{multinode.WithSyncCommitteeMessagesQuorum(0)},
+			err:    "problem with parameters: sync committee messages quorum must be at least 1",
+		},
+		{
+			name:   "SyncCommitteeMessagesQuorumTooHigh",
+			params: []multinode.Parameter{multinode.WithSyncCommitteeMessagesQuorum(3)},
+			err:    "problem with parameters: sync committee messages quorum of 3 greater than 2 submitters",
+		},
+		{
+			name:   "SyncCommitteeContributionsQuorumZero",
+			params: []multinode.Parameter{multinode.WithSyncCommitteeContributionsQuorum(0)},
+			err:    "problem with parameters: sync committee contributions quorum must be at least 1",
+		},
+		{
+			name:   "SyncCommitteeContributionsQuorumTooHigh",
+			params: []multinode.Parameter{multinode.WithSyncCommitteeContributionsQuorum(3)},
+			err:    "problem with parameters: sync committee contributions quorum of 3 greater than 2 submitters",
+		},
+		{
+			name: "Good",
+			params: []multinode.Parameter{
+				multinode.WithProposalQuorum(2),
+				multinode.WithAttestationsQuorum(2),
+				multinode.WithAggregateAttestationsQuorum(1),
+				multinode.WithSyncCommitteeMessagesQuorum(2),
+				multinode.WithSyncCommitteeContributionsQuorum(1),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := quorumService(false, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQuorumSubmissions(t *testing.T) {
+	ctx := context.Background()
+
+	submissions := map[string]func(s *multinode.Service) error{
+		"Proposal": func(s *multinode.Service) error {
+			return s.SubmitProposal(ctx, &api.VersionedSignedProposal{
+				Version: spec.DataVersionDeneb,
+				Deneb: &apiv1deneb.SignedBlockContents{
+					SignedBlock: &deneb.SignedBeaconBlock{
+						Message: &deneb.BeaconBlock{
+							Slot: 1,
+						},
+					},
+				},
+			})
+		},
+		"Attestations": func(s *multinode.Service) error {
+			return s.SubmitAttestations(ctx, []*phase0.Attestation{
+				{
+					Data: &phase0.AttestationData{Slot: 1},
+				},
+			})
+		},
+		"AggregateAttestations": func(s *multinode.Service) error {
+			return s.SubmitAggregateAttestations(ctx, []*phase0.SignedAggregateAndProof{
+				{
+					Message: &phase0.AggregateAndProof{
+						Aggregate: &phase0.Attestation{
+							Data: &phase0.AttestationData{Slot: 1},
+						},
+					},
+				},
+			})
+		},
+		"SyncCommitteeMessages": func(s *multinode.Service) error {
+			return s.SubmitSyncCommitteeMessages(ctx, []*altair.SyncCommitteeMessage{
+				{
+					Slot: 1,
+				},
+			})
+		},
+		"SyncCommitteeContributions": func(s *multinode.Service) error {
+			return s.SubmitSyncCommitteeContributions(ctx, []*altair.SignedContributionAndProof{
+				{
+					Message: &altair.ContributionAndProof{
+						Contribution: &altair.SyncCommitteeContribution{
+							Slot: 1,
+						},
+					},
+				},
+			})
+		},
+	}
+	quorumParams := map[string]func(quorum int) multinode.Parameter{
+		"Proposal":                   multinode.WithProposalQuorum,
+		"Attestations":               multinode.WithAttestationsQuorum,
+		"AggregateAttestations":      multinode.WithAggregateAttestationsQuorum,
+		"SyncCommitteeMessages":      multinode.WithSyncCommitteeMessagesQuorum,
+		"SyncCommitteeContributions": multinode.WithSyncCommitteeContributionsQuorum,
+	}
+
+	tests := []struct {
+		name     string
+		quorum   int
+		erroring bool
+		err      string
+	}{
+		{
+			name:   "QuorumOne",
+			quorum: 1,
+		},
+		{
+			name:     "QuorumOneErroring",
+			quorum:   1,
+			erroring: true,
+		},
+		{
+			name:   "QuorumTwo",
+			quorum: 2,
+		},
+		{
+			name:     "QuorumTwoErroring",
+			quorum:   2,
+			erroring: true,
+			err:      "1 of 2 required successful submissions before timeout",
+		},
+	}
+
+	for dutyType, submit := range submissions {
+		for _, test := range tests {
+			t.Run(dutyType+test.name, func(t *testing.T) {
+				s, err := quorumService(test.erroring, quorumParams[dutyType](test.quorum))
+				require.NoError(t, err)
+				err = submit(s)
+				if test.err != "" {
+					require.EqualError(t, err, test.err)
+				} else {
+					require.NoError(t, err)
+				}
+			})
+		}
+	}
+}
@@ -37,6 +37,11 @@ type Service struct {
 	syncCommitteeMessagesSubmitter        map[string]eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionSubmitters   map[string]eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitters  map[string]eth2client.SyncCommitteeContributionsSubmitter
+	proposalQuorum                        int
+	attestationsQuorum                    int
+	aggregateAttestationsQuorum           int
+	syncCommitteeMessagesQuorum           int
+	syncCommitteeContributionsQuorum      int
 }
 
 // module-wide log.
@@ -67,6 +72,11 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		syncCommitteeMessagesSubmitter:        parameters.syncCommitteeMessagesSubmitter,
 		syncCommitteeSubscriptionSubmitters:   parameters.syncCommitteeSubscriptionsSubmitters,
 		syncCommitteeContributionsSubmitters:  parameters.syncCommitteeContributionsSubmitters,
+		proposalQuorum:                        parameters.proposalQuorum,
+		attestationsQuorum:                    parameters.attestationsQuorum,
+		aggregateAttestationsQuorum:           parameters.aggregateAttestationsQuorum,
+		syncCommitteeMessagesQuorum:           parameters.syncCommitteeMessagesQuorum,
+		syncCommitteeContributionsQuorum:      parameters.syncCommitteeContributionsQuorum,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
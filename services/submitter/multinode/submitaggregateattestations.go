@@ -15,7 +15,6 @@ package multinode
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -38,30 +37,20 @@ func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregates []
 		return errors.New("no aggregate attestations supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
+	q := newQuorum(s.aggregateAttestationsQuorum)
 	for name, submitter := range s.aggregateAttestationsSubmitters {
-		go s.submitAggregateAttestations(ctx, sem, w, name, aggregates, submitter)
+		go s.submitAggregateAttestations(ctx, sem, q, name, aggregates, submitter)
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return q.wait(s.timeout)
 }
 
 // submitAggregateAttestations carries out the internal work of submitting aggregate attestations.
 // skipcq: RVV-B0001
 func (s *Service) submitAggregateAttestations(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	aggregates []*phase0.SignedAggregateAndProof,
 	submitter eth2client.AggregateAttestationsSubmitter,
@@ -83,6 +72,6 @@ func (s *Service) submitAggregateAttestations(ctx context.Context,
 		return
 	}
 
-	w.Signal()
+	q.success()
 	log.Trace().Msg("Submitted aggregate attestations")
 }
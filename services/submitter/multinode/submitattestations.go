@@ -40,30 +40,20 @@ func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0
 		return errors.New("no attestations supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
+	q := newQuorum(s.attestationsQuorum)
 	for name, submitter := range s.attestationsSubmitters {
-		go s.submitAttestations(ctx, sem, w, name, attestations, submitter)
+		go s.submitAttestations(ctx, sem, q, name, attestations, submitter)
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return q.wait(s.timeout)
 }
 
 // submitAttestations carries out the internal work of submitting attestations.
 // skipcq: RVV-B0001
 func (s *Service) submitAttestations(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	attestations []*phase0.Attestation,
 	submitter eth2client.AttestationsSubmitter,
@@ -95,7 +85,7 @@ func (s *Service) submitAttestations(ctx context.Context,
 		return
 	}
 
-	w.Signal()
+	q.success()
 	log.Trace().Msg("Submitted attestations")
 }
 
@@ -15,7 +15,6 @@ package multinode
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -38,30 +37,20 @@ func (s *Service) SubmitProposal(ctx context.Context, proposal *api.VersionedSig
 		return errors.New("no proposal supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
+	q := newQuorum(s.proposalQuorum)
 	for name, submitter := range s.proposalSubmitters {
-		go s.submitProposal(ctx, sem, w, name, proposal, submitter)
+		go s.submitProposal(ctx, sem, q, name, proposal, submitter)
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return q.wait(s.timeout)
 }
 
 // submitProposal carries out the internal work of submitting beacon blocks.
 // skipcq: RVV-B0001
 func (s *Service) submitProposal(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	proposal *api.VersionedSignedProposal,
 	submitter eth2client.ProposalSubmitter,
@@ -95,6 +84,6 @@ func (s *Service) submitProposal(ctx context.Context,
 		return
 	}
 
-	w.Signal()
+	q.success()
 	log.Trace().Msg("Submitted proposal")
 }
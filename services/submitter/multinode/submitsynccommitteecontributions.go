@@ -17,7 +17,6 @@ import (
 	"context"
 	"encoding/json"
 	"strings"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -40,30 +39,20 @@ func (s *Service) SubmitSyncCommitteeContributions(ctx context.Context, contribu
 		return errors.New("no sync committee contribution and proofs supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
+	q := newQuorum(s.syncCommitteeContributionsQuorum)
 	for name, submitter := range s.syncCommitteeContributionsSubmitters {
-		go s.submitSyncCommitteeContributions(ctx, sem, w, name, contributionAndProofs, submitter)
+		go s.submitSyncCommitteeContributions(ctx, sem, q, name, contributionAndProofs, submitter)
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return q.wait(s.timeout)
 }
 
 // submitSyncCommitteeContributions carries out the internal work of submitting sync committee contributions.
 // skipcq: RVV-B0001
 func (s *Service) submitSyncCommitteeContributions(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	contributionAndProofs []*altair.SignedContributionAndProof,
 	submitter eth2client.SyncCommitteeContributionsSubmitter,
@@ -88,7 +77,7 @@ func (s *Service) submitSyncCommitteeContributions(ctx context.Context,
 		return
 	}
 
-	w.Signal()
+	q.success()
 	log.Trace().Msg("Submitted sync committee contribution and proofs")
 }
 
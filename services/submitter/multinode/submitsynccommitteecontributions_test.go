@@ -254,3 +254,62 @@ func TestSubmitSyncCommitteeContributionsSleepySuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestSubmitSyncCommitteeContributionsMixed(t *testing.T) {
+	ctx := context.Background()
+
+	capture := logger.NewLogCapture()
+
+	s, err := multinode.New(context.Background(),
+		multinode.WithLogLevel(zerolog.TraceLevel),
+		multinode.WithTimeout(2*time.Second),
+		multinode.WithProcessConcurrency(4),
+		multinode.WithAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{
+			"1": mock.NewAttestationsSubmitter(),
+		}),
+		multinode.WithProposalSubmitters(map[string]eth2client.ProposalSubmitter{
+			"1": mock.NewProposalSubmitter(),
+		}),
+		multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+			"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+			"1": mock.NewAggregateAttestationsSubmitter(),
+		}),
+		multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+			"1": mock.NewProposalPreparationsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+			"1": mock.NewSyncCommitteeMessagesSubmitter(),
+		}),
+		multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+			"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+			"1": mock.NewErroringSyncCommitteeContributionsSubmitter(),
+			"2": mock.NewErroringSyncCommitteeContributionsSubmitter(),
+			"3": mock.NewSleepySyncCommitteeContributionsSubmitter(50*time.Millisecond, mock.NewSyncCommitteeContributionsSubmitter()),
+			"4": mock.NewSleepySyncCommitteeContributionsSubmitter(time.Second, mock.NewSyncCommitteeContributionsSubmitter()),
+		}),
+	)
+	require.NoError(t, err)
+
+	started := time.Now()
+	err = s.SubmitSyncCommitteeContributions(ctx, []*altair.SignedContributionAndProof{
+		{
+			Message: &altair.ContributionAndProof{
+				Contribution: &altair.SyncCommitteeContribution{
+					Slot: 5,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	// Submission succeeds on the first acceptance, without waiting for slower nodes.
+	require.Less(t, time.Since(started), 500*time.Millisecond)
+
+	// Return happens prior to the log message, so wait before asserting.
+	time.Sleep(time.Millisecond)
+	capture.AssertHasEntry(t, "Failed to submit sync committee contribution and proofs")
+	capture.AssertHasEntry(t, "Submitted sync committee contribution and proofs")
+}
@@ -17,7 +17,6 @@ import (
 	"context"
 	"encoding/json"
 	"strings"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -40,30 +39,20 @@ func (s *Service) SubmitSyncCommitteeMessages(ctx context.Context, messages []*a
 		return errors.New("no sync committee messages supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
+	q := newQuorum(s.syncCommitteeMessagesQuorum)
 	for name, submitter := range s.syncCommitteeMessagesSubmitter {
-		go s.submitSyncCommitteeMessages(ctx, sem, w, name, messages, submitter)
+		go s.submitSyncCommitteeMessages(ctx, sem, q, name, messages, submitter)
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return q.wait(s.timeout)
 }
 
 // submitSyncCommitteeMessages carries out the internal work of submitting sync committee messages.
 // skipcq: RVV-B0001
 func (s *Service) submitSyncCommitteeMessages(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	messages []*altair.SyncCommitteeMessage,
 	submitter eth2client.SyncCommitteeMessagesSubmitter,
@@ -88,7 +77,7 @@ func (s *Service) submitSyncCommitteeMessages(ctx context.Context,
 		return
 	}
 
-	w.Signal()
+	q.success()
 	log.Trace().Msg("Submitted sync committee messages")
 }
 
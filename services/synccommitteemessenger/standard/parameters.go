@@ -15,6 +15,7 @@ package standard
 
 import (
 	"context"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/vouch/services/accountmanager"
@@ -36,6 +37,9 @@ type parameters struct {
 	syncCommitteeAggregator             synccommitteeaggregator.Service
 	specProvider                        eth2client.SpecProvider
 	beaconBlockRootProvider             eth2client.BeaconBlockRootProvider
+	beaconBlockHeadersProvider          eth2client.BeaconBlockHeadersProvider
+	headFreshnessWait                   time.Duration
+	retryRemainingFraction              float64
 	syncCommitteeMessagesSubmitter      submitter.SyncCommitteeMessagesSubmitter
 	validatingAccountsProvider          accountmanager.ValidatingAccountsProvider
 	syncCommitteeRootSigner             signer.SyncCommitteeRootSigner
@@ -103,6 +107,29 @@ func WithBeaconBlockRootProvider(provider eth2client.BeaconBlockRootProvider) Pa
 	})
 }
 
+// WithBeaconBlockHeadersProvider sets the beacon block headers provider.
+func WithBeaconBlockHeadersProvider(provider eth2client.BeaconBlockHeadersProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.beaconBlockHeadersProvider = provider
+	})
+}
+
+// WithHeadFreshnessWait sets the maximum time to wait for the beacon node's head
+// to reach the slot of the message before messaging.  0 disables the check.
+func WithHeadFreshnessWait(wait time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.headFreshnessWait = wait
+	})
+}
+
+// WithRetryRemainingFraction sets the fraction of the slot that must remain
+// for a failed sync committee message submission to be retried.
+func WithRetryRemainingFraction(fraction float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.retryRemainingFraction = fraction
+	})
+}
+
 // WithSyncCommitteeMessagesSubmitter sets the sync committee messages submitter.
 func WithSyncCommitteeMessagesSubmitter(submitter submitter.SyncCommitteeMessagesSubmitter) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -168,6 +195,15 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.beaconBlockRootProvider == nil {
 		return nil, errors.New("no beacon block root provider specified")
 	}
+	if parameters.headFreshnessWait < 0 {
+		return nil, errors.New("head freshness wait cannot be negative")
+	}
+	if parameters.headFreshnessWait > 0 && parameters.beaconBlockHeadersProvider == nil {
+		return nil, errors.New("no beacon block headers provider specified")
+	}
+	if parameters.retryRemainingFraction < 0 || parameters.retryRemainingFraction > 1 {
+		return nil, errors.New("retry remaining fraction must be between 0 and 1")
+	}
 	if parameters.syncCommitteeMessagesSubmitter == nil {
 		return nil, errors.New("no sync committee messages submitter specified")
 	}
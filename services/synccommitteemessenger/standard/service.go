@@ -32,6 +32,7 @@ import (
 	"github.com/attestantio/vouch/services/submitter"
 	"github.com/attestantio/vouch/services/synccommitteeaggregator"
 	"github.com/attestantio/vouch/services/synccommitteemessenger"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -39,6 +40,13 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
+// headFreshnessInterval is the interval between checks of the beacon node's head
+// when waiting for it to reach the slot of the message.
+const headFreshnessInterval = 100 * time.Millisecond
+
+// submissionRetryInterval is the interval between attempts to submit sync committee messages.
+const submissionRetryInterval = 500 * time.Millisecond
+
 // Service is a beacon block attester.
 type Service struct {
 	monitor                           metrics.SyncCommitteeMessageMonitor
@@ -51,6 +59,9 @@ type Service struct {
 	syncCommitteeAggregator           synccommitteeaggregator.Service
 	validatingAccountsProvider        accountmanager.ValidatingAccountsProvider
 	beaconBlockRootProvider           eth2client.BeaconBlockRootProvider
+	beaconBlockHeadersProvider        eth2client.BeaconBlockHeadersProvider
+	headFreshnessWait                 time.Duration
+	retryRemainingFraction            float64
 	syncCommitteeMessagesSubmitter    submitter.SyncCommitteeMessagesSubmitter
 	syncCommitteeSelectionSigner      signer.SyncCommitteeSelectionSigner
 	syncCommitteeRootSigner           signer.SyncCommitteeRootSigner
@@ -109,6 +120,9 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		syncCommitteeAggregator:           parameters.syncCommitteeAggregator,
 		validatingAccountsProvider:        parameters.validatingAccountsProvider,
 		beaconBlockRootProvider:           parameters.beaconBlockRootProvider,
+		beaconBlockHeadersProvider:        parameters.beaconBlockHeadersProvider,
+		headFreshnessWait:                 parameters.headFreshnessWait,
+		retryRemainingFraction:            parameters.retryRemainingFraction,
 		syncCommitteeMessagesSubmitter:    parameters.syncCommitteeMessagesSubmitter,
 		syncCommitteeSelectionSigner:      parameters.syncCommitteeSelectionSigner,
 		syncCommitteeRootSigner:           parameters.syncCommitteeRootSigner,
@@ -163,6 +177,10 @@ func (s *Service) Message(ctx context.Context, data interface{}) ([]*altair.Sync
 		return nil, errors.New("passed invalid data structure")
 	}
 
+	if s.headFreshnessWait > 0 {
+		s.waitForFreshHead(ctx, duty.Slot())
+	}
+
 	// Fetch the beacon block root.
 	beaconBlockRootResponse, err := s.beaconBlockRootProvider.BeaconBlockRoot(ctx, &api.BeaconBlockRootOpts{
 		Block: "head",
@@ -215,17 +233,75 @@ func (s *Service) Message(ctx context.Context, data interface{}) ([]*altair.Sync
 	}
 	wg.Wait()
 
-	if err := s.syncCommitteeMessagesSubmitter.SubmitSyncCommitteeMessages(ctx, msgs); err != nil {
-		log.Trace().Dur("elapsed", time.Since(started)).Err(err).Msg("Failed to submit sync committee messages")
+	slotStart := s.chainTimeService.StartOfSlot(duty.Slot())
+	retryDeadline := util.RetryDeadline(slotStart, s.chainTimeService.StartOfSlot(duty.Slot()+1).Sub(slotStart), s.retryRemainingFraction)
+	attempts, err := util.RetryUntil(ctx, retryDeadline, submissionRetryInterval, func(ctx context.Context) error {
+		err := s.syncCommitteeMessagesSubmitter.SubmitSyncCommitteeMessages(ctx, msgs)
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to submit sync committee messages")
+		}
+		return err
+	})
+	if err != nil {
+		log.Trace().Dur("elapsed", time.Since(started)).Int("attempts", attempts).Err(err).Msg("Failed to submit sync committee messages")
 		s.monitor.SyncCommitteeMessagesCompleted(started, duty.Slot(), len(msgs), "failed")
 		return nil, errors.Wrap(err, "failed to submit sync committee messages")
 	}
-	log.Trace().Dur("elapsed", time.Since(started)).Msg("Submitted sync committee messages")
+	log.Trace().Dur("elapsed", time.Since(started)).Int("attempts", attempts).Msg("Submitted sync committee messages")
 	s.monitor.SyncCommitteeMessagesCompleted(started, duty.Slot(), len(msgs), "succeeded")
 
 	return msgs, nil
 }
 
+// waitForFreshHead waits for the beacon node's head to reach the given slot,
+// up to the configured maximum wait.  If the head does not reach the slot in
+// time, most likely because the slot is empty, it returns regardless.
+// Returns true if the head reached the slot.
+func (s *Service) waitForFreshHead(ctx context.Context, slot phase0.Slot) bool {
+	ctx, span := otel.Tracer("attestantio.vouch.services.synccommitteemessenger.standard").Start(ctx, "waitForFreshHead")
+	defer span.End()
+
+	started := time.Now()
+	deadline := started.Add(s.headFreshnessWait)
+	for {
+		headSlot, err := s.headSlot(ctx)
+		switch {
+		case err != nil:
+			log.Debug().Err(err).Msg("Failed to obtain head slot")
+		case headSlot >= slot:
+			log.Trace().Dur("elapsed", time.Since(started)).Msg("Beacon node head is fresh")
+			return true
+		default:
+			log.Trace().Uint64("head_slot", uint64(headSlot)).Uint64("slot", uint64(slot)).Msg("Beacon node head is stale")
+		}
+
+		if time.Now().Add(headFreshnessInterval).After(deadline) {
+			log.Debug().Uint64("slot", uint64(slot)).Dur("elapsed", time.Since(started)).Msg("Beacon node head did not reach slot; messaging with current head")
+			return false
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(headFreshnessInterval):
+		}
+	}
+}
+
+// headSlot obtains the slot of the beacon node's head.
+func (s *Service) headSlot(ctx context.Context) (phase0.Slot, error) {
+	headerResponse, err := s.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+		Block: "head",
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain head beacon block header")
+	}
+	if headerResponse.Data == nil || headerResponse.Data.Header == nil || headerResponse.Data.Header.Message == nil {
+		return 0, errors.New("head beacon block header missing data")
+	}
+
+	return headerResponse.Data.Header.Message.Slot, nil
+}
+
 func (s *Service) contribute(ctx context.Context,
 	account e2wtypes.Account,
 	epoch phase0.Epoch,
@@ -0,0 +1,113 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+// headProvider returns a head that advances by one slot every call, up to a maximum.
+type headProvider struct {
+	mu      sync.Mutex
+	slot    phase0.Slot
+	maxSlot phase0.Slot
+	calls   int
+}
+
+func (p *headProvider) BeaconBlockHeader(_ context.Context,
+	_ *api.BeaconBlockHeaderOpts,
+) (
+	*api.Response[*apiv1.BeaconBlockHeader],
+	error,
+) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	slot := p.slot
+	if p.slot < p.maxSlot {
+		p.slot++
+	}
+
+	return &api.Response[*apiv1.BeaconBlockHeader]{
+		Data: &apiv1.BeaconBlockHeader{
+			Header: &phase0.SignedBeaconBlockHeader{
+				Message: &phase0.BeaconBlockHeader{
+					Slot: slot,
+				},
+			},
+		},
+	}, nil
+}
+
+func TestWaitForFreshHead(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		provider *headProvider
+		slot     phase0.Slot
+		wait     time.Duration
+		fresh    bool
+		calls    int
+	}{
+		{
+			name:     "Fresh",
+			provider: &headProvider{slot: 10, maxSlot: 10},
+			slot:     10,
+			wait:     time.Second,
+			fresh:    true,
+			calls:    1,
+		},
+		{
+			name:     "StaleThenFresh",
+			provider: &headProvider{slot: 8, maxSlot: 10},
+			slot:     10,
+			wait:     time.Second,
+			fresh:    true,
+			calls:    3,
+		},
+		{
+			name:     "Stale",
+			provider: &headProvider{slot: 9, maxSlot: 9},
+			slot:     10,
+			wait:     350 * time.Millisecond,
+			fresh:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				beaconBlockHeadersProvider: test.provider,
+				headFreshnessWait:          test.wait,
+			}
+			started := time.Now()
+			require.Equal(t, test.fresh, s.waitForFreshHead(ctx, test.slot))
+			if test.calls > 0 {
+				require.Equal(t, test.calls, test.provider.calls)
+			} else {
+				require.Greater(t, test.provider.calls, 1)
+			}
+			require.Less(t, time.Since(started), test.wait+headFreshnessInterval)
+		})
+	}
+}
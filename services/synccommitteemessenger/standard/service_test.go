@@ -15,10 +15,12 @@ package standard_test
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
 	mocketh2client "github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
 	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
 	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
@@ -26,10 +28,16 @@ import (
 	mocksigner "github.com/attestantio/vouch/services/signer/mock"
 	nullsubmitter "github.com/attestantio/vouch/services/submitter/null"
 	mocksynccommitteeaggregator "github.com/attestantio/vouch/services/synccommitteeaggregator/mock"
+	"github.com/attestantio/vouch/services/synccommitteemessenger"
 	"github.com/attestantio/vouch/services/synccommitteemessenger/standard"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 func TestService(t *testing.T) {
@@ -282,3 +290,103 @@ func TestService(t *testing.T) {
 		})
 	}
 }
+
+// forkAwareSigner records the epoch used to obtain the domain for each
+// signature, and returns a signature that depends on the fork for that epoch.
+type forkAwareSigner struct {
+	forkEpoch phase0.Epoch
+	mu        sync.Mutex
+	epochs    []phase0.Epoch
+}
+
+func (s *forkAwareSigner) SignSyncCommitteeRoot(_ context.Context,
+	_ e2wtypes.Account,
+	epoch phase0.Epoch,
+	_ phase0.Root,
+) (
+	phase0.BLSSignature,
+	error,
+) {
+	s.mu.Lock()
+	s.epochs = append(s.epochs, epoch)
+	s.mu.Unlock()
+
+	sig := phase0.BLSSignature{}
+	if epoch >= s.forkEpoch {
+		sig[0] = 0x01
+	}
+
+	return sig, nil
+}
+
+func TestMessageAcrossFork(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	nullSubmitter, err := nullsubmitter.New(ctx)
+	require.NoError(t, err)
+	mockETH2Client, err := mocketh2client.New(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	// The fork is part way through the first sync committee period.
+	forkEpoch := phase0.Epoch(10)
+	signer := &forkAwareSigner{forkEpoch: forkEpoch}
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithProcessConcurrency(1),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithChainTimeService(chainTime),
+		standard.WithSyncCommitteeAggregator(mocksynccommitteeaggregator.New()),
+		standard.WithSpecProvider(specProvider),
+		standard.WithBeaconBlockRootProvider(mockETH2Client),
+		standard.WithSyncCommitteeMessagesSubmitter(nullSubmitter),
+		standard.WithValidatingAccountsProvider(mockaccountmanager.NewValidatingAccountsProvider()),
+		standard.WithSyncCommitteeRootSigner(signer),
+		standard.WithSyncCommitteeSelectionSigner(mocksigner.New()),
+		standard.WithSyncCommitteeSubscriptionsSubmitter(nullSubmitter),
+	)
+	require.NoError(t, err)
+
+	lastPreForkSlot := chainTime.FirstSlotOfEpoch(forkEpoch) - 1
+	firstPostForkSlot := chainTime.FirstSlotOfEpoch(forkEpoch)
+
+	// Ensure that both slots are in the same sync committee period.
+	require.Equal(t,
+		uint64(chainTime.SlotToEpoch(lastPreForkSlot))/256,
+		uint64(chainTime.SlotToEpoch(firstPostForkSlot))/256,
+	)
+
+	for _, slot := range []phase0.Slot{lastPreForkSlot, firstPostForkSlot} {
+		duty := synccommitteemessenger.NewDuty(slot, map[phase0.ValidatorIndex][]phase0.CommitteeIndex{1: {0}})
+		duty.SetAccount(1, account)
+		msgs, err := s.Message(ctx, duty)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		if slot < firstPostForkSlot {
+			require.Equal(t, byte(0x00), msgs[0].Signature[0])
+		} else {
+			require.Equal(t, byte(0x01), msgs[0].Signature[0])
+		}
+	}
+
+	require.Equal(t, []phase0.Epoch{forkEpoch - 1, forkEpoch}, signer.epochs)
+}
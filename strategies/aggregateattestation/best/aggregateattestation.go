@@ -1,4 +1,4 @@
-// Copyright © 2020, 2022 Attestant Limited.
+// Copyright © 2020 - 2024 Attestant Limited.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -14,7 +14,9 @@
 package best
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -52,6 +54,7 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 
 	started := time.Now()
 	log := util.LogWithID(ctx, log, "strategy_id")
+	results := util.NewProviderResults(s.logResults, started, s.aggregateAttestationProviders)
 
 	// We have two timeouts: a soft timeout and a hard timeout.
 	// At the soft timeout, we return if we have any responses so far.
@@ -83,6 +86,7 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 		select {
 		case resp := <-respCh:
 			responded++
+			results.Succeeded(resp.provider, resp.score)
 			log.Trace().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", resp.provider).
@@ -97,6 +101,7 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 			}
 		case err := <-errCh:
 			errored++
+			results.Errored(err.provider, err.err)
 			log.Debug().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", err.provider).
@@ -132,6 +137,7 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 		select {
 		case resp := <-respCh:
 			responded++
+			results.Succeeded(resp.provider, resp.score)
 			log.Trace().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", resp.provider).
@@ -146,6 +152,7 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 			}
 		case err := <-errCh:
 			errored++
+			results.Errored(err.provider, err.err)
 			log.Debug().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", err.provider).
@@ -173,6 +180,7 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 		Int("timed_out", timedOut).
 		Msg("Results")
 
+	results.Log(log, bestProvider)
 	if bestAggregateAttestation == nil {
 		return nil, errors.New("no aggregate attestations received")
 	}
@@ -184,6 +192,11 @@ func (s *Service) AggregateAttestation(ctx context.Context,
 	if bestProvider != "" {
 		s.clientMonitor.StrategyOperation("best", bestProvider, "aggregate attestation", time.Since(started))
 	}
+	span.SetAttributes(
+		attribute.String("provider", bestProvider),
+		attribute.Float64("score", bestScore),
+		attribute.Int64("elapsed_ms", time.Since(started).Milliseconds()),
+	)
 
 	return &api.Response[*phase0.Attestation]{
 		Data:     bestAggregateAttestation,
@@ -222,6 +235,13 @@ func (s *Service) aggregateAttestation(ctx context.Context,
 		}
 		return
 	}
+	if err := verifyAggregateAttestationData(aggregateAttestation, opts.AttestationDataRoot); err != nil {
+		errCh <- &aggregateAttestationError{
+			provider: name,
+			err:      err,
+		}
+		return
+	}
 
 	score := s.scoreAggregateAttestation(ctx, name, aggregateAttestation)
 	respCh <- &aggregateAttestationResponse{
@@ -230,3 +250,21 @@ func (s *Service) aggregateAttestation(ctx context.Context,
 		score:     score,
 	}
 }
+
+// verifyAggregateAttestationData ensures that the aggregate attestation is for the
+// requested attestation data.  An aggregate for different data cannot be used,
+// regardless of how many attestations it contains.
+func verifyAggregateAttestationData(aggregate *phase0.Attestation, attestationDataRoot phase0.Root) error {
+	if aggregate.Data == nil {
+		return errors.New("aggregate attestation data nil")
+	}
+	root, err := aggregate.Data.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain aggregate attestation data root")
+	}
+	if !bytes.Equal(root[:], attestationDataRoot[:]) {
+		return fmt.Errorf("aggregate attestation data root %#x does not match requested root %#x", root, attestationDataRoot)
+	}
+
+	return nil
+}
@@ -28,7 +28,24 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// mockAttestationDataRoot returns the root of the attestation data provided
+// by the mock aggregate attestation provider for the given slot.
+func mockAttestationDataRoot(t *testing.T, slot phase0.Slot) phase0.Root {
+	t.Helper()
+
+	response, err := mock.NewAggregateAttestationProvider().AggregateAttestation(context.Background(), &api.AggregateAttestationOpts{
+		Slot: slot,
+	})
+	require.NoError(t, err)
+	root, err := response.Data.Data.HashTreeRoot()
+	require.NoError(t, err)
+
+	return root
+}
+
 func TestAggregateAttestation(t *testing.T) {
+	attestationDataRoot := mockAttestationDataRoot(t, 12345)
+
 	tests := []struct {
 		name                string
 		params              []best.Parameter
@@ -46,11 +63,8 @@ func TestAggregateAttestation(t *testing.T) {
 					"good": mock.NewAggregateAttestationProvider(),
 				}),
 			},
-			slot: 12345,
-			attestationDataRoot: phase0.Root{
-				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
-				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
-			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
 		},
 		{
 			name: "Timeout",
@@ -61,12 +75,9 @@ func TestAggregateAttestation(t *testing.T) {
 					"sleepy": mock.NewSleepyAggregateAttestationProvider(5*time.Second, mock.NewAggregateAttestationProvider()),
 				}),
 			},
-			slot: 12345,
-			attestationDataRoot: phase0.Root{
-				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
-				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
-			},
-			err: "no aggregate attestations received",
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			err:                 "no aggregate attestations received",
 		},
 		{
 			name: "GoodMixed",
@@ -78,11 +89,8 @@ func TestAggregateAttestation(t *testing.T) {
 					"sleepy": mock.NewSleepyAggregateAttestationProvider(time.Second, mock.NewAggregateAttestationProvider()),
 				}),
 			},
-			slot: 12345,
-			attestationDataRoot: phase0.Root{
-				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
-				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
-			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
 		},
 		{
 			name: "SoftTimeoutWithResponses",
@@ -94,12 +102,9 @@ func TestAggregateAttestation(t *testing.T) {
 					"sleepy": mock.NewSleepyAggregateAttestationProvider(2*time.Second, mock.NewAggregateAttestationProvider()),
 				}),
 			},
-			slot: 12345,
-			attestationDataRoot: phase0.Root{
-				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
-				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
-			},
-			logEntries: []string{"Soft timeout reached with responses"},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			logEntries:          []string{"Soft timeout reached with responses"},
 		},
 		{
 			name: "SoftTimeoutWithoutResponses",
@@ -110,12 +115,9 @@ func TestAggregateAttestation(t *testing.T) {
 					"sleepy": mock.NewSleepyAggregateAttestationProvider(2*time.Second, mock.NewAggregateAttestationProvider()),
 				}),
 			},
-			slot: 12345,
-			attestationDataRoot: phase0.Root{
-				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
-				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
-			},
-			logEntries: []string{"Soft timeout reached with no responses"},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			logEntries:          []string{"Soft timeout reached with no responses"},
 		},
 		{
 			name: "SoftTimeoutWithError",
@@ -127,12 +129,38 @@ func TestAggregateAttestation(t *testing.T) {
 					"sleepy": mock.NewSleepyAggregateAttestationProvider(2*time.Second, mock.NewAggregateAttestationProvider()),
 				}),
 			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			logEntries:          []string{"Soft timeout reached with no responses"},
+		},
+		{
+			name: "DataRootMismatch",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"good": mock.NewAggregateAttestationProvider(),
+				}),
+			},
 			slot: 12345,
 			attestationDataRoot: phase0.Root{
 				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
 				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
 			},
-			logEntries: []string{"Soft timeout reached with no responses"},
+			err: "no aggregate attestations received",
+		},
+		{
+			name: "DivergentOnly",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"divergent": mock.NewDivergentAggregateAttestationProvider(mock.NewAggregateAttestationProvider()),
+				}),
+			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			err:                 "no aggregate attestations received",
 		},
 	}
 
@@ -157,3 +185,29 @@ func TestAggregateAttestation(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateAttestationDivergentData(t *testing.T) {
+	ctx := context.Background()
+	attestationDataRoot := mockAttestationDataRoot(t, 12345)
+
+	s, err := best.New(ctx,
+		best.WithLogLevel(zerolog.Disabled),
+		best.WithTimeout(2*time.Second),
+		best.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+			"correct":   mock.NewAggregateAttestationProvider(),
+			"divergent": mock.NewDivergentAggregateAttestationProvider(mock.NewAggregateAttestationProvider()),
+		}),
+	)
+	require.NoError(t, err)
+
+	// The divergent aggregate is larger, but must not be selected.
+	aggregate, err := s.AggregateAttestation(ctx, &api.AggregateAttestationOpts{
+		Slot:                12345,
+		AttestationDataRoot: attestationDataRoot,
+	})
+	require.NoError(t, err)
+	root, err := aggregate.Data.Data.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, attestationDataRoot, phase0.Root(root))
+	require.Equal(t, uint64(6), aggregate.Data.AggregationBits.Count())
+}
@@ -33,6 +33,9 @@ type parameters struct {
 	processConcurrency            int64
 	aggregateAttestationProviders map[string]eth2client.AggregateAttestationProvider
 	timeout                       time.Duration
+	completionThreshold           float64
+	completionBonus               float64
+	logResults                    bool
 }
 
 // Parameter is the interface for service parameters.
@@ -81,12 +84,36 @@ func WithTimeout(timeout time.Duration) Parameter {
 	})
 }
 
+// WithCompletionThreshold sets the fraction of a committee that an aggregate
+// must include to be considered complete.
+func WithCompletionThreshold(threshold float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.completionThreshold = threshold
+	})
+}
+
+// WithCompletionBonus sets the bonus added to the score of aggregates that
+// are considered complete.
+func WithCompletionBonus(bonus float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.completionBonus = bonus
+	})
+}
+
+// WithLogResults logs the result from each provider for each call to the strategy.
+func WithLogResults(logResults bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logResults = logResults
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel:           zerolog.GlobalLevel(),
-		clientMonitor:      nullmetrics.New(context.Background()),
-		processConcurrency: int64(runtime.GOMAXPROCS(-1)),
+		logLevel:            zerolog.GlobalLevel(),
+		clientMonitor:       nullmetrics.New(context.Background()),
+		processConcurrency:  int64(runtime.GOMAXPROCS(-1)),
+		completionThreshold: 1,
 	}
 	for _, p := range params {
 		if params != nil {
@@ -106,6 +133,12 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if len(parameters.aggregateAttestationProviders) == 0 {
 		return nil, errors.New("no aggregate attestation providers specified")
 	}
+	if parameters.completionThreshold <= 0 || parameters.completionThreshold > 1 {
+		return nil, errors.New("completion threshold must be greater than 0 and no more than 1")
+	}
+	if parameters.completionBonus < 0 {
+		return nil, errors.New("completion bonus cannot be negative")
+	}
 
 	return &parameters, nil
 }
@@ -20,8 +20,9 @@ import (
 )
 
 // scoreAggregateAttestation generates a score for an aggregate attestation.
-// The score is relative to the completeness of the aggregate.
-func (*Service) scoreAggregateAttestation(_ context.Context,
+// The score is relative to the completeness of the aggregate, with a bonus
+// if the aggregate includes at least the completion threshold of its committee.
+func (s *Service) scoreAggregateAttestation(_ context.Context,
 	name string,
 	aggregate *phase0.Attestation,
 ) float64 {
@@ -37,6 +38,9 @@ func (*Service) scoreAggregateAttestation(_ context.Context,
 		}
 	}
 	score := float64(included) / float64(total)
+	if s.completionBonus > 0 && score >= s.completionThreshold {
+		score += s.completionBonus
+	}
 
 	log.Trace().
 		Str("provider", name).
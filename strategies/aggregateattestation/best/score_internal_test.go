@@ -86,3 +86,68 @@ func TestScore(t *testing.T) {
 		})
 	}
 }
+
+func TestScoreCompletionBonus(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithTimeout(2*time.Second),
+		WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+			"good": mock.NewAggregateAttestationProvider(),
+		}),
+		WithCompletionThreshold(0.95),
+		WithCompletionBonus(0.1),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		aggregate *phase0.Attestation
+		score     float64
+	}{
+		{
+			name: "Partial",
+			aggregate: &phase0.Attestation{
+				AggregationBits: populatedBitlist(100, 90),
+				Data: &phase0.AttestationData{
+					Slot: 5,
+				},
+			},
+			score: 0.9,
+		},
+		{
+			name: "NearlyComplete",
+			aggregate: &phase0.Attestation{
+				AggregationBits: populatedBitlist(100, 95),
+				Data: &phase0.AttestationData{
+					Slot: 5,
+				},
+			},
+			score: 1.05,
+		},
+		{
+			name: "Complete",
+			aggregate: &phase0.Attestation{
+				AggregationBits: populatedBitlist(100, 100),
+				Data: &phase0.AttestationData{
+					Slot: 5,
+				},
+			},
+			score: 1.1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			score := s.scoreAggregateAttestation(ctx, "test", test.aggregate)
+			require.InDelta(t, test.score, score, 1e-9)
+		})
+	}
+
+	// A completing aggregate should outscore a partial aggregate by more
+	// than the difference in participation alone.
+	partial := s.scoreAggregateAttestation(ctx, "test", tests[0].aggregate)
+	complete := s.scoreAggregateAttestation(ctx, "test", tests[2].aggregate)
+	require.Greater(t, complete-partial, 0.1)
+}
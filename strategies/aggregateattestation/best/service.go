@@ -30,6 +30,9 @@ type Service struct {
 	processConcurrency            int64
 	aggregateAttestationProviders map[string]eth2client.AggregateAttestationProvider
 	timeout                       time.Duration
+	completionThreshold           float64
+	completionBonus               float64
+	logResults                    bool
 }
 
 // module-wide log.
@@ -50,9 +53,12 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 
 	s := &Service{
 		timeout:                       parameters.timeout,
+		logResults:                    parameters.logResults,
 		clientMonitor:                 parameters.clientMonitor,
 		processConcurrency:            parameters.processConcurrency,
 		aggregateAttestationProviders: parameters.aggregateAttestationProviders,
+		completionThreshold:           parameters.completionThreshold,
+		completionBonus:               parameters.completionBonus,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
@@ -90,6 +90,26 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no aggregate attestation providers specified",
 		},
+		{
+			name: "CompletionThresholdZero",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				best.WithCompletionThreshold(0),
+			},
+			err: "problem with parameters: completion threshold must be greater than 0 and no more than 1",
+		},
+		{
+			name: "CompletionBonusNegative",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				best.WithCompletionBonus(-0.1),
+			},
+			err: "problem with parameters: completion bonus cannot be negative",
+		},
 		{
 			name: "Good",
 			params: []best.Parameter{
@@ -0,0 +1,118 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fallback_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/strategies/aggregateattestation/fallback"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingAggregateAttestationProvider is a provider that does not return until its context is done.
+type blockingAggregateAttestationProvider struct{}
+
+func (*blockingAggregateAttestationProvider) AggregateAttestation(ctx context.Context,
+	_ *api.AggregateAttestationOpts,
+) (
+	*api.Response[*phase0.Attestation],
+	error,
+) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func TestAggregateAttestation(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []eth2client.AggregateAttestationProvider
+		err       string
+	}{
+		{
+			name: "Good",
+			providers: []eth2client.AggregateAttestationProvider{
+				mock.NewAggregateAttestationProvider(),
+				mock.NewErroringAggregateAttestationProvider(),
+			},
+		},
+		{
+			name: "PrimaryErroring",
+			providers: []eth2client.AggregateAttestationProvider{
+				mock.NewErroringAggregateAttestationProvider(),
+				mock.NewAggregateAttestationProvider(),
+			},
+		},
+		{
+			name: "PrimaryAndSecondaryErroring",
+			providers: []eth2client.AggregateAttestationProvider{
+				mock.NewErroringAggregateAttestationProvider(),
+				mock.NewErroringAggregateAttestationProvider(),
+				mock.NewAggregateAttestationProvider(),
+			},
+		},
+		{
+			name: "AllErroring",
+			providers: []eth2client.AggregateAttestationProvider{
+				mock.NewErroringAggregateAttestationProvider(),
+				mock.NewErroringAggregateAttestationProvider(),
+			},
+			err: "no strategy provided aggregate attestation",
+		},
+		{
+			name: "SlowPrimary",
+			providers: []eth2client.AggregateAttestationProvider{
+				&blockingAggregateAttestationProvider{},
+				mock.NewAggregateAttestationProvider(),
+			},
+		},
+		{
+			name: "PrimaryExhaustsTimeout",
+			providers: []eth2client.AggregateAttestationProvider{
+				mock.NewSleepyAggregateAttestationProvider(2*time.Second, mock.NewErroringAggregateAttestationProvider()),
+				mock.NewAggregateAttestationProvider(),
+			},
+			err: "no strategy provided aggregate attestation",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := fallback.New(context.Background(),
+				fallback.WithLogLevel(zerolog.Disabled),
+				fallback.WithTimeout(time.Second),
+				fallback.WithAggregateAttestationProviders(test.providers),
+			)
+			require.NoError(t, err)
+
+			response, err := s.AggregateAttestation(context.Background(), &api.AggregateAttestationOpts{
+				Slot: 1,
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				require.NotNil(t, response.Data)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fallback is a strategy that obtains aggregate attestation from an
+// ordered list of strategies, moving on to the next strategy if the
+// previous one fails to provide a result.
+package fallback
+
+import (
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                      zerolog.Level
+	aggregateAttestationProviders []eth2client.AggregateAttestationProvider
+	timeout                       time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithAggregateAttestationProviders sets the aggregate attestation providers, in the order in which they are tried.
+func WithAggregateAttestationProviders(providers []eth2client.AggregateAttestationProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.aggregateAttestationProviders = providers
+	})
+}
+
+// WithTimeout sets the overall timeout for requests across all providers.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if len(parameters.aggregateAttestationProviders) == 0 {
+		return nil, errors.New("no aggregate attestation providers specified")
+	}
+
+	return &parameters, nil
+}
@@ -15,6 +15,7 @@ package best
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -31,6 +32,7 @@ type attestationDataResponse struct {
 	provider        string
 	attestationData *phase0.AttestationData
 	score           float64
+	headSlot        phase0.Slot
 }
 
 type attestationDataError struct {
@@ -52,6 +54,19 @@ func (s *Service) AttestationData(ctx context.Context,
 
 	started := time.Now()
 	log := util.LogWithID(ctx, log, "strategy_id").With().Uint64("slot", uint64(opts.Slot)).Logger()
+	results := util.NewProviderResults(s.logResults, started, s.attestationDataProviders)
+
+	// If configured, obtain the finalized checkpoint in parallel with the attestation data.
+	var finalityCh chan *finalityResponse
+	if len(s.finalityProviders) > 0 {
+		finalityCh = make(chan *finalityResponse, 1)
+		go func(ctx context.Context) {
+			ctx, cancel := context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+			finalized, err := s.quorumFinalizedCheckpoint(ctx)
+			finalityCh <- &finalityResponse{finalized: finalized, err: err}
+		}(ctx)
+	}
 
 	// We have two timeouts: a soft timeout and a hard timeout.
 	// At the soft timeout, we return if we have any responses so far.
@@ -74,15 +89,14 @@ func (s *Service) AttestationData(ctx context.Context,
 	errored := 0
 	timedOut := 0
 	softTimedOut := 0
-	bestScore := float64(0)
-	var bestAttestationData *phase0.AttestationData
-	var bestProvider string
+	responses := make([]*attestationDataResponse, 0, requests)
 
 	// Loop 1: prior to soft timeout.
 	for responded+errored+timedOut+softTimedOut != requests {
 		select {
 		case resp := <-respCh:
 			responded++
+			results.Succeeded(resp.provider, resp.score)
 			log.Trace().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", resp.provider).
@@ -90,13 +104,10 @@ func (s *Service) AttestationData(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
-			if bestAttestationData == nil || resp.score > bestScore {
-				bestAttestationData = resp.attestationData
-				bestScore = resp.score
-				bestProvider = resp.provider
-			}
+			responses = append(responses, resp)
 		case err := <-errCh:
 			errored++
+			results.Errored(err.provider, err.err)
 			log.Debug().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", err.provider).
@@ -132,6 +143,7 @@ func (s *Service) AttestationData(ctx context.Context,
 		select {
 		case resp := <-respCh:
 			responded++
+			results.Succeeded(resp.provider, resp.score)
 			log.Trace().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", resp.provider).
@@ -139,13 +151,10 @@ func (s *Service) AttestationData(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
-			if bestAttestationData == nil || resp.score > bestScore {
-				bestAttestationData = resp.attestationData
-				bestScore = resp.score
-				bestProvider = resp.provider
-			}
+			responses = append(responses, resp)
 		case err := <-errCh:
 			errored++
+			results.Errored(err.provider, err.err)
 			log.Debug().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", err.provider).
@@ -173,13 +182,41 @@ func (s *Service) AttestationData(ctx context.Context,
 		Int("timed_out", timedOut).
 		Msg("Results")
 
+	bestScore := float64(0)
+	var bestAttestationData *phase0.AttestationData
+	var bestProvider string
+	selectable := s.applyHeadSlotPolicy(log, responses)
+	selectable = s.applyProposalConsistencyPolicy(log, opts.Slot, selectable)
+	for _, resp := range selectable {
+		if bestAttestationData == nil || resp.score > bestScore {
+			bestAttestationData = resp.attestationData
+			bestScore = resp.score
+			bestProvider = resp.provider
+		}
+	}
+	results.Log(log, bestProvider)
 	if bestAttestationData == nil {
 		return nil, errors.New("no attestations received")
 	}
 	log.Trace().Str("provider", bestProvider).Stringer("attestation_data", bestAttestationData).Float64("score", bestScore).Msg("Selected best attestation")
+
+	if finalityCh != nil {
+		finality := <-finalityCh
+		if finality.err != nil {
+			log.Warn().Err(finality.err).Msg("Beacon nodes do not agree on finalized checkpoint; cannot check attestation data source")
+		} else if err := checkSourceAgainstFinalized(bestAttestationData, finality.finalized); err != nil {
+			log.Warn().Err(err).Msg("Attestation data source disagrees with finalized checkpoint; not using attestation data")
+			return nil, errors.Wrap(err, "attestation data failed finality check")
+		}
+	}
 	if bestProvider != "" {
 		s.clientMonitor.StrategyOperation("best", bestProvider, "attestation data", time.Since(started))
 	}
+	span.SetAttributes(
+		attribute.String("provider", bestProvider),
+		attribute.Float64("score", bestScore),
+		attribute.Int64("elapsed_ms", time.Since(started).Milliseconds()),
+	)
 
 	return &api.Response[*phase0.AttestationData]{
 		Data:     bestAttestationData,
@@ -233,11 +270,26 @@ func (s *Service) attestationData(ctx context.Context,
 		}
 		return
 	}
+	// A beacon node that is ahead of the network can return attestation data
+	// for a slot that has yet to start, which must not be signed.
+	if currentSlot := s.chainTime.CurrentSlot(); attestationData.Slot > currentSlot {
+		errCh <- &attestationDataError{
+			provider: name,
+			err:      fmt.Errorf("attestation data slot %d is in the future (current slot %d)", attestationData.Slot, currentSlot),
+		}
+		return
+	}
 
 	score := s.scoreAttestationData(ctx, name, attestationData)
+	// A head slot of 0 means that the head slot is unknown.
+	headSlot, err := s.blockRootToSlotCache.BlockRootToSlot(ctx, attestationData.BeaconBlockRoot)
+	if err != nil {
+		headSlot = 0
+	}
 	respCh <- &attestationDataResponse{
 		provider:        name,
 		attestationData: attestationData,
 		score:           score,
+		headSlot:        headSlot,
 	}
 }
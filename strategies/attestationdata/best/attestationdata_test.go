@@ -34,7 +34,8 @@ import (
 func TestAttestationData(t *testing.T) {
 	ctx := context.Background()
 
-	genesisTime := time.Now()
+	// Genesis is far enough in the past that the test slots have started.
+	genesisTime := time.Now().Add(-48 * time.Hour)
 	genesisProvider := mock.NewGenesisProvider(genesisTime)
 	specProvider := mock.NewSpecProvider()
 	chainTime, err := standardchaintime.New(ctx,
@@ -145,6 +146,21 @@ func TestAttestationData(t *testing.T) {
 			committeeIndex: 3,
 			logEntries:     []string{"Soft timeout reached with no responses"},
 		},
+		{
+			name: "FutureSlot",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good": mock.NewAttestationDataProvider(),
+				}),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cache),
+			},
+			slot:           20000,
+			committeeIndex: 3,
+			err:            "no attestations received",
+		},
 	}
 
 	for _, test := range tests {
@@ -168,3 +184,143 @@ func TestAttestationData(t *testing.T) {
 		})
 	}
 }
+
+func TestAttestationDataLogResults(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis is far enough in the past that the test slots have started.
+	genesisTime := time.Now().Add(-48 * time.Hour)
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	cache := mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)
+
+	for _, logResults := range []bool{false, true} {
+		capture := logger.NewLogCapture()
+		s, err := best.New(ctx,
+			best.WithLogLevel(zerolog.TraceLevel),
+			best.WithTimeout(2*time.Second),
+			best.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+				"good":   mock.NewAttestationDataProvider(),
+				"error":  mock.NewErroringAttestationDataProvider(),
+				"sleepy": mock.NewSleepyAttestationDataProvider(5*time.Second, mock.NewAttestationDataProvider()),
+			}),
+			best.WithChainTime(chainTime),
+			best.WithBlockRootToSlotCache(cache),
+			best.WithLogResults(logResults),
+		)
+		require.NoError(t, err)
+		_, err = s.AttestationData(ctx, &api.AttestationDataOpts{
+			Slot:           12345,
+			CommitteeIndex: 3,
+		})
+		require.NoError(t, err)
+
+		results := map[string]map[string]interface{}{
+			"good":   {"message": "Provider result", "provider": "good", "result": "succeeded", "selected": true},
+			"error":  {"message": "Provider result", "provider": "error", "result": "errored", "selected": false},
+			"sleepy": {"message": "Provider result", "provider": "sleepy", "result": "timed out", "selected": false},
+		}
+		for provider, fields := range results {
+			require.Equal(t, logResults, capture.HasLog(fields), provider)
+		}
+	}
+}
+
+// headAttestationDataProvider provides attestation data with a given head
+// and source epoch.
+type headAttestationDataProvider struct {
+	head        phase0.Root
+	sourceEpoch phase0.Epoch
+}
+
+func (p *headAttestationDataProvider) AttestationData(_ context.Context,
+	opts *api.AttestationDataOpts,
+) (
+	*api.Response[*phase0.AttestationData],
+	error,
+) {
+	return &api.Response[*phase0.AttestationData]{
+		Data: &phase0.AttestationData{
+			Slot:            opts.Slot,
+			Index:           opts.CommitteeIndex,
+			BeaconBlockRoot: p.head,
+			Source: &phase0.Checkpoint{
+				Epoch: p.sourceEpoch,
+			},
+			Target: &phase0.Checkpoint{
+				Epoch: phase0.Epoch(opts.Slot / 32),
+			},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestAttestationDataProposalConsistency(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis is far enough in the past that the test slots have started.
+	genesisTime := time.Now().Add(-48 * time.Hour)
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	proposalRoot := phase0.Root{0x01}
+	competingRoot := phase0.Root{0x02}
+	slot := phase0.Slot(12345)
+
+	for _, policy := range []string{"none", "check", "prefer"} {
+		t.Run(policy, func(t *testing.T) {
+			cacheSvc := mockcache.New(map[phase0.Root]phase0.Slot{
+				competingRoot: slot,
+			})
+			// Propose a block for the slot.
+			cacheSvc.(cache.ProposedBlockRootSetter).SetProposedBlockRoot(slot, proposalRoot)
+			cacheSvc.(cache.BlockRootToSlotSetter).SetBlockRootToSlot(proposalRoot, slot)
+
+			s, err := best.New(ctx,
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2*time.Second),
+				best.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					// The competing head scores higher, due to its later source.
+					"ours": &headAttestationDataProvider{
+						head:        proposalRoot,
+						sourceEpoch: phase0.Epoch(slot/32 - 2),
+					},
+					"competing": &headAttestationDataProvider{
+						head:        competingRoot,
+						sourceEpoch: phase0.Epoch(slot/32 - 1),
+					},
+				}),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+				best.WithProposedBlockRootCache(cacheSvc.(cache.ProposedBlockRootProvider)),
+				best.WithProposalConsistency(policy),
+			)
+			require.NoError(t, err)
+
+			// Attest in the slot of the proposal.
+			attestationData, err := s.AttestationData(ctx, &api.AttestationDataOpts{
+				Slot:           slot,
+				CommitteeIndex: 3,
+			})
+			require.NoError(t, err)
+			if policy == "prefer" {
+				require.Equal(t, proposalRoot, attestationData.Data.BeaconBlockRoot)
+			} else {
+				require.Equal(t, competingRoot, attestationData.Data.BeaconBlockRoot)
+			}
+		})
+	}
+}
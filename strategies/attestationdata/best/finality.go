@@ -0,0 +1,108 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+)
+
+type finalityResponse struct {
+	provider  string
+	finalized *phase0.Checkpoint
+	err       error
+}
+
+// quorumFinalizedCheckpoint obtains the finalized checkpoint from each of the
+// finality providers, and returns it if more than half of the providers agree.
+func (s *Service) quorumFinalizedCheckpoint(ctx context.Context) (*phase0.Checkpoint, error) {
+	ctx, span := otel.Tracer("attestantio.vouch.strategies.attestationdata.best").Start(ctx, "quorumFinalizedCheckpoint")
+	defer span.End()
+
+	respCh := make(chan *finalityResponse, len(s.finalityProviders))
+	for name, provider := range s.finalityProviders {
+		go func(name string, provider eth2client.FinalityProvider) {
+			finalityResp, err := provider.Finality(ctx, &api.FinalityOpts{
+				State: "head",
+			})
+			switch {
+			case err != nil:
+				respCh <- &finalityResponse{provider: name, err: err}
+			case finalityResp.Data == nil || finalityResp.Data.Finalized == nil:
+				respCh <- &finalityResponse{provider: name, err: errors.New("finality data missing")}
+			default:
+				respCh <- &finalityResponse{provider: name, finalized: finalityResp.Data.Finalized}
+			}
+		}(name, provider)
+	}
+
+	checkpoints := make([]*phase0.Checkpoint, 0, len(s.finalityProviders))
+	votes := make([]int, 0, len(s.finalityProviders))
+	for range s.finalityProviders {
+		resp := <-respCh
+		if resp.err != nil {
+			log.Debug().Str("provider", resp.provider).Err(resp.err).Msg("Failed to obtain finality")
+			continue
+		}
+		found := false
+		for i := range checkpoints {
+			if checkpointsEqual(checkpoints[i], resp.finalized) {
+				votes[i]++
+				found = true
+				break
+			}
+		}
+		if !found {
+			checkpoints = append(checkpoints, resp.finalized)
+			votes = append(votes, 1)
+		}
+	}
+
+	quorum := len(s.finalityProviders)/2 + 1
+	for i := range checkpoints {
+		if votes[i] >= quorum {
+			return checkpoints[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no quorum of %d for finalized checkpoint (%d distinct checkpoints received)", quorum, len(checkpoints))
+}
+
+// checkSourceAgainstFinalized checks that the source of the attestation data
+// is consistent with the finalized checkpoint.  The source cannot be earlier than
+// the finalized checkpoint, and if it is at the same epoch it must have the same root.
+func checkSourceAgainstFinalized(data *phase0.AttestationData, finalized *phase0.Checkpoint) error {
+	if data.Source == nil {
+		return errors.New("attestation data source nil")
+	}
+	if data.Source.Epoch < finalized.Epoch {
+		return fmt.Errorf("attestation data source epoch %d earlier than finalized epoch %d", data.Source.Epoch, finalized.Epoch)
+	}
+	if data.Source.Epoch == finalized.Epoch && !bytes.Equal(data.Source.Root[:], finalized.Root[:]) {
+		return fmt.Errorf("attestation data source root %#x does not match finalized root %#x", data.Source.Root, finalized.Root)
+	}
+
+	return nil
+}
+
+func checkpointsEqual(a *phase0.Checkpoint, b *phase0.Checkpoint) bool {
+	return a.Epoch == b.Epoch && bytes.Equal(a.Root[:], b.Root[:])
+}
@@ -0,0 +1,162 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+type finalityProvider struct {
+	finalized *phase0.Checkpoint
+}
+
+func (p *finalityProvider) Finality(_ context.Context, _ *api.FinalityOpts) (*api.Response[*apiv1.Finality], error) {
+	if p.finalized == nil {
+		return nil, errors.New("error")
+	}
+
+	return &api.Response[*apiv1.Finality]{
+		Data: &apiv1.Finality{
+			Finalized: p.finalized,
+		},
+	}, nil
+}
+
+func TestQuorumFinalizedCheckpoint(t *testing.T) {
+	ctx := context.Background()
+
+	checkpoint1 := &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x01}}
+	checkpoint2 := &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x02}}
+	checkpoint3 := &phase0.Checkpoint{Epoch: 11, Root: phase0.Root{0x03}}
+
+	tests := []struct {
+		name      string
+		providers map[string]eth2client.FinalityProvider
+		expected  *phase0.Checkpoint
+		err       string
+	}{
+		{
+			name: "Agree",
+			providers: map[string]eth2client.FinalityProvider{
+				"1": &finalityProvider{finalized: checkpoint1},
+				"2": &finalityProvider{finalized: checkpoint1},
+				"3": &finalityProvider{finalized: checkpoint1},
+			},
+			expected: checkpoint1,
+		},
+		{
+			name: "Majority",
+			providers: map[string]eth2client.FinalityProvider{
+				"1": &finalityProvider{finalized: checkpoint1},
+				"2": &finalityProvider{finalized: checkpoint2},
+				"3": &finalityProvider{finalized: checkpoint1},
+			},
+			expected: checkpoint1,
+		},
+		{
+			name: "MajorityWithError",
+			providers: map[string]eth2client.FinalityProvider{
+				"1": &finalityProvider{finalized: checkpoint1},
+				"2": &finalityProvider{},
+				"3": &finalityProvider{finalized: checkpoint1},
+			},
+			expected: checkpoint1,
+		},
+		{
+			name: "Disagree",
+			providers: map[string]eth2client.FinalityProvider{
+				"1": &finalityProvider{finalized: checkpoint1},
+				"2": &finalityProvider{finalized: checkpoint2},
+				"3": &finalityProvider{finalized: checkpoint3},
+			},
+			err: "no quorum of 2 for finalized checkpoint (3 distinct checkpoints received)",
+		},
+		{
+			name: "Errors",
+			providers: map[string]eth2client.FinalityProvider{
+				"1": &finalityProvider{finalized: checkpoint1},
+				"2": &finalityProvider{},
+				"3": &finalityProvider{},
+			},
+			err: "no quorum of 2 for finalized checkpoint (1 distinct checkpoints received)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				finalityProviders: test.providers,
+			}
+			finalized, err := s.quorumFinalizedCheckpoint(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, finalized)
+			}
+		})
+	}
+}
+
+func TestCheckSourceAgainstFinalized(t *testing.T) {
+	finalized := &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x01}}
+
+	tests := []struct {
+		name   string
+		source *phase0.Checkpoint
+		err    string
+	}{
+		{
+			name: "SourceNil",
+			err:  "attestation data source nil",
+		},
+		{
+			name:   "SourceEarlier",
+			source: &phase0.Checkpoint{Epoch: 9, Root: phase0.Root{0x01}},
+			err:    "attestation data source epoch 9 earlier than finalized epoch 10",
+		},
+		{
+			name:   "SourceRootMismatch",
+			source: &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x02}},
+			err:    "attestation data source root 0x0200000000000000000000000000000000000000000000000000000000000000 does not match finalized root 0x0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:   "SourceFinalized",
+			source: &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x01}},
+		},
+		{
+			name:   "SourceJustified",
+			source: &phase0.Checkpoint{Epoch: 11, Root: phase0.Root{0x02}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkSourceAgainstFinalized(&phase0.AttestationData{Source: test.source}, finalized)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+)
+
+// applyHeadSlotPolicy notes any disagreement between beacon nodes about the
+// head slot, and returns the responses that can be selected under the head
+// slot policy.  Responses with an unknown head slot are only selectable if
+// the policy is 'none'.
+func (s *Service) applyHeadSlotPolicy(log zerolog.Logger,
+	responses []*attestationDataResponse,
+) []*attestationDataResponse {
+	headSlots := make(map[phase0.Slot]int)
+	maxHeadSlot := phase0.Slot(0)
+	for _, resp := range responses {
+		if resp.headSlot == 0 {
+			continue
+		}
+		headSlots[resp.headSlot]++
+		if resp.headSlot > maxHeadSlot {
+			maxHeadSlot = resp.headSlot
+		}
+	}
+	if len(headSlots) < 2 {
+		// No disagreement.
+		return responses
+	}
+
+	monitorHeadSlotDisagreement()
+	log.Debug().Int("head_slots", len(headSlots)).Uint64("max_head_slot", uint64(maxHeadSlot)).Msg("Beacon nodes disagree on head slot")
+
+	var selectedHeadSlot phase0.Slot
+	switch s.headSlotPolicy {
+	case "max":
+		selectedHeadSlot = maxHeadSlot
+	case "quorum":
+		// Ties are broken in favour of the higher slot.
+		reported := 0
+		for headSlot, count := range headSlots {
+			if count > reported || (count == reported && headSlot > selectedHeadSlot) {
+				selectedHeadSlot = headSlot
+				reported = count
+			}
+		}
+	default:
+		return responses
+	}
+
+	selectable := make([]*attestationDataResponse, 0, len(responses))
+	for _, resp := range responses {
+		if resp.headSlot != selectedHeadSlot {
+			log.Trace().Str("provider", resp.provider).Uint64("head_slot", uint64(resp.headSlot)).Msg("Excluding response with lagging head slot")
+			continue
+		}
+		selectable = append(selectable, resp)
+	}
+
+	return selectable
+}
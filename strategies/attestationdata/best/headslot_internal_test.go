@@ -0,0 +1,141 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyHeadSlotPolicy(t *testing.T) {
+	agreeing := []*attestationDataResponse{
+		{provider: "node 1", headSlot: 100, score: 1},
+		{provider: "node 2", headSlot: 100, score: 2},
+	}
+	// node 3 is lagging, but its response scores highest.
+	disagreeing := []*attestationDataResponse{
+		{provider: "node 1", headSlot: 100, score: 1},
+		{provider: "node 2", headSlot: 100, score: 2},
+		{provider: "node 3", headSlot: 99, score: 3},
+	}
+	// node 3 is ahead, but alone.
+	ahead := []*attestationDataResponse{
+		{provider: "node 1", headSlot: 100, score: 1},
+		{provider: "node 2", headSlot: 100, score: 2},
+		{provider: "node 3", headSlot: 101, score: 3},
+	}
+	unknown := []*attestationDataResponse{
+		{provider: "node 1", headSlot: 100, score: 1},
+		{provider: "node 2", headSlot: 0, score: 2},
+	}
+	tied := []*attestationDataResponse{
+		{provider: "node 1", headSlot: 100, score: 1},
+		{provider: "node 2", headSlot: 99, score: 2},
+	}
+
+	tests := []struct {
+		name      string
+		policy    string
+		responses []*attestationDataResponse
+		providers []string
+	}{
+		{
+			name:      "Empty",
+			policy:    "max",
+			responses: []*attestationDataResponse{},
+			providers: []string{},
+		},
+		{
+			name:      "AgreeingMax",
+			policy:    "max",
+			responses: agreeing,
+			providers: []string{"node 1", "node 2"},
+		},
+		{
+			name:      "DisagreeingNone",
+			policy:    "none",
+			responses: disagreeing,
+			providers: []string{"node 1", "node 2", "node 3"},
+		},
+		{
+			name:      "DisagreeingMax",
+			policy:    "max",
+			responses: disagreeing,
+			providers: []string{"node 1", "node 2"},
+		},
+		{
+			name:      "DisagreeingQuorum",
+			policy:    "quorum",
+			responses: disagreeing,
+			providers: []string{"node 1", "node 2"},
+		},
+		{
+			name:      "AheadMax",
+			policy:    "max",
+			responses: ahead,
+			providers: []string{"node 3"},
+		},
+		{
+			name:      "AheadQuorum",
+			policy:    "quorum",
+			responses: ahead,
+			providers: []string{"node 1", "node 2"},
+		},
+		{
+			name:      "UnknownMax",
+			policy:    "max",
+			responses: unknown,
+			providers: []string{"node 1", "node 2"},
+		},
+		{
+			name:      "TiedQuorum",
+			policy:    "quorum",
+			responses: tied,
+			providers: []string{"node 1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				headSlotPolicy: test.policy,
+			}
+			selectable := s.applyHeadSlotPolicy(zerolog.Nop(), test.responses)
+			providers := make([]string, 0, len(selectable))
+			for _, resp := range selectable {
+				providers = append(providers, resp.provider)
+			}
+			require.Equal(t, test.providers, providers)
+		})
+	}
+}
+
+func TestApplyHeadSlotPolicyHeadSlots(t *testing.T) {
+	s := &Service{
+		headSlotPolicy: "max",
+	}
+	selectable := s.applyHeadSlotPolicy(zerolog.Nop(), []*attestationDataResponse{
+		{provider: "node 1", headSlot: 98},
+		{provider: "node 2", headSlot: 100},
+		{provider: "node 3", headSlot: 99},
+		{provider: "node 4", headSlot: 100},
+	})
+	require.Len(t, selectable, 2)
+	for _, resp := range selectable {
+		require.Equal(t, phase0.Slot(100), resp.headSlot)
+	}
+}
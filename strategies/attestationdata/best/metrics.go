@@ -0,0 +1,86 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	headSlotDisagreementsMetric prometheus.Counter
+	proposalConsistencyMetric   *prometheus.CounterVec
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if headSlotDisagreementsMetric != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	headSlotDisagreementsMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "attestationdata_strategy",
+		Name:      "head_slot_disagreements_total",
+		Help:      "The number of times that beacon nodes providing attestation data disagreed on the head slot.",
+	})
+	if err := prometheus.Register(headSlotDisagreementsMetric); err != nil {
+		return errors.Wrap(err, "failed to register vouch_attestationdata_strategy_head_slot_disagreements_total")
+	}
+
+	proposalConsistencyMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "attestationdata_strategy",
+		Name:      "proposal_consistency_total",
+		Help:      "The consistency of attestation data with blocks recently proposed by our validators.",
+	}, []string{"result"})
+	if err := prometheus.Register(proposalConsistencyMetric); err != nil {
+		return errors.Wrap(err, "failed to register vouch_attestationdata_strategy_proposal_consistency_total")
+	}
+
+	return nil
+}
+
+// monitorHeadSlotDisagreement notes that beacon nodes disagreed on the head slot.
+func monitorHeadSlotDisagreement() {
+	if headSlotDisagreementsMetric == nil {
+		// Not yet registered.
+		return
+	}
+
+	headSlotDisagreementsMetric.Inc()
+}
+
+// monitorProposalConsistency notes the consistency of attestation data with our recent proposal.
+func monitorProposalConsistency(result string) {
+	if proposalConsistencyMetric == nil {
+		// Not yet registered.
+		return
+	}
+
+	proposalConsistencyMetric.WithLabelValues(result).Inc()
+}
@@ -17,6 +17,7 @@ package best
 
 import (
 	"context"
+	"fmt"
 	"runtime"
 	"time"
 
@@ -31,12 +32,18 @@ import (
 
 type parameters struct {
 	logLevel                 zerolog.Level
+	monitor                  metrics.Service
 	clientMonitor            metrics.ClientMonitor
 	processConcurrency       int64
 	attestationDataProviders map[string]eth2client.AttestationDataProvider
 	timeout                  time.Duration
 	chainTime                chaintime.Service
 	blockRootToSlotCache     cache.BlockRootToSlotProvider
+	finalityProviders        map[string]eth2client.FinalityProvider
+	headSlotPolicy           string
+	proposalConsistency      string
+	proposedBlockRootCache   cache.ProposedBlockRootProvider
+	logResults               bool
 }
 
 // Parameter is the interface for service parameters.
@@ -57,6 +64,13 @@ func WithLogLevel(logLevel zerolog.Level) Parameter {
 	})
 }
 
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
 // WithClientMonitor sets the client monitor for the service.
 func WithClientMonitor(monitor metrics.ClientMonitor) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -78,6 +92,45 @@ func WithAttestationDataProviders(providers map[string]eth2client.AttestationDat
 	})
 }
 
+// WithFinalityProviders sets the finality providers.  If supplied, the source of
+// the selected attestation data is checked against the finalized checkpoint
+// agreed by a quorum of the providers.
+func WithFinalityProviders(providers map[string]eth2client.FinalityProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.finalityProviders = providers
+	})
+}
+
+// WithHeadSlotPolicy sets the policy for selecting attestation data when
+// the beacon nodes disagree on the head slot.  'none' selects from all
+// responses, 'max' selects only from responses with the highest head slot,
+// and 'quorum' selects only from responses with the head slot reported by
+// the most beacon nodes.
+func WithHeadSlotPolicy(policy string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.headSlotPolicy = policy
+	})
+}
+
+// WithProposalConsistency sets the policy for keeping attestation data
+// consistent with blocks recently proposed by our validators.  'none' takes
+// no account of our proposals, 'check' notes attestation data that is
+// inconsistent with our proposal, and 'prefer' additionally selects only
+// from consistent attestation data if any is available.
+func WithProposalConsistency(policy string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposalConsistency = policy
+	})
+}
+
+// WithProposedBlockRootCache sets the provider of the roots of blocks
+// proposed by our validators.
+func WithProposedBlockRootCache(cache cache.ProposedBlockRootProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposedBlockRootCache = cache
+	})
+}
+
 // WithTimeout sets the timeout for requests.
 func WithTimeout(timeout time.Duration) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -99,12 +152,22 @@ func WithBlockRootToSlotCache(cache cache.BlockRootToSlotProvider) Parameter {
 	})
 }
 
+// WithLogResults logs the result from each provider for each call to the strategy.
+func WithLogResults(logResults bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logResults = logResults
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel:           zerolog.GlobalLevel(),
-		clientMonitor:      nullmetrics.New(context.Background()),
-		processConcurrency: int64(runtime.GOMAXPROCS(-1)),
+		logLevel:            zerolog.GlobalLevel(),
+		monitor:             nullmetrics.New(context.Background()),
+		clientMonitor:       nullmetrics.New(context.Background()),
+		processConcurrency:  int64(runtime.GOMAXPROCS(-1)),
+		headSlotPolicy:      "none",
+		proposalConsistency: "none",
 	}
 	for _, p := range params {
 		if params != nil {
@@ -115,6 +178,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.timeout == 0 {
 		return nil, errors.New("no timeout specified")
 	}
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
 	if parameters.clientMonitor == nil {
 		return nil, errors.New("no client monitor specified")
 	}
@@ -130,6 +196,20 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.blockRootToSlotCache == nil {
 		return nil, errors.New("no block root to slot cache specified")
 	}
+	switch parameters.headSlotPolicy {
+	case "none", "max", "quorum":
+	default:
+		return nil, fmt.Errorf("unknown head slot policy %q", parameters.headSlotPolicy)
+	}
+	switch parameters.proposalConsistency {
+	case "none":
+	case "check", "prefer":
+		if parameters.proposedBlockRootCache == nil {
+			return nil, errors.New("no proposed block root cache specified")
+		}
+	default:
+		return nil, fmt.Errorf("unknown proposal consistency policy %q", parameters.proposalConsistency)
+	}
 
 	return &parameters, nil
 }
@@ -0,0 +1,86 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+)
+
+// proposalConsistencySlots is the number of slots, up to and including the
+// attestation slot, in which a proposal by our validators is considered
+// recent enough for attestation data to be consistent with it.
+const proposalConsistencySlots = 2
+
+// recentProposal returns the slot and root of the most recent block proposed
+// by our validators that is relevant to attestations for the given slot.
+func (s *Service) recentProposal(slot phase0.Slot) (phase0.Slot, phase0.Root, bool) {
+	for i := phase0.Slot(0); i < proposalConsistencySlots && i <= slot; i++ {
+		if root, exists := s.proposedBlockRootCache.ProposedBlockRoot(slot - i); exists {
+			return slot - i, root, true
+		}
+	}
+
+	return 0, phase0.Root{}, false
+}
+
+// applyProposalConsistencyPolicy notes any attestation data that is
+// inconsistent with a block recently proposed by our validators, and returns
+// the responses that can be selected under the proposal consistency policy.
+// Attestation data is consistent if its head is our proposal, or if its head
+// is later than our proposal; a head at or before the slot of our proposal
+// that is not our proposal means that the beacon node either has not seen our
+// block or has chosen a competing block.
+func (s *Service) applyProposalConsistencyPolicy(log zerolog.Logger,
+	slot phase0.Slot,
+	responses []*attestationDataResponse,
+) []*attestationDataResponse {
+	if s.proposalConsistency == "none" || len(responses) == 0 {
+		return responses
+	}
+
+	proposalSlot, proposalRoot, found := s.recentProposal(slot)
+	if !found {
+		// No recent proposal, so nothing with which to be consistent.
+		return responses
+	}
+
+	consistent := make([]*attestationDataResponse, 0, len(responses))
+	for _, resp := range responses {
+		if resp.attestationData.BeaconBlockRoot == proposalRoot || resp.headSlot > proposalSlot {
+			consistent = append(consistent, resp)
+			continue
+		}
+		log.Trace().Str("provider", resp.provider).Uint64("head_slot", uint64(resp.headSlot)).Msg("Response inconsistent with our proposal")
+	}
+
+	switch {
+	case len(consistent) == len(responses):
+		monitorProposalConsistency("consistent")
+		return responses
+	case len(consistent) == 0:
+		monitorProposalConsistency("inconsistent")
+		log.Debug().Uint64("proposal_slot", uint64(proposalSlot)).Stringer("proposal_root", proposalRoot).Msg("No attestation data consistent with our proposal")
+		return responses
+	default:
+		monitorProposalConsistency("partial")
+		log.Debug().Uint64("proposal_slot", uint64(proposalSlot)).Stringer("proposal_root", proposalRoot).Int("consistent", len(consistent)).Int("responses", len(responses)).Msg("Some attestation data inconsistent with our proposal")
+	}
+
+	if s.proposalConsistency == "prefer" {
+		return consistent
+	}
+
+	return responses
+}
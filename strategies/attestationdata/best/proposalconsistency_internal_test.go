@@ -0,0 +1,160 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyProposalConsistencyPolicy(t *testing.T) {
+	proposalRoot := phase0.Root{0x01}
+	otherRoot := phase0.Root{0x02}
+
+	proposedBlockRootCache := mockcache.New(map[phase0.Root]phase0.Slot{})
+	proposedBlockRootCache.(cache.ProposedBlockRootSetter).SetProposedBlockRoot(100, proposalRoot)
+
+	response := func(provider string, root phase0.Root, headSlot phase0.Slot) *attestationDataResponse {
+		return &attestationDataResponse{
+			provider:        provider,
+			attestationData: &phase0.AttestationData{BeaconBlockRoot: root},
+			headSlot:        headSlot,
+		}
+	}
+
+	// All nodes have our proposal as their head.
+	consistent := []*attestationDataResponse{
+		response("node 1", proposalRoot, 100),
+		response("node 2", proposalRoot, 100),
+	}
+	// node 2 has not seen our proposal.
+	partial := []*attestationDataResponse{
+		response("node 1", proposalRoot, 100),
+		response("node 2", otherRoot, 99),
+	}
+	// node 2 has a competing block for the slot of our proposal.
+	competing := []*attestationDataResponse{
+		response("node 1", proposalRoot, 100),
+		response("node 2", otherRoot, 100),
+	}
+	// node 2 has a later head, which is assumed to build on our proposal.
+	later := []*attestationDataResponse{
+		response("node 1", proposalRoot, 100),
+		response("node 2", otherRoot, 101),
+	}
+	// No node has our proposal.
+	inconsistent := []*attestationDataResponse{
+		response("node 1", otherRoot, 99),
+		response("node 2", otherRoot, 99),
+	}
+
+	tests := []struct {
+		name      string
+		policy    string
+		slot      phase0.Slot
+		responses []*attestationDataResponse
+		providers []string
+	}{
+		{
+			name:      "Empty",
+			policy:    "prefer",
+			slot:      100,
+			responses: []*attestationDataResponse{},
+			providers: []string{},
+		},
+		{
+			name:      "PartialNone",
+			policy:    "none",
+			slot:      100,
+			responses: partial,
+			providers: []string{"node 1", "node 2"},
+		},
+		{
+			name:      "ConsistentPrefer",
+			policy:    "prefer",
+			slot:      100,
+			responses: consistent,
+			providers: []string{"node 1", "node 2"},
+		},
+		{
+			name:      "PartialCheck",
+			policy:    "check",
+			slot:      100,
+			responses: partial,
+			providers: []string{"node 1", "node 2"},
+		},
+		{
+			name:      "PartialPrefer",
+			policy:    "prefer",
+			slot:      100,
+			responses: partial,
+			providers: []string{"node 1"},
+		},
+		{
+			name:      "CompetingPrefer",
+			policy:    "prefer",
+			slot:      100,
+			responses: competing,
+			providers: []string{"node 1"},
+		},
+		{
+			name:      "LaterPrefer",
+			policy:    "prefer",
+			slot:      101,
+			responses: later,
+			providers: []string{"node 1", "node 2"},
+		},
+		{
+			name:      "InconsistentPrefer",
+			policy:    "prefer",
+			slot:      100,
+			responses: inconsistent,
+			providers: []string{"node 1", "node 2"},
+		},
+		{
+			name:      "PreviousSlotPrefer",
+			policy:    "prefer",
+			slot:      101,
+			responses: partial,
+			providers: []string{"node 1"},
+		},
+		{
+			name:      "NoRecentProposal",
+			policy:    "prefer",
+			slot:      102,
+			responses: partial,
+			providers: []string{"node 1", "node 2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				proposalConsistency:    test.policy,
+				proposedBlockRootCache: proposedBlockRootCache.(cache.ProposedBlockRootProvider),
+			}
+			selectable := s.applyProposalConsistencyPolicy(zerolog.Nop(), test.slot, test.responses)
+			providers := make([]string, 0, len(selectable))
+			for _, resp := range selectable {
+				providers = append(providers, resp.provider)
+			}
+			require.Equal(t, test.providers, providers)
+		})
+	}
+}
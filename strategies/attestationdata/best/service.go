@@ -34,13 +34,18 @@ type Service struct {
 	timeout                  time.Duration
 	chainTime                chaintime.Service
 	blockRootToSlotCache     cache.BlockRootToSlotProvider
+	finalityProviders        map[string]eth2client.FinalityProvider
+	headSlotPolicy           string
+	proposalConsistency      string
+	proposedBlockRootCache   cache.ProposedBlockRootProvider
+	logResults               bool
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new attestation data strategy.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -52,13 +57,22 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		log = log.Level(parameters.logLevel)
 	}
 
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.Wrap(err, "failed to register metrics")
+	}
+
 	s := &Service{
 		timeout:                  parameters.timeout,
+		logResults:               parameters.logResults,
 		clientMonitor:            parameters.clientMonitor,
 		processConcurrency:       parameters.processConcurrency,
 		attestationDataProviders: parameters.attestationDataProviders,
 		chainTime:                parameters.chainTime,
 		blockRootToSlotCache:     parameters.blockRootToSlotCache,
+		finalityProviders:        parameters.finalityProviders,
+		headSlotPolicy:           parameters.headSlotPolicy,
+		proposalConsistency:      parameters.proposalConsistency,
+		proposedBlockRootCache:   parameters.proposedBlockRootCache,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
@@ -46,6 +46,7 @@ func TestService(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	proposedBlockRootCache := mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.ProposedBlockRootProvider)
 	cache := mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)
 
 	tests := []struct {
@@ -138,6 +139,66 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no block root to slot cache specified",
 		},
+		{
+			name: "HeadSlotPolicyUnknown",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAttestationDataProviders(attestationDataProviders),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cache),
+				best.WithHeadSlotPolicy("min"),
+			},
+			err: `problem with parameters: unknown head slot policy "min"`,
+		},
+		{
+			name: "HeadSlotPolicyQuorum",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAttestationDataProviders(attestationDataProviders),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cache),
+				best.WithHeadSlotPolicy("quorum"),
+			},
+		},
+		{
+			name: "ProposalConsistencyUnknown",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAttestationDataProviders(attestationDataProviders),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cache),
+				best.WithProposedBlockRootCache(proposedBlockRootCache),
+				best.WithProposalConsistency("always"),
+			},
+			err: `problem with parameters: unknown proposal consistency policy "always"`,
+		},
+		{
+			name: "ProposedBlockRootCacheMissing",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAttestationDataProviders(attestationDataProviders),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cache),
+				best.WithProposalConsistency("prefer"),
+			},
+			err: "problem with parameters: no proposed block root cache specified",
+		},
+		{
+			name: "ProposalConsistencyPrefer",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAttestationDataProviders(attestationDataProviders),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cache),
+				best.WithProposedBlockRootCache(proposedBlockRootCache),
+				best.WithProposalConsistency("prefer"),
+			},
+		},
 	}
 
 	for _, test := range tests {
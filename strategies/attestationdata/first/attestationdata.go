@@ -61,6 +61,16 @@ func (s *Service) AttestationData(ctx context.Context,
 			}
 			attestationData := attestationDataResponse.Data
 			log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained attestation data")
+			if attestationData == nil {
+				log.Warn().Msg("Attestation data nil")
+				return
+			}
+			// A beacon node that is ahead of the network can return attestation data
+			// for a slot that has yet to start, which must not be signed.
+			if s.chainTime != nil && attestationData.Slot > s.chainTime.CurrentSlot() {
+				log.Warn().Uint64("attestation_data_slot", uint64(attestationData.Slot)).Msg("Attestation data is for a future slot")
+				return
+			}
 
 			ch <- attestationData
 		}(ctx, name, provider, respCh)
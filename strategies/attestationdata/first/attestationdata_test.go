@@ -22,12 +22,26 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
 	"github.com/attestantio/vouch/strategies/attestationdata/first"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 )
 
 func TestAttestationData(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis is far enough in the past that the test slots have started.
+	genesisTime := time.Now().Add(-48 * time.Hour)
+	genesisProvider := mock.NewGenesisProvider(genesisTime)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
 	tests := []struct {
 		name           string
 		params         []first.Parameter
@@ -73,6 +87,33 @@ func TestAttestationData(t *testing.T) {
 			slot:           12345,
 			committeeIndex: 3,
 		},
+		{
+			name: "GoodWithChainTime",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(2 * time.Second),
+				first.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good": mock.NewAttestationDataProvider(),
+				}),
+				first.WithChainTime(chainTime),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+		},
+		{
+			name: "FutureSlot",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(time.Second),
+				first.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good": mock.NewAttestationDataProvider(),
+				}),
+				first.WithChainTime(chainTime),
+			},
+			slot:           20000,
+			committeeIndex: 3,
+			err:            "failed to obtain attestation data before timeout",
+		},
 	}
 
 	for _, test := range tests {
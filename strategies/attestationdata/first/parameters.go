@@ -20,6 +20,7 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	"github.com/pkg/errors"
@@ -31,6 +32,7 @@ type parameters struct {
 	clientMonitor            metrics.ClientMonitor
 	attestationDataProviders map[string]eth2client.AttestationDataProvider
 	timeout                  time.Duration
+	chainTime                chaintime.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -72,6 +74,14 @@ func WithTimeout(timeout time.Duration) Parameter {
 	})
 }
 
+// WithChainTime sets the chain time provider for this service.
+// If set, attestation data for future slots is rejected.
+func WithChainTime(chainTime chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = chainTime
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -29,6 +30,7 @@ type Service struct {
 	clientMonitor            metrics.ClientMonitor
 	attestationDataProviders map[string]eth2client.AttestationDataProvider
 	timeout                  time.Duration
+	chainTime                chaintime.Service
 }
 
 // module-wide log.
@@ -51,6 +53,7 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		attestationDataProviders: parameters.attestationDataProviders,
 		timeout:                  parameters.timeout,
 		clientMonitor:            parameters.clientMonitor,
+		chainTime:                parameters.chainTime,
 	}
 
 	return s, nil
@@ -194,6 +194,15 @@ func (s *Service) attestationData(ctx context.Context,
 		}
 		return
 	}
+	// A beacon node that is ahead of the network can return attestation data
+	// for a slot that has yet to start, which must not be signed.
+	if currentSlot := s.chainTime.CurrentSlot(); attestationData.Slot > currentSlot {
+		errCh <- &attestationDataError{
+			provider: providerName,
+			err:      fmt.Errorf("attestation data slot %d is in the future (current slot %d)", attestationData.Slot, currentSlot),
+		}
+		return
+	}
 
 	respCh <- &attestationDataResponse{
 		provider:        providerName,
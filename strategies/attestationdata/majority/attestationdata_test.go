@@ -34,7 +34,8 @@ import (
 func TestAttestationData(t *testing.T) {
 	ctx := context.Background()
 
-	genesisTime := time.Now()
+	// Genesis is far enough in the past that the test slots have started.
+	genesisTime := time.Now().Add(-48 * time.Hour)
 	genesisProvider := mock.NewGenesisProvider(genesisTime)
 	specProvider := mock.NewSpecProvider()
 	chainTime, err := standardchaintime.New(ctx,
@@ -145,6 +146,21 @@ func TestAttestationData(t *testing.T) {
 			committeeIndex: 3,
 			logEntries:     []string{"Soft timeout reached with no responses"},
 		},
+		{
+			name: "FutureSlot",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good": mock.NewAttestationDataProvider(),
+				}),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cache),
+			},
+			slot:           20000,
+			committeeIndex: 3,
+			err:            "no attestations received",
+		},
 	}
 
 	for _, test := range tests {
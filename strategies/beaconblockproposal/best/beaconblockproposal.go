@@ -16,6 +16,7 @@ package best
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -33,6 +35,16 @@ type beaconBlockResponse struct {
 	provider string
 	proposal *api.VersionedProposal
 	score    float64
+	// breakdown is the breakdown of the score.
+	breakdown *scoreBreakdown
+	// empty is true if the proposal contains neither attestations nor transactions.
+	empty bool
+	// shadowScore is the score from the shadow scorer, valid if shadowScored is true.
+	shadowScore  float64
+	shadowScored bool
+	// duplicateOf is the provider that returned an identical proposal, if any.
+	// Duplicate proposals are neither scored nor considered for selection.
+	duplicateOf string
 }
 
 type beaconBlockError struct {
@@ -54,6 +66,7 @@ func (s *Service) Proposal(ctx context.Context,
 
 	started := time.Now()
 	log := util.LogWithID(ctx, log, "strategy_id").With().Uint64("slot", uint64(opts.Slot)).Logger()
+	results := util.NewProviderResults(s.logResults, started, s.proposalProviders)
 
 	// We have two timeouts: a soft timeout and a hard timeout.
 	// At the soft timeout, we return if we have any responses so far.
@@ -66,6 +79,7 @@ func (s *Service) Proposal(ctx context.Context,
 
 	respCh := make(chan *beaconBlockResponse, requests)
 	errCh := make(chan *beaconBlockError, requests)
+	roots := newCandidateRoots()
 	// Kick off the requests.
 	for name, provider := range s.proposalProviders {
 		providerGraffiti := opts.Graffiti[:]
@@ -89,7 +103,7 @@ func (s *Service) Proposal(ctx context.Context,
 				}
 			}
 		}
-		go s.beaconBlockProposal(ctx, started, name, provider, respCh, errCh, opts)
+		go s.beaconBlockProposal(ctx, started, name, provider, roots, respCh, errCh, opts)
 	}
 
 	// Wait for all responses (or context done).
@@ -98,14 +112,34 @@ func (s *Service) Proposal(ctx context.Context,
 	timedOut := 0
 	softTimedOut := 0
 	bestScore := float64(0)
+	scores := make([]float64, 0, requests)
+	candidates := make([]*beaconBlockResponse, 0, requests)
 	var bestProposal *api.VersionedProposal
 	var bestProvider string
+	bestEmpty := false
+	bestShadowScore := float64(0)
+	var bestShadowProvider string
 
 	// Loop 1: prior to soft timeout.
 	for responded+errored+timedOut+softTimedOut != requests {
 		select {
 		case resp := <-respCh:
 			responded++
+			if resp.duplicateOf != "" {
+				results.Duplicated(resp.provider, resp.duplicateOf)
+				log.Trace().
+					Dur("elapsed", time.Since(started)).
+					Str("provider", resp.provider).
+					Str("duplicate_of", resp.duplicateOf).
+					Msg("Duplicate response received")
+
+				continue
+			}
+			results.Succeeded(resp.provider, resp.score)
+			if len(candidates) == 0 {
+				// Duplicates are not candidates, so are not counted here.
+				monitorFirstCandidate(time.Since(s.chainTime.StartOfSlot(opts.Slot)))
+			}
 			log.Trace().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", resp.provider).
@@ -113,13 +147,21 @@ func (s *Service) Proposal(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
-			if bestProposal == nil || resp.score > bestScore {
+			scores = append(scores, resp.score)
+			candidates = append(candidates, resp)
+			if bestProposal == nil || preferResponse(resp, bestScore, bestEmpty) {
 				bestProposal = resp.proposal
 				bestScore = resp.score
 				bestProvider = resp.provider
+				bestEmpty = resp.empty
+			}
+			if resp.shadowScored && (bestShadowProvider == "" || resp.shadowScore > bestShadowScore) {
+				bestShadowScore = resp.shadowScore
+				bestShadowProvider = resp.provider
 			}
 		case err := <-errCh:
 			errored++
+			results.Errored(err.provider, err.err)
 			log.Debug().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", err.provider).
@@ -155,6 +197,21 @@ func (s *Service) Proposal(ctx context.Context,
 		select {
 		case resp := <-respCh:
 			responded++
+			if resp.duplicateOf != "" {
+				results.Duplicated(resp.provider, resp.duplicateOf)
+				log.Trace().
+					Dur("elapsed", time.Since(started)).
+					Str("provider", resp.provider).
+					Str("duplicate_of", resp.duplicateOf).
+					Msg("Duplicate response received")
+
+				continue
+			}
+			results.Succeeded(resp.provider, resp.score)
+			if len(candidates) == 0 {
+				// Duplicates are not candidates, so are not counted here.
+				monitorFirstCandidate(time.Since(s.chainTime.StartOfSlot(opts.Slot)))
+			}
 			log.Trace().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", resp.provider).
@@ -162,13 +219,21 @@ func (s *Service) Proposal(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
-			if bestProposal == nil || resp.score > bestScore {
+			scores = append(scores, resp.score)
+			candidates = append(candidates, resp)
+			if bestProposal == nil || preferResponse(resp, bestScore, bestEmpty) {
 				bestProposal = resp.proposal
 				bestScore = resp.score
 				bestProvider = resp.provider
+				bestEmpty = resp.empty
+			}
+			if resp.shadowScored && (bestShadowProvider == "" || resp.shadowScore > bestShadowScore) {
+				bestShadowScore = resp.shadowScore
+				bestShadowProvider = resp.provider
 			}
 		case err := <-errCh:
 			errored++
+			results.Errored(err.provider, err.err)
 			log.Debug().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", err.provider).
@@ -197,15 +262,48 @@ func (s *Service) Proposal(ctx context.Context,
 		Int("timed_out", timedOut).
 		Msg("Results")
 
+	diversitySelected := false
+	if bestProposal != nil && s.diversityBias > 0 {
+		if selected := s.applyDiversityBias(bestProvider, bestScore, candidates); selected.provider != bestProvider {
+			log.Debug().
+				Str("provider", bestProvider).
+				Float64("score", bestScore).
+				Str("selected_provider", selected.provider).
+				Float64("selected_score", selected.score).
+				Msg("Diversity bias selected proposal from less-used provider")
+			bestProposal = selected.proposal
+			bestScore = selected.score
+			bestProvider = selected.provider
+			diversitySelected = true
+		}
+	}
+
+	results.Log(log, bestProvider)
 	if bestProposal == nil {
 		return nil, errors.New("no proposals received")
 	}
+	if bestEmpty {
+		// Proposing an empty block is better than missing the slot.
+		log.Warn().Str("provider", bestProvider).Msg("All proposals received are empty; proposing empty block")
+	}
+	s.recordSelection(bestProvider)
+	s.recordExplanation(opts.Slot, bestProvider, selectionReason(bestProvider, bestEmpty, diversitySelected, candidates), candidates)
 	log.Trace().Str("provider", bestProvider).Stringer("proposal", bestProposal).Float64("score", bestScore).Dur("elapsed", time.Since(started)).Msg("Selected best proposal")
+	if margin, ok := scoreMargin(scores); ok {
+		log.Trace().Float64("margin", margin).Msg("Margin over second-best proposal")
+		monitorScoreMargin(margin)
+	}
+	if s.shadowScorer != nil {
+		s.compareShadowSelection(log, bestProvider, bestScore, bestShadowProvider, bestShadowScore)
+	}
 	if bestProvider != "" {
 		s.clientMonitor.StrategyOperation("best", bestProvider, "beacon block proposal", time.Since(started))
 	}
 
 	span.SetAttributes(
+		attribute.String("provider", bestProvider),
+		attribute.Float64("score", bestScore),
+		attribute.Int64("elapsed_ms", time.Since(started).Milliseconds()),
 		attribute.String("value", new(big.Int).Add(bestProposal.ConsensusValue, bestProposal.ExecutionValue).String()),
 		attribute.Bool("blinded", bestProposal.Blinded),
 	)
@@ -219,6 +317,7 @@ func (s *Service) beaconBlockProposal(ctx context.Context,
 	started time.Time,
 	name string,
 	provider eth2client.ProposalProvider,
+	roots *candidateRoots,
 	respCh chan *beaconBlockResponse,
 	errCh chan *beaconBlockError,
 	opts *api.ProposalOpts,
@@ -241,6 +340,25 @@ func (s *Service) beaconBlockProposal(ctx context.Context,
 	proposal := proposalResponse.Data
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained beacon block proposal")
 
+	// A desynced beacon node could return a block for a slot other than that requested.
+	proposalSlot, err := proposal.Slot()
+	if err != nil {
+		errCh <- &beaconBlockError{
+			provider: name,
+			err:      errors.Wrap(err, "failed to obtain slot for beacon block"),
+		}
+
+		return
+	}
+	if proposalSlot != opts.Slot {
+		errCh <- &beaconBlockError{
+			provider: name,
+			err:      fmt.Errorf("beacon block obtained for slot %d rather than requested slot %d", proposalSlot, opts.Slot),
+		}
+
+		return
+	}
+
 	if proposal.Version != spec.DataVersionPhase0 &&
 		proposal.Version != spec.DataVersionAltair {
 		feeRecipient, err := proposal.FeeRecipient()
@@ -262,11 +380,124 @@ func (s *Service) beaconBlockProposal(ctx context.Context,
 		}
 	}
 
-	score := s.scoreBeaconBlockProposal(ctx, name, proposal)
+	// Identical proposals, for example from providers backed by the same
+	// beacon node, are only scored once.
+	if root, err := proposal.Root(); err != nil {
+		log.Debug().Str("provider", name).Err(err).Msg("Failed to obtain root of beacon block proposal; not checking for duplicates")
+	} else if claimant, claimed := roots.claim(root, proposal.Blinded, name); !claimed {
+		log.Debug().Str("provider", name).Str("duplicate_of", claimant).Msg("Beacon block proposal is a duplicate")
+		monitorProposalScored("duplicate")
+		respCh <- &beaconBlockResponse{
+			provider:    name,
+			proposal:    proposal,
+			duplicateOf: claimant,
+		}
+
+		return
+	}
+
+	// The value used for scoring depends on the configured value source.
+	valued := s.valuedProposal(ctx, name, proposal)
+
+	// Scoring is bounded by the process concurrency, so that a large number
+	// of providers cannot swamp the available processors.
+	if err := s.scoringSem.Acquire(ctx, 1); err != nil {
+		errCh <- &beaconBlockError{
+			provider: name,
+			err:      errors.Wrap(err, "failed to acquire scoring semaphore"),
+		}
+
+		return
+	}
+	breakdown, err := s.scoreBeaconBlockProposalBreakdown(ctx, name, valued)
+	s.scoringSem.Release(1)
+	if err != nil {
+		monitorProposalScored("errored")
+		errCh <- &beaconBlockError{
+			provider: name,
+			err:      errors.Wrap(err, "failed to score beacon block"),
+		}
+
+		return
+	}
+	if slashed, err := s.managedSlashedValidators(ctx, proposal); err != nil {
+		log.Debug().Str("provider", name).Err(err).Msg("Failed to check proposal for slashings of managed validators")
+	} else if len(slashed) > 0 {
+		// The proposal contains evidence that validators we manage have signed
+		// slashable messages.  This is not something we want to be rewarded for
+		// including, and suggests that the validators are running elsewhere.
+		log.Error().Str("provider", name).Uints64("validator_indices", validatorIndicesToUint64s(slashed)).Msg("Beacon block proposal slashes managed validators; check for duplicate validator instances")
+		monitorManagedValidatorSlashings(len(slashed))
+		breakdown.slashesManagedValidators = true
+		breakdown.scale = 0
+		breakdown.score = 0
+	}
+	score := breakdown.score
+	empty := proposalIsEmpty(proposal)
+	// Each proposal is counted once, under the most specific result that applies.
+	switch {
+	case empty:
+		log.Debug().Str("provider", name).Msg("Beacon block proposal is empty")
+		monitorProposalScored("empty")
+	case score == 0:
+		// A zero score is not an error; the block is still selectable.
+		log.Debug().Str("provider", name).Msg("Beacon block proposal has zero score")
+		monitorProposalScored("zero")
+	default:
+		monitorProposalScored("scored")
+	}
+	if newVotes, duplicateVotes, err := s.attestationVotes(proposal); err != nil {
+		log.Debug().Str("provider", name).Err(err).Msg("Failed to count attestation votes")
+	} else {
+		log.Trace().Str("provider", name).Int("new_votes", newVotes).Int("duplicate_votes", duplicateVotes).Msg("Counted attestation votes")
+		monitorAttestationVotes(newVotes, duplicateVotes)
+	}
 	span.SetAttributes(attribute.Float64("score", score))
-	respCh <- &beaconBlockResponse{
-		provider: name,
-		proposal: proposal,
-		score:    score,
+	resp := &beaconBlockResponse{
+		provider:  name,
+		proposal:  proposal,
+		score:     score,
+		breakdown: breakdown,
+		empty:     empty,
+	}
+	if s.shadowScorer != nil {
+		shadowScore, err := s.shadowScorer(ctx, name, valued)
+		if err != nil {
+			log.Debug().Str("provider", name).Err(err).Msg("Failed to shadow score beacon block")
+		} else {
+			resp.shadowScore = shadowScore
+			resp.shadowScored = true
+		}
+	}
+	respCh <- resp
+}
+
+// compareShadowSelection compares the production selection with that of the shadow scorer.
+// The production selection is always used; this only logs and records any differences.
+func (*Service) compareShadowSelection(log zerolog.Logger,
+	provider string,
+	score float64,
+	shadowProvider string,
+	shadowScore float64,
+) {
+	if shadowProvider == "" {
+		log.Debug().Msg("Shadow scorer did not score any proposals")
+		monitorShadowSelection("unscored")
+
+		return
 	}
+	if provider == shadowProvider {
+		log.Trace().Str("provider", provider).Float64("shadow_score", shadowScore).Msg("Shadow scorer agreed with selection")
+		monitorShadowSelection("agreed")
+
+		return
+	}
+
+	log.Info().
+		Str("provider", provider).
+		Float64("score", score).
+		Str("shadow_provider", shadowProvider).
+		Float64("shadow_score", shadowScore).
+		Msg("Shadow scorer would have selected a different proposal")
+	monitorShadowSelection("disagreed")
 }
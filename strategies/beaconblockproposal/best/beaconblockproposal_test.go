@@ -112,6 +112,44 @@ func TestProposal(t *testing.T) {
 			slot:           12345,
 			committeeIndex: 3,
 		},
+		{
+			name: "WrongSlot",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(2),
+				best.WithSignedBeaconBlockProvider(signedBeaconBlockProvider),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"wrongslot": mock.NewWrongSlotProposalProvider(mock.NewProposalProvider()),
+				}),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			err:            "no proposals received",
+		},
+		{
+			name: "WrongSlotMixed",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(2),
+				best.WithSignedBeaconBlockProvider(signedBeaconBlockProvider),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"wrongslot": mock.NewWrongSlotProposalProvider(mock.NewProposalProvider()),
+					"sleepy":    mock.NewSleepyProposalProvider(100*time.Millisecond, mock.NewProposalProvider()),
+				}),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+		},
 		{
 			name: "SoftTimeoutWithResponses",
 			params: []best.Parameter{
@@ -171,6 +209,26 @@ func TestProposal(t *testing.T) {
 			committeeIndex: 3,
 			logEntries:     []string{"Soft timeout reached with no responses"},
 		},
+		{
+			name: "Duplicates",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(2),
+				best.WithSignedBeaconBlockProvider(signedBeaconBlockProvider),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"one": mock.NewProposalProvider(),
+					"two": mock.NewProposalProvider(),
+				}),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			logEntries:     []string{"Beacon block proposal is a duplicate", "Duplicate response received"},
+		},
 	}
 
 	for _, test := range tests {
@@ -194,6 +252,13 @@ func TestProposal(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				require.NotNil(t, proposal)
+				proposalSlot, err := proposal.Data.Slot()
+				require.NoError(t, err)
+				require.Equal(t, test.slot, proposalSlot)
+				explanation := s.LatestProposalExplanation()
+				require.NotNil(t, explanation)
+				require.Equal(t, test.slot, explanation.Slot)
+				require.NotEmpty(t, explanation.Candidates)
 			}
 			for _, entry := range test.logEntries {
 				capture.AssertHasEntry(t, entry)
@@ -0,0 +1,73 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+// applyDiversityBias returns the proposal to select from the candidates.
+// Candidates whose score is within the diversity bias of the best score are
+// considered equivalent, and of these the one from the provider that has been
+// selected least often is chosen, falling back to the higher score.  This
+// avoids always taking blocks from a single dominant provider when it offers
+// no material advantage.  Empty proposals are only considered if all of the
+// candidates are empty.
+func (s *Service) applyDiversityBias(bestProvider string,
+	bestScore float64,
+	candidates []*beaconBlockResponse,
+) *beaconBlockResponse {
+	threshold := bestScore * (1 - s.diversityBias)
+	allEmpty := true
+	for _, candidate := range candidates {
+		if !candidate.empty {
+			allEmpty = false
+			break
+		}
+	}
+
+	s.selectionsMu.Lock()
+	defer s.selectionsMu.Unlock()
+
+	var selected *beaconBlockResponse
+	for _, candidate := range candidates {
+		if candidate.score < threshold {
+			continue
+		}
+		if candidate.empty && !allEmpty {
+			continue
+		}
+		if selected == nil {
+			selected = candidate
+			continue
+		}
+		candidateSelections := s.selections[candidate.provider]
+		selectedSelections := s.selections[selected.provider]
+		switch {
+		case candidateSelections < selectedSelections:
+			selected = candidate
+		case candidateSelections == selectedSelections && candidate.score > selected.score:
+			selected = candidate
+		case candidateSelections == selectedSelections && candidate.score == selected.score && candidate.provider == bestProvider:
+			selected = candidate
+		}
+	}
+
+	return selected
+}
+
+// recordSelection records the provider of a selected proposal.
+func (s *Service) recordSelection(provider string) {
+	s.selectionsMu.Lock()
+	s.selections[provider]++
+	s.selectionsMu.Unlock()
+
+	monitorProviderSelection(provider)
+}
@@ -0,0 +1,122 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDiversityBias(t *testing.T) {
+	tests := []struct {
+		name       string
+		bias       float64
+		selections map[string]uint64
+		candidates []*beaconBlockResponse
+		best       string
+		provider   string
+	}{
+		{
+			name:       "TiedUnusedProvider",
+			bias:       0.01,
+			selections: map[string]uint64{"dominant": 10},
+			candidates: []*beaconBlockResponse{
+				{provider: "dominant", score: 100},
+				{provider: "other", score: 100},
+			},
+			best:     "dominant",
+			provider: "other",
+		},
+		{
+			name:       "CloseLessUsedProvider",
+			bias:       0.01,
+			selections: map[string]uint64{"dominant": 10, "other": 2},
+			candidates: []*beaconBlockResponse{
+				{provider: "dominant", score: 100},
+				{provider: "other", score: 99.5},
+			},
+			best:     "dominant",
+			provider: "other",
+		},
+		{
+			name:       "OutsideBias",
+			bias:       0.01,
+			selections: map[string]uint64{"dominant": 10},
+			candidates: []*beaconBlockResponse{
+				{provider: "dominant", score: 100},
+				{provider: "other", score: 98},
+			},
+			best:     "dominant",
+			provider: "dominant",
+		},
+		{
+			name:       "TiedEquallyUsed",
+			bias:       0.01,
+			selections: map[string]uint64{"dominant": 3, "other": 3},
+			candidates: []*beaconBlockResponse{
+				{provider: "other", score: 100},
+				{provider: "dominant", score: 100},
+			},
+			best:     "dominant",
+			provider: "dominant",
+		},
+		{
+			name:       "CloseEquallyUsedHigherScore",
+			bias:       0.01,
+			selections: map[string]uint64{"dominant": 3, "other": 3},
+			candidates: []*beaconBlockResponse{
+				{provider: "other", score: 99.5},
+				{provider: "dominant", score: 100},
+			},
+			best:     "dominant",
+			provider: "dominant",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				diversityBias: test.bias,
+				selections:    test.selections,
+			}
+			bestScore := float64(0)
+			for _, candidate := range test.candidates {
+				if candidate.provider == test.best {
+					bestScore = candidate.score
+				}
+			}
+			selected := s.applyDiversityBias(test.best, bestScore, test.candidates)
+			require.Equal(t, test.provider, selected.provider)
+		})
+	}
+}
+
+func TestDiversityBiasRotatesTiedProviders(t *testing.T) {
+	s := &Service{
+		diversityBias: 0.01,
+		selections:    make(map[string]uint64),
+	}
+	candidates := []*beaconBlockResponse{
+		{provider: "a", score: 100},
+		{provider: "b", score: 100},
+	}
+
+	// With tied proposals selections alternate between providers rather than always using the first.
+	for range 4 {
+		selected := s.applyDiversityBias("a", 100, candidates)
+		s.recordSelection(selected.provider)
+	}
+	require.Equal(t, map[string]uint64{"a": 2, "b": 2}, s.selections)
+}
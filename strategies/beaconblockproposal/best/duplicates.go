@@ -0,0 +1,63 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// candidateRoots tracks the roots of the candidate proposals for a single
+// request, so that identical proposals are only scored and considered for
+// selection once.  Identical proposals can be returned when multiple
+// providers are backed by the same beacon node, or as an artifact of retries.
+type candidateRoots struct {
+	mu    sync.Mutex
+	roots map[candidateRoot]string
+}
+
+// candidateRoot identifies a candidate proposal.  Blinded and unblinded
+// proposals with the same root are not interchangeable, so are kept distinct.
+type candidateRoot struct {
+	root    phase0.Root
+	blinded bool
+}
+
+// newCandidateRoots creates a new set of candidate roots.
+func newCandidateRoots() *candidateRoots {
+	return &candidateRoots{
+		roots: make(map[candidateRoot]string),
+	}
+}
+
+// claim claims the proposal with the given root for the provider.  If the
+// proposal has already been claimed it returns the provider that claimed it
+// and false, otherwise it returns the provider and true.
+func (c *candidateRoots) claim(root phase0.Root, blinded bool, provider string) (string, bool) {
+	key := candidateRoot{
+		root:    root,
+		blinded: blinded,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if claimant, exists := c.roots[key]; exists {
+		return claimant, false
+	}
+	c.roots[key] = provider
+
+	return provider, true
+}
@@ -0,0 +1,84 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandidateRootsClaim(t *testing.T) {
+	roots := newCandidateRoots()
+
+	claimant, claimed := roots.claim(phase0.Root{0x01}, false, "one")
+	require.True(t, claimed)
+	require.Equal(t, "one", claimant)
+
+	// The same root from a different provider is a duplicate.
+	claimant, claimed = roots.claim(phase0.Root{0x01}, false, "two")
+	require.False(t, claimed)
+	require.Equal(t, "one", claimant)
+
+	// The same root from the same provider is also a duplicate.
+	claimant, claimed = roots.claim(phase0.Root{0x01}, false, "one")
+	require.False(t, claimed)
+	require.Equal(t, "one", claimant)
+
+	// A different root is not a duplicate.
+	claimant, claimed = roots.claim(phase0.Root{0x02}, false, "two")
+	require.True(t, claimed)
+	require.Equal(t, "two", claimant)
+
+	// A blinded proposal is not a duplicate of an unblinded proposal.
+	claimant, claimed = roots.claim(phase0.Root{0x01}, true, "three")
+	require.True(t, claimed)
+	require.Equal(t, "three", claimant)
+}
+
+func TestBeaconBlockProposalDuplicates(t *testing.T) {
+	ctx := context.Background()
+
+	s := valuedProvidersService(t, map[string]eth2client.ProposalProvider{
+		"one":   mock.NewProposalProvider(),
+		"two":   mock.NewProposalProvider(),
+		"three": mock.NewProposalProvider(),
+	})
+
+	opts := &api.ProposalOpts{Slot: 12345}
+	roots := newCandidateRoots()
+	respCh := make(chan *beaconBlockResponse, 3)
+	errCh := make(chan *beaconBlockError, 3)
+	for _, name := range []string{"one", "two", "three"} {
+		s.beaconBlockProposal(ctx, time.Now(), name, s.proposalProviders[name], roots, respCh, errCh, opts)
+	}
+	require.Empty(t, errCh)
+	require.Len(t, respCh, 3)
+
+	// Only the first of the identical proposals is scored.
+	first := <-respCh
+	require.Equal(t, "one", first.provider)
+	require.Empty(t, first.duplicateOf)
+	for range 2 {
+		resp := <-respCh
+		require.Equal(t, "one", resp.duplicateOf)
+		require.Zero(t, resp.score)
+	}
+}
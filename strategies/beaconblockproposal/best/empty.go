@@ -0,0 +1,57 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+)
+
+// proposalIsEmpty returns true if the proposal contains neither attestations
+// nor execution transactions.  Blinded proposals are never considered empty,
+// as their transactions are not available for inspection.
+func proposalIsEmpty(proposal *api.VersionedProposal) bool {
+	if proposal.Blinded {
+		return false
+	}
+
+	attestations, err := proposal.Attestations()
+	if err != nil || len(attestations) > 0 {
+		return false
+	}
+
+	if proposal.Version >= spec.DataVersionBellatrix {
+		transactions, err := proposal.Transactions()
+		if err != nil || len(transactions) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// preferResponse returns true if the response should be selected over the
+// current best response.  A non-empty proposal is always preferred to an empty
+// one regardless of score, but an empty proposal remains selectable so that
+// the slot is not missed if that is all that is available.
+func preferResponse(resp *beaconBlockResponse,
+	bestScore float64,
+	bestEmpty bool,
+) bool {
+	if resp.empty != bestEmpty {
+		return !resp.empty
+	}
+
+	return resp.score > bestScore
+}
@@ -0,0 +1,150 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/vouch/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// emptyProposalProvider returns proposals from the next provider with their
+// attestations and transactions removed.
+type emptyProposalProvider struct {
+	next eth2client.ProposalProvider
+}
+
+func (p *emptyProposalProvider) Proposal(ctx context.Context,
+	opts *api.ProposalOpts,
+) (
+	*api.Response[*api.VersionedProposal],
+	error,
+) {
+	resp, err := p.next.Proposal(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp.Data.Capella.Body.Attestations = nil
+	resp.Data.Capella.Body.ExecutionPayload.Transactions = nil
+
+	return resp, nil
+}
+
+func TestProposalIsEmpty(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		mutate   func(proposal *api.VersionedProposal)
+		expected bool
+	}{
+		{
+			name:   "Attestations",
+			mutate: func(_ *api.VersionedProposal) {},
+		},
+		{
+			name: "Empty",
+			mutate: func(proposal *api.VersionedProposal) {
+				proposal.Capella.Body.Attestations = nil
+			},
+			expected: true,
+		},
+		{
+			name: "Transactions",
+			mutate: func(proposal *api.VersionedProposal) {
+				proposal.Capella.Body.Attestations = nil
+				proposal.Capella.Body.ExecutionPayload.Transactions = []bellatrix.Transaction{{0x01}}
+			},
+		},
+		{
+			name: "Blinded",
+			mutate: func(proposal *api.VersionedProposal) {
+				proposal.Capella.Body.Attestations = nil
+				proposal.Blinded = true
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := mock.NewProposalProvider().Proposal(ctx, &api.ProposalOpts{Slot: 12345})
+			require.NoError(t, err)
+			test.mutate(resp.Data)
+			require.Equal(t, test.expected, proposalIsEmpty(resp.Data))
+		})
+	}
+}
+
+func TestProposalEmptySelection(t *testing.T) {
+	ctx := context.Background()
+
+	full := mock.NewProposalProvider()
+	// The empty proposal is more valuable than the full proposal, so would be
+	// selected on score alone.
+	empty := &valuedProposalProvider{
+		next:           &emptyProposalProvider{next: mock.NewProposalProvider()},
+		consensusValue: big.NewInt(1000000),
+		executionValue: big.NewInt(1000000),
+	}
+
+	tests := []struct {
+		name          string
+		providers     map[string]eth2client.ProposalProvider
+		diversityBias float64
+		expectedEmpty bool
+	}{
+		{
+			name: "Mixed",
+			providers: map[string]eth2client.ProposalProvider{
+				"empty": empty,
+				"full":  full,
+			},
+		},
+		{
+			name: "MixedDiversityBias",
+			providers: map[string]eth2client.ProposalProvider{
+				"empty": empty,
+				"full":  full,
+			},
+			diversityBias: 1,
+		},
+		{
+			name: "AllEmpty",
+			providers: map[string]eth2client.ProposalProvider{
+				"empty 1": empty,
+				"empty 2": empty,
+			},
+			expectedEmpty: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := valuedProvidersService(t, test.providers)
+			s.diversityBias = test.diversityBias
+			res, err := s.Proposal(ctx, &api.ProposalOpts{
+				Slot: 12345,
+			})
+			require.NoError(t, err)
+			require.NotNil(t, res.Data)
+			require.Equal(t, test.expectedEmpty, proposalIsEmpty(res.Data))
+		})
+	}
+}
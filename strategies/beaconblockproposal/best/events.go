@@ -121,6 +121,7 @@ func (s *Service) updateBlockVotes(_ context.Context,
 	}
 
 	s.priorBlocksVotesMu.Lock()
+	priorBlockVotes.chainVotes = s.chainVotes(parentRoot, votes)
 	s.priorBlocksVotes[root] = priorBlockVotes
 	for k, v := range s.priorBlocksVotes {
 		// Keep 2 epochs' worth of data as per comment above.
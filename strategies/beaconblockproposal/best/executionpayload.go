@@ -0,0 +1,108 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+)
+
+// executionPayloadScoring configures the contribution of a proposal's
+// execution payload to its score.
+type executionPayloadScoring struct {
+	// factor is the value added to the score of a proposal whose execution
+	// payload is at the baselines.  A value of 0 disables the contribution.
+	factor               float64
+	gasBaseline          uint64
+	transactionsBaseline uint64
+}
+
+// score returns the contribution of the proposal's execution payload to its
+// score.  This is the factor scaled by the gas used and number of transactions
+// in the payload, each normalised against its baseline and averaged.  Blinded
+// proposals do not expose their transactions, so are scaled by gas used alone.
+func (e executionPayloadScoring) score(blockProposal *api.VersionedProposal) float64 {
+	if e.factor == 0 {
+		return 0
+	}
+	gasUsed, transactions, hasTransactions, exists := executionPayloadUsage(blockProposal)
+	if !exists {
+		return 0
+	}
+
+	usage := float64(gasUsed) / float64(e.gasBaseline)
+	if hasTransactions {
+		usage = (usage + float64(transactions)/float64(e.transactionsBaseline)) / 2
+	}
+
+	return e.factor * usage
+}
+
+// executionPayloadUsage returns the gas used by, and number of transactions in,
+// the execution payload of the proposal.  The number of transactions is only
+// available if hasTransactions is true.  If the proposal does not have an
+// execution payload then exists is false.
+func executionPayloadUsage(blockProposal *api.VersionedProposal) (uint64, int, bool, bool) {
+	var gasUsed uint64
+	switch blockProposal.Version {
+	case spec.DataVersionBellatrix:
+		if blockProposal.Blinded {
+			if blockProposal.BellatrixBlinded == nil || blockProposal.BellatrixBlinded.Body == nil || blockProposal.BellatrixBlinded.Body.ExecutionPayloadHeader == nil {
+				return 0, 0, false, false
+			}
+			gasUsed = blockProposal.BellatrixBlinded.Body.ExecutionPayloadHeader.GasUsed
+		} else {
+			if blockProposal.Bellatrix == nil || blockProposal.Bellatrix.Body == nil || blockProposal.Bellatrix.Body.ExecutionPayload == nil {
+				return 0, 0, false, false
+			}
+			gasUsed = blockProposal.Bellatrix.Body.ExecutionPayload.GasUsed
+		}
+	case spec.DataVersionCapella:
+		if blockProposal.Blinded {
+			if blockProposal.CapellaBlinded == nil || blockProposal.CapellaBlinded.Body == nil || blockProposal.CapellaBlinded.Body.ExecutionPayloadHeader == nil {
+				return 0, 0, false, false
+			}
+			gasUsed = blockProposal.CapellaBlinded.Body.ExecutionPayloadHeader.GasUsed
+		} else {
+			if blockProposal.Capella == nil || blockProposal.Capella.Body == nil || blockProposal.Capella.Body.ExecutionPayload == nil {
+				return 0, 0, false, false
+			}
+			gasUsed = blockProposal.Capella.Body.ExecutionPayload.GasUsed
+		}
+	case spec.DataVersionDeneb:
+		if blockProposal.Blinded {
+			if blockProposal.DenebBlinded == nil || blockProposal.DenebBlinded.Body == nil || blockProposal.DenebBlinded.Body.ExecutionPayloadHeader == nil {
+				return 0, 0, false, false
+			}
+			gasUsed = blockProposal.DenebBlinded.Body.ExecutionPayloadHeader.GasUsed
+		} else {
+			if blockProposal.Deneb == nil || blockProposal.Deneb.Block == nil || blockProposal.Deneb.Block.Body == nil || blockProposal.Deneb.Block.Body.ExecutionPayload == nil {
+				return 0, 0, false, false
+			}
+			gasUsed = blockProposal.Deneb.Block.Body.ExecutionPayload.GasUsed
+		}
+	default:
+		return 0, 0, false, false
+	}
+
+	if blockProposal.Blinded {
+		return gasUsed, 0, false, true
+	}
+	transactions, err := blockProposal.Transactions()
+	if err != nil {
+		return gasUsed, 0, false, true
+	}
+
+	return gasUsed, len(transactions), true, true
+}
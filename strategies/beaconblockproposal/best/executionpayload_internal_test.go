@@ -0,0 +1,167 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/stretchr/testify/require"
+)
+
+// capellaProposalWithPayload returns a Capella proposal with the given gas used
+// and number of transactions in its execution payload.
+func capellaProposalWithPayload(gasUsed uint64, transactions int) *api.VersionedProposal {
+	payloadTransactions := make([]bellatrix.Transaction, transactions)
+	for i := range payloadTransactions {
+		payloadTransactions[i] = bellatrix.Transaction{0x01}
+	}
+
+	return &api.VersionedProposal{
+		Version:        spec.DataVersionCapella,
+		ConsensusValue: big.NewInt(1000),
+		ExecutionValue: big.NewInt(1000),
+		Capella: &capella.BeaconBlock{
+			Body: &capella.BeaconBlockBody{
+				ExecutionPayload: &capella.ExecutionPayload{
+					GasUsed:      gasUsed,
+					Transactions: payloadTransactions,
+				},
+			},
+		},
+	}
+}
+
+func TestExecutionPayloadScore(t *testing.T) {
+	scoring := executionPayloadScoring{
+		factor:               100,
+		gasBaseline:          1000,
+		transactionsBaseline: 10,
+	}
+
+	tests := []struct {
+		name     string
+		scoring  executionPayloadScoring
+		proposal *api.VersionedProposal
+		score    float64
+	}{
+		{
+			name:     "Disabled",
+			proposal: capellaProposalWithPayload(1000, 10),
+			score:    0,
+		},
+		{
+			name:     "Baseline",
+			scoring:  scoring,
+			proposal: capellaProposalWithPayload(1000, 10),
+			score:    100,
+		},
+		{
+			name:     "DoubleGas",
+			scoring:  scoring,
+			proposal: capellaProposalWithPayload(2000, 10),
+			score:    150,
+		},
+		{
+			name:     "DoubleTransactions",
+			scoring:  scoring,
+			proposal: capellaProposalWithPayload(1000, 20),
+			score:    150,
+		},
+		{
+			name:     "DoubleGasAndTransactions",
+			scoring:  scoring,
+			proposal: capellaProposalWithPayload(2000, 20),
+			score:    200,
+		},
+		{
+			name:     "Empty",
+			scoring:  scoring,
+			proposal: capellaProposalWithPayload(0, 0),
+			score:    0,
+		},
+		{
+			// Blinded proposals do not expose their transactions, so are scored by gas used alone.
+			name:    "BlindedGasOnly",
+			scoring: scoring,
+			proposal: &api.VersionedProposal{
+				Version: spec.DataVersionCapella,
+				Blinded: true,
+				CapellaBlinded: &apiv1capella.BlindedBeaconBlock{
+					Body: &apiv1capella.BlindedBeaconBlockBody{
+						ExecutionPayloadHeader: &capella.ExecutionPayloadHeader{
+							GasUsed: 2000,
+						},
+					},
+				},
+			},
+			score: 200,
+		},
+		{
+			name:    "NoPayload",
+			scoring: scoring,
+			proposal: &api.VersionedProposal{
+				Version: spec.DataVersionCapella,
+				Capella: &capella.BeaconBlock{
+					Body: &capella.BeaconBlockBody{},
+				},
+			},
+			score: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.score, test.scoring.score(test.proposal))
+		})
+	}
+}
+
+func TestScoreExecutionPayloadGasUsed(t *testing.T) {
+	ctx := context.Background()
+
+	// Proposals that differ only in the gas used by their execution payload.
+	highGas := capellaProposalWithPayload(20000000, 150)
+	lowGas := capellaProposalWithPayload(10000000, 150)
+
+	// With the default neutral factor the proposals score equally.
+	s := &Service{
+		executionValueWeight: 1,
+		executionPayloadScoring: executionPayloadScoring{
+			gasBaseline:          15000000,
+			transactionsBaseline: 150,
+		},
+	}
+	highScore, err := s.scoreBeaconBlockProposal(ctx, "high", highGas)
+	require.NoError(t, err)
+	lowScore, err := s.scoreBeaconBlockProposal(ctx, "low", lowGas)
+	require.NoError(t, err)
+	require.Equal(t, highScore, lowScore)
+
+	// With a factor the proposal with the higher gas used wins.
+	s.executionPayloadScoring.factor = 300
+	highScore, err = s.scoreBeaconBlockProposal(ctx, "high", highGas)
+	require.NoError(t, err)
+	lowScore, err = s.scoreBeaconBlockProposal(ctx, "low", lowGas)
+	require.NoError(t, err)
+	require.Greater(t, highScore, lowScore)
+	require.Equal(t, float64(2350), highScore)
+	require.Equal(t, float64(2250), lowScore)
+}
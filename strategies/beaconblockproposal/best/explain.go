@@ -0,0 +1,117 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/snapshot"
+)
+
+const (
+	selectionReasonOnly         = "only proposal received"
+	selectionReasonHighestScore = "highest score"
+	selectionReasonNonEmpty     = "highest scoring proposal that is not empty"
+	selectionReasonAllEmpty     = "all proposals empty; highest score"
+	selectionReasonDiversity    = "diversity bias towards less-used provider"
+)
+
+// selectionReason returns the reason that the given provider's proposal was
+// selected from the candidates.
+func selectionReason(provider string,
+	empty bool,
+	diversitySelected bool,
+	candidates []*beaconBlockResponse,
+) string {
+	switch {
+	case diversitySelected:
+		return selectionReasonDiversity
+	case len(candidates) == 1:
+		return selectionReasonOnly
+	case empty:
+		return selectionReasonAllEmpty
+	}
+
+	var selectedScore float64
+	for _, candidate := range candidates {
+		if candidate.provider == provider {
+			selectedScore = candidate.score
+		}
+	}
+	for _, candidate := range candidates {
+		if candidate.empty && candidate.score > selectedScore {
+			return selectionReasonNonEmpty
+		}
+	}
+
+	return selectionReasonHighestScore
+}
+
+// recordExplanation records the explanation for the selection of a proposal.
+func (s *Service) recordExplanation(slot phase0.Slot,
+	provider string,
+	reason string,
+	candidates []*beaconBlockResponse,
+) {
+	explanation := &snapshot.ProposalExplanation{
+		Slot:             slot,
+		SelectedProvider: provider,
+		Reason:           reason,
+		Candidates:       make([]*snapshot.ProposalCandidate, 0, len(candidates)),
+	}
+	for _, candidate := range candidates {
+		if candidate.breakdown == nil {
+			continue
+		}
+		explanation.Candidates = append(explanation.Candidates, proposalCandidate(candidate))
+	}
+	sort.SliceStable(explanation.Candidates, func(i int, j int) bool {
+		return explanation.Candidates[i].Score > explanation.Candidates[j].Score
+	})
+
+	s.latestExplanationMu.Lock()
+	s.latestExplanation = explanation
+	s.latestExplanationMu.Unlock()
+}
+
+// proposalCandidate returns the explanation of a candidate proposal.
+func proposalCandidate(candidate *beaconBlockResponse) *snapshot.ProposalCandidate {
+	res := &snapshot.ProposalCandidate{
+		Provider:                 candidate.provider,
+		ConsensusValue:           candidate.breakdown.consensusValue.String(),
+		ExecutionValue:           candidate.breakdown.executionValue.String(),
+		ExecutionValueWeight:     candidate.breakdown.executionValueWeight,
+		ExecutionPayloadScore:    candidate.breakdown.executionPayloadScore,
+		SlashesManagedValidators: candidate.breakdown.slashesManagedValidators,
+		Scale:                    candidate.breakdown.scale,
+		Empty:                    candidate.empty,
+		Score:                    candidate.score,
+	}
+	if candidate.breakdown.hasSyncAggregate {
+		syncParticipation := candidate.breakdown.syncParticipation
+		res.SyncParticipation = &syncParticipation
+	}
+
+	return res
+}
+
+// LatestProposalExplanation returns the explanation for the most recently
+// selected beacon block proposal, or nil if none has been selected.
+func (s *Service) LatestProposalExplanation() *snapshot.ProposalExplanation {
+	s.latestExplanationMu.RLock()
+	defer s.latestExplanationMu.RUnlock()
+
+	return s.latestExplanation
+}
@@ -0,0 +1,155 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectionReason(t *testing.T) {
+	tests := []struct {
+		name              string
+		provider          string
+		empty             bool
+		diversitySelected bool
+		candidates        []*beaconBlockResponse
+		reason            string
+	}{
+		{
+			name:     "Only",
+			provider: "a",
+			candidates: []*beaconBlockResponse{
+				{provider: "a", score: 100},
+			},
+			reason: selectionReasonOnly,
+		},
+		{
+			name:     "HighestScore",
+			provider: "a",
+			candidates: []*beaconBlockResponse{
+				{provider: "a", score: 100},
+				{provider: "b", score: 50},
+			},
+			reason: selectionReasonHighestScore,
+		},
+		{
+			name:     "NonEmpty",
+			provider: "a",
+			candidates: []*beaconBlockResponse{
+				{provider: "a", score: 100},
+				{provider: "b", score: 200, empty: true},
+			},
+			reason: selectionReasonNonEmpty,
+		},
+		{
+			name:     "AllEmpty",
+			provider: "a",
+			empty:    true,
+			candidates: []*beaconBlockResponse{
+				{provider: "a", score: 100, empty: true},
+				{provider: "b", score: 50, empty: true},
+			},
+			reason: selectionReasonAllEmpty,
+		},
+		{
+			name:              "Diversity",
+			provider:          "b",
+			diversitySelected: true,
+			candidates: []*beaconBlockResponse{
+				{provider: "a", score: 100},
+				{provider: "b", score: 99.5},
+			},
+			reason: selectionReasonDiversity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.reason, selectionReason(test.provider, test.empty, test.diversitySelected, test.candidates))
+		})
+	}
+}
+
+func TestRecordExplanation(t *testing.T) {
+	s := &Service{}
+	require.Nil(t, s.LatestProposalExplanation())
+
+	candidates := []*beaconBlockResponse{
+		{
+			provider: "low",
+			score:    1500,
+			breakdown: &scoreBreakdown{
+				consensusValue:       big.NewInt(1000),
+				executionValue:       big.NewInt(1000),
+				executionValueWeight: 1,
+				syncParticipation:    0.25,
+				hasSyncAggregate:     true,
+				scale:                0.75,
+				score:                1500,
+			},
+		},
+		{
+			provider: "high",
+			score:    3050,
+			breakdown: &scoreBreakdown{
+				consensusValue:        big.NewInt(1000),
+				executionValue:        big.NewInt(2000),
+				executionValueWeight:  1,
+				executionPayloadScore: 50,
+				scale:                 1,
+				score:                 3050,
+			},
+		},
+		{
+			provider: "slashing",
+			empty:    true,
+			breakdown: &scoreBreakdown{
+				consensusValue:           big.NewInt(5000),
+				executionValue:           big.NewInt(0),
+				executionValueWeight:     1,
+				slashesManagedValidators: true,
+			},
+		},
+	}
+	s.recordExplanation(10, "high", selectionReasonHighestScore, candidates)
+
+	explanation := s.LatestProposalExplanation()
+	require.NotNil(t, explanation)
+	require.Equal(t, uint64(10), uint64(explanation.Slot))
+	require.Equal(t, "high", explanation.SelectedProvider)
+	require.Equal(t, selectionReasonHighestScore, explanation.Reason)
+	require.Len(t, explanation.Candidates, 3)
+
+	// Candidates are ordered by score.
+	require.Equal(t, "high", explanation.Candidates[0].Provider)
+	require.Equal(t, "1000", explanation.Candidates[0].ConsensusValue)
+	require.Equal(t, "2000", explanation.Candidates[0].ExecutionValue)
+	require.InDelta(t, 50, explanation.Candidates[0].ExecutionPayloadScore, 0)
+	require.Nil(t, explanation.Candidates[0].SyncParticipation)
+	require.InDelta(t, 1, explanation.Candidates[0].Scale, 0)
+	require.InDelta(t, 3050, explanation.Candidates[0].Score, 0)
+
+	require.Equal(t, "low", explanation.Candidates[1].Provider)
+	require.NotNil(t, explanation.Candidates[1].SyncParticipation)
+	require.InDelta(t, 0.25, *explanation.Candidates[1].SyncParticipation, 0)
+	require.InDelta(t, 0.75, explanation.Candidates[1].Scale, 0)
+
+	require.Equal(t, "slashing", explanation.Candidates[2].Provider)
+	require.True(t, explanation.Candidates[2].SlashesManagedValidators)
+	require.True(t, explanation.Candidates[2].Empty)
+	require.InDelta(t, 0, explanation.Candidates[2].Score, 0)
+}
@@ -0,0 +1,216 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	scoreMarginMetric    prometheus.Histogram
+	firstCandidateMetric prometheus.Histogram
+	proposalsScored      *prometheus.CounterVec
+	shadowSelections     *prometheus.CounterVec
+	attestationVotes     *prometheus.CounterVec
+	managedSlashings     prometheus.Counter
+	providerSelections   *prometheus.CounterVec
+	priorBlocksLookups   *prometheus.CounterVec
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if scoreMarginMetric != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	scoreMarginMetric = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposal_strategy",
+		Name:      "score_margin_meth",
+		Help:      "The difference in score between the best and second-best proposals (in mETH).",
+		Buckets:   prometheus.LinearBuckets(0, 10, 101),
+	})
+	if err := prometheus.Register(scoreMarginMetric); err != nil {
+		return errors.Wrap(err, "failed to register vouch_beaconblockproposal_strategy_score_margin_meth")
+	}
+
+	firstCandidateMetric = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposal_strategy",
+		Name:      "first_candidate_seconds",
+		Help:      "The time in to the slot at which the first candidate proposal was received.",
+		Buckets:   prometheus.LinearBuckets(0.1, 0.1, 40),
+	})
+	if err := prometheus.Register(firstCandidateMetric); err != nil {
+		return errors.Wrap(err, "failed to register vouch_beaconblockproposal_strategy_first_candidate_seconds")
+	}
+
+	proposalsScored = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposal_strategy",
+		Name:      "proposals_scored_total",
+		Help:      "The number of proposals scored.",
+	}, []string{"result"})
+	if err := prometheus.Register(proposalsScored); err != nil {
+		return errors.Wrap(err, "failed to register vouch_beaconblockproposal_strategy_proposals_scored_total")
+	}
+
+	shadowSelections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposal_strategy",
+		Name:      "shadow_selections_total",
+		Help:      "The number of proposal selections compared against the shadow scorer.",
+	}, []string{"result"})
+	if err := prometheus.Register(shadowSelections); err != nil {
+		return errors.Wrap(err, "failed to register vouch_beaconblockproposal_strategy_shadow_selections_total")
+	}
+
+	attestationVotes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposal_strategy",
+		Name:      "attestation_votes_total",
+		Help:      "The number of attestation votes in scored proposals.",
+	}, []string{"result"})
+	if err := prometheus.Register(attestationVotes); err != nil {
+		return errors.Wrap(err, "failed to register vouch_beaconblockproposal_strategy_attestation_votes_total")
+	}
+
+	managedSlashings = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposal_strategy",
+		Name:      "managed_validator_slashings_total",
+		Help:      "The number of managed validators slashed by scored proposals.",
+	})
+	if err := prometheus.Register(managedSlashings); err != nil {
+		return errors.Wrap(err, "failed to register vouch_beaconblockproposal_strategy_managed_validator_slashings_total")
+	}
+
+	providerSelections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposal_strategy",
+		Name:      "provider_selections_total",
+		Help:      "The number of proposals selected from each provider.",
+	}, []string{"provider"})
+	if err := prometheus.Register(providerSelections); err != nil {
+		return errors.Wrap(err, "failed to register vouch_beaconblockproposal_strategy_provider_selections_total")
+	}
+
+	priorBlocksLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "beaconblockproposal_strategy",
+		Name:      "prior_blocks_lookups_total",
+		Help:      "The number of lookups of the parent of a proposal in the prior blocks cache.",
+	}, []string{"result"})
+	if err := prometheus.Register(priorBlocksLookups); err != nil {
+		return errors.Wrap(err, "failed to register vouch_beaconblockproposal_strategy_prior_blocks_lookups_total")
+	}
+
+	return nil
+}
+
+// monitorScoreMargin provides the margin between the best and second-best proposal scores.
+func monitorScoreMargin(margin float64) {
+	if scoreMarginMetric == nil {
+		// Not yet registered.
+		return
+	}
+
+	scoreMarginMetric.Observe(margin / 1e15)
+}
+
+// monitorFirstCandidate provides the time in to the slot at which the first candidate proposal was received.
+func monitorFirstCandidate(delay time.Duration) {
+	if firstCandidateMetric == nil {
+		// Not yet registered.
+		return
+	}
+
+	firstCandidateMetric.Observe(delay.Seconds())
+}
+
+// monitorProposalScored is called when a proposal has been scored.
+func monitorProposalScored(result string) {
+	if proposalsScored == nil {
+		// Not yet registered.
+		return
+	}
+
+	proposalsScored.WithLabelValues(result).Inc()
+}
+
+// monitorShadowSelection is called when the production selection has been compared against the shadow selection.
+func monitorShadowSelection(result string) {
+	if shadowSelections == nil {
+		// Not yet registered.
+		return
+	}
+
+	shadowSelections.WithLabelValues(result).Inc()
+}
+
+// monitorAttestationVotes provides the number of new and duplicate attestation votes in a scored proposal.
+func monitorAttestationVotes(newVotes int, duplicateVotes int) {
+	if attestationVotes == nil {
+		// Not yet registered.
+		return
+	}
+
+	attestationVotes.WithLabelValues("new").Add(float64(newVotes))
+	attestationVotes.WithLabelValues("duplicate").Add(float64(duplicateVotes))
+}
+
+// monitorManagedValidatorSlashings provides the number of managed validators slashed by a scored proposal.
+func monitorManagedValidatorSlashings(validators int) {
+	if managedSlashings == nil {
+		// Not yet registered.
+		return
+	}
+
+	managedSlashings.Add(float64(validators))
+}
+
+// monitorProviderSelection is called when a proposal from a provider has been selected.
+func monitorProviderSelection(provider string) {
+	if providerSelections == nil {
+		// Not yet registered.
+		return
+	}
+
+	providerSelections.WithLabelValues(provider).Inc()
+}
+
+// monitorPriorBlocksLookup is called when the prior blocks cache has been checked for the parent of a proposal.
+func monitorPriorBlocksLookup(result string) {
+	if priorBlocksLookups == nil {
+		// Not yet registered.
+		return
+	}
+
+	priorBlocksLookups.WithLabelValues(result).Inc()
+}
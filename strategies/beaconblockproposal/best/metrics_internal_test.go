@@ -0,0 +1,189 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstCandidateMetric(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis is set such that slot 1 starts now.
+	genesisTime := time.Now().Add(-12 * time.Second)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	cacheSvc := mockcache.New(map[phase0.Root]phase0.Slot{})
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithTimeout(2*time.Second),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithChainTimeService(chainTime),
+		WithSpecProvider(specProvider),
+		WithProcessConcurrency(2),
+		WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+		WithProposalProviders(map[string]eth2client.ProposalProvider{
+			"fast": mock.NewSleepyProposalProvider(200*time.Millisecond, mock.NewProposalProvider()),
+			"slow": mock.NewSleepyProposalProvider(600*time.Millisecond, mock.NewProposalProvider()),
+		}),
+		WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+	)
+	require.NoError(t, err)
+
+	// Use an unregistered histogram to capture the metric.
+	originalMetric := firstCandidateMetric
+	defer func() { firstCandidateMetric = originalMetric }()
+	firstCandidateMetric = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_first_candidate_seconds",
+		Buckets: prometheus.LinearBuckets(0.1, 0.1, 40),
+	})
+
+	_, err = s.Proposal(ctx, &api.ProposalOpts{
+		Slot: 1,
+	})
+	require.NoError(t, err)
+
+	metric := &dto.Metric{}
+	require.NoError(t, firstCandidateMetric.Write(metric))
+	// Only the first candidate is recorded, and it is the fast one.
+	require.Equal(t, uint64(1), metric.GetHistogram().GetSampleCount())
+	require.GreaterOrEqual(t, metric.GetHistogram().GetSampleSum(), 0.2)
+	require.Less(t, metric.GetHistogram().GetSampleSum(), 0.6)
+}
+
+func TestProposalScoredMetric(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now().Add(-12 * time.Second)
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	cacheSvc := mockcache.New(map[phase0.Root]phase0.Slot{})
+
+	// Two providers return identical proposals, so one is a duplicate, and the
+	// third returns an empty proposal.
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithTimeout(2*time.Second),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithChainTimeService(chainTime),
+		WithSpecProvider(specProvider),
+		WithProcessConcurrency(2),
+		WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+		WithProposalProviders(map[string]eth2client.ProposalProvider{
+			"one":   mock.NewProposalProvider(),
+			"two":   mock.NewProposalProvider(),
+			"empty": &emptyProposalProvider{next: mock.NewProposalProvider()},
+		}),
+		WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+	)
+	require.NoError(t, err)
+
+	// Use an unregistered counter to capture the metric.
+	originalMetric := proposalsScored
+	defer func() { proposalsScored = originalMetric }()
+	proposalsScored = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "test_proposals_scored_total",
+	}, []string{"result"})
+
+	_, err = s.Proposal(ctx, &api.ProposalOpts{
+		Slot: 1,
+	})
+	require.NoError(t, err)
+
+	// Each proposal is counted exactly once.
+	total := float64(0)
+	for _, result := range []string{"scored", "zero", "empty", "errored", "duplicate"} {
+		metric := &dto.Metric{}
+		require.NoError(t, proposalsScored.WithLabelValues(result).Write(metric))
+		total += metric.GetCounter().GetValue()
+	}
+	require.Equal(t, float64(3), total)
+	for _, result := range []string{"empty", "duplicate"} {
+		metric := &dto.Metric{}
+		require.NoError(t, proposalsScored.WithLabelValues(result).Write(metric))
+		require.Equal(t, float64(1), metric.GetCounter().GetValue(), result)
+	}
+}
+
+func TestPriorBlocksLookupMetric(t *testing.T) {
+	knownRoot := phase0.Root{0x01}
+	unknownRoot := phase0.Root{0x02}
+
+	s := &Service{
+		priorBlocksWalkLimit: 32,
+		priorBlocksVotes: map[phase0.Root]*priorBlockVotes{
+			knownRoot: {
+				root:  knownRoot,
+				slot:  100,
+				votes: map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist{},
+			},
+		},
+	}
+
+	// Use an unregistered counter to capture the metric.
+	originalMetric := priorBlocksLookups
+	defer func() { priorBlocksLookups = originalMetric }()
+	priorBlocksLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "test_prior_blocks_lookups_total",
+	}, []string{"result"})
+
+	for _, parentRoot := range []phase0.Root{knownRoot, unknownRoot, unknownRoot} {
+		_, _, err := s.attestationVotes(&api.VersionedProposal{
+			Version: spec.DataVersionAltair,
+			Altair: &altair.BeaconBlock{
+				Slot:       101,
+				ParentRoot: parentRoot,
+				Body:       &altair.BeaconBlockBody{},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	metric := &dto.Metric{}
+	require.NoError(t, priorBlocksLookups.WithLabelValues("hit").Write(metric))
+	require.Equal(t, float64(1), metric.GetCounter().GetValue())
+	metric = &dto.Metric{}
+	require.NoError(t, priorBlocksLookups.WithLabelValues("miss").Write(metric))
+	require.Equal(t, float64(2), metric.GetCounter().GetValue())
+}
@@ -17,9 +17,12 @@ package best
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/attestantio/go-block-relay/services/builderbidprovider"
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/accountmanager"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
@@ -30,6 +33,7 @@ import (
 
 type parameters struct {
 	logLevel                  zerolog.Level
+	monitor                   metrics.Service
 	clientMonitor             metrics.ClientMonitor
 	processConcurrency        int64
 	eventsProvider            eth2client.EventsProvider
@@ -40,6 +44,32 @@ type parameters struct {
 	timeout                   time.Duration
 	blockRootToSlotCache      cache.BlockRootToSlotProvider
 	executionPayloadFactor    float64
+	executionValueWeight      float64
+	syncParticipationMinimum  float64
+	syncParticipationPenalty  float64
+	logResults                bool
+
+	// Execution payload scoring.
+	executionPayloadGasBaseline          uint64
+	executionPayloadTransactionsBaseline uint64
+
+	// Maximum number of prior blocks walked when counting prior votes.
+	priorBlocksWalkLimit uint64
+
+	// Detection of slashings of managed validators.
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
+
+	// Shadow scoring.
+	shadowScoring                  bool
+	shadowSyncParticipationMinimum float64
+	shadowSyncParticipationPenalty float64
+
+	// Bias toward less-used providers.
+	diversityBias float64
+
+	// Source of proposal values.
+	valueSource        string
+	builderBidProvider builderbidprovider.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -67,6 +97,13 @@ func WithTimeout(timeout time.Duration) Parameter {
 	})
 }
 
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
 // WithClientMonitor sets the client monitor for the service.
 func WithClientMonitor(monitor metrics.ClientMonitor) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -123,18 +160,132 @@ func WithBlockRootToSlotCache(cache cache.BlockRootToSlotProvider) Parameter {
 	})
 }
 
-// WithExecutionPayloadFactor sets the relative weight of execution payload gas to block score.
+// WithExecutionPayloadFactor sets the value added to the score of a proposal whose execution payload is at the baselines.
 func WithExecutionPayloadFactor(factor float64) Parameter {
 	return parameterFunc(func(p *parameters) {
 		p.executionPayloadFactor = factor
 	})
 }
 
+// WithExecutionPayloadGasBaseline sets the gas used against which execution payloads are normalised when scoring.
+func WithExecutionPayloadGasBaseline(baseline uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.executionPayloadGasBaseline = baseline
+	})
+}
+
+// WithExecutionPayloadTransactionsBaseline sets the number of transactions against which execution payloads are normalised when scoring.
+func WithExecutionPayloadTransactionsBaseline(baseline uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.executionPayloadTransactionsBaseline = baseline
+	})
+}
+
+// WithExecutionValueWeight sets the weight applied to the execution value of a proposal relative to its consensus value.
+func WithExecutionValueWeight(weight float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.executionValueWeight = weight
+	})
+}
+
+// WithSyncParticipationMinimum sets the fraction of sync committee participation below which a proposal is penalised.
+func WithSyncParticipationMinimum(minimum float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.syncParticipationMinimum = minimum
+	})
+}
+
+// WithSyncParticipationPenalty sets the fraction of the score removed from proposals with insufficient sync committee participation.
+func WithSyncParticipationPenalty(penalty float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.syncParticipationPenalty = penalty
+	})
+}
+
+// WithPriorBlocksWalkLimit sets the maximum number of prior blocks walked when
+// looking for votes already included on the chain of a proposal.  If 0, the
+// number of slots per epoch is used.
+func WithPriorBlocksWalkLimit(limit uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.priorBlocksWalkLimit = limit
+	})
+}
+
+// WithValidatingAccountsProvider sets the provider of managed validators.  If
+// set, proposals containing slashings of managed validators are not rewarded.
+func WithValidatingAccountsProvider(provider accountmanager.ValidatingAccountsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatingAccountsProvider = provider
+	})
+}
+
+// WithShadowScoring enables scoring of proposals with a shadow scorer alongside the production scorer.
+// The shadow scorer does not affect the proposal selected, but differences in selection are logged and recorded.
+func WithShadowScoring(enabled bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.shadowScoring = enabled
+	})
+}
+
+// WithShadowSyncParticipationMinimum sets the sync participation minimum used by the shadow scorer.
+func WithShadowSyncParticipationMinimum(minimum float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.shadowSyncParticipationMinimum = minimum
+	})
+}
+
+// WithShadowSyncParticipationPenalty sets the sync participation penalty used by the shadow scorer.
+func WithShadowSyncParticipationPenalty(penalty float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.shadowSyncParticipationPenalty = penalty
+	})
+}
+
+// WithLogResults logs the result from each provider for each call to the strategy.
+func WithLogResults(logResults bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logResults = logResults
+	})
+}
+
+// WithDiversityBias sets the fraction of the best score within which a proposal
+// from a less-used provider is preferred.  0 disables the bias.
+func WithDiversityBias(bias float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.diversityBias = bias
+	})
+}
+
+// WithValueSource sets the source of the execution value used when scoring
+// proposals.  This can be "beacon-node" to use the value reported by the
+// beacon node, "consensus" to ignore execution value, or "relay" to use the
+// value of the relay bid for blinded proposals.
+func WithValueSource(source string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.valueSource = source
+	})
+}
+
+// WithBuilderBidProvider sets the provider of relay bids, used when the value
+// source is "relay".
+func WithBuilderBidProvider(provider builderbidprovider.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.builderBidProvider = provider
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
 		logLevel:      zerolog.GlobalLevel(),
+		monitor:       nullmetrics.New(context.Background()),
 		clientMonitor: nullmetrics.New(context.Background()),
+		valueSource:   "beacon-node",
+
+		executionValueWeight: 1,
+
+		executionPayloadGasBaseline:          15000000,
+		executionPayloadTransactionsBaseline: 150,
 	}
 	for _, p := range params {
 		if params != nil {
@@ -145,6 +296,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.timeout == 0 {
 		return nil, errors.New("no timeout specified")
 	}
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
 	if parameters.clientMonitor == nil {
 		return nil, errors.New("no client monitor specified")
 	}
@@ -169,6 +323,42 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.blockRootToSlotCache == nil {
 		return nil, errors.New("no block root to slot cache specified")
 	}
+	if parameters.syncParticipationMinimum < 0 || parameters.syncParticipationMinimum > 1 {
+		return nil, errors.New("sync participation minimum must be between 0 and 1")
+	}
+	if parameters.syncParticipationPenalty < 0 || parameters.syncParticipationPenalty > 1 {
+		return nil, errors.New("sync participation penalty must be between 0 and 1")
+	}
+	if parameters.shadowSyncParticipationMinimum < 0 || parameters.shadowSyncParticipationMinimum > 1 {
+		return nil, errors.New("shadow sync participation minimum must be between 0 and 1")
+	}
+	if parameters.shadowSyncParticipationPenalty < 0 || parameters.shadowSyncParticipationPenalty > 1 {
+		return nil, errors.New("shadow sync participation penalty must be between 0 and 1")
+	}
+	if parameters.executionValueWeight < 0 {
+		return nil, errors.New("execution value weight cannot be negative")
+	}
+	if parameters.executionPayloadFactor < 0 {
+		return nil, errors.New("execution payload factor cannot be negative")
+	}
+	if parameters.executionPayloadGasBaseline == 0 {
+		return nil, errors.New("no execution payload gas baseline specified")
+	}
+	if parameters.executionPayloadTransactionsBaseline == 0 {
+		return nil, errors.New("no execution payload transactions baseline specified")
+	}
+	if parameters.diversityBias < 0 || parameters.diversityBias > 1 {
+		return nil, errors.New("diversity bias must be between 0 and 1")
+	}
+	switch parameters.valueSource {
+	case "beacon-node", "consensus":
+	case "relay":
+		if parameters.builderBidProvider == nil {
+			return nil, errors.New("no builder bid provider specified")
+		}
+	default:
+		return nil, fmt.Errorf("unknown value source %q", parameters.valueSource)
+	}
 
 	return &parameters, nil
 }
@@ -16,28 +16,199 @@ package best
 import (
 	"context"
 	"math/big"
+	"sort"
 
 	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/pkg/errors"
 )
 
+// scoreBreakdown is the breakdown of the score of a beacon block proposal.
+type scoreBreakdown struct {
+	consensusValue        *big.Int
+	executionValue        *big.Int
+	executionValueWeight  float64
+	executionPayloadScore float64
+	syncParticipation     float64
+	hasSyncAggregate      bool
+	// scale is the multiplier applied to the summed values, for example
+	// due to a sync participation penalty.
+	scale float64
+	// slashesManagedValidators is true if the proposal slashes validators
+	// managed by this instance, in which case the score is 0.
+	slashesManagedValidators bool
+	score                    float64
+}
+
 // scoreBeaconBlockPropsal generates a score for a beacon block.
-// The score is the reward expected by proposing the block.
-func (*Service) scoreBeaconBlockProposal(_ context.Context,
+// The score is the consensus value of the block plus its execution value
+// scaled by the execution value weight, plus any contribution from its
+// execution payload, reduced by the sync participation
+// penalty if the block's sync aggregate has participation below the configured
+// minimum.
+// A valid block can legitimately score 0, for example in a quiet slot; an
+// error is returned only if the block cannot be scored.
+func (s *Service) scoreBeaconBlockProposal(ctx context.Context,
+	name string,
+	blockProposal *api.VersionedProposal,
+) (
+	float64,
+	error,
+) {
+	breakdown, err := s.scoreBeaconBlockProposalBreakdown(ctx, name, blockProposal)
+	if err != nil {
+		return 0, err
+	}
+
+	return breakdown.score, nil
+}
+
+// scoreBeaconBlockProposalBreakdown generates a score for a beacon block,
+// along with the components from which the score was generated.
+func (s *Service) scoreBeaconBlockProposalBreakdown(_ context.Context,
 	name string,
 	blockProposal *api.VersionedProposal,
-) float64 {
+) (
+	*scoreBreakdown,
+	error,
+) {
+	return scoreProposalBreakdown(name, blockProposal, s.executionValueWeight, s.syncParticipationMinimum, s.syncParticipationPenalty, s.executionPayloadScoring)
+}
+
+// scoreProposal scores a proposal with the given execution value weight, sync
+// participation minimum and penalty, and execution payload scoring.
+func scoreProposal(name string,
+	blockProposal *api.VersionedProposal,
+	executionValueWeight float64,
+	syncParticipationMinimum float64,
+	syncParticipationPenalty float64,
+	executionPayloadScoring executionPayloadScoring,
+) (
+	float64,
+	error,
+) {
+	breakdown, err := scoreProposalBreakdown(name, blockProposal, executionValueWeight, syncParticipationMinimum, syncParticipationPenalty, executionPayloadScoring)
+	if err != nil {
+		return 0, err
+	}
+
+	return breakdown.score, nil
+}
+
+// scoreProposalBreakdown scores a proposal as per scoreProposal, returning
+// the components of the score as well as the score itself.
+func scoreProposalBreakdown(name string,
+	blockProposal *api.VersionedProposal,
+	executionValueWeight float64,
+	syncParticipationMinimum float64,
+	syncParticipationPenalty float64,
+	executionPayloadScoring executionPayloadScoring,
+) (
+	*scoreBreakdown,
+	error,
+) {
 	if blockProposal == nil {
-		return 0
+		return nil, errors.New("no proposal")
+	}
+	if blockProposal.ConsensusValue == nil {
+		return nil, errors.New("proposal has no consensus value")
 	}
+	if blockProposal.ExecutionValue == nil {
+		return nil, errors.New("proposal has no execution value")
+	}
+
+	executionValue := new(big.Float).SetInt(blockProposal.ExecutionValue)
+	executionValue.Mul(executionValue, big.NewFloat(executionValueWeight))
+	score, _ := executionValue.Add(executionValue, new(big.Float).SetInt(blockProposal.ConsensusValue)).Float64()
+	executionPayloadScore := executionPayloadScoring.score(blockProposal)
+	score += executionPayloadScore
 
-	score, _ := new(big.Int).Add(blockProposal.ConsensusValue, blockProposal.ExecutionValue).Float64()
+	scale := float64(1)
+	syncParticipation, hasSyncAggregate := syncAggregateParticipation(blockProposal)
+	if hasSyncAggregate && syncParticipation < syncParticipationMinimum {
+		log.Trace().
+			Str("name", name).
+			Float64("sync_participation", syncParticipation).
+			Float64("minimum", syncParticipationMinimum).
+			Msg("Sync participation below minimum; applying penalty")
+		scale = 1 - syncParticipationPenalty
+		score *= scale
+	}
 
 	log.Trace().
 		Str("name", name).
 		Stringer("consensus_value", blockProposal.ConsensusValue).
 		Stringer("execution_value", blockProposal.ExecutionValue).
+		Float64("execution_payload_score", executionPayloadScore).
+		Float64("sync_participation", syncParticipation).
 		Float64("score", score).
 		Msg("Scored block")
 
-	return score
+	return &scoreBreakdown{
+		consensusValue:        blockProposal.ConsensusValue,
+		executionValue:        blockProposal.ExecutionValue,
+		executionValueWeight:  executionValueWeight,
+		executionPayloadScore: executionPayloadScore,
+		syncParticipation:     syncParticipation,
+		hasSyncAggregate:      hasSyncAggregate,
+		scale:                 scale,
+		score:                 score,
+	}, nil
+}
+
+// syncAggregateParticipation returns the fraction of the sync committee that
+// participated in the sync aggregate of the proposal, or false if the proposal
+// does not contain a sync aggregate.
+func syncAggregateParticipation(blockProposal *api.VersionedProposal) (float64, bool) {
+	var syncAggregate *altair.SyncAggregate
+	switch blockProposal.Version {
+	case spec.DataVersionAltair:
+		if blockProposal.Altair != nil && blockProposal.Altair.Body != nil {
+			syncAggregate = blockProposal.Altair.Body.SyncAggregate
+		}
+	case spec.DataVersionBellatrix:
+		if blockProposal.Blinded {
+			if blockProposal.BellatrixBlinded != nil && blockProposal.BellatrixBlinded.Body != nil {
+				syncAggregate = blockProposal.BellatrixBlinded.Body.SyncAggregate
+			}
+		} else if blockProposal.Bellatrix != nil && blockProposal.Bellatrix.Body != nil {
+			syncAggregate = blockProposal.Bellatrix.Body.SyncAggregate
+		}
+	case spec.DataVersionCapella:
+		if blockProposal.Blinded {
+			if blockProposal.CapellaBlinded != nil && blockProposal.CapellaBlinded.Body != nil {
+				syncAggregate = blockProposal.CapellaBlinded.Body.SyncAggregate
+			}
+		} else if blockProposal.Capella != nil && blockProposal.Capella.Body != nil {
+			syncAggregate = blockProposal.Capella.Body.SyncAggregate
+		}
+	case spec.DataVersionDeneb:
+		if blockProposal.Blinded {
+			if blockProposal.DenebBlinded != nil && blockProposal.DenebBlinded.Body != nil {
+				syncAggregate = blockProposal.DenebBlinded.Body.SyncAggregate
+			}
+		} else if blockProposal.Deneb != nil && blockProposal.Deneb.Block != nil && blockProposal.Deneb.Block.Body != nil {
+			syncAggregate = blockProposal.Deneb.Block.Body.SyncAggregate
+		}
+	}
+	if syncAggregate == nil || syncAggregate.SyncCommitteeBits.Len() == 0 {
+		return 0, false
+	}
+
+	return float64(syncAggregate.SyncCommitteeBits.Count()) / float64(syncAggregate.SyncCommitteeBits.Len()), true
+}
+
+// scoreMargin returns the difference between the highest and second-highest
+// scores, or false if fewer than two scores are supplied.
+func scoreMargin(scores []float64) (float64, bool) {
+	if len(scores) < 2 {
+		return 0, false
+	}
+
+	sorted := make([]float64, len(scores))
+	copy(sorted, scores)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+
+	return sorted[0] - sorted[1], true
 }
@@ -0,0 +1,430 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreMargin(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []float64
+		margin float64
+		ok     bool
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name:   "Single",
+			scores: []float64{100},
+		},
+		{
+			name:   "Two",
+			scores: []float64{100, 75},
+			margin: 25,
+			ok:     true,
+		},
+		{
+			name:   "Unordered",
+			scores: []float64{40, 120, 90, 10},
+			margin: 30,
+			ok:     true,
+		},
+		{
+			name:   "Tied",
+			scores: []float64{90, 120, 120},
+			margin: 0,
+			ok:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			margin, ok := scoreMargin(test.scores)
+			require.Equal(t, test.ok, ok)
+			require.Equal(t, test.margin, margin)
+		})
+	}
+}
+
+func TestScoreMarginDoesNotReorder(t *testing.T) {
+	scores := []float64{40, 120, 90}
+	_, _ = scoreMargin(scores)
+	require.Equal(t, []float64{40, 120, 90}, scores)
+}
+
+func capellaProposalWithSyncParticipation(participants uint64) *api.VersionedProposal {
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < participants; i++ {
+		bits.SetBitAt(i, true)
+	}
+
+	return &api.VersionedProposal{
+		Version:        spec.DataVersionCapella,
+		ConsensusValue: big.NewInt(1000),
+		ExecutionValue: big.NewInt(1000),
+		Capella: &capella.BeaconBlock{
+			Body: &capella.BeaconBlockBody{
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: bits,
+				},
+			},
+		},
+	}
+}
+
+func TestScoreSyncParticipation(t *testing.T) {
+	ctx := context.Background()
+
+	highParticipation := capellaProposalWithSyncParticipation(500)
+	lowParticipation := capellaProposalWithSyncParticipation(100)
+	noSyncAggregate := &api.VersionedProposal{
+		Version:        spec.DataVersionCapella,
+		ConsensusValue: big.NewInt(1000),
+		ExecutionValue: big.NewInt(1000),
+		Capella: &capella.BeaconBlock{
+			Body: &capella.BeaconBlockBody{},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		minimum  float64
+		penalty  float64
+		proposal *api.VersionedProposal
+		score    float64
+	}{
+		{
+			name:     "HighParticipationDisabled",
+			proposal: highParticipation,
+			score:    2000,
+		},
+		{
+			name:     "LowParticipationDisabled",
+			proposal: lowParticipation,
+			score:    2000,
+		},
+		{
+			name:     "HighParticipation",
+			minimum:  0.5,
+			penalty:  0.25,
+			proposal: highParticipation,
+			score:    2000,
+		},
+		{
+			name:     "LowParticipation",
+			minimum:  0.5,
+			penalty:  0.25,
+			proposal: lowParticipation,
+			score:    1500,
+		},
+		{
+			name:     "NoSyncAggregate",
+			minimum:  0.5,
+			penalty:  0.25,
+			proposal: noSyncAggregate,
+			score:    2000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				executionValueWeight:     1,
+				syncParticipationMinimum: test.minimum,
+				syncParticipationPenalty: test.penalty,
+			}
+			score, err := s.scoreBeaconBlockProposal(ctx, test.name, test.proposal)
+			require.NoError(t, err)
+			require.Equal(t, test.score, score)
+		})
+	}
+
+	// With a penalty, a block with high participation beats an otherwise
+	// equal block with low participation.
+	s := &Service{
+		executionValueWeight:     1,
+		syncParticipationMinimum: 0.5,
+		syncParticipationPenalty: 0.25,
+	}
+	highScore, err := s.scoreBeaconBlockProposal(ctx, "high", highParticipation)
+	require.NoError(t, err)
+	lowScore, err := s.scoreBeaconBlockProposal(ctx, "low", lowParticipation)
+	require.NoError(t, err)
+	require.Greater(t, highScore, lowScore)
+}
+
+// versionedProposal returns a proposal of the given version with the given values.
+func versionedProposal(version spec.DataVersion, blinded bool, consensusValue int64, executionValue int64) *api.VersionedProposal {
+	proposal := &api.VersionedProposal{
+		Version:        version,
+		Blinded:        blinded,
+		ConsensusValue: big.NewInt(consensusValue),
+		ExecutionValue: big.NewInt(executionValue),
+	}
+	switch {
+	case version == spec.DataVersionBellatrix && blinded:
+		proposal.BellatrixBlinded = &apiv1bellatrix.BlindedBeaconBlock{Body: &apiv1bellatrix.BlindedBeaconBlockBody{}}
+	case version == spec.DataVersionBellatrix:
+		proposal.Bellatrix = &bellatrix.BeaconBlock{Body: &bellatrix.BeaconBlockBody{}}
+	case version == spec.DataVersionCapella && blinded:
+		proposal.CapellaBlinded = &apiv1capella.BlindedBeaconBlock{Body: &apiv1capella.BlindedBeaconBlockBody{}}
+	case version == spec.DataVersionCapella:
+		proposal.Capella = &capella.BeaconBlock{Body: &capella.BeaconBlockBody{}}
+	case version == spec.DataVersionDeneb && blinded:
+		proposal.DenebBlinded = &apiv1deneb.BlindedBeaconBlock{Body: &apiv1deneb.BlindedBeaconBlockBody{}}
+	case version == spec.DataVersionDeneb:
+		proposal.Deneb = &apiv1deneb.BlockContents{Block: &deneb.BeaconBlock{Body: &deneb.BeaconBlockBody{}}}
+	}
+
+	return proposal
+}
+
+func TestScoreExecutionValue(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		version spec.DataVersion
+		blinded bool
+	}{
+		{
+			name:    "Bellatrix",
+			version: spec.DataVersionBellatrix,
+		},
+		{
+			name:    "BellatrixBlinded",
+			version: spec.DataVersionBellatrix,
+			blinded: true,
+		},
+		{
+			name:    "Capella",
+			version: spec.DataVersionCapella,
+		},
+		{
+			name:    "CapellaBlinded",
+			version: spec.DataVersionCapella,
+			blinded: true,
+		},
+		{
+			name:    "Deneb",
+			version: spec.DataVersionDeneb,
+		},
+		{
+			name:    "DenebBlinded",
+			version: spec.DataVersionDeneb,
+			blinded: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{executionValueWeight: 1}
+
+			// With equal consensus values, the higher execution value scores higher.
+			highScore, err := s.scoreBeaconBlockProposal(ctx, "high", versionedProposal(test.version, test.blinded, 1000, 3000))
+			require.NoError(t, err)
+			lowScore, err := s.scoreBeaconBlockProposal(ctx, "low", versionedProposal(test.version, test.blinded, 1000, 2000))
+			require.NoError(t, err)
+			require.Equal(t, float64(4000), highScore)
+			require.Equal(t, float64(3000), lowScore)
+			require.Greater(t, highScore, lowScore)
+
+			// Consensus and execution value are weighted equally.
+			consensusScore, err := s.scoreBeaconBlockProposal(ctx, "consensus", versionedProposal(test.version, test.blinded, 3000, 1000))
+			require.NoError(t, err)
+			require.Equal(t, highScore, consensusScore)
+		})
+	}
+}
+
+func TestScoreExecutionValueWeight(t *testing.T) {
+	ctx := context.Background()
+
+	consensus := versionedProposal(spec.DataVersionCapella, false, 1000, 0)
+	execution := versionedProposal(spec.DataVersionCapella, false, 100, 10000)
+
+	tests := []struct {
+		name               string
+		weight             float64
+		consensusScore     float64
+		executionScore     float64
+		executionPreferred bool
+	}{
+		{
+			name:           "ConsensusOnly",
+			weight:         0,
+			consensusScore: 1000,
+			executionScore: 100,
+		},
+		{
+			name:               "Default",
+			weight:             1,
+			consensusScore:     1000,
+			executionScore:     10100,
+			executionPreferred: true,
+		},
+		{
+			name:           "Low",
+			weight:         0.01,
+			consensusScore: 1000,
+			executionScore: 200,
+		},
+		{
+			name:               "High",
+			weight:             1000,
+			consensusScore:     1000,
+			executionScore:     10000100,
+			executionPreferred: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{executionValueWeight: test.weight}
+			consensusScore, err := s.scoreBeaconBlockProposal(ctx, "consensus", consensus)
+			require.NoError(t, err)
+			require.Equal(t, test.consensusScore, consensusScore)
+			executionScore, err := s.scoreBeaconBlockProposal(ctx, "execution", execution)
+			require.NoError(t, err)
+			require.Equal(t, test.executionScore, executionScore)
+			require.Equal(t, test.executionPreferred, executionScore > consensusScore)
+		})
+	}
+}
+
+// TestScoreSlashings ensures that blocks containing slashings are scored by the
+// consensus value reported by the beacon node, which reflects the reward for
+// including the slashing given the current validator set, rather than by a
+// fixed weight relative to attestations.
+func TestScoreSlashings(t *testing.T) {
+	ctx := context.Background()
+
+	slashing := versionedProposal(spec.DataVersionCapella, false, 0, 0)
+	slashing.Capella.Body.ProposerSlashings = []*phase0.ProposerSlashing{{}}
+	attestations := versionedProposal(spec.DataVersionCapella, false, 0, 0)
+	attestations.Capella.Body.Attestations = make([]*phase0.Attestation, 128)
+
+	tests := []struct {
+		name                  string
+		slashingValue         int64
+		attestationsValue     int64
+		slashingPreferred     bool
+		attestationsPreferred bool
+	}{
+		{
+			name:              "SlashingMoreValuable",
+			slashingValue:     60000000,
+			attestationsValue: 40000000,
+			slashingPreferred: true,
+		},
+		{
+			name:                  "AttestationsMoreValuable",
+			slashingValue:         20000000,
+			attestationsValue:     40000000,
+			attestationsPreferred: true,
+		},
+	}
+
+	s := &Service{executionValueWeight: 1}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slashing.ConsensusValue = big.NewInt(test.slashingValue)
+			attestations.ConsensusValue = big.NewInt(test.attestationsValue)
+
+			slashingScore, err := s.scoreBeaconBlockProposal(ctx, "slashing", slashing)
+			require.NoError(t, err)
+			attestationsScore, err := s.scoreBeaconBlockProposal(ctx, "attestations", attestations)
+			require.NoError(t, err)
+			require.Equal(t, float64(test.slashingValue), slashingScore)
+			require.Equal(t, float64(test.attestationsValue), attestationsScore)
+			require.Equal(t, test.slashingPreferred, slashingScore > attestationsScore)
+			require.Equal(t, test.attestationsPreferred, attestationsScore > slashingScore)
+		})
+	}
+}
+
+func TestScoreErrors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		proposal *api.VersionedProposal
+		score    float64
+		err      string
+	}{
+		{
+			name: "Nil",
+			err:  "no proposal",
+		},
+		{
+			name: "ConsensusValueMissing",
+			proposal: &api.VersionedProposal{
+				Version:        spec.DataVersionCapella,
+				ExecutionValue: big.NewInt(0),
+				Capella:        &capella.BeaconBlock{Body: &capella.BeaconBlockBody{}},
+			},
+			err: "proposal has no consensus value",
+		},
+		{
+			name: "ExecutionValueMissing",
+			proposal: &api.VersionedProposal{
+				Version:        spec.DataVersionCapella,
+				ConsensusValue: big.NewInt(0),
+				Capella:        &capella.BeaconBlock{Body: &capella.BeaconBlockBody{}},
+			},
+			err: "proposal has no execution value",
+		},
+		{
+			name: "Zero",
+			proposal: &api.VersionedProposal{
+				Version:        spec.DataVersionCapella,
+				ConsensusValue: big.NewInt(0),
+				ExecutionValue: big.NewInt(0),
+				Capella:        &capella.BeaconBlock{Body: &capella.BeaconBlockBody{}},
+			},
+			score: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{executionValueWeight: 1}
+			score, err := s.scoreBeaconBlockProposal(ctx, test.name, test.proposal)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.score, score)
+			}
+		})
+	}
+}
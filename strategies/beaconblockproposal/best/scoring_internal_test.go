@@ -0,0 +1,297 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// valuedProposalProvider returns proposals from the next provider with the
+// given values.  The values are also written to the graffiti of the proposal,
+// so that proposals with different values are not treated as duplicates.
+type valuedProposalProvider struct {
+	next           eth2client.ProposalProvider
+	consensusValue *big.Int
+	executionValue *big.Int
+}
+
+func (p *valuedProposalProvider) Proposal(ctx context.Context,
+	opts *api.ProposalOpts,
+) (
+	*api.Response[*api.VersionedProposal],
+	error,
+) {
+	resp, err := p.next.Proposal(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp.Data.ConsensusValue = p.consensusValue
+	resp.Data.ExecutionValue = p.executionValue
+	if resp.Data.Capella != nil {
+		copy(resp.Data.Capella.Body.Graffiti[:], fmt.Sprintf("%v/%v", p.consensusValue, p.executionValue))
+	}
+
+	return resp, nil
+}
+
+func valuedProvidersService(t *testing.T, proposalProviders map[string]eth2client.ProposalProvider) *Service {
+	t.Helper()
+	ctx := context.Background()
+
+	genesisProvider := mock.NewGenesisProvider(time.Now())
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithTimeout(time.Second),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithChainTimeService(chainTime),
+		WithSpecProvider(specProvider),
+		WithProcessConcurrency(2),
+		WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+		WithProposalProviders(proposalProviders),
+		WithBlockRootToSlotCache(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)),
+	)
+	require.NoError(t, err)
+
+	return s
+}
+
+func manyProvidersService(t testing.TB, providers int, concurrency int64) *Service {
+	t.Helper()
+	ctx := context.Background()
+
+	genesisProvider := mock.NewGenesisProvider(time.Now())
+	specProvider := mock.NewSpecProvider()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(genesisProvider),
+		standardchaintime.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	proposalProviders := make(map[string]eth2client.ProposalProvider, providers)
+	for i := range providers {
+		proposalProviders[fmt.Sprintf("provider %d", i)] = mock.NewProposalProvider()
+	}
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithTimeout(time.Second),
+		WithEventsProvider(mock.NewEventsProvider()),
+		WithChainTimeService(chainTime),
+		WithSpecProvider(specProvider),
+		WithProcessConcurrency(concurrency),
+		WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+		WithProposalProviders(proposalProviders),
+		WithBlockRootToSlotCache(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)),
+	)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestProposalScoringConcurrency(t *testing.T) {
+	ctx := context.Background()
+	concurrency := int64(2)
+	s := manyProvidersService(t, 32, concurrency)
+
+	// Hold all of the scoring slots; no proposal can be scored.
+	require.NoError(t, s.scoringSem.Acquire(ctx, concurrency))
+	_, err := s.Proposal(ctx, &api.ProposalOpts{
+		Slot: 12345,
+	})
+	require.EqualError(t, err, "no proposals received")
+
+	// Release the slots; all proposals are scored within the bound.
+	s.scoringSem.Release(concurrency)
+	res, err := s.Proposal(ctx, &api.ProposalOpts{
+		Slot: 12345,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res.Data)
+
+	// All slots are free again once the proposal has been selected.
+	require.True(t, s.scoringSem.TryAcquire(concurrency))
+	s.scoringSem.Release(concurrency)
+}
+
+func TestProposalZeroScore(t *testing.T) {
+	ctx := context.Background()
+
+	zero := &valuedProposalProvider{
+		next:           mock.NewProposalProvider(),
+		consensusValue: big.NewInt(0),
+		executionValue: big.NewInt(0),
+	}
+	unscorable := &valuedProposalProvider{
+		next: mock.NewProposalProvider(),
+	}
+
+	tests := []struct {
+		name      string
+		providers map[string]eth2client.ProposalProvider
+		err       string
+	}{
+		{
+			name: "AllZero",
+			providers: map[string]eth2client.ProposalProvider{
+				"zero 1": zero,
+				"zero 2": zero,
+			},
+		},
+		{
+			name: "ZeroAndUnscorable",
+			providers: map[string]eth2client.ProposalProvider{
+				"zero":       zero,
+				"unscorable": unscorable,
+			},
+		},
+		{
+			name: "Unscorable",
+			providers: map[string]eth2client.ProposalProvider{
+				"unscorable": unscorable,
+			},
+			err: "no proposals received",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := valuedProvidersService(t, test.providers)
+			res, err := s.Proposal(ctx, &api.ProposalOpts{
+				Slot: 12345,
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, res.Data)
+				require.Equal(t, int64(0), res.Data.ConsensusValue.Int64())
+				require.Equal(t, int64(0), res.Data.ExecutionValue.Int64())
+			}
+		})
+	}
+}
+
+func TestProposalShadowScoring(t *testing.T) {
+	ctx := context.Background()
+
+	providers := map[string]eth2client.ProposalProvider{
+		"low": &valuedProposalProvider{
+			next:           mock.NewProposalProvider(),
+			consensusValue: big.NewInt(1000),
+			executionValue: big.NewInt(0),
+		},
+		"high": &valuedProposalProvider{
+			next:           mock.NewProposalProvider(),
+			consensusValue: big.NewInt(2000),
+			executionValue: big.NewInt(0),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		shadowScorer func(ctx context.Context, name string, blockProposal *api.VersionedProposal) (float64, error)
+		result       string
+	}{
+		{
+			name: "Agreed",
+			shadowScorer: func(_ context.Context, name string, blockProposal *api.VersionedProposal) (float64, error) {
+				return scoreProposal(name, blockProposal, 1, 0, 0, executionPayloadScoring{})
+			},
+			result: "agreed",
+		},
+		{
+			name: "Disagreed",
+			shadowScorer: func(_ context.Context, name string, blockProposal *api.VersionedProposal) (float64, error) {
+				score, err := scoreProposal(name, blockProposal, 1, 0, 0, executionPayloadScoring{})
+
+				return -score, err
+			},
+			result: "disagreed",
+		},
+		{
+			name: "Unscored",
+			shadowScorer: func(_ context.Context, _ string, _ *api.VersionedProposal) (float64, error) {
+				return 0, errors.New("shadow scorer failed")
+			},
+			result: "unscored",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Use an unregistered counter to capture the metric.
+			originalMetric := shadowSelections
+			defer func() { shadowSelections = originalMetric }()
+			shadowSelections = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "test_shadow_selections_total",
+			}, []string{"result"})
+
+			s := valuedProvidersService(t, providers)
+			s.shadowScorer = test.shadowScorer
+
+			res, err := s.Proposal(ctx, &api.ProposalOpts{
+				Slot: 12345,
+			})
+			require.NoError(t, err)
+			// The production choice is always used.
+			require.Equal(t, int64(2000), res.Data.ConsensusValue.Int64())
+
+			metric := &dto.Metric{}
+			require.NoError(t, shadowSelections.WithLabelValues(test.result).Write(metric))
+			require.Equal(t, float64(1), metric.GetCounter().GetValue())
+		})
+	}
+}
+
+func BenchmarkProposalManyProviders(b *testing.B) {
+	ctx := context.Background()
+	for _, concurrency := range []int64{1, 4, 64} {
+		b.Run(fmt.Sprintf("Concurrency%d", concurrency), func(b *testing.B) {
+			s := manyProvidersService(b, 64, concurrency)
+			b.ResetTimer()
+			for range b.N {
+				_, err := s.Proposal(ctx, &api.ProposalOpts{
+					Slot: 12345,
+				})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
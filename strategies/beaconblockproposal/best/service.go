@@ -18,16 +18,20 @@ import (
 	"sync"
 	"time"
 
+	"github.com/attestantio/go-block-relay/services/builderbidprovider"
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/accountmanager"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/snapshot"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
+	"golang.org/x/sync/semaphore"
 )
 
 // Service is the provider for beacon block proposals.
@@ -39,7 +43,20 @@ type Service struct {
 	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
 	timeout                   time.Duration
 	blockRootToSlotCache      cache.BlockRootToSlotProvider
-	executionPayloadFactor    float64
+	executionPayloadScoring   executionPayloadScoring
+	executionValueWeight      float64
+	syncParticipationMinimum  float64
+	syncParticipationPenalty  float64
+	scoringSem                *semaphore.Weighted
+	logResults                bool
+
+	// validatingAccountsProvider, if set, allows detection of proposals
+	// containing slashings of managed validators.
+	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
+
+	// shadowScorer, if set, scores proposals alongside the production scorer
+	// without affecting the proposal selected.
+	shadowScorer func(ctx context.Context, name string, blockProposal *api.VersionedProposal) (float64, error)
 
 	// Spec values for scoring proposals.
 	slotsPerEpoch      uint64
@@ -52,6 +69,24 @@ type Service struct {
 
 	priorBlocksVotes   map[phase0.Root]*priorBlockVotes
 	priorBlocksVotesMu sync.RWMutex
+	// priorBlocksWalkLimit is the maximum number of prior blocks whose votes
+	// are considered when counting prior votes.
+	priorBlocksWalkLimit uint64
+
+	// diversityBias, if non-zero, prefers proposals from less-used providers
+	// whose score is within this fraction of the best score.
+	diversityBias float64
+	selections    map[string]uint64
+	selectionsMu  sync.Mutex
+
+	// valueSource defines where the execution value of a proposal comes
+	// from when scoring; see valuedProposal.
+	valueSource        string
+	builderBidProvider builderbidprovider.Service
+
+	// latestExplanation explains the selection of the most recent proposal.
+	latestExplanation   *snapshot.ProposalExplanation
+	latestExplanationMu sync.RWMutex
 }
 
 type priorBlockVotes struct {
@@ -60,6 +95,10 @@ type priorBlockVotes struct {
 	slot   phase0.Slot
 	// votes is a map of attestation slot -> committee index -> votes
 	votes map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist
+	// chainVotes are the votes in this block and the blocks before it on
+	// its chain, calculated when the block is received so that scoring does
+	// not need to walk the chain.
+	chainVotes []map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist
 }
 
 // module-wide log.
@@ -78,6 +117,10 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		log = log.Level(parameters.logLevel)
 	}
 
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.Wrap(err, "failed to register metrics")
+	}
+
 	specResponse, err := parameters.specProvider.Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain spec")
@@ -159,6 +202,7 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		proposalProviders:         parameters.proposalProviders,
 		signedBeaconBlockProvider: parameters.signedBeaconBlockProvider,
 		timeout:                   parameters.timeout,
+		logResults:                parameters.logResults,
 		blockRootToSlotCache:      parameters.blockRootToSlotCache,
 		clientMonitor:             parameters.clientMonitor,
 		slotsPerEpoch:             slotsPerEpoch,
@@ -169,7 +213,37 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		proposerWeight:            proposerWeight,
 		weightDenominator:         weightDenominator,
 		priorBlocksVotes:          make(map[phase0.Root]*priorBlockVotes),
-		executionPayloadFactor:    parameters.executionPayloadFactor,
+		priorBlocksWalkLimit:      parameters.priorBlocksWalkLimit,
+		diversityBias:             parameters.diversityBias,
+		selections:                make(map[string]uint64),
+		valueSource:               parameters.valueSource,
+		builderBidProvider:        parameters.builderBidProvider,
+		executionValueWeight:      parameters.executionValueWeight,
+		syncParticipationMinimum:  parameters.syncParticipationMinimum,
+		syncParticipationPenalty:  parameters.syncParticipationPenalty,
+		scoringSem:                semaphore.NewWeighted(parameters.processConcurrency),
+	}
+	s.executionPayloadScoring = executionPayloadScoring{
+		factor:               parameters.executionPayloadFactor,
+		gasBaseline:          parameters.executionPayloadGasBaseline,
+		transactionsBaseline: parameters.executionPayloadTransactionsBaseline,
+	}
+	if s.priorBlocksWalkLimit == 0 {
+		// Attestations older than an epoch cannot be included in a proposal,
+		// so there is no need to walk further back than this.
+		s.priorBlocksWalkLimit = slotsPerEpoch
+	}
+	s.validatingAccountsProvider = parameters.validatingAccountsProvider
+	if parameters.shadowScoring {
+		shadowSyncParticipationMinimum := parameters.shadowSyncParticipationMinimum
+		shadowSyncParticipationPenalty := parameters.shadowSyncParticipationPenalty
+		s.shadowScorer = func(_ context.Context, name string, blockProposal *api.VersionedProposal) (float64, error) {
+			return scoreProposal(name, blockProposal, s.executionValueWeight, shadowSyncParticipationMinimum, shadowSyncParticipationPenalty, s.executionPayloadScoring)
+		}
+		log.Info().
+			Float64("sync_participation_minimum", shadowSyncParticipationMinimum).
+			Float64("sync_participation_penalty", shadowSyncParticipationPenalty).
+			Msg("Shadow scoring enabled")
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
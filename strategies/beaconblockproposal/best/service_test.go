@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	mockbuilderbidprovider "github.com/attestantio/go-block-relay/services/builderbidprovider/mock"
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
@@ -276,6 +277,197 @@ func TestService(t *testing.T) {
 			},
 			err: "failed to add head event handler: error",
 		},
+		{
+			name: "SyncParticipationPenaltyInvalid",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithClientMonitor(null.New(context.Background())),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"one":   mock.NewProposalProvider(),
+					"two":   mock.NewProposalProvider(),
+					"three": mock.NewProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+				best.WithSyncParticipationMinimum(0.5),
+				best.WithSyncParticipationPenalty(1.5),
+			},
+			err: "problem with parameters: sync participation penalty must be between 0 and 1",
+		},
+		{
+			name: "ExecutionPayloadFactorNegative",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithClientMonitor(null.New(context.Background())),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"one":   mock.NewProposalProvider(),
+					"two":   mock.NewProposalProvider(),
+					"three": mock.NewProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+				best.WithExecutionPayloadFactor(-1),
+			},
+			err: "problem with parameters: execution payload factor cannot be negative",
+		},
+		{
+			name: "ExecutionValueWeightNegative",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithClientMonitor(null.New(context.Background())),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"one":   mock.NewProposalProvider(),
+					"two":   mock.NewProposalProvider(),
+					"three": mock.NewProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+				best.WithExecutionValueWeight(-1),
+			},
+			err: "problem with parameters: execution value weight cannot be negative",
+		},
+		{
+			name: "ExecutionPayloadGasBaselineZero",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithClientMonitor(null.New(context.Background())),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"one":   mock.NewProposalProvider(),
+					"two":   mock.NewProposalProvider(),
+					"three": mock.NewProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+				best.WithExecutionPayloadGasBaseline(0),
+			},
+			err: "problem with parameters: no execution payload gas baseline specified",
+		},
+		{
+			name: "ExecutionPayloadTransactionsBaselineZero",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithClientMonitor(null.New(context.Background())),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"one":   mock.NewProposalProvider(),
+					"two":   mock.NewProposalProvider(),
+					"three": mock.NewProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+				best.WithExecutionPayloadTransactionsBaseline(0),
+			},
+			err: "problem with parameters: no execution payload transactions baseline specified",
+		},
+		{
+			name: "ShadowSyncParticipationMinimumInvalid",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithClientMonitor(null.New(context.Background())),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"one":   mock.NewProposalProvider(),
+					"two":   mock.NewProposalProvider(),
+					"three": mock.NewProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+				best.WithShadowScoring(true),
+				best.WithShadowSyncParticipationMinimum(-0.5),
+			},
+			err: "problem with parameters: shadow sync participation minimum must be between 0 and 1",
+		},
+		{
+			name: "ValueSourceUnknown",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithClientMonitor(null.New(context.Background())),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"one":   mock.NewProposalProvider(),
+					"two":   mock.NewProposalProvider(),
+					"three": mock.NewProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+				best.WithValueSource("bid"),
+			},
+			err: "problem with parameters: unknown value source \"bid\"",
+		},
+		{
+			name: "ValueSourceRelayBuilderBidProviderMissing",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithClientMonitor(null.New(context.Background())),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"one":   mock.NewProposalProvider(),
+					"two":   mock.NewProposalProvider(),
+					"three": mock.NewProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+				best.WithValueSource("relay"),
+			},
+			err: "problem with parameters: no builder bid provider specified",
+		},
+		{
+			name: "ValueSourceRelay",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithClientMonitor(null.New(context.Background())),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithProposalProviders(map[string]eth2client.ProposalProvider{
+					"one":   mock.NewProposalProvider(),
+					"two":   mock.NewProposalProvider(),
+					"three": mock.NewProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(blockToSlotCache),
+				best.WithValueSource("relay"),
+				best.WithBuilderBidProvider(mockbuilderbidprovider.New()),
+			},
+		},
 		{
 			name: "Good",
 			params: []best.Parameter{
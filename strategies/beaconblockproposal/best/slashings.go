@@ -0,0 +1,149 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// managedSlashedValidators returns the indices of managed validators that are
+// slashed by the attester and proposer slashings in the proposal.
+func (s *Service) managedSlashedValidators(ctx context.Context,
+	blockProposal *api.VersionedProposal,
+) (
+	[]phase0.ValidatorIndex,
+	error,
+) {
+	if s.validatingAccountsProvider == nil {
+		return nil, nil
+	}
+
+	attesterSlashings, proposerSlashings := proposalSlashings(blockProposal)
+	slashed := slashedValidators(attesterSlashings, proposerSlashings)
+	if len(slashed) == 0 {
+		return nil, nil
+	}
+
+	slot, err := blockProposal.Slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain slot")
+	}
+	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpochByIndex(ctx, s.chainTime.SlotToEpoch(slot), slashed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain managed validators")
+	}
+
+	managed := make([]phase0.ValidatorIndex, 0, len(accounts))
+	for index := range accounts {
+		managed = append(managed, index)
+	}
+	sort.Slice(managed, func(i, j int) bool { return managed[i] < managed[j] })
+
+	return managed, nil
+}
+
+// slashedValidators returns the indices of validators slashed by the given
+// attester and proposer slashings.
+func slashedValidators(attesterSlashings []*phase0.AttesterSlashing,
+	proposerSlashings []*phase0.ProposerSlashing,
+) []phase0.ValidatorIndex {
+	slashed := make(map[phase0.ValidatorIndex]struct{})
+
+	for _, slashing := range attesterSlashings {
+		if slashing == nil || slashing.Attestation1 == nil || slashing.Attestation2 == nil {
+			continue
+		}
+		// Validators are slashed only if they are in both attestations.
+		attestation1Indices := make(map[uint64]struct{}, len(slashing.Attestation1.AttestingIndices))
+		for _, index := range slashing.Attestation1.AttestingIndices {
+			attestation1Indices[index] = struct{}{}
+		}
+		for _, index := range slashing.Attestation2.AttestingIndices {
+			if _, exists := attestation1Indices[index]; exists {
+				slashed[phase0.ValidatorIndex(index)] = struct{}{}
+			}
+		}
+	}
+
+	for _, slashing := range proposerSlashings {
+		if slashing == nil || slashing.SignedHeader1 == nil || slashing.SignedHeader1.Message == nil {
+			continue
+		}
+		slashed[slashing.SignedHeader1.Message.ProposerIndex] = struct{}{}
+	}
+
+	indices := make([]phase0.ValidatorIndex, 0, len(slashed))
+	for index := range slashed {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	return indices
+}
+
+// proposalSlashings returns the attester and proposer slashings contained in the proposal.
+func proposalSlashings(blockProposal *api.VersionedProposal) ([]*phase0.AttesterSlashing, []*phase0.ProposerSlashing) {
+	switch blockProposal.Version {
+	case spec.DataVersionPhase0:
+		if blockProposal.Phase0 != nil && blockProposal.Phase0.Body != nil {
+			return blockProposal.Phase0.Body.AttesterSlashings, blockProposal.Phase0.Body.ProposerSlashings
+		}
+	case spec.DataVersionAltair:
+		if blockProposal.Altair != nil && blockProposal.Altair.Body != nil {
+			return blockProposal.Altair.Body.AttesterSlashings, blockProposal.Altair.Body.ProposerSlashings
+		}
+	case spec.DataVersionBellatrix:
+		if blockProposal.Blinded {
+			if blockProposal.BellatrixBlinded != nil && blockProposal.BellatrixBlinded.Body != nil {
+				return blockProposal.BellatrixBlinded.Body.AttesterSlashings, blockProposal.BellatrixBlinded.Body.ProposerSlashings
+			}
+		} else if blockProposal.Bellatrix != nil && blockProposal.Bellatrix.Body != nil {
+			return blockProposal.Bellatrix.Body.AttesterSlashings, blockProposal.Bellatrix.Body.ProposerSlashings
+		}
+	case spec.DataVersionCapella:
+		if blockProposal.Blinded {
+			if blockProposal.CapellaBlinded != nil && blockProposal.CapellaBlinded.Body != nil {
+				return blockProposal.CapellaBlinded.Body.AttesterSlashings, blockProposal.CapellaBlinded.Body.ProposerSlashings
+			}
+		} else if blockProposal.Capella != nil && blockProposal.Capella.Body != nil {
+			return blockProposal.Capella.Body.AttesterSlashings, blockProposal.Capella.Body.ProposerSlashings
+		}
+	case spec.DataVersionDeneb:
+		if blockProposal.Blinded {
+			if blockProposal.DenebBlinded != nil && blockProposal.DenebBlinded.Body != nil {
+				return blockProposal.DenebBlinded.Body.AttesterSlashings, blockProposal.DenebBlinded.Body.ProposerSlashings
+			}
+		} else if blockProposal.Deneb != nil && blockProposal.Deneb.Block != nil && blockProposal.Deneb.Block.Body != nil {
+			return blockProposal.Deneb.Block.Body.AttesterSlashings, blockProposal.Deneb.Block.Body.ProposerSlashings
+		}
+	}
+
+	return nil, nil
+}
+
+// validatorIndicesToUint64s converts validator indices for logging.
+func validatorIndicesToUint64s(indices []phase0.ValidatorIndex) []uint64 {
+	res := make([]uint64, len(indices))
+	for i := range indices {
+		res[i] = uint64(indices[i])
+	}
+
+	return res
+}
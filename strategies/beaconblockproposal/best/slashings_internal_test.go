@@ -0,0 +1,191 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedSlashedValidators(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	validatingAccountsProvider.AddAccount(2, nil)
+	validatingAccountsProvider.AddAccount(7, nil)
+
+	proposalWith := func(attesterSlashings []*phase0.AttesterSlashing, proposerSlashings []*phase0.ProposerSlashing) *api.VersionedProposal {
+		return &api.VersionedProposal{
+			Version: spec.DataVersionAltair,
+			Altair: &altair.BeaconBlock{
+				Slot: 100,
+				Body: &altair.BeaconBlockBody{
+					AttesterSlashings: attesterSlashings,
+					ProposerSlashings: proposerSlashings,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                       string
+		validatingAccountsProvider bool
+		proposal                   *api.VersionedProposal
+		slashed                    []phase0.ValidatorIndex
+	}{
+		{
+			name:                       "NoProvider",
+			validatingAccountsProvider: false,
+			proposal: proposalWith(nil, []*phase0.ProposerSlashing{
+				{SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: 2}}},
+			}),
+		},
+		{
+			name:                       "NoSlashings",
+			validatingAccountsProvider: true,
+			proposal:                   proposalWith(nil, nil),
+		},
+		{
+			name:                       "UnmanagedSlashings",
+			validatingAccountsProvider: true,
+			proposal: proposalWith([]*phase0.AttesterSlashing{
+				{
+					Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{1, 3}},
+					Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{3, 4}},
+				},
+			}, []*phase0.ProposerSlashing{
+				{SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: 5}}},
+			}),
+		},
+		{
+			name:                       "ManagedNotInBothAttestations",
+			validatingAccountsProvider: true,
+			proposal: proposalWith([]*phase0.AttesterSlashing{
+				{
+					Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{2, 3}},
+					Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{3, 7}},
+				},
+			}, nil),
+		},
+		{
+			name:                       "ManagedSlashings",
+			validatingAccountsProvider: true,
+			proposal: proposalWith([]*phase0.AttesterSlashing{
+				{
+					Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{1, 2, 3, 7}},
+					Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{3, 7}},
+				},
+			}, []*phase0.ProposerSlashing{
+				{SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: 2}}},
+			}),
+			slashed: []phase0.ValidatorIndex{2, 7},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				chainTime: chainTime,
+			}
+			if test.validatingAccountsProvider {
+				s.validatingAccountsProvider = validatingAccountsProvider
+			}
+			slashed, err := s.managedSlashedValidators(ctx, test.proposal)
+			require.NoError(t, err)
+			if len(test.slashed) == 0 {
+				require.Empty(t, slashed)
+			} else {
+				require.Equal(t, test.slashed, slashed)
+			}
+		})
+	}
+}
+
+func TestSlashedValidators(t *testing.T) {
+	tests := []struct {
+		name              string
+		attesterSlashings []*phase0.AttesterSlashing
+		proposerSlashings []*phase0.ProposerSlashing
+		slashed           []phase0.ValidatorIndex
+	}{
+		{
+			name:    "Empty",
+			slashed: []phase0.ValidatorIndex{},
+		},
+		{
+			name: "Nil",
+			attesterSlashings: []*phase0.AttesterSlashing{
+				nil,
+				{Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{1}}},
+			},
+			proposerSlashings: []*phase0.ProposerSlashing{
+				nil,
+				{SignedHeader1: &phase0.SignedBeaconBlockHeader{}},
+			},
+			slashed: []phase0.ValidatorIndex{},
+		},
+		{
+			name: "OverlappingAttesterSlashings",
+			attesterSlashings: []*phase0.AttesterSlashing{
+				{
+					Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{1, 2, 3}},
+					Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{2, 3}},
+				},
+				{
+					Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{3, 4}},
+					Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{3, 4, 5}},
+				},
+			},
+			slashed: []phase0.ValidatorIndex{2, 3, 4},
+		},
+		{
+			name: "AttesterAndProposerSlashings",
+			attesterSlashings: []*phase0.AttesterSlashing{
+				{
+					Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{2, 3}},
+					Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{2, 3}},
+				},
+			},
+			proposerSlashings: []*phase0.ProposerSlashing{
+				{SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: 3}}},
+				{SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: 6}}},
+			},
+			slashed: []phase0.ValidatorIndex{2, 3, 6},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.slashed, slashedValidators(test.attesterSlashings, test.proposerSlashings))
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+)
+
+// valuedProposal returns the proposal with its execution value set according
+// to the configured value source, for scoring.  The value source defines
+// which of the beacon node's reported value and the relay bid is used for the
+// execution value; they are never added together, so builder value is counted
+// once.
+//
+//   - "beacon-node" uses the consensus and execution values reported by the
+//     beacon node.
+//   - "consensus" ignores the execution value, so that proposals are scored
+//     solely on their consensus value.
+//   - "relay" uses the value of the relay bid obtained by Vouch for blinded
+//     proposals that contain the bid's payload, and the value reported by the
+//     beacon node otherwise.
+//
+// The proposal supplied is not altered.
+func (s *Service) valuedProposal(ctx context.Context,
+	name string,
+	blockProposal *api.VersionedProposal,
+) *api.VersionedProposal {
+	switch s.valueSource {
+	case "consensus":
+		valued := *blockProposal
+		valued.ExecutionValue = big.NewInt(0)
+
+		return &valued
+	case "relay":
+		if !blockProposal.Blinded {
+			return blockProposal
+		}
+		value, err := s.relayBidValue(ctx, blockProposal)
+		if err != nil {
+			log.Debug().Str("provider", name).Err(err).Msg("No relay bid value for blinded proposal; using beacon node value")
+
+			return blockProposal
+		}
+		log.Trace().
+			Str("provider", name).
+			Stringer("beacon_node_value", blockProposal.ExecutionValue).
+			Stringer("relay_value", value).
+			Msg("Using relay bid value for blinded proposal")
+		valued := *blockProposal
+		valued.ExecutionValue = value
+
+		return &valued
+	default:
+		return blockProposal
+	}
+}
+
+// relayBidValue returns the value of the relay bid whose payload is contained
+// in the blinded proposal.
+func (s *Service) relayBidValue(ctx context.Context,
+	blockProposal *api.VersionedProposal,
+) (
+	*big.Int,
+	error,
+) {
+	if s.validatingAccountsProvider == nil {
+		return nil, errors.New("no validating accounts provider")
+	}
+
+	slot, err := blockProposal.Slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain slot")
+	}
+	proposerIndex, err := blockProposal.ProposerIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proposer index")
+	}
+	parentHash, blockHash, err := blindedPayloadHashes(blockProposal)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.validatingAccountsProvider.ValidatingAccountsForEpochByIndex(ctx, s.chainTime.SlotToEpoch(slot), []phase0.ValidatorIndex{proposerIndex})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proposer account")
+	}
+	account, exists := accounts[proposerIndex]
+	if !exists {
+		return nil, errors.New("proposer is not a managed validator")
+	}
+
+	bid, err := s.builderBidProvider.BuilderBid(ctx, slot, parentHash, util.ValidatorPubkey(account))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain relay bid")
+	}
+	if bid == nil {
+		return nil, errors.New("no relay bid")
+	}
+	bidBlockHash, err := bid.BlockHash()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain relay bid block hash")
+	}
+	if bidBlockHash != blockHash {
+		return nil, errors.New("proposal does not contain relay bid payload")
+	}
+	value, err := bid.Value()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain relay bid value")
+	}
+
+	return value.ToBig(), nil
+}
+
+// blindedPayloadHashes returns the parent and block hashes of the execution
+// payload header in a blinded proposal.
+func blindedPayloadHashes(blockProposal *api.VersionedProposal) (phase0.Hash32, phase0.Hash32, error) {
+	switch blockProposal.Version {
+	case spec.DataVersionBellatrix:
+		if blockProposal.BellatrixBlinded == nil ||
+			blockProposal.BellatrixBlinded.Body == nil ||
+			blockProposal.BellatrixBlinded.Body.ExecutionPayloadHeader == nil {
+			return phase0.Hash32{}, phase0.Hash32{}, errors.New("no execution payload header")
+		}
+		header := blockProposal.BellatrixBlinded.Body.ExecutionPayloadHeader
+
+		return header.ParentHash, header.BlockHash, nil
+	case spec.DataVersionCapella:
+		if blockProposal.CapellaBlinded == nil ||
+			blockProposal.CapellaBlinded.Body == nil ||
+			blockProposal.CapellaBlinded.Body.ExecutionPayloadHeader == nil {
+			return phase0.Hash32{}, phase0.Hash32{}, errors.New("no execution payload header")
+		}
+		header := blockProposal.CapellaBlinded.Body.ExecutionPayloadHeader
+
+		return header.ParentHash, header.BlockHash, nil
+	case spec.DataVersionDeneb:
+		if blockProposal.DenebBlinded == nil ||
+			blockProposal.DenebBlinded.Body == nil ||
+			blockProposal.DenebBlinded.Body.ExecutionPayloadHeader == nil {
+			return phase0.Hash32{}, phase0.Hash32{}, errors.New("no execution payload header")
+		}
+		header := blockProposal.DenebBlinded.Body.ExecutionPayloadHeader
+
+		return header.ParentHash, header.BlockHash, nil
+	default:
+		return phase0.Hash32{}, phase0.Hash32{}, errors.New("unsupported version for blinded proposal")
+	}
+}
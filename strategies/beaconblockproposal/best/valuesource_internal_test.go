@@ -0,0 +1,265 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	builderdeneb "github.com/attestantio/go-builder-client/api/deneb"
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/util"
+	"github.com/holiman/uint256"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// recordingBuilderBidProvider returns a fixed bid, recording the parameters
+// with which it was called.
+type recordingBuilderBidProvider struct {
+	bid        *builderspec.VersionedSignedBuilderBid
+	parentHash phase0.Hash32
+	pubkey     phase0.BLSPubKey
+}
+
+func (p *recordingBuilderBidProvider) BuilderBid(_ context.Context,
+	_ phase0.Slot,
+	parentHash phase0.Hash32,
+	pubkey phase0.BLSPubKey,
+) (
+	*builderspec.VersionedSignedBuilderBid,
+	error,
+) {
+	p.parentHash = parentHash
+	p.pubkey = pubkey
+
+	return p.bid, nil
+}
+
+func TestValuedProposal(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	validatingAccountsProvider.AddAccount(2, account)
+
+	parentHash := phase0.Hash32{0x01}
+	blockHash := phase0.Hash32{0x02}
+
+	blindedProposal := func(proposerIndex phase0.ValidatorIndex) *api.VersionedProposal {
+		return &api.VersionedProposal{
+			Version:        spec.DataVersionDeneb,
+			Blinded:        true,
+			ConsensusValue: big.NewInt(100),
+			ExecutionValue: big.NewInt(300),
+			DenebBlinded: &apiv1deneb.BlindedBeaconBlock{
+				Slot:          100,
+				ProposerIndex: proposerIndex,
+				Body: &apiv1deneb.BlindedBeaconBlockBody{
+					ExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{
+						ParentHash: parentHash,
+						BlockHash:  blockHash,
+					},
+				},
+			},
+		}
+	}
+	unblindedProposal := &api.VersionedProposal{
+		Version:        spec.DataVersionCapella,
+		ConsensusValue: big.NewInt(100),
+		ExecutionValue: big.NewInt(300),
+		Capella: &capella.BeaconBlock{
+			Slot:          100,
+			ProposerIndex: 2,
+		},
+	}
+	bid := func(hash phase0.Hash32) *builderspec.VersionedSignedBuilderBid {
+		return &builderspec.VersionedSignedBuilderBid{
+			Version: spec.DataVersionDeneb,
+			Deneb: &builderdeneb.SignedBuilderBid{
+				Message: &builderdeneb.BuilderBid{
+					Header: &deneb.ExecutionPayloadHeader{
+						ParentHash: parentHash,
+						BlockHash:  hash,
+					},
+					Value: uint256.NewInt(250),
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		valueSource    string
+		proposal       *api.VersionedProposal
+		bid            *builderspec.VersionedSignedBuilderBid
+		executionValue *big.Int
+	}{
+		{
+			name:           "BeaconNode",
+			valueSource:    "beacon-node",
+			proposal:       blindedProposal(2),
+			bid:            bid(blockHash),
+			executionValue: big.NewInt(300),
+		},
+		{
+			name:           "Consensus",
+			valueSource:    "consensus",
+			proposal:       blindedProposal(2),
+			bid:            bid(blockHash),
+			executionValue: big.NewInt(0),
+		},
+		{
+			name:           "ConsensusUnblinded",
+			valueSource:    "consensus",
+			proposal:       unblindedProposal,
+			executionValue: big.NewInt(0),
+		},
+		{
+			name:           "Relay",
+			valueSource:    "relay",
+			proposal:       blindedProposal(2),
+			bid:            bid(blockHash),
+			executionValue: big.NewInt(250),
+		},
+		{
+			name:           "RelayUnblinded",
+			valueSource:    "relay",
+			proposal:       unblindedProposal,
+			bid:            bid(blockHash),
+			executionValue: big.NewInt(300),
+		},
+		{
+			name:           "RelayNoBid",
+			valueSource:    "relay",
+			proposal:       blindedProposal(2),
+			executionValue: big.NewInt(300),
+		},
+		{
+			name:           "RelayOtherPayload",
+			valueSource:    "relay",
+			proposal:       blindedProposal(2),
+			bid:            bid(phase0.Hash32{0x03}),
+			executionValue: big.NewInt(300),
+		},
+		{
+			name:           "RelayUnmanagedProposer",
+			valueSource:    "relay",
+			proposal:       blindedProposal(9),
+			bid:            bid(blockHash),
+			executionValue: big.NewInt(300),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				chainTime:                  chainTime,
+				validatingAccountsProvider: validatingAccountsProvider,
+				valueSource:                test.valueSource,
+				builderBidProvider:         &recordingBuilderBidProvider{bid: test.bid},
+			}
+			valued := s.valuedProposal(ctx, test.name, test.proposal)
+			require.Equal(t, test.executionValue, valued.ExecutionValue)
+			require.Equal(t, big.NewInt(100), valued.ConsensusValue)
+			// The original proposal must not be altered.
+			require.Equal(t, big.NewInt(300), test.proposal.ExecutionValue)
+		})
+	}
+}
+
+func TestRelayBidValueLookup(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, e2types.InitBLS())
+	store := scratch.New()
+	encryptor := keystorev4.New()
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), store, encryptor, make([]byte, 64))
+	require.NoError(t, err)
+	require.Nil(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account", []byte("pass"))
+	require.NoError(t, err)
+
+	validatingAccountsProvider := mockaccountmanager.NewValidatingAccountsProvider()
+	validatingAccountsProvider.AddAccount(2, account)
+
+	builderBidProvider := &recordingBuilderBidProvider{}
+	s := &Service{
+		chainTime:                  chainTime,
+		validatingAccountsProvider: validatingAccountsProvider,
+		valueSource:                "relay",
+		builderBidProvider:         builderBidProvider,
+	}
+
+	proposal := &api.VersionedProposal{
+		Version:        spec.DataVersionDeneb,
+		Blinded:        true,
+		ConsensusValue: big.NewInt(100),
+		ExecutionValue: big.NewInt(300),
+		DenebBlinded: &apiv1deneb.BlindedBeaconBlock{
+			Slot:          100,
+			ProposerIndex: 2,
+			Body: &apiv1deneb.BlindedBeaconBlockBody{
+				ExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{
+					ParentHash: phase0.Hash32{0x01},
+					BlockHash:  phase0.Hash32{0x02},
+				},
+			},
+		},
+	}
+	_, err = s.relayBidValue(ctx, proposal)
+	require.EqualError(t, err, "no relay bid")
+
+	// The bid is requested for the proposal's parent and proposer.
+	require.Equal(t, phase0.Hash32{0x01}, builderBidProvider.parentHash)
+	require.Equal(t, util.ValidatorPubkey(account), builderBidProvider.pubkey)
+}
@@ -0,0 +1,126 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// attestationVotes returns the number of attestation votes in the proposal
+// that are new, and the number that are duplicates of votes either already
+// included in prior blocks on the proposal's chain or earlier in the proposal
+// itself.
+func (s *Service) attestationVotes(blockProposal *api.VersionedProposal) (int, int, error) {
+	parentRoot, err := blockProposal.ParentRoot()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to obtain parent root")
+	}
+	attestations, err := blockProposal.Attestations()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to obtain attestations")
+	}
+
+	// The votes in prior blocks on this chain are gathered when each block is
+	// received, so only a single lookup is required here.
+	var priorVotes []map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist
+	s.priorBlocksVotesMu.RLock()
+	parent, exists := s.priorBlocksVotes[parentRoot]
+	if exists {
+		priorVotes = parent.chainVotes
+	}
+	s.priorBlocksVotesMu.RUnlock()
+	if !exists {
+		// Without prior votes all votes are considered new, so the count is less accurate.
+		log.Trace().Stringer("parent_root", parentRoot).Msg("Parent not in prior blocks cache; no prior votes")
+		monitorPriorBlocksLookup("miss")
+	} else {
+		monitorPriorBlocksLookup("hit")
+	}
+
+	newVotes := 0
+	duplicateVotes := 0
+	attested := make(map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist)
+	for _, attestation := range attestations {
+		data := attestation.Data
+		if _, exists := attested[data.Slot]; !exists {
+			attested[data.Slot] = make(map[phase0.CommitteeIndex]bitfield.Bitlist)
+		}
+		if _, exists := attested[data.Slot][data.Index]; !exists {
+			attested[data.Slot][data.Index] = bitfield.NewBitlist(attestation.AggregationBits.Len())
+		}
+		for i := range attestation.AggregationBits.Len() {
+			if !attestation.AggregationBits.BitAt(i) {
+				continue
+			}
+			if attested[data.Slot][data.Index].BitAt(i) || priorVoteForAttestation(priorVotes, data.Slot, data.Index, i) {
+				duplicateVotes++
+				continue
+			}
+			attested[data.Slot][data.Index].SetBitAt(i, true)
+			newVotes++
+		}
+	}
+
+	return newVotes, duplicateVotes, nil
+}
+
+// chainVotes returns the votes in a block and in the blocks before it on its
+// chain, most recent first.  The chain is limited to the prior blocks walk
+// limit; votes in blocks beyond the limit are not included.
+// The chain is built from that of the block's parent, so this must be called
+// with the prior blocks votes lock held.
+func (s *Service) chainVotes(parentRoot phase0.Root,
+	votes map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist,
+) []map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist {
+	if s.priorBlocksWalkLimit == 0 {
+		return nil
+	}
+
+	var parentChainVotes []map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist
+	if parent, exists := s.priorBlocksVotes[parentRoot]; exists {
+		parentChainVotes = parent.chainVotes
+	}
+	if uint64(len(parentChainVotes)) >= s.priorBlocksWalkLimit {
+		log.Trace().Stringer("parent_root", parentRoot).Uint64("limit", s.priorBlocksWalkLimit).Msg("Prior blocks walk limit reached")
+		parentChainVotes = parentChainVotes[:s.priorBlocksWalkLimit-1]
+	}
+
+	res := make([]map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist, 0, len(parentChainVotes)+1)
+	res = append(res, votes)
+	res = append(res, parentChainVotes...)
+
+	return res
+}
+
+// priorVoteForAttestation returns true if the given vote is present in any of the prior votes.
+func priorVoteForAttestation(priorVotes []map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist,
+	slot phase0.Slot,
+	index phase0.CommitteeIndex,
+	bit uint64,
+) bool {
+	for _, votes := range priorVotes {
+		committeeVotes, exists := votes[slot][index]
+		if !exists {
+			continue
+		}
+		if bit < committeeVotes.Len() && committeeVotes.BitAt(bit) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,279 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/testutil"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationVotes(t *testing.T) {
+	grandparentRoot := testutil.HexToRoot("0x0101010101010101010101010101010101010101010101010101010101010101")
+	parentRoot := testutil.HexToRoot("0x0202020202020202020202020202020202020202020202020202020202020202")
+	forkRoot := testutil.HexToRoot("0x0303030303030303030303030303030303030303030303030303030303030303")
+
+	s := &Service{
+		priorBlocksWalkLimit: 32,
+		priorBlocksVotes:     make(map[phase0.Root]*priorBlockVotes),
+	}
+	for _, block := range []*priorBlockVotes{
+		{
+			root: grandparentRoot,
+			slot: 101,
+			votes: map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist{
+				101: {1: bitList(6, 128)},
+			},
+		},
+		{
+			root:   parentRoot,
+			parent: grandparentRoot,
+			slot:   102,
+			votes: map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist{
+				101: {1: bitList(4, 128)},
+			},
+		},
+		// A block on another chain, whose votes must not be counted as duplicates.
+		{
+			root:   forkRoot,
+			parent: grandparentRoot,
+			slot:   102,
+			votes: map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist{
+				100: {0: bitList(128, 128)},
+			},
+		},
+	} {
+		addPriorBlockVotes(s, block)
+	}
+
+	tests := []struct {
+		name       string
+		proposal   *api.VersionedProposal
+		newVotes   int
+		duplicates int
+		err        string
+	}{
+		{
+			name:     "Empty",
+			proposal: &api.VersionedProposal{},
+			err:      "failed to obtain parent root: data missing",
+		},
+		{
+			name: "NoAttestations",
+			proposal: &api.VersionedProposal{
+				Version: spec.DataVersionAltair,
+				Altair: &altair.BeaconBlock{
+					Slot:       103,
+					ParentRoot: parentRoot,
+					Body:       &altair.BeaconBlockBody{},
+				},
+			},
+		},
+		{
+			name: "OverlappingVotes",
+			proposal: &api.VersionedProposal{
+				Version: spec.DataVersionAltair,
+				Altair: &altair.BeaconBlock{
+					Slot:       103,
+					ParentRoot: parentRoot,
+					Body: &altair.BeaconBlockBody{
+						Attestations: []*phase0.Attestation{
+							// 10 new votes.
+							{
+								AggregationBits: bitList(10, 128),
+								Data:            &phase0.AttestationData{Slot: 100, Index: 0},
+							},
+							// 5 votes duplicating those earlier in the block.
+							{
+								AggregationBits: bitList(5, 128),
+								Data:            &phase0.AttestationData{Slot: 100, Index: 0},
+							},
+							// 6 votes duplicating those in prior blocks, and 4 new votes.
+							{
+								AggregationBits: bitList(10, 128),
+								Data:            &phase0.AttestationData{Slot: 101, Index: 1},
+							},
+						},
+					},
+				},
+			},
+			newVotes:   14,
+			duplicates: 11,
+		},
+		{
+			name: "UnknownParent",
+			proposal: &api.VersionedProposal{
+				Version: spec.DataVersionAltair,
+				Altair: &altair.BeaconBlock{
+					Slot:       103,
+					ParentRoot: testutil.HexToRoot("0x0404040404040404040404040404040404040404040404040404040404040404"),
+					Body: &altair.BeaconBlockBody{
+						Attestations: []*phase0.Attestation{
+							{
+								AggregationBits: bitList(10, 128),
+								Data:            &phase0.AttestationData{Slot: 101, Index: 1},
+							},
+						},
+					},
+				},
+			},
+			newVotes: 10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newVotes, duplicates, err := s.attestationVotes(test.proposal)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.newVotes, newVotes)
+				require.Equal(t, test.duplicates, duplicates)
+			}
+		})
+	}
+}
+
+func TestAttestationVotesWalkLimit(t *testing.T) {
+	// A long chain of prior blocks, each with a single vote for its own slot.
+	chainLength := 10000
+	roots := make([]phase0.Root, chainLength+1)
+	s := &Service{
+		priorBlocksWalkLimit: 4,
+		priorBlocksVotes:     make(map[phase0.Root]*priorBlockVotes, chainLength),
+	}
+	for i := 1; i <= chainLength; i++ {
+		roots[i] = phase0.Root{byte(i >> 8), byte(i), 0x01}
+		addPriorBlockVotes(s, &priorBlockVotes{
+			root:   roots[i],
+			parent: roots[i-1],
+			slot:   phase0.Slot(i),
+			votes: map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist{
+				phase0.Slot(i): {0: bitList(1, 128)},
+			},
+		})
+	}
+
+	// Two blocks that claim each other as parent.
+	cycleRoot1 := phase0.Root{0x02}
+	cycleRoot2 := phase0.Root{0x03}
+	addPriorBlockVotes(s, &priorBlockVotes{root: cycleRoot1, parent: cycleRoot2, slot: 1})
+	addPriorBlockVotes(s, &priorBlockVotes{root: cycleRoot2, parent: cycleRoot1, slot: 2})
+
+	proposal := func(parentRoot phase0.Root, slots ...phase0.Slot) *api.VersionedProposal {
+		attestations := make([]*phase0.Attestation, 0, len(slots))
+		for _, slot := range slots {
+			attestations = append(attestations, &phase0.Attestation{
+				AggregationBits: bitList(1, 128),
+				Data:            &phase0.AttestationData{Slot: slot, Index: 0},
+			})
+		}
+
+		return &api.VersionedProposal{
+			Version: spec.DataVersionAltair,
+			Altair: &altair.BeaconBlock{
+				Slot:       phase0.Slot(chainLength + 1),
+				ParentRoot: parentRoot,
+				Body: &altair.BeaconBlockBody{
+					Attestations: attestations,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		proposal   *api.VersionedProposal
+		newVotes   int
+		duplicates int
+	}{
+		{
+			name: "LongChain",
+			// Votes in the 4 most recent blocks are duplicates; older votes are beyond the limit.
+			proposal:   proposal(roots[chainLength], phase0.Slot(chainLength), phase0.Slot(chainLength-3), phase0.Slot(chainLength-4), 1),
+			newVotes:   2,
+			duplicates: 2,
+		},
+		{
+			name:     "Cycle",
+			proposal: proposal(cycleRoot1, 1),
+			newVotes: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			done := make(chan struct{})
+			var newVotes, duplicates int
+			var err error
+			go func() {
+				newVotes, duplicates, err = s.attestationVotes(test.proposal)
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				require.FailNow(t, "counting votes did not terminate")
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.newVotes, newVotes)
+			require.Equal(t, test.duplicates, duplicates)
+		})
+	}
+}
+
+// addPriorBlockVotes adds the votes for a block as updateBlockVotes does.
+func addPriorBlockVotes(s *Service, block *priorBlockVotes) {
+	s.priorBlocksVotesMu.Lock()
+	defer s.priorBlocksVotesMu.Unlock()
+	block.chainVotes = s.chainVotes(block.parent, block.votes)
+	s.priorBlocksVotes[block.root] = block
+}
+
+func TestChainVotes(t *testing.T) {
+	s := &Service{
+		priorBlocksWalkLimit: 2,
+		priorBlocksVotes:     make(map[phase0.Root]*priorBlockVotes),
+	}
+
+	roots := []phase0.Root{{0x01}, {0x02}, {0x03}}
+	for i, root := range roots {
+		block := &priorBlockVotes{
+			root: root,
+			slot: phase0.Slot(i + 1),
+			votes: map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist{
+				phase0.Slot(i + 1): {0: bitList(1, 128)},
+			},
+		}
+		if i > 0 {
+			block.parent = roots[i-1]
+		}
+		addPriorBlockVotes(s, block)
+	}
+
+	// Each block's chain holds its own votes and those of its parent, most recent first.
+	require.Len(t, s.priorBlocksVotes[roots[0]].chainVotes, 1)
+	require.Len(t, s.priorBlocksVotes[roots[1]].chainVotes, 2)
+	require.Len(t, s.priorBlocksVotes[roots[2]].chainVotes, 2)
+	require.Contains(t, s.priorBlocksVotes[roots[2]].chainVotes[0], phase0.Slot(3))
+	require.Contains(t, s.priorBlocksVotes[roots[2]].chainVotes[1], phase0.Slot(2))
+}
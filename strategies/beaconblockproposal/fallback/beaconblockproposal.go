@@ -0,0 +1,75 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fallback
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Proposal provides beacon block proposal from the first of the strategies
+// to return a result, trying each in turn until the timeout is reached.
+// Each strategy is given an equal share of the time remaining when it is
+// tried, so a slow strategy always leaves time for those after it.
+func (s *Service) Proposal(ctx context.Context,
+	opts *api.ProposalOpts,
+) (
+	*api.Response[*api.VersionedProposal],
+	error,
+) {
+	ctx, span := otel.Tracer("attestantio.vouch.strategies.beaconblockproposal.fallback").Start(ctx, "Proposal", trace.WithAttributes(
+		attribute.Int64("slot", int64(opts.Slot)),
+	))
+	defer span.End()
+
+	started := time.Now()
+	log := util.LogWithID(ctx, log, "strategy_id").With().Uint64("slot", uint64(opts.Slot)).Logger()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	for i, provider := range s.proposalProviders {
+		if ctx.Err() != nil {
+			log.Warn().Dur("elapsed", time.Since(started)).Int("strategy_index", i).Msg("Timeout reached before trying strategy")
+			break
+		}
+		// Each strategy is given an equal share of the time remaining, so that
+		// a slow strategy cannot use up the time needed by those after it.
+		providerCtx, providerCancel := util.DeadlineShare(ctx, len(s.proposalProviders)-i)
+		response, err := provider.Proposal(providerCtx, opts)
+		providerCancel()
+		if err != nil {
+			log.Debug().Dur("elapsed", time.Since(started)).Int("strategy_index", i).Err(err).Msg("Strategy failed to provide beacon block proposal")
+			continue
+		}
+		if response == nil || response.Data == nil {
+			log.Debug().Dur("elapsed", time.Since(started)).Int("strategy_index", i).Msg("Strategy returned no beacon block proposal")
+			continue
+		}
+		if i > 0 {
+			log.Info().Dur("elapsed", time.Since(started)).Int("strategy_index", i).Msg("Obtained beacon block proposal from fallback strategy")
+		}
+
+		return response, nil
+	}
+
+	return nil, errors.New("no strategy provided beacon block proposal")
+}
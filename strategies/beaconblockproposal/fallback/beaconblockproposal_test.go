@@ -0,0 +1,117 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fallback_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/strategies/beaconblockproposal/fallback"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingProposalProvider is a provider that does not return until its context is done.
+type blockingProposalProvider struct{}
+
+func (*blockingProposalProvider) Proposal(ctx context.Context,
+	_ *api.ProposalOpts,
+) (
+	*api.Response[*api.VersionedProposal],
+	error,
+) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func TestProposal(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []eth2client.ProposalProvider
+		err       string
+	}{
+		{
+			name: "Good",
+			providers: []eth2client.ProposalProvider{
+				mock.NewProposalProvider(),
+				mock.NewErroringProposalProvider(),
+			},
+		},
+		{
+			name: "PrimaryErroring",
+			providers: []eth2client.ProposalProvider{
+				mock.NewErroringProposalProvider(),
+				mock.NewProposalProvider(),
+			},
+		},
+		{
+			name: "PrimaryAndSecondaryErroring",
+			providers: []eth2client.ProposalProvider{
+				mock.NewErroringProposalProvider(),
+				mock.NewErroringProposalProvider(),
+				mock.NewProposalProvider(),
+			},
+		},
+		{
+			name: "AllErroring",
+			providers: []eth2client.ProposalProvider{
+				mock.NewErroringProposalProvider(),
+				mock.NewErroringProposalProvider(),
+			},
+			err: "no strategy provided beacon block proposal",
+		},
+		{
+			name: "SlowPrimary",
+			providers: []eth2client.ProposalProvider{
+				&blockingProposalProvider{},
+				mock.NewProposalProvider(),
+			},
+		},
+		{
+			name: "PrimaryExhaustsTimeout",
+			providers: []eth2client.ProposalProvider{
+				mock.NewSleepyProposalProvider(2*time.Second, mock.NewErroringProposalProvider()),
+				mock.NewProposalProvider(),
+			},
+			err: "no strategy provided beacon block proposal",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := fallback.New(context.Background(),
+				fallback.WithLogLevel(zerolog.Disabled),
+				fallback.WithTimeout(time.Second),
+				fallback.WithProposalProviders(test.providers),
+			)
+			require.NoError(t, err)
+
+			response, err := s.Proposal(context.Background(), &api.ProposalOpts{
+				Slot: 1,
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				require.NotNil(t, response.Data)
+			}
+		})
+	}
+}
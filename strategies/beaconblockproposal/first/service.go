@@ -94,6 +94,19 @@ func (s *Service) Proposal(ctx context.Context,
 			proposal := proposalResponse.Data
 			log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained beacon block proposal")
 
+			// A desynced beacon node could return a block for a slot other than that requested.
+			proposalSlot, err := proposal.Slot()
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to obtain slot for beacon block proposal")
+
+				return
+			}
+			if proposalSlot != opts.Slot {
+				log.Warn().Uint64("proposal_slot", uint64(proposalSlot)).Msg("Beacon block proposal for incorrect slot; ignoring")
+
+				return
+			}
+
 			ch <- proposal
 		}(ctx, name, provider, proposalCh)
 	}
@@ -0,0 +1,118 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fallback_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/strategies/beaconblockroot/fallback"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingBeaconBlockRootProvider is a provider that does not return until its context is done.
+type blockingBeaconBlockRootProvider struct{}
+
+func (*blockingBeaconBlockRootProvider) BeaconBlockRoot(ctx context.Context,
+	_ *api.BeaconBlockRootOpts,
+) (
+	*api.Response[*phase0.Root],
+	error,
+) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func TestBeaconBlockRoot(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []eth2client.BeaconBlockRootProvider
+		err       string
+	}{
+		{
+			name: "Good",
+			providers: []eth2client.BeaconBlockRootProvider{
+				mock.NewBeaconBlockRootProvider(),
+				mock.NewErroringBeaconBlockRootProvider(),
+			},
+		},
+		{
+			name: "PrimaryErroring",
+			providers: []eth2client.BeaconBlockRootProvider{
+				mock.NewErroringBeaconBlockRootProvider(),
+				mock.NewBeaconBlockRootProvider(),
+			},
+		},
+		{
+			name: "PrimaryAndSecondaryErroring",
+			providers: []eth2client.BeaconBlockRootProvider{
+				mock.NewErroringBeaconBlockRootProvider(),
+				mock.NewErroringBeaconBlockRootProvider(),
+				mock.NewBeaconBlockRootProvider(),
+			},
+		},
+		{
+			name: "AllErroring",
+			providers: []eth2client.BeaconBlockRootProvider{
+				mock.NewErroringBeaconBlockRootProvider(),
+				mock.NewErroringBeaconBlockRootProvider(),
+			},
+			err: "no strategy provided beacon block root",
+		},
+		{
+			name: "SlowPrimary",
+			providers: []eth2client.BeaconBlockRootProvider{
+				&blockingBeaconBlockRootProvider{},
+				mock.NewBeaconBlockRootProvider(),
+			},
+		},
+		{
+			name: "PrimaryExhaustsTimeout",
+			providers: []eth2client.BeaconBlockRootProvider{
+				mock.NewSleepyBeaconBlockRootProvider(2*time.Second, mock.NewErroringBeaconBlockRootProvider()),
+				mock.NewBeaconBlockRootProvider(),
+			},
+			err: "no strategy provided beacon block root",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := fallback.New(context.Background(),
+				fallback.WithLogLevel(zerolog.Disabled),
+				fallback.WithTimeout(time.Second),
+				fallback.WithBeaconBlockRootProviders(test.providers),
+			)
+			require.NoError(t, err)
+
+			response, err := s.BeaconBlockRoot(context.Background(), &api.BeaconBlockRootOpts{
+				Block: "head",
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				require.NotNil(t, response.Data)
+			}
+		})
+	}
+}
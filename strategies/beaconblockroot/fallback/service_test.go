@@ -0,0 +1,83 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fallback_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/strategies/beaconblockroot/fallback"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	providers := []eth2client.BeaconBlockRootProvider{
+		mock.NewBeaconBlockRootProvider(),
+	}
+
+	tests := []struct {
+		name   string
+		params []fallback.Parameter
+		err    string
+	}{
+		{
+			name: "TimeoutMissing",
+			params: []fallback.Parameter{
+				fallback.WithLogLevel(zerolog.TraceLevel),
+				fallback.WithBeaconBlockRootProviders(providers),
+			},
+			err: "problem with parameters: no timeout specified",
+		},
+		{
+			name: "ProvidersMissing",
+			params: []fallback.Parameter{
+				fallback.WithLogLevel(zerolog.TraceLevel),
+				fallback.WithTimeout(2 * time.Second),
+			},
+			err: "problem with parameters: no beacon block root providers specified",
+		},
+		{
+			name: "ProvidersEmpty",
+			params: []fallback.Parameter{
+				fallback.WithLogLevel(zerolog.TraceLevel),
+				fallback.WithTimeout(2 * time.Second),
+				fallback.WithBeaconBlockRootProviders([]eth2client.BeaconBlockRootProvider{}),
+			},
+			err: "problem with parameters: no beacon block root providers specified",
+		},
+		{
+			name: "Good",
+			params: []fallback.Parameter{
+				fallback.WithLogLevel(zerolog.TraceLevel),
+				fallback.WithTimeout(2 * time.Second),
+				fallback.WithBeaconBlockRootProviders(providers),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := fallback.New(context.Background(), test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
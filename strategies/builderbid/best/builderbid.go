@@ -33,11 +33,15 @@ import (
 	"github.com/rs/zerolog"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	"go.opentelemetry.io/otel"
+	"golang.org/x/sync/semaphore"
 )
 
 // zeroExecutionAddress is used for comparison purposes.
 var zeroExecutionAddress bellatrix.ExecutionAddress
 
+// zeroHash is used for comparison purposes.
+var zeroHash phase0.Hash32
+
 // zeroValue is used for comparison purposes.
 var zeroValue uint256.Int
 
@@ -89,7 +93,7 @@ func (s *Service) BuilderBid(ctx context.Context,
 	hardCtx, cancel := context.WithTimeout(ctx, s.timeout)
 	softCtx, softCancel := context.WithTimeout(hardCtx, s.timeout/2)
 
-	respCh, errCh := s.issueBuilderBidRequests(ctx, slot, parentHash, pubkey, proposerConfig, excludedBuilders, res, resPrivileged)
+	respCh, errCh := s.issueBuilderBidRequests(ctx, hardCtx, slot, parentHash, pubkey, proposerConfig, excludedBuilders, res, resPrivileged)
 	span.AddEvent("Issued requests")
 
 	responded, errored, bestScore, bestPrivilegedScore := s.builderBidLoop1(softCtx, started, requests, res, resPrivileged, respCh, errCh, privilegedBuilders)
@@ -285,7 +289,9 @@ func (*Service) setBuilderBid(ctx context.Context,
 }
 
 // issueBuilderBidRequests issues the builder bid requests to all suitable providers.
+// Requests are abandoned when the supplied request context is done.
 func (s *Service) issueBuilderBidRequests(ctx context.Context,
+	requestCtx context.Context,
 	slot phase0.Slot,
 	parentHash phase0.Hash32,
 	pubkey phase0.BLSPubKey,
@@ -303,6 +309,10 @@ func (s *Service) issueBuilderBidRequests(ctx context.Context,
 
 	respCh := make(chan *builderBidResponse, requests)
 	errCh := make(chan *builderBidError, requests)
+	var sem *semaphore.Weighted
+	if s.relayConcurrency > 0 {
+		sem = semaphore.NewWeighted(s.relayConcurrency)
+	}
 	// Kick off the requests.  Continue on errors to issue as many requests as we are able.
 	for _, relay := range proposerConfig.Relays {
 		builderClient, err := util.FetchBuilderClient(ctx, relay.Address, s.monitor, s.releaseVersion)
@@ -321,13 +331,14 @@ func (s *Service) issueBuilderBidRequests(ctx context.Context,
 		}
 		res.AllProviders = append(res.AllProviders, provider)
 		resPrivileged.AllProviders = append(resPrivileged.AllProviders, provider)
-		go s.builderBid(ctx, provider, respCh, errCh, slot, parentHash, pubkey, relay, excludedBuilders)
+		go s.builderBid(requestCtx, sem, provider, respCh, errCh, slot, parentHash, pubkey, relay, excludedBuilders)
 	}
 
 	return respCh, errCh
 }
 
 func (s *Service) builderBid(ctx context.Context,
+	sem *semaphore.Weighted,
 	provider builderclient.BuilderBidProvider,
 	respCh chan *builderBidResponse,
 	errCh chan *builderBidError,
@@ -343,7 +354,25 @@ func (s *Service) builderBid(ctx context.Context,
 		time.Sleep(relayConfig.Grace)
 	}
 
-	builderBid, err := s.obtainBid(ctx, provider, slot, parentHash, pubkey)
+	if sem != nil {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			errCh <- &builderBidError{
+				provider: provider,
+				err:      errors.Wrap(err, "failed to acquire semaphore"),
+			}
+
+			return
+		}
+		defer sem.Release(1)
+	}
+
+	bidCtx := ctx
+	if s.relayTimeout > 0 {
+		var cancel context.CancelFunc
+		bidCtx, cancel = context.WithTimeout(ctx, s.relayTimeout)
+		defer cancel()
+	}
+	builderBid, err := s.obtainBid(bidCtx, provider, slot, parentHash, pubkey)
 	if err != nil {
 		errCh <- &builderBidError{
 			provider: provider,
@@ -404,7 +433,7 @@ func (s *Service) builderBid(ctx context.Context,
 		return
 	}
 
-	if err := s.verifyBidDetails(ctx, builderBid, slot, relayConfig, provider); err != nil {
+	if err := s.verifyBidDetails(ctx, builderBid, slot, parentHash, relayConfig, provider); err != nil {
 		errCh <- &builderBidError{
 			provider: provider,
 			err:      err,
@@ -474,11 +503,19 @@ func (*Service) getBidValue(_ context.Context,
 func (s *Service) verifyBidDetails(ctx context.Context,
 	bid *builderspec.VersionedSignedBuilderBid,
 	slot phase0.Slot,
+	parentHash phase0.Hash32,
 	relayConfig *beaconblockproposer.RelayConfig,
 	provider builderclient.BuilderBidProvider,
 ) error {
 	log := zerolog.Ctx(ctx)
 
+	if s.strictVerify {
+		if err := verifyBidHeader(bid, parentHash); err != nil {
+			log.Warn().Err(err).Msg("Failed to verify bid header")
+			return err
+		}
+	}
+
 	feeRecipient, err := bid.FeeRecipient()
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain builder bid fee recipient")
@@ -523,6 +560,10 @@ func (s *Service) verifyBidSignature(ctx context.Context,
 		// Try to fetch directly from the provider.
 		relayPubkey = provider.Pubkey()
 		if relayPubkey == nil {
+			if s.strictVerify {
+				log.Warn().Msg("Relay configuration does not contain public key; cannot verify bid")
+				return false, errors.New("no public key available to verify bid")
+			}
 			log.Trace().Msg("Relay configuration does not contain public key; skipping validation")
 			return true, nil
 		}
@@ -579,6 +620,32 @@ func (s *Service) verifyBidSignature(ctx context.Context,
 	return verified, nil
 }
 
+// verifyBidHeader verifies the integrity of the execution payload header in a bid.
+func verifyBidHeader(bid *builderspec.VersionedSignedBuilderBid,
+	parentHash phase0.Hash32,
+) error {
+	bidParentHash, err := bid.ParentHash()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain builder bid parent hash")
+	}
+	if !bytes.Equal(bidParentHash[:], parentHash[:]) {
+		return fmt.Errorf("provided parent hash %#x not expected value of %#x", bidParentHash, parentHash)
+	}
+
+	blockHash, err := bid.BlockHash()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain builder bid block hash")
+	}
+	if bytes.Equal(blockHash[:], zeroHash[:]) {
+		return errors.New("zero block hash")
+	}
+	if bytes.Equal(blockHash[:], parentHash[:]) {
+		return errors.New("block hash matches parent hash")
+	}
+
+	return nil
+}
+
 // bidsEqual returns true if the two bids are equal.
 // Bids are considered equal if they have the same header.
 // Note that this function is only called if the bids have the same value, so that is not checked here.
@@ -39,6 +39,16 @@ func pubkey(input string) *phase0.BLSPubKey {
 	return &key
 }
 
+func hash32(input string) phase0.Hash32 {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	var hash phase0.Hash32
+	copy(hash[:], data)
+	return hash
+}
+
 func domain(input string) phase0.Domain {
 	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
 	if err != nil {
@@ -91,6 +101,15 @@ func TestVerifyBidSignature(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name:        "TamperedBid",
+			bid:         []byte(`{"version":"BELLATRIX","data":{"message":{"header":{"parent_hash":"0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a","fee_recipient":"0x320715b08bcf4cac1df2c55288a6bad79da1566b","state_root":"0xa47d81eb2717c3e2ae136e82e1242c4b350cda041f189aac422a16a9a7c6fca5","receipts_root":"0xd080a066ff223b1c759709fa9cd8d9105952cb7a5b231beafe683f964e2ab0d4","logs_bloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","prev_randao":"0x924ac8e956cf60a79b10ed4087c4678862eae91c0c9c50c768eeb3ee852786de","block_number":"2229624","gas_limit":"30000000","gas_used":"42000","timestamp":"1667652084","extra_data":"0x496c6c756d696e61746520446d6f63726174697a6520447374726962757465","base_fee_per_gas":"7","block_hash":"0xf843fff3b010a668e97a7958a1fab678ce34b06dc394452df17dad43a0f8a9ad","transactions_root":"0x6febb1545754c4ebcf3335dad815f2380289156ef264f72a69260535cdcad4e8"},"value":"52499999853001","pubkey":"0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"},"signature":"0x877681cc963750f3b63968baded23994f4e460b8b38a9ea11ba4c2fe0aba6c3902004248ac61c914092641b743fff44303ddff9e82be46da780ebff0fa777867424dc8e3b5bfe2b2484651dab270676cd4edf105508651cbd62f544f53b74191"}}`),
+			relayConfig: &beaconblockproposer.RelayConfig{},
+			provider: &mock.BuilderClient{
+				MockPubkey: pubkey("0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"),
+			},
+			expected: false,
+		},
 		{
 			name:        "InvalidSignature",
 			bid:         []byte(`{"version":"BELLATRIX","data":{"message":{"header":{"parent_hash":"0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a","fee_recipient":"0x320715b08bcf4cac1df2c55288a6bad79da1566b","state_root":"0xa47d81eb2717c3e2ae136e82e1242c4b350cda041f189aac422a16a9a7c6fca5","receipts_root":"0xd080a066ff223b1c759709fa9cd8d9105952cb7a5b231beafe683f964e2ab0d4","logs_bloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","prev_randao":"0x924ac8e956cf60a79b10ed4087c4678862eae91c0c9c50c768eeb3ee852786de","block_number":"2229624","gas_limit":"30000000","gas_used":"42000","timestamp":"1667652084","extra_data":"0x496c6c756d696e61746520446d6f63726174697a6520447374726962757465","base_fee_per_gas":"7","block_hash":"0xf843fff3b010a668e97a7958a1fab678ce34b06dc394452df17dad43a0f8a9ad","transactions_root":"0x6febb1545754c4ebcf3335dad815f2380289156ef264f72a69260535cdcad4e8"},"value":"52499999853000","pubkey":"0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"},"signature":"0x877681cc963750f3b63968baded23994f4e460b8b38a9ea11ba4c2fe0aba6c3902004248ac61c914092641b743fff44303ddff9e82be46da780ebff0fa777867424dc8e3b5bfe2b2484651dab270676cd4edf105508651cbd62f544f53b74190"}}`),
@@ -134,3 +153,68 @@ func TestVerifyBidSignature(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyBidSignatureStrict(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	s := &Service{
+		strictVerify:             true,
+		relayPubkeys:             make(map[phase0.BLSPubKey]*e2types.BLSPublicKey),
+		applicationBuilderDomain: domain("0x00000001d3010778cd08ee514b08fe67b6c503b510987a4ce43f42306d97c67c"),
+	}
+
+	bid := &builderspec.VersionedSignedBuilderBid{}
+	require.NoError(t, json.Unmarshal([]byte(`{"version":"BELLATRIX","data":{"message":{"header":{"parent_hash":"0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a","fee_recipient":"0x320715b08bcf4cac1df2c55288a6bad79da1566b","state_root":"0xa47d81eb2717c3e2ae136e82e1242c4b350cda041f189aac422a16a9a7c6fca5","receipts_root":"0xd080a066ff223b1c759709fa9cd8d9105952cb7a5b231beafe683f964e2ab0d4","logs_bloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","prev_randao":"0x924ac8e956cf60a79b10ed4087c4678862eae91c0c9c50c768eeb3ee852786de","block_number":"2229624","gas_limit":"30000000","gas_used":"42000","timestamp":"1667652084","extra_data":"0x496c6c756d696e61746520446d6f63726174697a6520447374726962757465","base_fee_per_gas":"7","block_hash":"0xf843fff3b010a668e97a7958a1fab678ce34b06dc394452df17dad43a0f8a9ad","transactions_root":"0x6febb1545754c4ebcf3335dad815f2380289156ef264f72a69260535cdcad4e8"},"value":"52499999853000","pubkey":"0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"},"signature":"0x877681cc963750f3b63968baded23994f4e460b8b38a9ea11ba4c2fe0aba6c3902004248ac61c914092641b743fff44303ddff9e82be46da780ebff0fa777867424dc8e3b5bfe2b2484651dab270676cd4edf105508651cbd62f544f53b74191"}}`), bid))
+
+	// No public key is available so strict verification should fail.
+	_, err := s.verifyBidSignature(ctx, &beaconblockproposer.RelayConfig{}, bid, &mock.BuilderClient{})
+	require.EqualError(t, err, "no public key available to verify bid")
+
+	// Public key available from the relay configuration.
+	verified, err := s.verifyBidSignature(ctx, &beaconblockproposer.RelayConfig{
+		PublicKey: pubkey("0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"),
+	}, bid, &mock.BuilderClient{})
+	require.NoError(t, err)
+	require.True(t, verified)
+}
+
+func TestVerifyBidHeader(t *testing.T) {
+	bid := &builderspec.VersionedSignedBuilderBid{}
+	require.NoError(t, json.Unmarshal([]byte(`{"version":"BELLATRIX","data":{"message":{"header":{"parent_hash":"0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a","fee_recipient":"0x320715b08bcf4cac1df2c55288a6bad79da1566b","state_root":"0xa47d81eb2717c3e2ae136e82e1242c4b350cda041f189aac422a16a9a7c6fca5","receipts_root":"0xd080a066ff223b1c759709fa9cd8d9105952cb7a5b231beafe683f964e2ab0d4","logs_bloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","prev_randao":"0x924ac8e956cf60a79b10ed4087c4678862eae91c0c9c50c768eeb3ee852786de","block_number":"2229624","gas_limit":"30000000","gas_used":"42000","timestamp":"1667652084","extra_data":"0x496c6c756d696e61746520446d6f63726174697a6520447374726962757465","base_fee_per_gas":"7","block_hash":"0xf843fff3b010a668e97a7958a1fab678ce34b06dc394452df17dad43a0f8a9ad","transactions_root":"0x6febb1545754c4ebcf3335dad815f2380289156ef264f72a69260535cdcad4e8"},"value":"52499999853000","pubkey":"0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"},"signature":"0x877681cc963750f3b63968baded23994f4e460b8b38a9ea11ba4c2fe0aba6c3902004248ac61c914092641b743fff44303ddff9e82be46da780ebff0fa777867424dc8e3b5bfe2b2484651dab270676cd4edf105508651cbd62f544f53b74191"}}`), bid))
+
+	tests := []struct {
+		name       string
+		parentHash phase0.Hash32
+		err        string
+	}{
+		{
+			name:       "Good",
+			parentHash: hash32("0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a"),
+		},
+		{
+			name:       "ParentHashMismatch",
+			parentHash: hash32("0x25b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a"),
+			err:        "provided parent hash 0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a not expected value of 0x25b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifyBidHeader(bid, test.parentHash)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyBidHeaderZeroBlockHash(t *testing.T) {
+	bid := &builderspec.VersionedSignedBuilderBid{}
+	require.NoError(t, json.Unmarshal([]byte(`{"version":"BELLATRIX","data":{"message":{"header":{"parent_hash":"0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a","fee_recipient":"0x320715b08bcf4cac1df2c55288a6bad79da1566b","state_root":"0xa47d81eb2717c3e2ae136e82e1242c4b350cda041f189aac422a16a9a7c6fca5","receipts_root":"0xd080a066ff223b1c759709fa9cd8d9105952cb7a5b231beafe683f964e2ab0d4","logs_bloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","prev_randao":"0x924ac8e956cf60a79b10ed4087c4678862eae91c0c9c50c768eeb3ee852786de","block_number":"2229624","gas_limit":"30000000","gas_used":"42000","timestamp":"1667652084","extra_data":"0x496c6c756d696e61746520446d6f63726174697a6520447374726962757465","base_fee_per_gas":"7","block_hash":"0x0000000000000000000000000000000000000000000000000000000000000000","transactions_root":"0x6febb1545754c4ebcf3335dad815f2380289156ef264f72a69260535cdcad4e8"},"value":"52499999853000","pubkey":"0x845bd072b7cd566f02faeb0a4033ce9399e42839ced64e8b2adcfc859ed1e8e1a5a293336a49feac6d9a5edb779be53a"},"signature":"0x877681cc963750f3b63968baded23994f4e460b8b38a9ea11ba4c2fe0aba6c3902004248ac61c914092641b743fff44303ddff9e82be46da780ebff0fa777867424dc8e3b5bfe2b2484651dab270676cd4edf105508651cbd62f544f53b74191"}}`), bid))
+
+	err := verifyBidHeader(bid, hash32("0x15b38d69d54789359784bd2826d2811e938e6abf87588ab75d0e62857494771a"))
+	require.EqualError(t, err, "zero block hash")
+}
@@ -27,13 +27,16 @@ import (
 )
 
 type parameters struct {
-	logLevel       zerolog.Level
-	monitor        metrics.Service
-	specProvider   consensusclient.SpecProvider
-	domainProvider consensusclient.DomainProvider
-	chainTime      chaintime.Service
-	timeout        time.Duration
-	releaseVersion string
+	logLevel         zerolog.Level
+	monitor          metrics.Service
+	specProvider     consensusclient.SpecProvider
+	domainProvider   consensusclient.DomainProvider
+	chainTime        chaintime.Service
+	timeout          time.Duration
+	releaseVersion   string
+	strictVerify     bool
+	relayConcurrency int64
+	relayTimeout     time.Duration
 }
 
 // Parameter is the interface for service parameters.
@@ -96,6 +99,29 @@ func WithReleaseVersion(version string) Parameter {
 	})
 }
 
+// WithStrictVerification requires that all bids are fully verified before they are accepted.
+func WithStrictVerification(strictVerify bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.strictVerify = strictVerify
+	})
+}
+
+// WithRelayConcurrency sets the maximum number of relays from which bids are
+// obtained concurrently.  0 means no limit.
+func WithRelayConcurrency(concurrency int64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.relayConcurrency = concurrency
+	})
+}
+
+// WithRelayTimeout sets the timeout for obtaining a bid from an individual
+// relay.  0 means that only the overall timeout applies.
+func WithRelayTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.relayTimeout = timeout
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -123,6 +149,12 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.timeout == 0 {
 		return nil, errors.New("no timeout specified")
 	}
+	if parameters.relayConcurrency < 0 {
+		return nil, errors.New("relay concurrency cannot be negative")
+	}
+	if parameters.relayTimeout < 0 {
+		return nil, errors.New("relay timeout cannot be negative")
+	}
 
 	return &parameters, nil
 }
@@ -0,0 +1,168 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+// relayTracker tracks the number of concurrent requests across relays.
+type relayTracker struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+// newRelay creates a relay that responds with no bid after the given latency.
+func newRelay(t *testing.T, tracker *relayTracker, latency time.Duration) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight := tracker.inFlight.Add(1)
+		defer tracker.inFlight.Add(-1)
+		for {
+			maxInFlight := tracker.maxInFlight.Load()
+			if inFlight <= maxInFlight || tracker.maxInFlight.CompareAndSwap(maxInFlight, inFlight) {
+				break
+			}
+		}
+
+		select {
+		case <-time.After(latency):
+			w.WriteHeader(http.StatusNoContent)
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestBuilderBidRelays(t *testing.T) {
+	ctx := context.Background()
+
+	viper.Reset()
+	viper.Set("timeout", 5*time.Second)
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider()),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		latencies        []time.Duration
+		timeout          time.Duration
+		relayConcurrency int64
+		relayTimeout     time.Duration
+		minDuration      time.Duration
+		maxDuration      time.Duration
+		maxInFlight      int32
+	}{
+		{
+			name:        "MixedLatency",
+			latencies:   []time.Duration{10 * time.Millisecond, 2 * time.Second},
+			timeout:     time.Second,
+			minDuration: time.Second,
+			maxDuration: 1500 * time.Millisecond,
+			maxInFlight: 2,
+		},
+		{
+			name:         "MixedLatencyRelayTimeout",
+			latencies:    []time.Duration{10 * time.Millisecond, 2 * time.Second},
+			timeout:      time.Second,
+			relayTimeout: 200 * time.Millisecond,
+			minDuration:  200 * time.Millisecond,
+			maxDuration:  time.Second,
+			maxInFlight:  2,
+		},
+		{
+			name:        "Unlimited",
+			latencies:   []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond},
+			minDuration: 100 * time.Millisecond,
+			maxDuration: 300 * time.Millisecond,
+			maxInFlight: 3,
+		},
+		{
+			name:             "Serial",
+			latencies:        []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond},
+			relayConcurrency: 1,
+			minDuration:      300 * time.Millisecond,
+			maxDuration:      time.Second,
+			maxInFlight:      1,
+		},
+		{
+			name:             "SerialDeadline",
+			latencies:        []time.Duration{500 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond},
+			timeout:          1200 * time.Millisecond,
+			relayConcurrency: 1,
+			minDuration:      time.Second,
+			maxDuration:      1500 * time.Millisecond,
+			maxInFlight:      1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timeout := test.timeout
+			if timeout == 0 {
+				timeout = 4 * time.Second
+			}
+			s, err := New(ctx,
+				WithLogLevel(zerolog.Disabled),
+				WithSpecProvider(mock.NewSpecProvider()),
+				WithDomainProvider(mock.NewDomainProvider()),
+				WithChainTime(chainTime),
+				WithTimeout(timeout),
+				WithRelayConcurrency(test.relayConcurrency),
+				WithRelayTimeout(test.relayTimeout),
+			)
+			require.NoError(t, err)
+
+			tracker := &relayTracker{}
+			proposerConfig := &beaconblockproposer.ProposerConfig{
+				Relays: make([]*beaconblockproposer.RelayConfig, 0, len(test.latencies)),
+			}
+			for _, latency := range test.latencies {
+				proposerConfig.Relays = append(proposerConfig.Relays, &beaconblockproposer.RelayConfig{
+					Address: newRelay(t, tracker, latency).URL,
+				})
+			}
+
+			started := time.Now()
+			res, err := s.BuilderBid(ctx, 1, phase0.Hash32{}, phase0.BLSPubKey{}, proposerConfig, nil, nil)
+			duration := time.Since(started)
+			require.NoError(t, err)
+			require.Nil(t, res.Bid)
+			require.Len(t, res.AllProviders, len(test.latencies))
+			require.GreaterOrEqual(t, duration, test.minDuration)
+			require.Less(t, duration, test.maxDuration)
+			require.Equal(t, test.maxInFlight, tracker.maxInFlight.Load())
+		})
+	}
+}
@@ -35,6 +35,9 @@ type Service struct {
 	chainTime                chaintime.Service
 	timeout                  time.Duration
 	releaseVersion           string
+	strictVerify             bool
+	relayConcurrency         int64
+	relayTimeout             time.Duration
 	relayPubkeys             map[phase0.BLSPubKey]*e2types.BLSPublicKey
 	relayPubkeysMu           sync.RWMutex
 	applicationBuilderDomain phase0.Domain
@@ -82,6 +85,9 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		chainTime:                parameters.chainTime,
 		timeout:                  parameters.timeout,
 		releaseVersion:           parameters.releaseVersion,
+		strictVerify:             parameters.strictVerify,
+		relayConcurrency:         parameters.relayConcurrency,
+		relayTimeout:             parameters.relayTimeout,
 		relayPubkeys:             make(map[phase0.BLSPubKey]*e2types.BLSPublicKey),
 		applicationBuilderDomain: domain,
 	}
@@ -0,0 +1,107 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// mergeSyncCommitteeContributions merges the contributions in the responses
+// with the best contribution where they add participants that are not already
+// present, in the same way that a beacon node aggregates sync committee messages.
+// Contributions that overlap with the merged contribution cannot be merged, as the
+// signatures of the overlapping participants would be counted more than once, so
+// are ignored.
+// It returns the merged contribution and the number of contributions merged in to it.
+func mergeSyncCommitteeContributions(best *altair.SyncCommitteeContribution,
+	responses []*syncCommitteeContributionResponse,
+) (
+	*altair.SyncCommitteeContribution,
+	int,
+	error,
+) {
+	// Merge higher-scoring contributions first, as they add the most participants.
+	candidates := make([]*syncCommitteeContributionResponse, len(responses))
+	copy(candidates, responses)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	aggregationBits := make([]byte, len(best.AggregationBits))
+	copy(aggregationBits, best.AggregationBits)
+	merged := &altair.SyncCommitteeContribution{
+		Slot:              best.Slot,
+		BeaconBlockRoot:   best.BeaconBlockRoot,
+		SubcommitteeIndex: best.SubcommitteeIndex,
+		AggregationBits:   aggregationBits,
+		Signature:         best.Signature,
+	}
+
+	var sigs []e2types.Signature
+	for _, candidate := range candidates {
+		contribution := candidate.contribution
+		if contribution == best {
+			continue
+		}
+		if contribution.Slot != merged.Slot ||
+			contribution.SubcommitteeIndex != merged.SubcommitteeIndex ||
+			contribution.BeaconBlockRoot != merged.BeaconBlockRoot {
+			log.Debug().Str("provider", candidate.provider).Msg("Contribution is for different data; not merging")
+			continue
+		}
+		if contribution.AggregationBits.Count() == 0 {
+			continue
+		}
+		overlaps, err := merged.AggregationBits.Overlaps(contribution.AggregationBits)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to check contribution overlap")
+		}
+		if overlaps {
+			log.Trace().Str("provider", candidate.provider).Msg("Contribution overlaps; not merging")
+			continue
+		}
+
+		sig, err := e2types.BLSSignatureFromBytes(contribution.Signature[:])
+		if err != nil {
+			log.Debug().Str("provider", candidate.provider).Err(err).Msg("Contribution has invalid signature; not merging")
+			continue
+		}
+		if len(sigs) == 0 {
+			// First merge, so add the signature of the best contribution.
+			bestSig, err := e2types.BLSSignatureFromBytes(best.Signature[:])
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "invalid signature for best contribution")
+			}
+			sigs = append(sigs, bestSig)
+		}
+		merged.AggregationBits, err = merged.AggregationBits.Or(contribution.AggregationBits)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to merge aggregation bits")
+		}
+		sigs = append(sigs, sig)
+		log.Trace().Str("provider", candidate.provider).Msg("Merged contribution")
+	}
+
+	if len(sigs) == 0 {
+		// Nothing merged.
+		return best, 0, nil
+	}
+	copy(merged.Signature[:], e2types.AggregateSignatures(sigs).Marshal())
+
+	return merged, len(sigs) - 1, nil
+}
@@ -0,0 +1,158 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// testContribution creates a contribution signed by the given participants.
+func testContribution(t *testing.T,
+	root phase0.Root,
+	keys []*e2types.BLSPrivateKey,
+	participants ...int,
+) *altair.SyncCommitteeContribution {
+	t.Helper()
+
+	bits := bitfield.NewBitvector128()
+	sigs := make([]e2types.Signature, 0, len(participants))
+	for _, participant := range participants {
+		bits.SetBitAt(uint64(participant), true)
+		sigs = append(sigs, keys[participant].Sign(root[:]))
+	}
+	contribution := &altair.SyncCommitteeContribution{
+		Slot:              1,
+		BeaconBlockRoot:   root,
+		SubcommitteeIndex: 2,
+		AggregationBits:   bits,
+	}
+	copy(contribution.Signature[:], e2types.AggregateSignatures(sigs).Marshal())
+
+	return contribution
+}
+
+func TestMergeSyncCommitteeContributions(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	keys := make([]*e2types.BLSPrivateKey, 6)
+	for i := range keys {
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+	root := phase0.Root{0x01}
+	otherRoot := phase0.Root{0x02}
+
+	tests := []struct {
+		name         string
+		best         *altair.SyncCommitteeContribution
+		others       []*altair.SyncCommitteeContribution
+		merged       int
+		participants []int
+	}{
+		{
+			name:         "Single",
+			best:         testContribution(t, root, keys, 0, 1),
+			participants: []int{0, 1},
+		},
+		{
+			name: "Disjoint",
+			best: testContribution(t, root, keys, 0, 1),
+			others: []*altair.SyncCommitteeContribution{
+				testContribution(t, root, keys, 2),
+				testContribution(t, root, keys, 3, 4),
+			},
+			merged:       2,
+			participants: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name: "Overlapping",
+			best: testContribution(t, root, keys, 0, 1),
+			others: []*altair.SyncCommitteeContribution{
+				testContribution(t, root, keys, 1, 2),
+			},
+			participants: []int{0, 1},
+		},
+		{
+			name: "Duplicate",
+			best: testContribution(t, root, keys, 0, 1),
+			others: []*altair.SyncCommitteeContribution{
+				testContribution(t, root, keys, 0, 1),
+			},
+			participants: []int{0, 1},
+		},
+		{
+			name: "OverlappingAndDisjoint",
+			best: testContribution(t, root, keys, 0, 1),
+			others: []*altair.SyncCommitteeContribution{
+				testContribution(t, root, keys, 1, 2),
+				testContribution(t, root, keys, 3),
+				testContribution(t, root, keys, 3, 4),
+				testContribution(t, root, keys, 5),
+			},
+			merged:       2,
+			participants: []int{0, 1, 3, 4, 5},
+		},
+		{
+			name: "DifferentRoot",
+			best: testContribution(t, root, keys, 0, 1),
+			others: []*altair.SyncCommitteeContribution{
+				testContribution(t, otherRoot, keys, 2),
+			},
+			participants: []int{0, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			responses := []*syncCommitteeContributionResponse{
+				{
+					provider:     "best",
+					contribution: test.best,
+					score:        float64(test.best.AggregationBits.Count()),
+				},
+			}
+			for _, other := range test.others {
+				responses = append(responses, &syncCommitteeContributionResponse{
+					provider:     "other",
+					contribution: other,
+					score:        float64(other.AggregationBits.Count()),
+				})
+			}
+
+			contribution, merged, err := mergeSyncCommitteeContributions(test.best, responses)
+			require.NoError(t, err)
+			require.Equal(t, test.merged, merged)
+
+			participants := make([]int, 0)
+			pubKeys := make([]e2types.PublicKey, 0)
+			for _, index := range contribution.AggregationBits.BitIndices() {
+				participants = append(participants, index)
+				pubKeys = append(pubKeys, keys[index].PublicKey())
+			}
+			require.Equal(t, test.participants, participants)
+
+			// The signature must be valid for the merged participants.
+			sig, err := e2types.BLSSignatureFromBytes(contribution.Signature[:])
+			require.NoError(t, err)
+			require.True(t, sig.VerifyAggregateCommon(root[:], pubKeys))
+		})
+	}
+}
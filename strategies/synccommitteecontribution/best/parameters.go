@@ -33,6 +33,8 @@ type parameters struct {
 	processConcurrency                 int64
 	syncCommitteeContributionProviders map[string]eth2client.SyncCommitteeContributionProvider
 	timeout                            time.Duration
+	logResults                         bool
+	mergeContributions                 bool
 }
 
 // Parameter is the interface for service parameters.
@@ -81,6 +83,21 @@ func WithTimeout(timeout time.Duration) Parameter {
 	})
 }
 
+// WithLogResults logs the result from each provider for each call to the strategy.
+func WithLogResults(logResults bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logResults = logResults
+	})
+}
+
+// WithMergeContributions merges contributions from different providers
+// that contain different participants in to the best contribution.
+func WithMergeContributions(merge bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.mergeContributions = merge
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -30,6 +30,8 @@ type Service struct {
 	processConcurrency                 int64
 	syncCommitteeContributionProviders map[string]eth2client.SyncCommitteeContributionProvider
 	timeout                            time.Duration
+	logResults                         bool
+	mergeContributions                 bool
 }
 
 // module-wide log.
@@ -50,9 +52,11 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 
 	s := &Service{
 		timeout:                            parameters.timeout,
+		logResults:                         parameters.logResults,
 		clientMonitor:                      parameters.clientMonitor,
 		processConcurrency:                 parameters.processConcurrency,
 		syncCommitteeContributionProviders: parameters.syncCommitteeContributionProviders,
+		mergeContributions:                 parameters.mergeContributions,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
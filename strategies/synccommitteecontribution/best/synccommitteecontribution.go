@@ -56,6 +56,7 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 
 	started := time.Now()
 	log := util.LogWithID(ctx, log, "strategy_id")
+	results := util.NewProviderResults(s.logResults, started, s.syncCommitteeContributionProviders)
 
 	// We have two timeouts: a soft timeout and a hard timeout.
 	// At the soft timeout, we return if we have any responses so far.
@@ -81,12 +82,14 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 	bestScore := float64(0)
 	var bestSyncCommitteeContribution *altair.SyncCommitteeContribution
 	var bestProvider string
+	responses := make([]*syncCommitteeContributionResponse, 0, requests)
 
 	// Loop 1: prior to soft timeout.
 	for responded+errored+timedOut+softTimedOut != requests {
 		select {
 		case resp := <-respCh:
 			responded++
+			results.Succeeded(resp.provider, resp.score)
 			log.Trace().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", resp.provider).
@@ -94,6 +97,7 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
+			responses = append(responses, resp)
 			if bestSyncCommitteeContribution == nil || resp.score > bestScore {
 				bestSyncCommitteeContribution = resp.contribution
 				bestScore = resp.score
@@ -101,6 +105,7 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 			}
 		case err := <-errCh:
 			errored++
+			results.Errored(err.provider, err.err)
 			log.Debug().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", err.provider).
@@ -136,6 +141,7 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 		select {
 		case resp := <-respCh:
 			responded++
+			results.Succeeded(resp.provider, resp.score)
 			log.Trace().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", resp.provider).
@@ -143,6 +149,7 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 				Int("errored", errored).
 				Int("timed_out", timedOut).
 				Msg("Response received")
+			responses = append(responses, resp)
 			if bestSyncCommitteeContribution == nil || resp.score > bestScore {
 				bestSyncCommitteeContribution = resp.contribution
 				bestScore = resp.score
@@ -150,6 +157,7 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 			}
 		case err := <-errCh:
 			errored++
+			results.Errored(err.provider, err.err)
 			log.Debug().
 				Dur("elapsed", time.Since(started)).
 				Str("provider", err.provider).
@@ -177,10 +185,24 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context,
 		Int("timed_out", timedOut).
 		Msg("Results")
 
+	results.Log(log, bestProvider)
 	if bestSyncCommitteeContribution == nil {
 		return nil, errors.New("no sync committee contribution received")
 	}
 	log.Trace().Str("provider", bestProvider).Stringer("sync_committee_contribution", bestSyncCommitteeContribution).Float64("score", bestScore).Msg("Selected best sync committee contribution")
+
+	if s.mergeContributions && len(responses) > 1 {
+		mergedContribution, merged, err := mergeSyncCommitteeContributions(bestSyncCommitteeContribution, responses)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to merge sync committee contributions; using best contribution")
+		} else if merged > 0 {
+			log.Trace().
+				Int("merged", merged).
+				Uint64("participants", mergedContribution.AggregationBits.Count()).
+				Msg("Merged sync committee contributions")
+			bestSyncCommitteeContribution = mergedContribution
+		}
+	}
 	if bestProvider != "" {
 		s.clientMonitor.StrategyOperation("best", bestProvider, "sync committee contribution", time.Since(started))
 	}
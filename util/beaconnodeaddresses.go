@@ -15,8 +15,10 @@ package util
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -40,3 +42,101 @@ func BeaconNodeAddresses(path string) []string {
 	}
 	return BeaconNodeAddresses(path[0:lastPeriod])
 }
+
+// operationStrategyStyles are the strategy styles for each operation that
+// use their own set of beacon nodes.
+var operationStrategyStyles = map[string][]string{
+	"strategies.aggregateattestation":      {"best", "first"},
+	"strategies.attestationdata":           {"best", "first", "majority"},
+	"strategies.beaconblockproposal":       {"best", "first"},
+	"strategies.beaconblockroot":           {"first", "majority"},
+	"strategies.synccommitteecontribution": {"best", "first"},
+}
+
+// submitterOperations are the operations for which the multinode submitter
+// uses its own set of beacon nodes.
+var submitterOperations = []string{
+	"aggregateattestation",
+	"attestation",
+	"beaconcommitteesubscription",
+	"proposal",
+	"proposalpreparation",
+	"synccommitteecontribution",
+	"synccommitteemessage",
+	"synccommitteesubscription",
+}
+
+// gossipOperations are the submitter operations whose objects are broadcast
+// over the gossip network, and so can be submitted to gossip beacon nodes.
+var gossipOperations = map[string]bool{
+	"aggregateattestation":      true,
+	"attestation":               true,
+	"proposal":                  true,
+	"synccommitteecontribution": true,
+	"synccommitteemessage":      true,
+}
+
+// BeaconNodeAddressesForSubmitting returns the beacon node addresses to which
+// the multinode submitter submits the given operation.  Addresses configured
+// for the operation itself take precedence.  Otherwise, objects that are
+// broadcast over gossip are submitted to the gossip beacon nodes if they are
+// configured, and everything else is submitted to the submitter beacon nodes.
+func BeaconNodeAddressesForSubmitting(operation string) []string {
+	path := fmt.Sprintf("submitter.%s.multinode", operation)
+
+	gossipAddresses := viper.GetStringSlice("submitter.gossip.beacon-node-addresses")
+	if !gossipOperations[operation] || len(gossipAddresses) == 0 {
+		return BeaconNodeAddresses(path)
+	}
+
+	for ; path != "submitter"; path = path[0:strings.LastIndex(path, ".")] {
+		key := fmt.Sprintf("%s.beacon-node-addresses", path)
+		if len(viper.GetStringSlice(key)) > 0 {
+			return viper.GetStringSlice(key)
+		}
+	}
+
+	return gossipAddresses
+}
+
+// BeaconNodeAddressPaths returns the configuration paths from which beacon
+// node addresses are obtained for each operation, given the configured
+// strategy styles.  An empty path refers to the top-level beacon nodes.
+func BeaconNodeAddressPaths() []string {
+	paths := []string{""}
+	for operation, styles := range operationStrategyStyles {
+		style := viper.GetString(fmt.Sprintf("%s.style", operation))
+		for _, candidate := range styles {
+			if style == candidate {
+				paths = append(paths, fmt.Sprintf("%s.%s", operation, style))
+				break
+			}
+		}
+	}
+	switch viper.GetString("submitter.style") {
+	case "multinode", "all":
+		for _, operation := range submitterOperations {
+			paths = append(paths, fmt.Sprintf("submitter.%s.multinode", operation))
+		}
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// CheckBeaconNodeAddresses confirms that every operation has at least one
+// beacon node address with which to carry out its work.
+func CheckBeaconNodeAddresses() error {
+	for _, path := range BeaconNodeAddressPaths() {
+		if len(BeaconNodeAddresses(path)) > 0 {
+			continue
+		}
+		if path == "" {
+			return errors.New("no beacon node addresses specified")
+		}
+
+		return fmt.Errorf("no beacon node addresses specified for %s", path)
+	}
+
+	return nil
+}
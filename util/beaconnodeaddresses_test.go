@@ -105,3 +105,193 @@ func TestBeaconNodeAddresses(t *testing.T) {
 		})
 	}
 }
+
+func TestBeaconNodeAddressesPerOperation(t *testing.T) {
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	prefix := "VOUCH_PEROPERATION"
+	env := map[string]string{
+		"BEACON_NODE_ADDRESSES":                                     "1 2 3",
+		"STRATEGIES_BEACONBLOCKPROPOSAL_STYLE":                      "best",
+		"STRATEGIES_BEACONBLOCKPROPOSAL_BEST_BEACON_NODE_ADDRESSES": "2",
+		"STRATEGIES_ATTESTATIONDATA_STYLE":                          "best",
+		"SUBMITTER_STYLE":                                           "multinode",
+		"SUBMITTER_PROPOSAL_MULTINODE_BEACON_NODE_ADDRESSES":        "2 3",
+	}
+	for k, v := range env {
+		os.Setenv(fmt.Sprintf("%s_%s", prefix, k), v)
+	}
+	viper.SetEnvPrefix(prefix)
+
+	require.Equal(t, []string{"2"}, util.BeaconNodeAddresses("strategies.beaconblockproposal.best"))
+	require.Equal(t, []string{"1", "2", "3"}, util.BeaconNodeAddresses("strategies.attestationdata.best"))
+	require.Equal(t, []string{"2", "3"}, util.BeaconNodeAddresses("submitter.proposal.multinode"))
+	require.Equal(t, []string{"1", "2", "3"}, util.BeaconNodeAddresses("submitter.attestation.multinode"))
+}
+
+func TestBeaconNodeAddressesForSubmitting(t *testing.T) {
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	tests := []struct {
+		name      string
+		env       map[string]string
+		operation string
+		expected  []string
+	}{
+		{
+			name: "NoGossip",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES": "1 2 3",
+			},
+			operation: "attestation",
+			expected:  []string{"1", "2", "3"},
+		},
+		{
+			name: "GossipAttestation",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":                                 "1 2 3",
+				"SUBMITTER_GOSSIP_BEACON_NODE_ADDRESSES":                "4",
+				"SUBMITTER_BEACON_NODE_ADDRESSES":                       "2 3",
+				"STRATEGIES_ATTESTATIONDATA_STYLE":                      "best",
+				"STRATEGIES_ATTESTATIONDATA_BEST_BEACON_NODE_ADDRESSES": "1",
+			},
+			operation: "attestation",
+			expected:  []string{"4"},
+		},
+		{
+			name: "GossipProposal",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":                  "1 2 3",
+				"SUBMITTER_GOSSIP_BEACON_NODE_ADDRESSES": "4 5",
+			},
+			operation: "proposal",
+			expected:  []string{"4", "5"},
+		},
+		{
+			name: "GossipSubscription",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":                  "1 2 3",
+				"SUBMITTER_GOSSIP_BEACON_NODE_ADDRESSES": "4",
+			},
+			operation: "beaconcommitteesubscription",
+			expected:  []string{"1", "2", "3"},
+		},
+		{
+			name: "GossipOperationOverride",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":                       "1 2 3",
+				"SUBMITTER_GOSSIP_BEACON_NODE_ADDRESSES":      "4",
+				"SUBMITTER_ATTESTATION_BEACON_NODE_ADDRESSES": "2",
+			},
+			operation: "attestation",
+			expected:  []string{"2"},
+		},
+		{
+			name: "GossipOperationMultinodeOverride",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":                                          "1 2 3",
+				"SUBMITTER_GOSSIP_BEACON_NODE_ADDRESSES":                         "4",
+				"SUBMITTER_SYNCCOMMITTEEMESSAGE_MULTINODE_BEACON_NODE_ADDRESSES": "3 4",
+			},
+			operation: "synccommitteemessage",
+			expected:  []string{"3", "4"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prefix := fmt.Sprintf("VOUCH_SUBMITTING%s", strings.ToUpper(test.name))
+			for k, v := range test.env {
+				os.Setenv(fmt.Sprintf("%s_%s", prefix, k), v)
+			}
+			viper.SetEnvPrefix(prefix)
+			require.Equal(t, test.expected, util.BeaconNodeAddressesForSubmitting(test.operation))
+		})
+	}
+}
+
+func TestCheckBeaconNodeAddresses(t *testing.T) {
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		err  string
+	}{
+		{
+			name: "Missing",
+			env:  map[string]string{},
+			err:  "no beacon node addresses specified",
+		},
+		{
+			name: "Root",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES": "1 2",
+			},
+		},
+		{
+			name: "StrategyInherits",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":                "1 2",
+				"STRATEGIES_BEACONBLOCKPROPOSAL_STYLE": "best",
+			},
+		},
+		{
+			name: "StrategyOverrides",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":                                     "1 2",
+				"STRATEGIES_BEACONBLOCKPROPOSAL_STYLE":                      "best",
+				"STRATEGIES_BEACONBLOCKPROPOSAL_BEST_BEACON_NODE_ADDRESSES": "2",
+			},
+		},
+		{
+			name: "StrategyOnly",
+			env: map[string]string{
+				"STRATEGIES_BEACONBLOCKPROPOSAL_STYLE":                      "best",
+				"STRATEGIES_BEACONBLOCKPROPOSAL_BEST_BEACON_NODE_ADDRESSES": "2",
+			},
+			err: "no beacon node addresses specified",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prefix := fmt.Sprintf("VOUCH_CHECK%s", strings.ToUpper(test.name))
+			for k, v := range test.env {
+				os.Setenv(fmt.Sprintf("%s_%s", prefix, k), v)
+			}
+			viper.SetEnvPrefix(prefix)
+			err := util.CheckBeaconNodeAddresses()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBeaconNodeAddressPaths(t *testing.T) {
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	prefix := "VOUCH_PATHS"
+	env := map[string]string{
+		"STRATEGIES_BEACONBLOCKPROPOSAL_STYLE": "first",
+		"STRATEGIES_ATTESTATIONDATA_STYLE":     "majority",
+		"STRATEGIES_BEACONBLOCKROOT_STYLE":     "unknown",
+	}
+	for k, v := range env {
+		os.Setenv(fmt.Sprintf("%s_%s", prefix, k), v)
+	}
+	viper.SetEnvPrefix(prefix)
+
+	require.Equal(t, []string{
+		"",
+		"strategies.attestationdata.majority",
+		"strategies.beaconblockproposal.first",
+	}, util.BeaconNodeAddressPaths())
+}
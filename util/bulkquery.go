@@ -0,0 +1,43 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// BulkQueryClient returns the client to use for heavy queries that are not
+// time-critical, such as obtaining the state of all validators.
+// If beacon node addresses are explicitly configured for bulk queries then
+// the client returned by fetch for those addresses is used, otherwise the
+// supplied client is returned.
+func BulkQueryClient(client eth2client.Service,
+	fetch func(addresses []string) (eth2client.Service, error),
+) (
+	eth2client.Service,
+	error,
+) {
+	if len(viper.GetStringSlice("bulk-query.beacon-node-addresses")) == 0 {
+		return client, nil
+	}
+
+	bulkQueryClient, err := fetch(BeaconNodeAddressesForBulkQueries())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch client for bulk queries")
+	}
+
+	return bulkQueryClient, nil
+}
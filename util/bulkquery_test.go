@@ -0,0 +1,103 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+// addressedClient is a minimal client that reports its address.
+type addressedClient struct {
+	address string
+}
+
+func (*addressedClient) Name() string { return "addressed" }
+
+func (c *addressedClient) Address() string { return c.address }
+
+func (*addressedClient) IsActive() bool { return true }
+
+func (*addressedClient) IsSynced() bool { return true }
+
+func TestBulkQueryClient(t *testing.T) {
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	client := &addressedClient{address: "1"}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		fetchErr error
+		address  string
+		err      string
+	}{
+		{
+			name: "Default",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES": "1",
+			},
+			address: "1",
+		},
+		{
+			name: "FetchFails",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":            "1",
+				"BULK_QUERY_BEACON_NODE_ADDRESSES": "2",
+			},
+			fetchErr: errors.New("unavailable"),
+			err:      "failed to fetch client for bulk queries: unavailable",
+		},
+		{
+			name: "BulkQuery",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":            "1",
+				"BULK_QUERY_BEACON_NODE_ADDRESSES": "2 3",
+			},
+			address: "2,3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prefix := fmt.Sprintf("VOUCH_BULKQUERYCLIENT_%s", strings.ToUpper(test.name))
+			for k, v := range test.env {
+				os.Setenv(fmt.Sprintf("%s_%s", prefix, k), v)
+			}
+			viper.SetEnvPrefix(prefix)
+			res, err := util.BulkQueryClient(client, func(addresses []string) (eth2client.Service, error) {
+				if test.fetchErr != nil {
+					return nil, test.fetchErr
+				}
+
+				return &addressedClient{address: strings.Join(addresses, ",")}, nil
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.address, res.Address())
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// defaultIndexChunkSize is the number of validator indices sent in each
+// request for duties if not otherwise configured.
+const defaultIndexChunkSize = 1024
+
+// IndexChunkSize returns the maximum number of validator indices to send to
+// a beacon node in a single request for duties.
+func IndexChunkSize() int {
+	if viper.GetInt("eth2client.index-chunk-size") > 0 {
+		return viper.GetInt("eth2client.index-chunk-size")
+	}
+
+	return defaultIndexChunkSize
+}
+
+// chunkIndices splits validator indices into chunks of at most the given size.
+func chunkIndices(indices []phase0.ValidatorIndex, chunkSize int) [][]phase0.ValidatorIndex {
+	chunks := make([][]phase0.ValidatorIndex, 0, (len(indices)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(indices); start += chunkSize {
+		end := start + chunkSize
+		if end > len(indices) {
+			end = len(indices)
+		}
+		chunks = append(chunks, indices[start:end])
+	}
+
+	return chunks
+}
+
+// dependentRootRetries is the number of times that a chunked request for
+// attester duties is repeated if the chunks disagree on the dependent root.
+const dependentRootRetries = 1
+
+// chunkedAttesterDutiesProvider requests attester duties in chunks of validator indices.
+type chunkedAttesterDutiesProvider struct {
+	provider  eth2client.AttesterDutiesProvider
+	chunkSize int
+}
+
+// ChunkedAttesterDutiesProvider wraps an attester duties provider so that
+// requests for more than the chunk size of validators are split into multiple
+// requests, and the results merged.  Attester duties are requested with the
+// indices in the body of a POST, so this is not about URL length; it bounds
+// the size of each request and response, keeping them within the body limits
+// of beacon nodes and any proxies in front of them, and allowing each to
+// complete within the request timeout.
+//
+// As the chunks are separate requests, a reorg between them could result in
+// duties calculated from different dependent roots.  The dependent root of
+// each chunk is checked, and the request repeated if they differ.
+func ChunkedAttesterDutiesProvider(provider eth2client.AttesterDutiesProvider, chunkSize int) eth2client.AttesterDutiesProvider {
+	if chunkSize <= 0 {
+		return provider
+	}
+
+	return &chunkedAttesterDutiesProvider{
+		provider:  provider,
+		chunkSize: chunkSize,
+	}
+}
+
+// AttesterDuties obtains attester duties.
+func (p *chunkedAttesterDutiesProvider) AttesterDuties(ctx context.Context,
+	opts *api.AttesterDutiesOpts,
+) (
+	*api.Response[[]*apiv1.AttesterDuty],
+	error,
+) {
+	if opts == nil || len(opts.Indices) <= p.chunkSize {
+		return p.provider.AttesterDuties(ctx, opts)
+	}
+
+	var err error
+	for attempt := 0; attempt <= dependentRootRetries; attempt++ {
+		var res *api.Response[[]*apiv1.AttesterDuty]
+		res, err = p.attesterDuties(ctx, opts)
+		if err == nil {
+			return res, nil
+		}
+		if !errors.Is(err, errDependentRootMismatch) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+// errDependentRootMismatch is returned when chunks of attester duties have
+// different dependent roots.
+var errDependentRootMismatch = errors.New("dependent root changed between chunks of attester duties")
+
+// attesterDuties obtains attester duties in chunks, ensuring that all chunks
+// have the same dependent root.
+func (p *chunkedAttesterDutiesProvider) attesterDuties(ctx context.Context,
+	opts *api.AttesterDutiesOpts,
+) (
+	*api.Response[[]*apiv1.AttesterDuty],
+	error,
+) {
+	res := &api.Response[[]*apiv1.AttesterDuty]{
+		Data: make([]*apiv1.AttesterDuty, 0, len(opts.Indices)),
+	}
+	var dependentRoot any
+	for i, chunk := range chunkIndices(opts.Indices, p.chunkSize) {
+		chunkOpts := *opts
+		chunkOpts.Indices = chunk
+		chunkRes, err := p.provider.AttesterDuties(ctx, &chunkOpts)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			dependentRoot = chunkRes.Metadata["dependent_root"]
+		} else if chunkDependentRoot := chunkRes.Metadata["dependent_root"]; chunkDependentRoot != dependentRoot {
+			return nil, errors.Wrapf(errDependentRootMismatch, "chunk %d has dependent root %v rather than %v", i, chunkDependentRoot, dependentRoot)
+		}
+		res.Data = append(res.Data, chunkRes.Data...)
+		if res.Metadata == nil {
+			res.Metadata = chunkRes.Metadata
+		}
+	}
+
+	return res, nil
+}
+
+// chunkedSyncCommitteeDutiesProvider requests sync committee duties in chunks of validator indices.
+type chunkedSyncCommitteeDutiesProvider struct {
+	provider  eth2client.SyncCommitteeDutiesProvider
+	chunkSize int
+}
+
+// ChunkedSyncCommitteeDutiesProvider wraps a sync committee duties provider
+// so that requests for more than the chunk size of validators are split into
+// multiple requests, and the results merged.
+func ChunkedSyncCommitteeDutiesProvider(provider eth2client.SyncCommitteeDutiesProvider, chunkSize int) eth2client.SyncCommitteeDutiesProvider {
+	if chunkSize <= 0 {
+		return provider
+	}
+
+	return &chunkedSyncCommitteeDutiesProvider{
+		provider:  provider,
+		chunkSize: chunkSize,
+	}
+}
+
+// SyncCommitteeDuties obtains sync committee duties.
+func (p *chunkedSyncCommitteeDutiesProvider) SyncCommitteeDuties(ctx context.Context,
+	opts *api.SyncCommitteeDutiesOpts,
+) (
+	*api.Response[[]*apiv1.SyncCommitteeDuty],
+	error,
+) {
+	if opts == nil || len(opts.Indices) <= p.chunkSize {
+		return p.provider.SyncCommitteeDuties(ctx, opts)
+	}
+
+	res := &api.Response[[]*apiv1.SyncCommitteeDuty]{
+		Data: make([]*apiv1.SyncCommitteeDuty, 0),
+	}
+	for _, chunk := range chunkIndices(opts.Indices, p.chunkSize) {
+		chunkOpts := *opts
+		chunkOpts.Indices = chunk
+		chunkRes, err := p.provider.SyncCommitteeDuties(ctx, &chunkOpts)
+		if err != nil {
+			return nil, err
+		}
+		res.Data = append(res.Data, chunkRes.Data...)
+		if res.Metadata == nil {
+			res.Metadata = chunkRes.Metadata
+		}
+	}
+
+	return res, nil
+}
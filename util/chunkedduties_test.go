@@ -0,0 +1,214 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDutiesProvider returns a duty for each requested validator, and
+// records the size of each request.  If dependent roots are supplied then the
+// nth request returns the nth dependent root.
+type recordingDutiesProvider struct {
+	requests       []int
+	failAfter      int
+	dependentRoots []string
+}
+
+func (p *recordingDutiesProvider) record(indices []phase0.ValidatorIndex) error {
+	if p.failAfter > 0 && len(p.requests) == p.failAfter {
+		return errors.New("request too large")
+	}
+	p.requests = append(p.requests, len(indices))
+
+	return nil
+}
+
+func (p *recordingDutiesProvider) AttesterDuties(_ context.Context,
+	opts *api.AttesterDutiesOpts,
+) (
+	*api.Response[[]*apiv1.AttesterDuty],
+	error,
+) {
+	if err := p.record(opts.Indices); err != nil {
+		return nil, err
+	}
+	duties := make([]*apiv1.AttesterDuty, 0, len(opts.Indices))
+	for _, index := range opts.Indices {
+		duties = append(duties, &apiv1.AttesterDuty{
+			ValidatorIndex: index,
+			Slot:           phase0.Slot(uint64(opts.Epoch) * 32),
+		})
+	}
+
+	dependentRoot := "0x01"
+	if len(p.dependentRoots) > 0 {
+		dependentRoot = p.dependentRoots[len(p.requests)-1]
+	}
+
+	return &api.Response[[]*apiv1.AttesterDuty]{
+		Data:     duties,
+		Metadata: map[string]any{"dependent_root": dependentRoot},
+	}, nil
+}
+
+func (p *recordingDutiesProvider) SyncCommitteeDuties(_ context.Context,
+	opts *api.SyncCommitteeDutiesOpts,
+) (
+	*api.Response[[]*apiv1.SyncCommitteeDuty],
+	error,
+) {
+	if err := p.record(opts.Indices); err != nil {
+		return nil, err
+	}
+	duties := make([]*apiv1.SyncCommitteeDuty, 0, len(opts.Indices))
+	for _, index := range opts.Indices {
+		duties = append(duties, &apiv1.SyncCommitteeDuty{
+			ValidatorIndex: index,
+		})
+	}
+
+	return &api.Response[[]*apiv1.SyncCommitteeDuty]{
+		Data:     duties,
+		Metadata: map[string]any{},
+	}, nil
+}
+
+func indices(count int) []phase0.ValidatorIndex {
+	res := make([]phase0.ValidatorIndex, count)
+	for i := range res {
+		res[i] = phase0.ValidatorIndex(i)
+	}
+
+	return res
+}
+
+func TestChunkedAttesterDutiesProvider(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		chunkSize      int
+		indices        int
+		failAfter      int
+		dependentRoots []string
+		requests       []int
+		dependentRoot  string
+		err            string
+	}{
+		{
+			name:      "Disabled",
+			chunkSize: 0,
+			indices:   25000,
+			requests:  []int{25000},
+		},
+		{
+			name:      "Single",
+			chunkSize: 1000,
+			indices:   1000,
+			requests:  []int{1000},
+		},
+		{
+			name:      "Large",
+			chunkSize: 1000,
+			indices:   25500,
+			requests: []int{
+				1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000,
+				1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000,
+				1000, 1000, 1000, 1000, 1000, 500,
+			},
+		},
+		{
+			name:      "ChunkFails",
+			chunkSize: 1000,
+			indices:   2500,
+			failAfter: 2,
+			requests:  []int{1000, 1000},
+			err:       "request too large",
+		},
+		{
+			name:           "DependentRootChanged",
+			chunkSize:      1000,
+			indices:        2500,
+			dependentRoots: []string{"0x01", "0x02", "0x02", "0x02", "0x02"},
+			requests:       []int{1000, 1000, 1000, 1000, 500},
+			dependentRoot:  "0x02",
+		},
+		{
+			name:           "DependentRootUnstable",
+			chunkSize:      1000,
+			indices:        2500,
+			dependentRoots: []string{"0x01", "0x02", "0x02", "0x03"},
+			requests:       []int{1000, 1000, 1000, 1000},
+			err:            "chunk 1 has dependent root 0x03 rather than 0x02: dependent root changed between chunks of attester duties",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			underlying := &recordingDutiesProvider{
+				failAfter:      test.failAfter,
+				dependentRoots: test.dependentRoots,
+			}
+			provider := util.ChunkedAttesterDutiesProvider(underlying, test.chunkSize)
+			requested := indices(test.indices)
+			res, err := provider.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+				Epoch:   5,
+				Indices: requested,
+			})
+			require.Equal(t, test.requests, underlying.requests)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.Data, test.indices)
+			for i, duty := range res.Data {
+				require.Equal(t, requested[i], duty.ValidatorIndex)
+				require.Equal(t, phase0.Slot(160), duty.Slot)
+			}
+			dependentRoot := test.dependentRoot
+			if dependentRoot == "" {
+				dependentRoot = "0x01"
+			}
+			require.Equal(t, dependentRoot, res.Metadata["dependent_root"])
+		})
+	}
+}
+
+func TestChunkedSyncCommitteeDutiesProvider(t *testing.T) {
+	ctx := context.Background()
+
+	underlying := &recordingDutiesProvider{}
+	provider := util.ChunkedSyncCommitteeDutiesProvider(underlying, 1024)
+	requested := indices(3000)
+	res, err := provider.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+		Epoch:   5,
+		Indices: requested,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1024, 1024, 952}, underlying.requests)
+	require.Len(t, res.Data, len(requested))
+	for i, duty := range res.Data {
+		require.Equal(t, requested[i], duty.ValidatorIndex)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	httpclient "github.com/attestantio/go-eth2-client/http"
+	multiclient "github.com/attestantio/go-eth2-client/multi"
+	"github.com/attestantio/vouch/util"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// staticBeaconNode serves the static beacon node endpoints, counting the
+// requests received for each.
+type staticBeaconNode struct {
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func (n *staticBeaconNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	n.requests[r.URL.Path]++
+	n.mu.Unlock()
+
+	var body string
+	switch r.URL.Path {
+	case "/eth/v1/node/syncing":
+		body = `{"data":{"head_slot":"100","sync_distance":"0","is_syncing":false,"is_optimistic":false,"el_offline":false}}`
+	case "/eth/v1/node/version":
+		body = `{"data":{"version":"test"}}`
+	case "/eth/v1/beacon/genesis":
+		body = `{"data":{"genesis_time":"1606824023","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95","genesis_fork_version":"0x00000000"}}`
+	case "/eth/v1/config/spec":
+		body = `{"data":{"SECONDS_PER_SLOT":"12","SLOTS_PER_EPOCH":"32"}}`
+	case "/eth/v1/config/fork_schedule":
+		body = `{"data":[{"previous_version":"0x00000000","current_version":"0x00000000","epoch":"0"}]}`
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(body))
+}
+
+func (n *staticBeaconNode) requestsFor(path string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.requests[path]
+}
+
+// TestStaticDataCached confirms that static beacon node data is fetched once
+// and then served from the consensus client's cache, regardless of the
+// wrappers that Vouch places around the client.
+func TestStaticDataCached(t *testing.T) {
+	tests := []struct {
+		name  string
+		grace time.Duration
+		multi bool
+	}{
+		{
+			name: "Client",
+		},
+		{
+			name:  "SyncGrace",
+			grace: time.Minute,
+		},
+		{
+			name:  "Multi",
+			multi: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			node := &staticBeaconNode{requests: make(map[string]int)}
+			server := httptest.NewServer(node)
+			defer server.Close()
+
+			client, err := httpclient.New(ctx,
+				httpclient.WithLogLevel(zerolog.Disabled),
+				httpclient.WithAddress(server.URL),
+			)
+			require.NoError(t, err)
+			var service eth2client.Service = util.SyncGraceClient(client.(*httpclient.Service), test.grace)
+			if test.multi {
+				service, err = multiclient.New(ctx,
+					multiclient.WithLogLevel(zerolog.Disabled),
+					multiclient.WithClients([]eth2client.Service{service}),
+				)
+				require.NoError(t, err)
+			}
+
+			for i := 0; i < 3; i++ {
+				_, err := service.(eth2client.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+				require.NoError(t, err)
+				_, err = service.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+				require.NoError(t, err)
+				_, err = service.(eth2client.ForkScheduleProvider).ForkSchedule(ctx, &api.ForkScheduleOpts{})
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, 1, node.requestsFor("/eth/v1/beacon/genesis"))
+			require.Equal(t, 1, node.requestsFor("/eth/v1/config/spec"))
+			require.Equal(t, 1, node.requestsFor("/eth/v1/config/fork_schedule"))
+		})
+	}
+}
@@ -98,6 +98,14 @@ func BeaconNodeAddressesForAttesting() []string {
 	return addresses
 }
 
+// BeaconNodeAddressesForBulkQueries returns the beacon node addresses to use for
+// heavy queries that are not time-critical, such as obtaining the state of all
+// validators.  If no addresses are explicitly configured for bulk queries then
+// the top-level beacon node addresses are returned.
+func BeaconNodeAddressesForBulkQueries() []string {
+	return BeaconNodeAddresses("bulk-query")
+}
+
 // HierarchicalBool returns the best configuration value for the path.
 func HierarchicalBool(variable string, path string) bool {
 	if path == "" {
@@ -196,3 +196,46 @@ func TestBeaconNodeAddressesForAttesting(t *testing.T) {
 		})
 	}
 }
+
+func TestBeaconNodeAddressesForBulkQueries(t *testing.T) {
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected []string
+	}{
+		{
+			name: "Default",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":                                 "1 2",
+				"STRATEGIES_ATTESTATIONDATA_BEST_BEACON_NODE_ADDRESSES": "3 4",
+			},
+			expected: []string{"1", "2"},
+		},
+		{
+			name: "BulkQuery",
+			env: map[string]string{
+				"BEACON_NODE_ADDRESSES":                                 "1 2",
+				"STRATEGIES_ATTESTATIONDATA_BEST_BEACON_NODE_ADDRESSES": "3 4",
+				"BULK_QUERY_BEACON_NODE_ADDRESSES":                      "5",
+			},
+			expected: []string{"5"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prefix := fmt.Sprintf("VOUCH_BEACONNODEADDRESSFORBULKQUERIES_%s", strings.ToUpper(test.name))
+			for k, v := range test.env {
+				os.Setenv(fmt.Sprintf("%s_%s", prefix, k), v)
+			}
+			viper.SetEnvPrefix(prefix)
+			res := util.BeaconNodeAddressesForBulkQueries()
+			sort.Strings(test.expected)
+			sort.Strings(res)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
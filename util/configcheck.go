@@ -0,0 +1,183 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// executionAddressLength is the length of an execution address, in bytes.
+const executionAddressLength = 20
+
+// configuredStyles are the valid values for each of the style configuration
+// items.  A style that is not set falls back to the default for the item.
+var configuredStyles = map[string][]string{
+	"scheduler.style":                            {"advanced", "basic"},
+	"strategies.aggregateattestation.style":      {"best", "fallback", "first"},
+	"strategies.attestationdata.style":           {"best", "fallback", "first", "majority"},
+	"strategies.beaconblockproposal.style":       {"best", "fallback", "first"},
+	"strategies.beaconblockroot.style":           {"fallback", "first", "majority"},
+	"strategies.builderbid.style":                {"best"},
+	"strategies.synccommitteecontribution.style": {"best", "fallback", "first"},
+	"submitter.style":                            {"all", "multinode"},
+}
+
+// CheckConfig carries out a validation pass over the configuration, returning
+// an actionable error for the first inconsistency found.  It is intended to
+// be called at startup, so that configuration mistakes are reported clearly
+// rather than surfacing as failures when the relevant service is started.
+func CheckConfig() error {
+	checks := []func() error{
+		checkStyles,
+		CheckBeaconNodeAddresses,
+		checkAccountManager,
+		checkFallbackFeeRecipient,
+		checkBlockRelay,
+		checkGossipBeaconNodeAddresses,
+	}
+	for _, check := range checks {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkStyles confirms that all configured styles are known.
+func checkStyles() error {
+	keys := make([]string, 0, len(configuredStyles))
+	for key := range configuredStyles {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		style := viper.GetString(key)
+		if style == "" {
+			continue
+		}
+		found := false
+		for _, candidate := range configuredStyles[key] {
+			if style == candidate {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown value %q for %s; valid values are %s", style, key, strings.Join(configuredStyles[key], ", "))
+		}
+	}
+
+	return nil
+}
+
+// checkAccountManager confirms that a single, complete, account manager is configured.
+func checkAccountManager() error {
+	dirkConfigured := len(viper.GetStringSlice("accountmanager.dirk.accounts")) > 0
+	walletConfigured := len(viper.GetStringSlice("accountmanager.wallet.accounts")) > 0
+
+	switch {
+	case dirkConfigured && walletConfigured:
+		return errors.New("both accountmanager.dirk.accounts and accountmanager.wallet.accounts are configured; Vouch only supports a single account manager")
+	case dirkConfigured:
+		if len(viper.GetStringSlice("accountmanager.dirk.endpoints")) == 0 {
+			return errors.New("dirk account manager configured but no accountmanager.dirk.endpoints listed")
+		}
+		if viper.GetString("accountmanager.dirk.client-cert") == "" {
+			return errors.New("dirk account manager configured but no accountmanager.dirk.client-cert supplied")
+		}
+		if viper.GetString("accountmanager.dirk.client-key") == "" {
+			return errors.New("dirk account manager configured but no accountmanager.dirk.client-key supplied")
+		}
+	case walletConfigured:
+		if len(viper.GetStringSlice("accountmanager.wallet.passphrases")) == 0 {
+			return errors.New("wallet account manager configured but no accountmanager.wallet.passphrases listed")
+		}
+	default:
+		return errors.New("no account manager configured; set either accountmanager.dirk.accounts or accountmanager.wallet.accounts")
+	}
+
+	return nil
+}
+
+// checkFallbackFeeRecipient confirms that the fallback fee recipient is a valid execution address.
+func checkFallbackFeeRecipient() error {
+	input := viper.GetString("blockrelay.fallback-fee-recipient")
+	if input == "" {
+		return errors.New("no blockrelay.fallback-fee-recipient supplied; this is required to propose blocks")
+	}
+	feeRecipient, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return fmt.Errorf("blockrelay.fallback-fee-recipient %q is not a valid hex string", input)
+	}
+	if len(feeRecipient) != executionAddressLength {
+		return fmt.Errorf("blockrelay.fallback-fee-recipient %q has %d bytes; an execution address has %d", input, len(feeRecipient), executionAddressLength)
+	}
+	zero := true
+	for _, b := range feeRecipient {
+		if b != 0 {
+			zero = false
+			break
+		}
+	}
+	if zero {
+		return errors.New("blockrelay.fallback-fee-recipient is the zero address; fees would be burnt")
+	}
+
+	return nil
+}
+
+// checkBlockRelay confirms that the relay-related settings are consistent.
+func checkBlockRelay() error {
+	if viper.GetBool("beaconblockproposer.relay-only") {
+		if viper.GetString("blockrelay.config.url") == "" {
+			return errors.New("beaconblockproposer.relay-only is set but no relays are configured in blockrelay.config.url")
+		}
+		if viper.GetBool("beaconblockproposer.fallback-proposal") {
+			return errors.New("beaconblockproposer.relay-only and beaconblockproposer.fallback-proposal cannot both be set")
+		}
+	}
+
+	clientCert := viper.GetString("blockrelay.config.client-cert")
+	clientKey := viper.GetString("blockrelay.config.client-key")
+	if clientCert != "" && clientKey == "" {
+		return errors.New("blockrelay.config.client-cert is set but blockrelay.config.client-key is not")
+	}
+	if clientCert == "" && clientKey != "" {
+		return errors.New("blockrelay.config.client-key is set but blockrelay.config.client-cert is not")
+	}
+
+	return nil
+}
+
+// checkGossipBeaconNodeAddresses confirms that gossip beacon nodes are only
+// configured for a submitter that can use them.
+func checkGossipBeaconNodeAddresses() error {
+	if len(viper.GetStringSlice("submitter.gossip.beacon-node-addresses")) == 0 {
+		return nil
+	}
+	switch viper.GetString("submitter.style") {
+	case "multinode", "all":
+		return nil
+	default:
+		return errors.New("submitter.gossip.beacon-node-addresses is set but submitter.style is not multinode")
+	}
+}
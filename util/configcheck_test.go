@@ -0,0 +1,187 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConfig(t *testing.T) {
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	base := map[string]string{
+		"BEACON_NODE_ADDRESSES":                "1 2",
+		"ACCOUNTMANAGER_WALLET_ACCOUNTS":       "wallet/account",
+		"ACCOUNTMANAGER_WALLET_PASSPHRASES":    "secret",
+		"BLOCKRELAY_FALLBACK_FEE_RECIPIENT":    "0x0000000000000000000000000000000000000001",
+		"STRATEGIES_BEACONBLOCKPROPOSAL_STYLE": "best",
+	}
+
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		err       string
+	}{
+		{
+			name: "Good",
+		},
+		{
+			name: "UnknownStyle",
+			overrides: map[string]string{
+				"STRATEGIES_BEACONBLOCKPROPOSAL_STYLE": "bets",
+			},
+			err: `unknown value "bets" for strategies.beaconblockproposal.style; valid values are best, fallback, first`,
+		},
+		{
+			name: "NoBeaconNodes",
+			overrides: map[string]string{
+				"BEACON_NODE_ADDRESSES": "",
+				"STRATEGIES_BEACONBLOCKPROPOSAL_BEST_BEACON_NODE_ADDRESSES": "1",
+			},
+			err: "no beacon node addresses specified",
+		},
+		{
+			name: "NoAccountManager",
+			overrides: map[string]string{
+				"ACCOUNTMANAGER_WALLET_ACCOUNTS": "",
+			},
+			err: "no account manager configured; set either accountmanager.dirk.accounts or accountmanager.wallet.accounts",
+		},
+		{
+			name: "MultipleAccountManagers",
+			overrides: map[string]string{
+				"ACCOUNTMANAGER_DIRK_ACCOUNTS": "wallet",
+			},
+			err: "both accountmanager.dirk.accounts and accountmanager.wallet.accounts are configured; Vouch only supports a single account manager",
+		},
+		{
+			name: "DirkNoEndpoints",
+			overrides: map[string]string{
+				"ACCOUNTMANAGER_WALLET_ACCOUNTS": "",
+				"ACCOUNTMANAGER_DIRK_ACCOUNTS":   "wallet",
+			},
+			err: "dirk account manager configured but no accountmanager.dirk.endpoints listed",
+		},
+		{
+			name: "DirkNoClientKey",
+			overrides: map[string]string{
+				"ACCOUNTMANAGER_WALLET_ACCOUNTS":  "",
+				"ACCOUNTMANAGER_DIRK_ACCOUNTS":    "wallet",
+				"ACCOUNTMANAGER_DIRK_ENDPOINTS":   "localhost:9091",
+				"ACCOUNTMANAGER_DIRK_CLIENT_CERT": "file:///client.crt",
+			},
+			err: "dirk account manager configured but no accountmanager.dirk.client-key supplied",
+		},
+		{
+			name: "WalletNoPassphrases",
+			overrides: map[string]string{
+				"ACCOUNTMANAGER_WALLET_PASSPHRASES": "",
+			},
+			err: "wallet account manager configured but no accountmanager.wallet.passphrases listed",
+		},
+		{
+			name: "FeeRecipientMissing",
+			overrides: map[string]string{
+				"BLOCKRELAY_FALLBACK_FEE_RECIPIENT": "",
+			},
+			err: "no blockrelay.fallback-fee-recipient supplied; this is required to propose blocks",
+		},
+		{
+			name: "FeeRecipientInvalid",
+			overrides: map[string]string{
+				"BLOCKRELAY_FALLBACK_FEE_RECIPIENT": "0xinvalid",
+			},
+			err: `blockrelay.fallback-fee-recipient "0xinvalid" is not a valid hex string`,
+		},
+		{
+			name: "FeeRecipientShort",
+			overrides: map[string]string{
+				"BLOCKRELAY_FALLBACK_FEE_RECIPIENT": "0x0001",
+			},
+			err: `blockrelay.fallback-fee-recipient "0x0001" has 2 bytes; an execution address has 20`,
+		},
+		{
+			name: "FeeRecipientZero",
+			overrides: map[string]string{
+				"BLOCKRELAY_FALLBACK_FEE_RECIPIENT": "0x0000000000000000000000000000000000000000",
+			},
+			err: "blockrelay.fallback-fee-recipient is the zero address; fees would be burnt",
+		},
+		{
+			name: "RelayOnlyNoRelays",
+			overrides: map[string]string{
+				"BEACONBLOCKPROPOSER_RELAY_ONLY": "true",
+			},
+			err: "beaconblockproposer.relay-only is set but no relays are configured in blockrelay.config.url",
+		},
+		{
+			name: "RelayOnlyWithFallbackProposal",
+			overrides: map[string]string{
+				"BEACONBLOCKPROPOSER_RELAY_ONLY":        "true",
+				"BEACONBLOCKPROPOSER_FALLBACK_PROPOSAL": "true",
+				"BLOCKRELAY_CONFIG_URL":                 "http://localhost:8080/config",
+			},
+			err: "beaconblockproposer.relay-only and beaconblockproposer.fallback-proposal cannot both be set",
+		},
+		{
+			name: "RelayClientCertWithoutKey",
+			overrides: map[string]string{
+				"BLOCKRELAY_CONFIG_URL":         "https://localhost:8080/config",
+				"BLOCKRELAY_CONFIG_CLIENT_CERT": "file:///client.crt",
+			},
+			err: "blockrelay.config.client-cert is set but blockrelay.config.client-key is not",
+		},
+		{
+			name: "GossipWithoutMultinode",
+			overrides: map[string]string{
+				"SUBMITTER_GOSSIP_BEACON_NODE_ADDRESSES": "3",
+			},
+			err: "submitter.gossip.beacon-node-addresses is set but submitter.style is not multinode",
+		},
+		{
+			name: "GossipWithMultinode",
+			overrides: map[string]string{
+				"SUBMITTER_STYLE":                        "multinode",
+				"SUBMITTER_GOSSIP_BEACON_NODE_ADDRESSES": "3",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prefix := fmt.Sprintf("VOUCH_CONFIGCHECK%s", strings.ToUpper(test.name))
+			for k, v := range base {
+				os.Setenv(fmt.Sprintf("%s_%s", prefix, k), v)
+			}
+			for k, v := range test.overrides {
+				os.Setenv(fmt.Sprintf("%s_%s", prefix, k), v)
+			}
+			viper.SetEnvPrefix(prefix)
+			err := util.CheckConfig()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright © 2021 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// DeadlineShare returns a context whose deadline is an equal share of the
+// time remaining before the deadline of the supplied context, split between
+// the given number of parties.  This allows a series of attempts to run
+// within a single deadline without an early attempt leaving no time for
+// those after it.
+// If the supplied context has no deadline, or there is only one party, the
+// returned context has the same deadline as the supplied context.
+func DeadlineShare(ctx context.Context, parties int) (context.Context, context.CancelFunc) {
+	deadline, hasDeadline := ctx.Deadline()
+	if !hasDeadline || parties <= 1 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, time.Until(deadline)/time.Duration(parties))
+}
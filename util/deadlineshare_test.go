@@ -0,0 +1,95 @@
+// Copyright © 2021 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadlineShare(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		parties     int
+		hasDeadline bool
+		remaining   time.Duration
+	}{
+		{
+			name:    "NoDeadline",
+			parties: 2,
+		},
+		{
+			name:        "SingleParty",
+			timeout:     time.Second,
+			parties:     1,
+			hasDeadline: true,
+			remaining:   time.Second,
+		},
+		{
+			name:        "ZeroParties",
+			timeout:     time.Second,
+			hasDeadline: true,
+			remaining:   time.Second,
+		},
+		{
+			name:        "TwoParties",
+			timeout:     time.Second,
+			parties:     2,
+			hasDeadline: true,
+			remaining:   500 * time.Millisecond,
+		},
+		{
+			name:        "FourParties",
+			timeout:     time.Second,
+			parties:     4,
+			hasDeadline: true,
+			remaining:   250 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+
+			shareCtx, shareCancel := util.DeadlineShare(ctx, test.parties)
+			defer shareCancel()
+
+			deadline, hasDeadline := shareCtx.Deadline()
+			require.Equal(t, test.hasDeadline, hasDeadline)
+			if test.hasDeadline {
+				require.InDelta(t, test.remaining, time.Until(deadline), float64(50*time.Millisecond))
+			}
+		})
+	}
+}
+
+func TestDeadlineShareCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	shareCtx, shareCancel := util.DeadlineShare(ctx, 2)
+	defer shareCancel()
+
+	// Cancelling the parent cancels the share.
+	cancel()
+	require.ErrorIs(t, shareCtx.Err(), context.Canceled)
+}
@@ -75,6 +75,43 @@ func TestLogLevel(t *testing.T) {
 			path:  "a.b.c",
 			level: zerolog.WarnLevel,
 		},
+		{
+			name: "StrategyOverride",
+			vars: map[string]string{
+				"log-level": "warn",
+				"strategies.beaconblockproposal.best.log-level": "trace",
+			},
+			path:  "strategies.beaconblockproposal.best",
+			level: zerolog.TraceLevel,
+		},
+		{
+			name: "StrategyOverrideSibling",
+			vars: map[string]string{
+				"log-level": "warn",
+				"strategies.beaconblockproposal.best.log-level": "trace",
+			},
+			path:  "strategies.beaconblockproposal.first",
+			level: zerolog.WarnLevel,
+		},
+		{
+			name: "StrategyOverrideOtherStrategy",
+			vars: map[string]string{
+				"log-level": "warn",
+				"strategies.beaconblockproposal.best.log-level": "trace",
+				"strategies.attestationdata.log-level":          "error",
+			},
+			path:  "strategies.attestationdata.best",
+			level: zerolog.ErrorLevel,
+		},
+		{
+			name: "StrategyOverrideLowerGlobal",
+			vars: map[string]string{
+				"log-level":                            "trace",
+				"strategies.attestationdata.log-level": "error",
+			},
+			path:  "strategies.attestationdata.majority",
+			level: zerolog.ErrorLevel,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,120 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// providerResult is the result of a request to a single provider.
+type providerResult struct {
+	elapsed time.Duration
+	score   float64
+	err     error
+	// duplicateOf is the provider that first returned the same result, if any.
+	duplicateOf string
+}
+
+// ProviderResults records the result of each provider request made by a
+// strategy, so that the reason for the strategy's choice can be diagnosed.
+// All functions are no-ops on a nil ProviderResults.
+type ProviderResults struct {
+	started   time.Time
+	providers []string
+	results   map[string]*providerResult
+}
+
+// NewProviderResults creates a record of provider results for a strategy call
+// to the given providers.  It returns nil if not enabled.
+func NewProviderResults[T any](enabled bool, started time.Time, providers map[string]T) *ProviderResults {
+	if !enabled {
+		return nil
+	}
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &ProviderResults{
+		started:   started,
+		providers: names,
+		results:   make(map[string]*providerResult, len(providers)),
+	}
+}
+
+// Succeeded records a successful response from a provider.
+func (r *ProviderResults) Succeeded(provider string, score float64) {
+	if r == nil {
+		return
+	}
+
+	r.results[provider] = &providerResult{
+		elapsed: time.Since(r.started),
+		score:   score,
+	}
+}
+
+// Errored records an error from a provider.
+func (r *ProviderResults) Errored(provider string, err error) {
+	if r == nil {
+		return
+	}
+
+	r.results[provider] = &providerResult{
+		elapsed: time.Since(r.started),
+		err:     err,
+	}
+}
+
+// Duplicated records a response from a provider that duplicates the response
+// already received from another provider.
+func (r *ProviderResults) Duplicated(provider string, duplicateOf string) {
+	if r == nil {
+		return
+	}
+
+	r.results[provider] = &providerResult{
+		elapsed:     time.Since(r.started),
+		duplicateOf: duplicateOf,
+	}
+}
+
+// Log logs the result of each provider at debug level.  Providers that
+// did not return a result are logged as timed out.
+func (r *ProviderResults) Log(log zerolog.Logger, selected string) {
+	if r == nil {
+		return
+	}
+
+	for _, provider := range r.providers {
+		e := log.Debug().Str("provider", provider).Bool("selected", provider == selected)
+		result, exists := r.results[provider]
+		switch {
+		case !exists:
+			e = e.Str("result", "timed out")
+		case result.err != nil:
+			e = e.Str("result", "errored").Dur("elapsed", result.elapsed).Err(result.err)
+		case result.duplicateOf != "":
+			e = e.Str("result", "duplicate").Dur("elapsed", result.elapsed).Str("duplicate_of", result.duplicateOf)
+		default:
+			e = e.Str("result", "succeeded").Dur("elapsed", result.elapsed).Float64("score", result.score)
+		}
+		e.Msg("Provider result")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/testing/logger"
+	"github.com/attestantio/vouch/util"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderResults(t *testing.T) {
+	providers := map[string]struct{}{
+		"first":  {},
+		"second": {},
+		"third":  {},
+		"fourth": {},
+	}
+
+	capture := logger.NewLogCapture()
+	results := util.NewProviderResults(true, time.Now(), providers)
+	results.Succeeded("first", 10)
+	results.Errored("second", errors.New("failed"))
+	results.Duplicated("fourth", "first")
+	results.Log(zerologger.Logger, "first")
+
+	require.True(t, capture.HasLog(map[string]interface{}{
+		"message":  "Provider result",
+		"provider": "first",
+		"result":   "succeeded",
+		"score":    float64(10),
+		"selected": true,
+	}))
+	require.True(t, capture.HasLog(map[string]interface{}{
+		"message":  "Provider result",
+		"provider": "second",
+		"result":   "errored",
+		"error":    "failed",
+		"selected": false,
+	}))
+	require.True(t, capture.HasLog(map[string]interface{}{
+		"message":  "Provider result",
+		"provider": "third",
+		"result":   "timed out",
+		"selected": false,
+	}))
+	require.True(t, capture.HasLog(map[string]interface{}{
+		"message":      "Provider result",
+		"provider":     "fourth",
+		"result":       "duplicate",
+		"duplicate_of": "first",
+		"selected":     false,
+	}))
+}
+
+func TestProviderResultsDisabled(t *testing.T) {
+	capture := logger.NewLogCapture()
+	results := util.NewProviderResults(false, time.Now(), map[string]struct{}{"first": {}})
+	require.Nil(t, results)
+	results.Succeeded("first", 10)
+	results.Log(zerologger.Logger, "first")
+	require.Empty(t, capture.Entries())
+}
@@ -0,0 +1,66 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// RetryDeadline returns the time after which no further retries should be
+// started for an operation in the slot starting at slotStart, such that the
+// given fraction of the slot remains.
+//
+// A fraction of 1 results in a deadline at the start of the slot, and hence
+// no retries; a fraction of 0 allows retries until the end of the slot.
+func RetryDeadline(slotStart time.Time, slotDuration time.Duration, remaining float64) time.Time {
+	if remaining < 0 {
+		remaining = 0
+	}
+	if remaining > 1 {
+		remaining = 1
+	}
+
+	return slotStart.Add(time.Duration((1 - remaining) * float64(slotDuration)))
+}
+
+// RetryUntil calls fn until it succeeds, the context is done, or the next
+// attempt would start after the deadline, waiting interval between attempts.
+// fn is always called at least once.  It returns the number of attempts made
+// and the error from the final attempt, if any.
+func RetryUntil(ctx context.Context,
+	deadline time.Time,
+	interval time.Duration,
+	fn func(ctx context.Context) error,
+) (
+	int,
+	error,
+) {
+	attempts := 0
+	for {
+		attempts++
+		err := fn(ctx)
+		if err == nil {
+			return attempts, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return attempts, err
+		}
+		select {
+		case <-ctx.Done():
+			return attempts, err
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryDeadline(t *testing.T) {
+	slotStart := time.Unix(1700000000, 0)
+	slotDuration := 12 * time.Second
+
+	tests := []struct {
+		name      string
+		remaining float64
+		expected  time.Time
+	}{
+		{
+			name:      "Zero",
+			remaining: 0,
+			expected:  slotStart.Add(12 * time.Second),
+		},
+		{
+			name:      "Quarter",
+			remaining: 0.25,
+			expected:  slotStart.Add(9 * time.Second),
+		},
+		{
+			name:      "Half",
+			remaining: 0.5,
+			expected:  slotStart.Add(6 * time.Second),
+		},
+		{
+			name:      "One",
+			remaining: 1,
+			expected:  slotStart,
+		},
+		{
+			name:      "Negative",
+			remaining: -0.5,
+			expected:  slotStart.Add(12 * time.Second),
+		},
+		{
+			name:      "TooLarge",
+			remaining: 1.5,
+			expected:  slotStart,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, util.RetryDeadline(slotStart, slotDuration, test.remaining))
+		})
+	}
+}
+
+func TestRetryUntil(t *testing.T) {
+	ctx := context.Background()
+	failure := errors.New("failed")
+
+	t.Run("Success", func(t *testing.T) {
+		attempts, err := util.RetryUntil(ctx, time.Now().Add(time.Second), 10*time.Millisecond, func(_ context.Context) error {
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("EventualSuccess", func(t *testing.T) {
+		calls := 0
+		attempts, err := util.RetryUntil(ctx, time.Now().Add(time.Second), 10*time.Millisecond, func(_ context.Context) error {
+			calls++
+			if calls < 3 {
+				return failure
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("DeadlinePassed", func(t *testing.T) {
+		attempts, err := util.RetryUntil(ctx, time.Now().Add(-time.Second), 10*time.Millisecond, func(_ context.Context) error {
+			return failure
+		})
+		require.ErrorIs(t, err, failure)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("StopsAtDeadlineFraction", func(t *testing.T) {
+		// A 1s slot with 40% remaining gives a deadline 600ms in to the slot.
+		slotStart := time.Now()
+		deadline := util.RetryDeadline(slotStart, time.Second, 0.4)
+		interval := 100 * time.Millisecond
+
+		var last time.Time
+		attempts, err := util.RetryUntil(ctx, deadline, interval, func(_ context.Context) error {
+			last = time.Now()
+			return failure
+		})
+		require.ErrorIs(t, err, failure)
+		// No attempt may start after the deadline.
+		require.False(t, last.After(deadline))
+		// The available time should have been used; attempts at roughly 0, 100, ..., 500ms.
+		require.GreaterOrEqual(t, attempts, 4)
+		require.LessOrEqual(t, attempts, 7)
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		calls := 0
+		attempts, err := util.RetryUntil(ctx, time.Now().Add(time.Minute), 10*time.Millisecond, func(_ context.Context) error {
+			calls++
+			if calls == 2 {
+				cancel()
+			}
+			return failure
+		})
+		require.ErrorIs(t, err, failure)
+		require.Equal(t, 2, attempts)
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	httpclient "github.com/attestantio/go-eth2-client/http"
+)
+
+// syncGrace tracks how long a beacon node has been synced, and only
+// considers it synced once it has been so for the grace period.
+type syncGrace struct {
+	grace  time.Duration
+	synced func() bool
+	now    func() time.Time
+
+	mu       sync.Mutex
+	syncedAt time.Time
+}
+
+// newSyncGrace creates a new sync grace tracker.  A node that is synced
+// when the tracker is created is trusted immediately.
+func newSyncGrace(grace time.Duration, synced func() bool, now func() time.Time) *syncGrace {
+	s := &syncGrace{
+		grace:  grace,
+		synced: synced,
+		now:    now,
+	}
+	if synced() {
+		s.syncedAt = now().Add(-grace)
+	}
+
+	return s
+}
+
+// isSynced returns true if the node has been synced for at least the grace period.
+func (s *syncGrace) isSynced() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.synced() {
+		// Any return to syncing restarts the grace period.
+		s.syncedAt = time.Time{}
+		return false
+	}
+	if s.syncedAt.IsZero() {
+		s.syncedAt = s.now()
+	}
+
+	return s.now().Sub(s.syncedAt) >= s.grace
+}
+
+// syncGraceClient is a consensus client that does not report itself as
+// synced until it has been synced for the grace period.
+type syncGraceClient struct {
+	*httpclient.Service
+	grace *syncGrace
+}
+
+// IsSynced returns true if the client has been synced for at least the grace period.
+func (c *syncGraceClient) IsSynced() bool {
+	return c.grace.isSynced()
+}
+
+// SyncGraceClient wraps a consensus client so that it is only reported as
+// synced once it has been synced for the grace period, avoiding the use of
+// a node that has just finished syncing and may flap back to syncing.
+// The returned client provides the same functions as the supplied client.
+// If the grace period is 0 the supplied client is returned unchanged.
+func SyncGraceClient(client *httpclient.Service, grace time.Duration) eth2client.Service {
+	if grace <= 0 {
+		return client
+	}
+
+	return &syncGraceClient{
+		Service: client,
+		grace:   newSyncGrace(grace, client.IsSynced, time.Now),
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncGrace(t *testing.T) {
+	grace := 12 * time.Second
+	now := time.Now()
+	clock := func() time.Time { return now }
+	synced := false
+	nodeSynced := func() bool { return synced }
+
+	s := newSyncGrace(grace, nodeSynced, clock)
+
+	// Node is syncing.
+	require.False(t, s.isSynced())
+
+	// Node reports synced; not used during the grace period.
+	synced = true
+	require.False(t, s.isSynced())
+	now = now.Add(grace / 2)
+	require.False(t, s.isSynced())
+
+	// Node flaps back to syncing, restarting the grace period.
+	synced = false
+	require.False(t, s.isSynced())
+	synced = true
+	now = now.Add(grace / 2)
+	require.False(t, s.isSynced())
+	now = now.Add(grace - time.Second)
+	require.False(t, s.isSynced())
+
+	// Grace period has passed.
+	now = now.Add(time.Second)
+	require.True(t, s.isSynced())
+}
+
+func TestSyncGraceSyncedAtStart(t *testing.T) {
+	now := time.Now()
+	s := newSyncGrace(12*time.Second, func() bool { return true }, func() time.Time { return now })
+	require.True(t, s.isSynced())
+}
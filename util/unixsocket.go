@@ -0,0 +1,92 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// unixSocketPrefix is the prefix for addresses served over a Unix domain socket.
+const unixSocketPrefix = "unix://"
+
+// UnixSocketPath returns the path of the Unix domain socket for the address,
+// or false if the address is not a Unix domain socket address.
+func UnixSocketPath(address string) (string, bool) {
+	if !strings.HasPrefix(address, unixSocketPrefix) {
+		return "", false
+	}
+	path := strings.TrimPrefix(address, unixSocketPrefix)
+	if path == "" {
+		return "", false
+	}
+
+	return path, true
+}
+
+// UnixSocketTransport returns an HTTP transport that sends all requests to the
+// Unix domain socket at the given path, regardless of the host requested.
+// Compression is left enabled, so gzip responses are requested and
+// decompressed transparently.
+func UnixSocketTransport(path string) *http.Transport {
+	dialer := &net.Dialer{}
+
+	return &http.Transport{
+		DialContext: func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", path)
+		},
+		MaxIdleConns:        64,
+		MaxIdleConnsPerHost: 64,
+		IdleConnTimeout:     384 * time.Second,
+	}
+}
+
+// StartUnixSocketProxy starts an HTTP proxy on the loopback interface that
+// forwards requests to the Unix domain socket at the given path, and returns
+// the address of the proxy.  This allows clients that cannot be supplied with
+// a custom transport to reach servers on Unix domain sockets.
+// The proxy is stopped when the context is done.
+func StartUnixSocketProxy(ctx context.Context, path string) (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to listen for unix socket proxy")
+	}
+
+	server := &http.Server{
+		Handler: &httputil.ReverseProxy{
+			Rewrite: func(r *httputil.ProxyRequest) {
+				r.SetURL(&url.URL{Scheme: "http", Host: "localhost"})
+			},
+			Transport: UnixSocketTransport(path),
+		},
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	return fmt.Sprintf("http://%s", listener.Addr().String()), nil
+}
@@ -0,0 +1,190 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixSocketPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		path    string
+		ok      bool
+	}{
+		{
+			name:    "Empty",
+			address: "",
+		},
+		{
+			name:    "HTTP",
+			address: "http://localhost:5052",
+		},
+		{
+			name:    "HostPort",
+			address: "localhost:5052",
+		},
+		{
+			name:    "NoPath",
+			address: "unix://",
+		},
+		{
+			name:    "Good",
+			address: "unix:///var/run/beacon.sock",
+			path:    "/var/run/beacon.sock",
+			ok:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, ok := util.UnixSocketPath(test.address)
+			require.Equal(t, test.ok, ok)
+			require.Equal(t, test.path, path)
+		})
+	}
+}
+
+func unixSocketServer(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "beacon.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/node/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"version":"test"}}`))
+	}))
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return path
+}
+
+func TestUnixSocketTransport(t *testing.T) {
+	path := unixSocketServer(t)
+
+	client := &http.Client{
+		Transport: util.UnixSocketTransport(path),
+	}
+	resp, err := client.Get("http://localhost/eth/v1/node/version")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"data":{"version":"test"}}`, string(body))
+}
+
+func TestUnixSocketProxy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := unixSocketServer(t)
+
+	address, err := util.StartUnixSocketProxy(ctx, path)
+	require.NoError(t, err)
+
+	resp, err := http.Get(address + "/eth/v1/node/version")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"data":{"version":"test"}}`, string(body))
+
+	resp, err = http.Get(address + "/unknown")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestUnixSocketCompression(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	block := strings.Repeat(`{"slot":"12345","proposer_index":"1"}`, 1000)
+
+	path := filepath.Join(t.TempDir(), "beacon.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			http.Error(w, "gzip not accepted", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gw := gzip.NewWriter(w)
+		_, _ = gw.Write([]byte(block))
+		_ = gw.Close()
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	address, err := util.StartUnixSocketProxy(ctx, path)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		client  *http.Client
+		address string
+	}{
+		{
+			name: "Transport",
+			client: &http.Client{
+				Transport: util.UnixSocketTransport(path),
+			},
+			address: "http://localhost",
+		},
+		{
+			name:    "Proxy",
+			client:  &http.Client{},
+			address: address,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := test.client.Get(test.address + "/eth/v3/validator/blocks/12345")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			// The response was compressed on the wire and transparently decompressed.
+			require.True(t, resp.Uncompressed)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, block, string(body))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// UserAgent returns the best user agent for the path.  If no user agent
+// is configured then a default identifying Vouch and its release version
+// is returned.
+func UserAgent(path string, releaseVersion string) string {
+	if path == "" {
+		if userAgent := viper.GetString("user-agent"); userAgent != "" {
+			return userAgent
+		}
+		return fmt.Sprintf("Vouch/%s", releaseVersion)
+	}
+
+	key := fmt.Sprintf("%s.user-agent", path)
+	if userAgent := viper.GetString(key); userAgent != "" {
+		return userAgent
+	}
+	// Lop off the child and try again.
+	lastPeriod := strings.LastIndex(path, ".")
+	if lastPeriod == -1 {
+		return UserAgent("", releaseVersion)
+	}
+	return UserAgent(path[0:lastPeriod], releaseVersion)
+}
@@ -0,0 +1,89 @@
+// Copyright © 2024 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		vars      map[string]string
+		path      string
+		userAgent string
+	}{
+		{
+			name:      "Default",
+			path:      "",
+			userAgent: "Vouch/1.2.3",
+		},
+		{
+			name:      "DefaultWithPath",
+			path:      "eth2client.localhost:5051",
+			userAgent: "Vouch/1.2.3",
+		},
+		{
+			name: "TopLevel",
+			vars: map[string]string{
+				"user-agent": "custom",
+			},
+			path:      "eth2client.localhost:5051",
+			userAgent: "custom",
+		},
+		{
+			name: "Client",
+			vars: map[string]string{
+				"eth2client.user-agent": "custom-client",
+			},
+			path:      "eth2client.localhost:5051",
+			userAgent: "custom-client",
+		},
+		{
+			name: "Override",
+			vars: map[string]string{
+				"user-agent":                           "custom",
+				"eth2client.user-agent":                "custom-client",
+				"eth2client.localhost:5051.user-agent": "custom-node",
+			},
+			path:      "eth2client.localhost:5051",
+			userAgent: "custom-node",
+		},
+		{
+			name: "OverrideOtherNode",
+			vars: map[string]string{
+				"eth2client.user-agent":                "custom-client",
+				"eth2client.localhost:5051.user-agent": "custom-node",
+			},
+			path:      "eth2client.localhost:5052",
+			userAgent: "custom-client",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			userAgent := util.UserAgent(test.path, "1.2.3")
+			require.Equal(t, test.userAgent, userAgent)
+		})
+	}
+}